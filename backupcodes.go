@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/backupcodes"
+	"qrgenerator_cli/helpers/encrypt"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/paperkey"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// Límites de la hoja: los códigos son cortos y el QR maestro tiene que
+// entrar en un solo símbolo
+const (
+	maxBackupCodes   = 50
+	maxBackupCodeLen = 64
+)
+
+// runBackupCodes imprime una lista de códigos de recuperación en un PDF, cada
+// uno como texto y como QR, con un QR maestro que los guarda todos cifrados
+func runBackupCodes(args []string) int {
+	flags := newFlagSet("backup-codes")
+	output := flags.String("o", "", i18n.T("Output .pdf sheet"))
+	title := flags.String("title", "", i18n.T("Service the codes belong to, shown in the heading"))
+	account := flags.String("account", "", i18n.T("User the codes are issued to"))
+	passphraseFile := flags.String("passphrase-file", "", i18n.Sprintf("File whose first line is the passphrase (default: $%s or a prompt)", passphraseEnv))
+	encryptTo := flags.String("encrypt-to", "", i18n.T("Encrypt the master QR for this age, SSH or GPG recipient instead of a passphrase"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli backup-codes -o sheet.pdf [flags] codes.txt (- reads standard input)\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() != 1 || *output == "" {
+		log.Errorf("%v", i18n.T("backup-codes needs one file of codes and -o"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	if !strings.EqualFold(filepath.Ext(*output), ".pdf") {
+		log.Errorf("%v", i18n.Errorf("%w: backup-codes writes a .pdf", qrgenerator.ErrInvalidInput))
+		return exitInvalidInput
+	}
+
+	codes, err := readBackupCodes(flags.Arg(0))
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+
+	sheet := backupcodes.Sheet{Title: *title, Account: *account, Created: time.Now()}
+	for _, code := range codes {
+		modules, err := moduleMatrix(code)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+		sheet.Codes = append(sheet.Codes, backupcodes.Code{Text: code, Modules: modules})
+	}
+
+	master, note, err := backupMaster(codes, *account, *passphraseFile, *encryptTo)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	if sheet.Master, err = moduleMatrix(master); err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	sheet.MasterNote = note
+
+	if err := backupcodes.Layout(sheet).Write(*output); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot write the PDF: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	log.Infof("%d backup codes written to %s", len(codes), *output)
+	return exitOK
+}
+
+// readBackupCodes lee un código por línea, sin las líneas vacías
+func readBackupCodes(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var codes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		code := strings.TrimSpace(scanner.Text())
+		if code == "" {
+			continue
+		}
+		if len(code) > maxBackupCodeLen {
+			return nil, i18n.Errorf("%w: backup code %d has %d characters; at most %d fit on the sheet", qrgenerator.ErrInvalidInput, len(codes)+1, len(code), maxBackupCodeLen)
+		}
+		codes = append(codes, code)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+	}
+	switch {
+	case len(codes) == 0:
+		return nil, i18n.Errorf("%w: no backup codes in %s", qrgenerator.ErrInvalidInput, path)
+	case len(codes) > maxBackupCodes:
+		return nil, i18n.Errorf("%w: %d backup codes; a sheet holds at most %d", qrgenerator.ErrInvalidInput, len(codes), maxBackupCodes)
+	}
+	return codes, nil
+}
+
+// backupMaster cifra la lista de códigos para el QR maestro y devuelve su
+// contenido y cómo recuperarla. Con un destinatario se cifra con age o gpg y
+// se lee con decode; si no, es un respaldo de paperkey de un solo QR.
+func backupMaster(codes []string, account, passphraseFile, recipient string) (string, string, error) {
+	list := strings.Join(codes, "\n") + "\n"
+	if recipient != "" {
+		sealed, err := encrypt.Encrypt(list, recipient)
+		if err != nil {
+			return "", "", i18n.Errorf("%w: %w", qrgenerator.ErrEncode, err)
+		}
+		return sealed, i18n.Sprintf("Encrypted to %s. To recover the codes, scan it and run: qrgenerator_cli decode IMAGE", recipient), nil
+	}
+
+	passphrase, err := readPassphrase(passphraseFile, true)
+	if err != nil {
+		return "", "", i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err)
+	}
+	name := "backup-codes.txt"
+	if account != "" {
+		name = account + "-backup-codes.txt"
+	}
+	envelope, err := paperkey.Seal(name, []byte(list), passphrase)
+	if err != nil {
+		return "", "", i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err)
+	}
+	chunks, err := transfer.Split(envelope, transfer.MaxChunkSize)
+	if err != nil || len(chunks) != 1 {
+		return "", "", i18n.Errorf("%w: the codes do not fit in one master QR; issue fewer or shorter codes", qrgenerator.ErrCapacityExceeded)
+	}
+	return chunks[0], i18n.T("Protected with the passphrase chosen when the sheet was issued. To recover the codes, scan it and run: qrgenerator_cli paperkey-restore IMAGE"), nil
+}
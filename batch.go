@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/payload"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// readPayloads lee un payload por línea. Las líneas vacías se ignoran; el resto
+// se toma tal cual, porque un payload puede empezar con cualquier carácter.
+func readPayloads(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf("%w: cannot read batch file: %w", qrgenerator.ErrIO, err)
+	}
+	defer f.Close()
+
+	var payloads []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			payloads = append(payloads, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("%w: cannot read batch file: %w", qrgenerator.ErrIO, err)
+	}
+	return payloads, nil
+}
+
+// preparePayload normaliza un payload URL, salvo con --no-normalize, y le
+// agrega los parámetros de campaña
+func preparePayload(opts *generateOptions, content string) (string, error) {
+	if opts.normalize {
+		normalized, err := payload.NormalizeURL(content)
+		if err != nil {
+			return "", err
+		}
+		if normalized != content {
+			opts.log.Infof("URL normalized to %s", normalized)
+			content = normalized
+		}
+	}
+	return payload.AddUTM(content, opts.utm)
+}
+
+// generateBatch genera un QR por cada línea del archivo de lote, por cada
+// registro con --template o por cada identificador de --count
+func generateBatch(opts *generateOptions) (int, string) {
+	log := opts.log
+	var payloads []string
+	var err error
+	switch {
+	case opts.structured:
+	case opts.split > 0:
+		payloads, err = splitPayload(opts.config.URL, opts.split)
+	case len(opts.identifiers) > 1:
+		payloads = opts.identifiers
+	case opts.template != nil:
+		if payloads, err = payload.RenderRecords(opts.template, opts.batch); err != nil {
+			err = i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err)
+		}
+	default:
+		payloads, err = readPayloads(opts.batch)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err), ""
+	}
+	if opts.split == 0 {
+		for i := range payloads {
+			if payloads[i], err = preparePayload(opts, payloads[i]); err != nil {
+				log.Errorf("%v", i18n.Errorf("%w: batch payload %d: %w", qrgenerator.ErrInvalidInput, i+1, err))
+				return exitInvalidInput, ""
+			}
+		}
+	}
+	switch {
+	case opts.structured:
+		log.Debugf("structured append: %d bytes in %d QR codes", len(opts.config.URL), opts.split)
+	case opts.split > 0:
+		log.Debugf("split: %d bytes in %d QR codes", len(opts.config.URL), len(payloads))
+	case opts.batch == "":
+		log.Debugf("%s: %d identifiers", opts.payloadType, len(payloads))
+	default:
+		log.Debugf("batch: %d payloads from %s", len(payloads), opts.batch)
+	}
+
+	var results []*qrgenerator.QRResult
+	if opts.structured {
+		results, err = qrgenerator.GenerateStructuredAppend(opts.config, opts.split)
+	} else {
+		results, err = qrgenerator.GenerateBatch(opts.config, payloads)
+	}
+	for _, result := range results {
+		logResult(log, result)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err), ""
+	}
+
+	if len(results) > 1 && results[0].OutputPath == results[1].OutputPath {
+		log.Infof("%d pages written to %s", len(results), results[0].OutputPath)
+	} else {
+		for _, result := range results {
+			log.Infof("QR written to %s", result.OutputPath)
+		}
+	}
+	if opts.identifiers != nil {
+		if code := writeIdentifiers(opts, results); code != exitOK {
+			return code, results[0].OutputPath
+		}
+	}
+	return exitOK, results[0].OutputPath
+}
+
+// splitPayload parte el payload en parts trozos con el formato de send-file,
+// así receive-file lo rearma y verifica
+func splitPayload(payload string, parts int) ([]string, error) {
+	if parts > len(payload) {
+		return nil, i18n.Errorf("%w: the payload has %d bytes; it cannot be split into %d QR codes", qrgenerator.ErrInvalidInput, len(payload), parts)
+	}
+	chunk := (len(payload) + parts - 1) / parts
+	if chunk > transfer.MaxChunkSize {
+		return nil, i18n.Errorf("%w: the payload needs at least %d QR codes with --split", qrgenerator.ErrCapacityExceeded, (len(payload)+transfer.MaxChunkSize-1)/transfer.MaxChunkSize)
+	}
+	payloads, err := transfer.Split([]byte(payload), chunk)
+	if err != nil {
+		return nil, i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err)
+	}
+	return payloads, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/bundle"
+	"qrgenerator_cli/helpers/config"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/update"
+)
+
+// runBundle arma o instala un paquete (tar.gz) con el binario, los archivos de
+// configuración y sus recursos, para equipos sin acceso a internet
+func runBundle(args []string) int {
+	flags := newFlagSet("bundle")
+	output := flags.String("o", "", i18n.T("Bundle to write (export), such as site.tar.gz"))
+	binary := flags.String("binary", "", i18n.T("Binary to include (export; default: this executable), such as a build for the target platform"))
+	platform := flags.String("platform", runtime.GOOS+"/"+runtime.GOARCH, i18n.T("Platform of the included binary, as GOOS/GOARCH (export)"))
+	configs := &outputList{}
+	flags.Var(configs, "config", i18n.T("Config file to include (export); repeat it for several"))
+	binDir := flags.String("bin-dir", "", i18n.T("Directory for the binary (import; default: the directory of this executable)"))
+	dataDir := flags.String("dir", "", i18n.T("Directory for the config files and assets (import; default: the user config directory)"))
+	list := flags.Bool("list", false, i18n.T("Only verify the bundle and list its files (import)"))
+	force := flags.Bool("force", false, i18n.T("Install a binary built for another platform (import)"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli bundle export -o site.tar.gz [flags] [asset...]\n       qrgenerator_cli bundle import [flags] site.tar.gz\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	// Los flags pueden ir entre los argumentos: bundle export -o site.tar.gz fonts/ --verbose
+	var positional []string
+	for flags.NArg() > 0 {
+		positional = append(positional, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+
+	switch {
+	case len(positional) > 0 && positional[0] == "export" && *output != "":
+		return bundleExport(log, *output, *binary, *platform, configs.paths, positional[1:])
+	case len(positional) == 2 && positional[0] == "import":
+		return bundleImport(log, positional[1], *binDir, *dataDir, *list, *force)
+	}
+	log.Errorf("%v", i18n.T("bundle needs export -o FILE, or import and one bundle"))
+	flags.Usage()
+	return exitInvalidInput
+}
+
+// bundleExport escribe el paquete con el binario, las configuraciones en
+// config/ y los recursos (archivos o directorios) en assets/
+func bundleExport(log *logger.Logger, output, binary, platform string, configs, assets []string) int {
+	if goos, goarch, ok := strings.Cut(platform, "/"); !ok || goos == "" || goarch == "" {
+		log.Errorf("%v", i18n.Errorf("%w: invalid --platform %q; use GOOS/GOARCH such as linux/amd64", qrgenerator.ErrInvalidInput, platform))
+		return exitInvalidInput
+	}
+	manifest := bundle.Manifest{Platform: platform, Created: time.Now().UTC().Truncate(time.Second)}
+	if binary == "" {
+		exe, err := os.Executable()
+		if err == nil {
+			exe, err = filepath.EvalSymlinks(exe)
+		}
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		binary, manifest.Version = exe, version
+	}
+
+	files := []bundle.File{{Source: binary, Path: path.Join(bundle.BinaryDir, filepath.Base(binary))}}
+	for _, source := range configs {
+		// Una configuración inválida se descubriría recién en el equipo sin red
+		if _, err := config.Load(source); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err))
+			return exitInvalidInput
+		}
+		files = append(files, bundle.File{Source: source, Path: path.Join(bundle.ConfigDir, filepath.Base(source))})
+	}
+	for _, source := range assets {
+		collected, err := bundle.Collect(bundle.AssetsDir, source)
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		files = append(files, collected...)
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	err = bundle.Write(out, manifest, files)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrInvalidInput, err))
+		return exitInvalidInput
+	}
+	for _, f := range files {
+		log.Debugf("bundled %s", f.Path)
+	}
+	log.Infof("%d files bundled in %s", len(files), output)
+	return exitOK
+}
+
+// bundleImport verifica el paquete y lo instala: el binario en binDir, de
+// forma atómica si reemplaza a otro, y el resto en dataDir
+func bundleImport(log *logger.Logger, source, binDir, dataDir string, list, force bool) int {
+	in, err := os.Open(source)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	manifest, contents, err := bundle.Read(in)
+	in.Close()
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitFailure
+	}
+
+	if list {
+		fmt.Printf("%s %s %s\n", manifest.Version, manifest.Platform, manifest.Created.Format(time.RFC3339))
+		for _, entry := range manifest.Files {
+			fmt.Printf("%10d  %s\n", entry.Size, entry.Path)
+		}
+		return exitOK
+	}
+
+	if current := runtime.GOOS + "/" + runtime.GOARCH; manifest.Platform != current && !force {
+		log.Errorf("the bundle is for %s and this machine is %s; use -force to install it anyway", manifest.Platform, current)
+		return exitFailure
+	}
+	if binDir == "" {
+		exe, err := os.Executable()
+		if err == nil {
+			exe, err = filepath.EvalSymlinks(exe)
+		}
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		binDir = filepath.Dir(exe)
+	}
+	if dataDir == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		dataDir = filepath.Join(dir, program)
+	}
+
+	for _, entry := range manifest.Files {
+		target := bundle.Target(entry.Path, binDir, dataDir)
+		binary := strings.HasPrefix(entry.Path, bundle.BinaryDir+"/")
+		if err := installFile(target, contents[entry.Path], entry.Mode, binary); err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				log.Errorf("permission denied writing %s; run again with sufficient privileges or choose another directory", target)
+			} else {
+				log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			}
+			return exitIO
+		}
+		log.Debugf("installed %s", target)
+	}
+	log.Infof("bundle %s installed: binary in %s, config and assets in %s", manifest.Version, binDir, dataDir)
+	return exitOK
+}
+
+// installFile escribe un archivo del paquete. Un binario que ya existe se
+// reemplaza de forma atómica, como en self-update, así un ejecutable en uso
+// no queda a medio escribir.
+func installFile(target string, data []byte, mode fs.FileMode, binary bool) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(target); err == nil && binary {
+		return update.Replace(target, data)
+	}
+	return os.WriteFile(target, data, mode.Perm()|0o200)
+}
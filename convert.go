@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// runConvert vuelve a dibujar un QR generado en otro formato. Las
+// exportaciones de la matriz (.json, .pbm, .txt) y las imágenes con manifiesto
+// (--manifest) se dibujan módulo a módulo; las demás imágenes se decodifican y se vuelven a codificar con el mismo nivel de
+// corrección.
+func runConvert(args []string) int {
+	flags := newFlagSet("convert")
+	size := flags.Int("size", 0, i18n.T("Size of the output in pixels (default: the width of the input image, or 256 for a module matrix)"))
+	format := flags.String("format", "", i18n.T("Output format; overrides the extension of the output"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli convert [flags] input output\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	var positional []string
+	for flags.NArg() > 0 {
+		positional = append(positional, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+	if len(positional) != 2 {
+		log.Errorf("%v", i18n.T("convert needs an input and an output file"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	input, output := positional[0], positional[1]
+
+	outputFormat, problems := qrgenerator.ResolveFormat(output, *format)
+	for _, problem := range problems {
+		if !problem.Warning {
+			log.Errorf("%v", problem.Err)
+			return exitInvalidInput
+		}
+		log.Warnf("%v", problem.Err)
+	}
+	outputs := []qrgenerator.Output{{Path: output, Format: outputFormat}}
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+
+	// Las exportaciones de la matriz y los archivos con manifiesto traen los módulos
+	var symbol [][]bool
+	if qrgenerator.IsMatrixFile(input) {
+		if symbol, err = qrgenerator.ParseMatrix(data, input); err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+	} else if manifest, err := qrgenerator.ReadManifest(data); err != nil {
+		log.Warnf("%s: %v; decoding the image instead", input, err)
+	} else if manifest != nil {
+		if symbol, err = manifest.Symbol(); err != nil {
+			log.Warnf("%s: %v; decoding the image instead", input, err)
+		}
+	}
+
+	var results []*qrgenerator.QRResult
+	if symbol != nil {
+		// La matriz tiene que ser un QR legible, y su contenido valida la salida
+		decoded, err := qrcodec.DecodeMatrix(qrcodec.Matrix(symbol))
+		if err != nil {
+			log.Errorf("%s: %v", input, err)
+			return exitFailure
+		}
+		log.Debugf("%s: drawing the stored module matrix", input)
+		config := qrgenerator.QRConfig{URL: decoded.Text, Size: *size, ExtraParams: map[string]string{}}
+		results, err = qrgenerator.GenerateFromMatrix(symbol, config, outputs)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+	} else {
+		img, err := qrcodec.LoadImage(data, input)
+		if err != nil {
+			log.Errorf("%s: %v", input, err)
+			return exitFailure
+		}
+		decoded, err := qrcodec.Decode(img)
+		if err != nil {
+			log.Errorf("%s: %v", input, err)
+			return exitFailure
+		}
+		if decoded.Inverted {
+			log.Warnf("%s: the symbol is inverted; the output has dark modules on a light background", input)
+		}
+		log.Debugf("%s: no module matrix; re-encoding the decoded payload (version %d, level %s)", input, decoded.Version, decoded.Level)
+		config := qrgenerator.QRConfig{URL: decoded.Text, Size: *size, ExtraParams: map[string]string{"ec": decoded.Level.String()}}
+		if config.Size == 0 {
+			config.Size = img.Bounds().Dx()
+		}
+		if decoded.FNC1 {
+			config.ExtraParams["gs1"] = "true"
+		}
+		results, err = qrgenerator.GenerateFormats(config, outputs)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+	}
+
+	for _, result := range results {
+		logResult(log, result)
+	}
+	log.Infof("%s converted to %s", input, output)
+	return exitOK
+}
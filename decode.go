@@ -0,0 +1,248 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/aztec"
+	"qrgenerator_cli/helpers/checkdigit"
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/datamatrix"
+	"qrgenerator_cli/helpers/encrypt"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/payload"
+	"qrgenerator_cli/helpers/pdf417"
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/sign"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// runDecode lee el QR de cada imagen y escribe su contenido en la salida
+// estándar; los payloads generados con --encrypt-to se descifran y los
+// generados con --compress se descomprimen solos. Los cifrados con --encrypt
+// se descifran con -decrypt, que pide la frase. Los QR de una secuencia de
+// structured append se juntan en un solo payload. Sale con exitCheckFailed si
+// faltan QR de la secuencia, la frase no descifra el payload o no coincide la
+// firma o el dígito de control.
+func runDecode(args []string) int {
+	flags := newFlagSet("decode")
+	raw := flags.Bool("raw", false, i18n.T("Print payloads as stored in the symbol, without decrypting or decompressing them, and GS1 element strings with their GS separators instead of the (AI) form"))
+	binary := flags.Bool("binary", false, i18n.T("Write the raw bytes of each symbol, as encoded with --input-file, without reading them as text or adding a newline"))
+	allowInverted := flags.Bool("allow-inverted", false, i18n.T("Do not warn about inverted (light-on-dark) symbols"))
+	decrypt := flags.Bool("decrypt", false, i18n.T("Decrypt payloads encrypted with --encrypt, asking for the passphrase once"))
+	passphraseFile := flags.String("passphrase-file", "", i18n.Sprintf("File whose first line is the passphrase (default: $%s or a prompt)", passphraseEnv))
+	identity := flags.String("identity", "", i18n.T("age identity file to decrypt payloads encrypted to an age or SSH key; GPG payloads use the keyring"))
+	verifySignature := flags.String("verify-signature", "", i18n.T("Verify the JWS signature of each payload, as written by --sign, with this PEM public key, certificate or private key, and print the signed payload"))
+	checkDigit := flags.String("check-digit", "", i18n.T("Verify the check characters of the serial number at the end of each payload: luhn or crc, as in the generator"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli decode [flags] image... (- reads standard input)\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() == 0 {
+		log.Errorf("no images to decode")
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	if *binary && *checkDigit != "" {
+		log.Errorf("%v", i18n.T("-binary cannot be combined with -check-digit"))
+		return exitInvalidInput
+	}
+	if *binary && *verifySignature != "" {
+		log.Errorf("%v", i18n.T("-binary cannot be combined with -verify-signature"))
+		return exitInvalidInput
+	}
+	if *checkDigit != "" {
+		if err := checkdigit.CheckScheme(strings.ToLower(*checkDigit)); err != nil {
+			log.Errorf("%v", err)
+			return exitInvalidInput
+		}
+	}
+
+	code := exitOK
+	// Los símbolos de una secuencia de structured append se juntan y se
+	// muestran una vez, en el lugar del primero que se leyó
+	type decoded struct {
+		path   string
+		result *qrcodec.Result
+		parts  []*qrcodec.Result // Símbolos leídos de la secuencia; nil si está solo
+	}
+	var items []*decoded
+	sequences := map[qrcodec.StructuredAppend]*decoded{}
+	for _, path := range flags.Args() {
+		var result *qrcodec.Result
+		var err error
+		if path == "-" {
+			result, err = qrcodec.DecodeReader(os.Stdin, "")
+		} else {
+			result, err = qrcodec.DecodeFile(path)
+		}
+		if err != nil {
+			log.Errorf("%s: %v", path, err)
+			code = exitFailure
+			continue
+		}
+		switch {
+		case result.Barcode == "code128":
+			log.Debugf("%s: Code 128, %d symbol characters", path, result.Version)
+		case result.Barcode == "code39":
+			log.Debugf("%s: Code 39, %d characters", path, result.Version)
+		case result.Barcode != "":
+			log.Debugf("%s: %s barcode", path, barcodeName(result.Barcode))
+		case result.PDF417 != (pdf417.Size{}):
+			log.Debugf("%s: PDF417 %s (columns x rows), security level %d", path, result.PDF417, result.PDF417Level)
+		case result.Aztec != (aztec.Size{}):
+			log.Debugf("%s: Aztec %s", path, result.Aztec)
+		case result.DataMatrix != (datamatrix.Size{}):
+			log.Debugf("%s: Data Matrix %s", path, result.DataMatrix)
+		case result.Shape != (qrcodec.RMQRShape{}):
+			log.Debugf("%s: rMQR %s, level %s", path, result.Shape, result.Level)
+		default:
+			log.Debugf("%s: version %d, level %s", path, result.Version, result.Level)
+		}
+		if result.ECI != 0 {
+			log.Debugf("%s: ECI %d", path, result.ECI)
+		}
+		// La imagen ya existe: las reglas de colores solo advierten
+		for _, problem := range qrgenerator.ColorProblems(result.Ink, result.Paper, *allowInverted) {
+			log.Warnf("%s: %v", path, problem.Err)
+		}
+
+		sa := result.StructuredAppend
+		if sa == nil {
+			items = append(items, &decoded{path: path, result: result})
+			continue
+		}
+		log.Debugf("%s: structured append, QR %d of %d", path, sa.Index+1, sa.Total)
+		key := qrcodec.StructuredAppend{Total: sa.Total, Parity: sa.Parity}
+		if sequence, ok := sequences[key]; ok {
+			sequence.parts = append(sequence.parts, result)
+			continue
+		}
+		sequences[key] = &decoded{path: path, parts: []*qrcodec.Result{result}}
+		items = append(items, sequences[key])
+	}
+
+	var passphrase *string // Se pide con el primer payload cifrado con frase
+	for _, item := range items {
+		path, result := item.path, item.result
+		if item.parts != nil {
+			total := item.parts[0].StructuredAppend.Total
+			if missing := qrcodec.Missing(item.parts); len(missing) > 0 {
+				for i := range missing {
+					missing[i]++
+				}
+				log.Errorf("%s: structured append: QR codes %s of %d missing", path, transfer.Ranges(missing), total)
+				code = exitCheckFailed
+				continue
+			}
+			joined, err := qrcodec.Join(item.parts)
+			if err != nil {
+				log.Errorf("%s: %v", path, err)
+				code = exitCheckFailed
+				continue
+			}
+			log.Debugf("%s: %d structured append QR codes joined", path, total)
+			result = joined
+		}
+
+		if *binary {
+			// Los bytes no se interpretan como texto (ni Latin-1 ni UTF-8)
+			os.Stdout.Write(result.Bytes())
+			continue
+		}
+
+		text := result.Text
+		switch {
+		case result.FNC1 && !*raw:
+			// Las cadenas GS1 se muestran en su forma legible: (01)...(17)...
+			if readable, ok := payload.GS1Readable(text); ok {
+				text = readable
+			} else {
+				log.Warnf("%s: GS1 data with unknown AIs; printed as transmitted", path)
+			}
+		case !*raw && encrypt.IsPassphraseEncrypted(text):
+			if !*decrypt {
+				log.Errorf("%s: %v", path, i18n.T("the payload is encrypted with a passphrase; pass -decrypt"))
+				code = exitFailure
+				continue
+			}
+			if passphrase == nil {
+				value, err := readPassphrase(*passphraseFile, false)
+				if err != nil {
+					log.Errorf("%v", err)
+					return exitFailure
+				}
+				passphrase = &value
+			}
+			plain, err := encrypt.DecryptPassphrase(text, *passphrase)
+			if err != nil {
+				log.Errorf("%s: %v", path, err)
+				code = exitCheckFailed
+				continue
+			}
+			log.Debugf("%s: decrypted %d -> %d bytes", path, len(text), len(plain))
+			if text, err = decompressed(log, path, plain); err != nil {
+				code = exitFailure
+				continue
+			}
+		case !*raw:
+			plain, encrypted, err := encrypt.Decrypt(text, *identity)
+			if err != nil {
+				log.Errorf("%s: %v", path, err)
+				code = exitFailure
+				continue
+			}
+			if encrypted {
+				log.Debugf("%s: decrypted %d -> %d bytes", path, len(text), len(plain))
+			}
+			if text, err = decompressed(log, path, plain); err != nil {
+				code = exitFailure
+				continue
+			}
+		}
+		if *verifySignature != "" {
+			signed, err := sign.Verify(text, *verifySignature)
+			if err != nil {
+				log.Errorf("%s: %v", path, err)
+				code = exitCheckFailed
+				continue
+			}
+			log.Debugf("%s: signature verified", path)
+			text = signed
+		} else if sign.IsJWS(text) {
+			log.Debugf("%s: signed payload printed as stored; --verify-signature checks it", path)
+		}
+		if *checkDigit != "" {
+			serial, ok, err := checkdigit.Verify(text, strings.ToLower(*checkDigit))
+			if err != nil || !ok {
+				log.Errorf("%s: the check characters of serial %q do not match", path, serial)
+				code = exitCheckFailed
+				continue
+			}
+			log.Debugf("%s: serial %s checked", path, serial)
+		}
+		os.Stdout.WriteString(text + "\n")
+	}
+	return code
+}
+
+// decompressed descomprime el payload si se generó con --compress
+func decompressed(log *logger.Logger, path, text string) (string, error) {
+	inflated, compressed, err := compress.Decompress(text)
+	if err != nil {
+		log.Errorf("%s: %v", path, err)
+		return "", err
+	}
+	if compressed {
+		log.Debugf("%s: decompressed %d -> %d bytes", path, len(text), len(inflated))
+	}
+	return inflated, nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// program es el nombre del binario en las páginas de manual
+const program = "qrgenerator_cli"
+
+// capture guarda el FlagSet de un comando mientras docs lee sus definiciones
+type capture struct {
+	flags *flag.FlagSet
+	usage bytes.Buffer // Lo que escribe su flags.Usage
+}
+
+// capturing no es nil mientras docs recorre los comandos
+var capturing *capture
+
+// newFlagSet crea el FlagSet de un comando. Mientras docs lee las
+// definiciones, el FlagSet se guarda y -h entra en pánico en lugar de salir,
+// así docs recupera el control sin que el comando haga nada.
+func newFlagSet(name string) *flag.FlagSet {
+	if capturing == nil {
+		return flag.NewFlagSet(name, flag.ExitOnError)
+	}
+	flags := flag.NewFlagSet(name, flag.PanicOnError)
+	flags.SetOutput(&capturing.usage)
+	capturing.flags = flags
+	return flags
+}
+
+// commandDoc es lo que documenta un comando: sus flags, tal como los
+// registra, y la línea de uso de su ayuda
+type commandDoc struct {
+	name     string // "" para la generación, "decode"...
+	summary  string
+	synopsis string
+	flags    *flag.FlagSet
+}
+
+// title es el nombre de la página: "qrgenerator_cli" o "qrgenerator_cli-decode"
+func (c commandDoc) title() string {
+	if c.name == "" {
+		return program
+	}
+	return program + "-" + c.name
+}
+
+// describe corre el comando con -h para quedarse con sus flags y su uso
+func describe(name string, run func(args []string) int) (doc commandDoc) {
+	capturing = &capture{}
+	defer func() {
+		// Solo se espera el pánico de -h; cualquier otro es un error de verdad
+		if r := recover(); r != nil && r != flag.ErrHelp {
+			panic(r)
+		}
+		doc.flags = capturing.flags
+		// La primera línea de la ayuda es "Usage: qrgenerator_cli decode [flags] image..."
+		first, _, _ := strings.Cut(capturing.usage.String(), "\n")
+		if _, synopsis, ok := strings.Cut(first, ": "); ok && strings.HasPrefix(synopsis, program) {
+			doc.synopsis = synopsis
+		} else {
+			doc.synopsis = strings.TrimSpace(program+" "+name) + " [flags]"
+		}
+		capturing = nil
+	}()
+	doc.name = name
+	run([]string{"-h"})
+	return doc
+}
+
+// commandDocs documenta la generación y cada subcomando, en orden alfabético
+func commandDocs() []commandDoc {
+	root := describe("", runGenerate)
+	root.summary = i18n.T(commands["generate"].summary)
+	root.synopsis = program + " generate [flags]"
+	docs := []commandDoc{root}
+
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		// La página principal documenta generate
+		if name == "generate" {
+			continue
+		}
+		doc := describe(name, commands[name].run)
+		doc.summary = i18n.T(commands[name].summary)
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// runDocs escribe la documentación de referencia a partir de las definiciones
+// de comandos y flags, en el idioma de --lang
+func runDocs(args []string) int {
+	flags := newFlagSet("docs")
+	output := flags.String("o", "", i18n.T("Output directory for man pages (default: current directory) or file for markdown (default: standard output)"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli docs man|markdown [flags]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	format := flags.Arg(0)
+	if format != "" {
+		// Los flags pueden ir después del formato: docs man -o share/man/man1
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+
+	if format != "man" && format != "markdown" || flags.NArg() > 0 {
+		log.Errorf("%v", i18n.T("docs needs a format: man or markdown"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	docs := commandDocs()
+	if format == "markdown" {
+		text := markdownReference(docs)
+		if *output == "" {
+			os.Stdout.WriteString(text)
+			return exitOK
+		}
+		if err := os.WriteFile(*output, []byte(text), 0o644); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		log.Infof("reference written to %s", *output)
+		return exitOK
+	}
+
+	dir := *output
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	date := manDate()
+	for _, doc := range docs {
+		path := filepath.Join(dir, doc.title()+".1")
+		if err := os.WriteFile(path, []byte(manPage(doc, docs, date)), 0o644); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		log.Debugf("man page written to %s", path)
+	}
+	log.Infof("%d man pages written to %s", len(docs), dir)
+	return exitOK
+}
+
+// manDate es la fecha de las páginas; SOURCE_DATE_EPOCH la fija para que los
+// paquetes se puedan reproducir
+func manDate() string {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC().Format("2006-01-02")
+	}
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// manPage arma la página de manual (roff, sección 1) de un comando. La de la
+// generación lista además los subcomandos.
+func manPage(doc commandDoc, all []commandDoc, date string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".TH %s 1 %q %q %q\n", strings.ToUpper(doc.title()), date, program+" "+version, i18n.T("User Commands"))
+	fmt.Fprintf(&b, ".SH %s\n%s \\- %s\n", i18n.T("NAME"), roffEscape(doc.title()), roffEscape(doc.summary))
+	fmt.Fprintf(&b, ".SH %s\n.B %s\n", i18n.T("SYNOPSIS"), roffEscape(doc.synopsis))
+
+	if doc.name == "" {
+		fmt.Fprintf(&b, ".SH %s\n", i18n.T("COMMANDS"))
+		for _, sub := range all[1:] {
+			fmt.Fprintf(&b, ".TP\n.B %s\n%s\n", roffEscape(sub.name), roffEscape(sub.summary))
+		}
+	}
+
+	fmt.Fprintf(&b, ".SH %s\n", i18n.T("OPTIONS"))
+	doc.flags.VisitAll(func(f *flag.Flag) {
+		arg, usage := flag.UnquoteUsage(f)
+		fmt.Fprintf(&b, ".TP\n\\fB\\-%s\\fR", roffEscape(f.Name))
+		if arg != "" {
+			fmt.Fprintf(&b, " \\fI%s\\fR", roffEscape(arg))
+		}
+		fmt.Fprintf(&b, "\n%s\n", roffEscape(usage+defaultNote(f)))
+	})
+
+	fmt.Fprintf(&b, ".SH %s\n", i18n.T("SEE ALSO"))
+	// La página principal remite a los subcomandos y cada subcomando a la principal
+	refs := []string{"\\fB" + program + "\\fR(1)"}
+	if doc.name == "" {
+		refs = nil
+		for _, sub := range all[1:] {
+			refs = append(refs, "\\fB"+roffEscape(sub.title())+"\\fR(1)")
+		}
+	}
+	b.WriteString(strings.Join(refs, ",\n") + "\n")
+	return b.String()
+}
+
+// roffEscape escapa el texto para roff: barras y guiones, y los puntos o
+// apóstrofos al principio de una línea, que roff tomaría como macros
+func roffEscape(text string) string {
+	text = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownReference arma la referencia en markdown de todos los comandos
+func markdownReference(docs []commandDoc) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", i18n.Sprintf("%s command reference", program))
+	for _, doc := range docs {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n\n```sh\n%s\n```\n\n", strings.TrimSpace(program+" "+doc.name), doc.summary, doc.synopsis)
+		fmt.Fprintf(&b, "| %s | %s |\n|------|-------------|\n", i18n.T("Flag"), i18n.T("Description"))
+		doc.flags.VisitAll(func(f *flag.Flag) {
+			arg, usage := flag.UnquoteUsage(f)
+			name := "-" + f.Name
+			if arg != "" {
+				name += " " + arg
+			}
+			usage = strings.ReplaceAll(strings.ReplaceAll(usage+defaultNote(f), "|", `\|`), "\n", " ")
+			fmt.Fprintf(&b, "| `%s` | %s |\n", name, usage)
+		})
+	}
+	return b.String()
+}
+
+// defaultNote es el valor por defecto de un flag, si no es el valor cero
+func defaultNote(f *flag.Flag) string {
+	switch f.DefValue {
+	case "", "false", "0", "[]":
+		return ""
+	}
+	return " " + i18n.Sprintf("(default: %s)", f.DefValue)
+}
@@ -0,0 +1,206 @@
+// Package aztec codifica y decodifica códigos Aztec (ISO/IEC 24778), los
+// de los pasajes de avión y de transporte: el ojo de buey central, el
+// mensaje de modo y las capas de datos alrededor, en los 4 tamaños
+// compactos y los 32 completos.
+package aztec
+
+import (
+	"fmt"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ErrDataTooLong indica que los datos no entran en ningún tamaño
+var ErrDataTooLong = i18n.NewError("data too long for an Aztec code")
+
+// DefaultECPercent es la corrección que recomienda el estándar: el 23% de
+// las palabras de datos, más 3
+const DefaultECPercent = 23
+
+// Size es un tamaño de Aztec: compacto (1-4 capas) o completo (1-32)
+type Size struct {
+	Compact bool
+	Layers  int
+}
+
+// Modules devuelve los módulos por lado, con la grilla de referencia de
+// los completos
+func (s Size) Modules() int {
+	if s.Compact {
+		return 11 + 4*s.Layers
+	}
+	base := s.baseModules()
+	return base + 1 + 2*((base/2-1)/15)
+}
+
+// baseModules es el lado sin la grilla de referencia
+func (s Size) baseModules() int {
+	if s.Compact {
+		return 11 + 4*s.Layers
+	}
+	return 14 + 4*s.Layers
+}
+
+// totalBits es la cantidad de bits de las capas de datos
+func (s Size) totalBits() int {
+	if s.Compact {
+		return (88 + 16*s.Layers) * s.Layers
+	}
+	return (112 + 16*s.Layers) * s.Layers
+}
+
+// wordSize es el ancho de las palabras de Reed-Solomon según las capas
+func (s Size) wordSize() int {
+	switch {
+	case s.Layers <= 2:
+		return 6
+	case s.Layers <= 8:
+		return 8
+	case s.Layers <= 22:
+		return 10
+	}
+	return 12
+}
+
+// maxDataWords es lo que admite el mensaje de modo: 6 bits en los
+// compactos y 11 en los completos
+func (s Size) maxDataWords() int {
+	if s.Compact {
+		return 64
+	}
+	return 2048
+}
+
+func (s Size) String() string {
+	n := s.Modules()
+	if s.Compact {
+		return fmt.Sprintf("%dx%d compact", n, n)
+	}
+	return fmt.Sprintf("%dx%d", n, n)
+}
+
+// Index es la posición del tamaño: 1-4 los compactos y 5-36 los completos
+func (s Size) Index() int {
+	if s.Compact {
+		return s.Layers
+	}
+	return 4 + s.Layers
+}
+
+// sizes son los tamaños que elige Encode, de menor a mayor: los compactos
+// y los completos desde 4 capas, porque de 1 a 3 un compacto del mismo
+// lado lleva más datos
+var sizes = func() []Size {
+	var list []Size
+	for layers := 1; layers <= 4; layers++ {
+		list = append(list, Size{Compact: true, Layers: layers})
+	}
+	for layers := 4; layers <= 32; layers++ {
+		list = append(list, Size{Layers: layers})
+	}
+	return list
+}()
+
+// SizeOf devuelve el tamaño que elige Encode para un símbolo de modules
+// módulos por lado
+func SizeOf(modules int) (Size, bool) {
+	for _, size := range sizes {
+		if size.Modules() == modules {
+			return size, true
+		}
+	}
+	return Size{}, false
+}
+
+// Largest es el tamaño más grande
+func Largest() Size {
+	return sizes[len(sizes)-1]
+}
+
+// Options son los parámetros de codificación
+type Options struct {
+	ECPercent int  // Corrección mínima, en % de las palabras de datos (más 3); 0 usa DefaultECPercent
+	GS1       bool // Los datos son una cadena GS1: FLG(0) inicial y como separador
+	ECI       int  // Designador ECI; 0 no lo declara
+}
+
+// Symbol es un Aztec codificado
+type Symbol struct {
+	Matrix [][]bool // Filas de módulos, true es oscuro; sin zona de silencio
+	Size   Size
+}
+
+// Encode codifica data en el tamaño más chico que lo contenga con la
+// corrección pedida. Si no entra en ninguno devuelve ErrDataTooLong.
+func Encode(data []byte, opts Options) (*Symbol, error) {
+	percent := opts.ECPercent
+	if percent == 0 {
+		percent = DefaultECPercent
+	}
+	bits := encodeHighLevel(data, opts)
+	for _, size := range sizes {
+		words := stuff(bits, size.wordSize())
+		total := size.totalBits() / size.wordSize()
+		if len(words) > size.maxDataWords() || len(words)+(len(words)*percent+99)/100+3 > total {
+			continue
+		}
+		return &Symbol{Matrix: render(size, words), Size: size}, nil
+	}
+	return nil, ErrDataTooLong
+}
+
+// Bits devuelve cuántos bits ocupa data antes del relleno, para comparar
+// con la capacidad de Largest
+func Bits(data []byte, opts Options) int {
+	return len(encodeHighLevel(data, opts))
+}
+
+// Capacity devuelve los bits de datos que entran en size con la
+// corrección pedida, sin contar el relleno de las palabras
+func Capacity(size Size, ecPercent int) int {
+	if ecPercent == 0 {
+		ecPercent = DefaultECPercent
+	}
+	total := size.totalBits() / size.wordSize()
+	words := min(size.maxDataWords(), total-3)
+	for words > 0 && words+(words*ecPercent+99)/100+3 > total {
+		words--
+	}
+	return words * size.wordSize()
+}
+
+// stuff parte los bits en palabras e inserta un bit cuando una palabra
+// quedaría toda en 0 o toda en 1, que el estándar reserva; la última se
+// completa con unos. Un mensaje vacío lleva una palabra de relleno, porque
+// el mensaje de modo no puede indicar cero.
+func stuff(bits []bool, wordSize int) []int {
+	var words []int
+	mask := 1<<wordSize - 2
+	for i := 0; i < len(bits); {
+		word := 0
+		for j := 0; j < wordSize-1; j++ {
+			if i+j >= len(bits) || bits[i+j] {
+				word |= 1 << (wordSize - 1 - j)
+			}
+		}
+		switch word {
+		case mask:
+			// Los primeros wordSize-1 bits en 1: se completa con 0
+			words = append(words, word)
+			i += wordSize - 1
+		case 0:
+			words = append(words, 1)
+			i += wordSize - 1
+		default:
+			if i+wordSize-1 >= len(bits) || bits[i+wordSize-1] {
+				word |= 1
+			}
+			words = append(words, word)
+			i += wordSize
+		}
+	}
+	if len(words) == 0 {
+		words = append(words, mask)
+	}
+	return words
+}
@@ -0,0 +1,246 @@
+package aztec
+
+import (
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un Aztec reconocible
+var errNotFound = i18n.NewError("no Aztec code found in the image")
+
+// Result es el contenido decodificado de un Aztec
+type Result struct {
+	Data      []byte // Datos, con GS entre los campos de una cadena GS1
+	Size      Size
+	ECI       int  // Designador ECI, 0 si no se declaró
+	GS1       bool // El mensaje empieza con FLG(0)
+	Corrected int  // Palabras corregidas por Reed-Solomon
+}
+
+// DecodeMatrix decodifica una grilla de módulos ya muestreada, sin zona de
+// silencio y derecha (con las marcas de orientación donde las pone render)
+func DecodeMatrix(matrix [][]bool) (*Result, error) {
+	n := len(matrix)
+	if n == 0 || n%2 == 0 || len(matrix[0]) != n {
+		return nil, errNotFound
+	}
+	center := n / 2
+	at := func(dx, dy int) bool {
+		x, y := center+dx, center+dy
+		return x >= 0 && y >= 0 && x < n && y < n && matrix[y][x]
+	}
+	var size Size
+	var dataWords int
+	var err error
+	for _, compact := range []bool{true, false} {
+		size, dataWords, err = readMode(at, compact)
+		if err == nil && size.Modules() == n && dataWords < size.totalBits()/size.wordSize() {
+			break
+		}
+		err = errNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	positions := size.dataPositions()
+	bits := make([]bool, len(positions))
+	for i, p := range positions {
+		bits[i] = matrix[p.y][p.x]
+	}
+	ws := size.wordSize()
+	words := toWords(bits[size.totalBits()%ws:], ws)
+	corrected, err := fields[ws].Correct(words, len(words)-dataWords)
+	if err != nil {
+		return nil, err
+	}
+	message, err := unstuff(words[:dataWords], ws)
+	if err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	result := &Result{Size: size, Corrected: corrected}
+	if err := decodeHighLevel(message, result); err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	return result, nil
+}
+
+// readMode lee el mensaje de modo alrededor del ojo de buey, con at
+// relativo al centro, y devuelve el tamaño y las palabras de datos
+func readMode(at func(dx, dy int) bool, compact bool) (Size, int, error) {
+	positions := modePositions(compact)
+	bits := make([]bool, len(positions))
+	for i, p := range positions {
+		bits[i] = at(p.x, p.y)
+	}
+	words := toWords(bits, 4)
+	ecc := 6
+	if compact {
+		ecc = 5
+	}
+	if _, err := fields[4].Correct(words, ecc); err != nil {
+		return Size{}, 0, err
+	}
+	if compact {
+		value := words[0]<<4 | words[1]
+		return Size{Compact: true, Layers: value>>6 + 1}, value&63 + 1, nil
+	}
+	value := words[0]<<12 | words[1]<<8 | words[2]<<4 | words[3]
+	return Size{Layers: value>>11 + 1}, value&2047 + 1, nil
+}
+
+// unstuff deshace el relleno de stuff: las palabras 1 y 2^n-2 valen n-1
+// ceros o unos, y las que son todo 0 o todo 1 no son válidas
+func unstuff(words []int, wordSize int) ([]bool, error) {
+	var out bitWriter
+	for _, word := range words {
+		switch word {
+		case 0, 1<<wordSize - 1:
+			return nil, i18n.NewError("invalid codeword")
+		case 1:
+			out.write(0, wordSize-1)
+		case 1<<wordSize - 2:
+			out.write(1<<(wordSize-1)-1, wordSize-1)
+		default:
+			out.write(word, wordSize)
+		}
+	}
+	return out, nil
+}
+
+// Detect busca un Aztec derecho en una imagen binarizada: el ojo de buey da
+// el centro y el tamaño del módulo, el mensaje de modo el tamaño del
+// símbolo. Devuelve la grilla de módulos muestreada.
+func Detect(dark func(x, y int) bool, width, height int) ([][]bool, error) {
+	for y := 0; y < height; y++ {
+		for _, h := range bullseyes(func(i int) bool { return dark(i, y) }, width) {
+			for _, v := range bullseyes(func(i int) bool { return dark(int(h.center), i) }, height) {
+				if v.module < h.module/1.5 || v.module > h.module*1.5 || v.center < float64(y)-h.module || v.center > float64(y)+h.module {
+					continue
+				}
+				if matrix := sample(dark, width, height, h.center, v.center, h.module); matrix != nil {
+					return matrix, nil
+				}
+			}
+		}
+	}
+	return nil, errNotFound
+}
+
+// candidate es un posible ojo de buey en una línea: el centro y el ancho
+// del módulo, en píxeles
+type candidate struct {
+	center, module float64
+}
+
+// bullseyes busca en una línea los anillos del ojo de buey: siete tramos
+// iguales con uno oscuro en el medio, entre dos oscuros que pueden seguir
+// con el mensaje de modo. Los datos pueden repetir el patrón, así que
+// devuelve todos los candidatos.
+func bullseyes(dark func(i int) bool, length int) []candidate {
+	var starts []int
+	for i := 0; i < length; i++ {
+		if i == 0 || dark(i) != dark(i-1) {
+			starts = append(starts, i)
+		}
+	}
+	starts = append(starts, length)
+	var found []candidate
+	for k := 4; k+5 < len(starts); k++ {
+		if !dark(starts[k]) {
+			continue
+		}
+		module := float64(starts[k+4]-starts[k-3]) / 7
+		ok := true
+		for j := k - 3; j <= k+3; j++ {
+			if run := float64(starts[j+1] - starts[j]); run < module/2 || run > module*1.5 {
+				ok = false
+			}
+		}
+		for _, j := range []int{k - 4, k + 4} {
+			if float64(starts[j+1]-starts[j]) < module/2 {
+				ok = false
+			}
+		}
+		if ok {
+			found = append(found, candidate{float64(starts[k]+starts[k+1]) / 2, module})
+		}
+	}
+	return found
+}
+
+// sample lee el mensaje de modo con el centro y el módulo del ojo de buey y
+// muestrea la grilla del tamaño que indica; nil si no hay un mensaje válido
+func sample(dark func(x, y int) bool, width, height int, cx, cy, module float64) [][]bool {
+	at := func(dx, dy int) bool {
+		x, y := int(cx+float64(dx)*module), int(cy+float64(dy)*module)
+		return x >= 0 && y >= 0 && x < width && y < height && dark(x, y)
+	}
+	for _, compact := range []bool{false, true} {
+		size, _, err := readMode(at, compact)
+		if err != nil || !rings(at, compact) {
+			continue
+		}
+		n := size.Modules()
+		half := float64(n) / 2
+
+		// El recuadro de los módulos oscuros afina el paso si coincide con
+		// el tamaño dentro de un módulo; los bordes pueden quedar claros
+		minX, minY, maxX, maxY := width, height, -1, -1
+		reach := (half + 1) * module
+		for y := max(0, int(cy-reach)); y < min(height, int(cy+reach)); y++ {
+			for x := max(0, int(cx-reach)); x < min(width, int(cx+reach)); x++ {
+				if dark(x, y) {
+					minX, minY = min(minX, x), min(minY, y)
+					maxX, maxY = max(maxX, x), max(maxY, y)
+				}
+			}
+		}
+		originX, stepX := cx-half*module, module
+		if w := float64(maxX - minX + 1); w > float64(n-1)*module && w < float64(n+1)*module {
+			originX, stepX = float64(minX), w/float64(n)
+		}
+		originY, stepY := cy-half*module, module
+		if h := float64(maxY - minY + 1); h > float64(n-1)*module && h < float64(n+1)*module {
+			originY, stepY = float64(minY), h/float64(n)
+		}
+
+		matrix := make([][]bool, n)
+		for row := range matrix {
+			matrix[row] = make([]bool, n)
+			for col := range matrix[row] {
+				x := int(originX + (float64(col)+0.5)*stepX)
+				y := int(originY + (float64(row)+0.5)*stepY)
+				matrix[row][col] = x >= 0 && y >= 0 && x < width && y < height && dark(x, y)
+			}
+		}
+		return matrix
+	}
+	return nil
+}
+
+// rings verifica los anillos del ojo de buey alrededor del centro, con
+// tolerancia para unos pocos módulos mal leídos: el patrón de una línea
+// puede repetirse en los datos, el de todo el cuadrado no
+func rings(at func(dx, dy int) bool, compact bool) bool {
+	eye := 6
+	if compact {
+		eye = 4
+	}
+	wrong, total := 0, 0
+	for dy := -eye; dy <= eye; dy++ {
+		for dx := -eye; dx <= eye; dx++ {
+			if at(dx, dy) != (max(abs(dx), abs(dy))%2 == 0) {
+				wrong++
+			}
+			total++
+		}
+	}
+	return wrong <= total/10
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,462 @@
+package aztec
+
+import (
+	"slices"
+	"strconv"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// mode es una de las tablas de caracteres del estándar
+type mode int
+
+// Tablas de caracteres; Digit usa palabras de 4 bits y las demás de 5
+const (
+	modeUpper mode = iota
+	modeLower
+	modeMixed
+	modeDigit
+	modePunct
+)
+
+// Códigos de control comunes a varias tablas
+const (
+	codePS = 0  // Punct Shift (en Punct, FLG(n))
+	codeBS = 31 // Binary Shift en Upper, Lower y Mixed
+)
+
+// gs es el separador de campos GS1 dentro de los datos
+const gs = 0x1d
+
+// tables son los caracteres de cada tabla por código; los códigos de
+// control quedan vacíos
+var tables = [5][]string{
+	modeUpper: append(append([]string{"", " "}, letters('A')...), "", "", "", ""),
+	modeLower: append(append([]string{"", " "}, letters('a')...), "", "", "", ""),
+	modeMixed: {"", " ", "\x01", "\x02", "\x03", "\x04", "\x05", "\x06", "\x07", "\b", "\t", "\n", "\x0b", "\f", "\r",
+		"\x1b", "\x1c", "\x1d", "\x1e", "\x1f", "@", "\\", "^", "_", "`", "|", "~", "\x7f", "", "", "", ""},
+	modeDigit: {"", " ", "0", "1", "2", "3", "4", "5", "6", "7", "8", "9", ",", ".", "", ""},
+	modePunct: {"", "\r", "\r\n", ". ", ", ", ": ", "!", "\"", "#", "$", "%", "&", "'", "(", ")",
+		"*", "+", ",", "-", ".", "/", ":", ";", "<", "=", ">", "?", "[", "]", "{", "}", ""},
+}
+
+// letters devuelve las 26 letras desde first
+func letters(first byte) []string {
+	out := make([]string, 26)
+	for i := range out {
+		out[i] = string(rune(first) + rune(i))
+	}
+	return out
+}
+
+// codes[m][c] es el código del byte c en la tabla m, más uno; 0 si no está
+var codes [5][256]int
+
+func init() {
+	for m, table := range tables {
+		for code, text := range table {
+			if len(text) == 1 {
+				codes[m][text[0]] = code + 1
+			}
+		}
+	}
+}
+
+// bitWriter acumula bits, del más significativo al menos significativo
+type bitWriter []bool
+
+func (w *bitWriter) write(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*w = append(*w, value>>i&1 == 1)
+	}
+}
+
+// highLevel escribe el flujo de bits del mensaje y lleva la tabla actual
+type highLevel struct {
+	out  bitWriter
+	mode mode
+}
+
+// code escribe un código con el ancho de la tabla actual
+func (h *highLevel) code(value int) {
+	if h.mode == modeDigit {
+		h.out.write(value, 4)
+	} else {
+		h.out.write(value, 5)
+	}
+}
+
+// latch pasa a la tabla to, pasando por Upper o Mixed si no hay un latch directo
+func (h *highLevel) latch(to mode) {
+	for h.mode != to {
+		switch {
+		case h.mode == modePunct:
+			h.code(31)
+			h.mode = modeUpper
+		case h.mode == modeDigit:
+			h.code(14)
+			h.mode = modeUpper
+		case to == modeUpper && h.mode == modeMixed:
+			h.code(29)
+			h.mode = modeUpper
+		case to == modeUpper:
+			// Lower no tiene U/L: se pasa por Digit
+			h.code(30)
+			h.mode = modeDigit
+		case to == modeLower:
+			h.code(28)
+			h.mode = modeLower
+		case to == modeMixed:
+			h.code(29)
+			h.mode = modeMixed
+		case to == modeDigit && h.mode == modeMixed:
+			h.code(29)
+			h.mode = modeUpper
+		case to == modeDigit:
+			h.code(30)
+			h.mode = modeDigit
+		case to == modePunct && h.mode == modeMixed:
+			h.code(30)
+			h.mode = modePunct
+		default:
+			h.code(29)
+			h.mode = modeMixed
+		}
+	}
+}
+
+// punct escribe un código de Punct, con P/S si no es la tabla actual
+func (h *highLevel) punct(value int) {
+	if h.mode != modePunct {
+		h.code(codePS)
+	}
+	h.out.write(value, 5)
+}
+
+// binary escribe bytes con Binary Shift, en tramos de hasta 2078
+func (h *highLevel) binary(data []byte) {
+	if h.mode == modeDigit || h.mode == modePunct {
+		h.latch(modeUpper)
+	}
+	for len(data) > 0 {
+		n := min(len(data), 2078)
+		h.code(codeBS)
+		if n <= 31 {
+			h.out.write(n, 5)
+		} else {
+			h.out.write(0, 5)
+			h.out.write(n-31, 11)
+		}
+		for _, b := range data[:n] {
+			h.out.write(int(b), 8)
+		}
+		data = data[n:]
+	}
+}
+
+// encodeHighLevel convierte los datos en el flujo de bits del mensaje. Las
+// cadenas GS1 empiezan con FLG(0) y lo usan como separador; el ECI va en
+// un FLG(n) con sus dígitos.
+func encodeHighLevel(data []byte, opts Options) []bool {
+	h := &highLevel{}
+	if opts.GS1 {
+		h.punct(0)
+		h.out.write(0, 3)
+	}
+	if opts.ECI > 0 {
+		digits := strconv.Itoa(opts.ECI)
+		h.punct(0)
+		h.out.write(len(digits), 3)
+		for _, d := range digits {
+			h.out.write(int(d-'0')+2, 4)
+		}
+	}
+
+	i := 0
+	for _, a := range plan(data, opts.GS1) {
+		switch a.kind {
+		case actChar:
+			h.latch(a.to)
+			h.code(charCode(a.to, data[i:i+a.n]))
+		case actPunctShift:
+			h.punct(charCode(modePunct, data[i:i+a.n]))
+		case actUpperShift:
+			// U/S: 28 en Lower, 15 en Digit
+			if h.mode == modeLower {
+				h.code(28)
+			} else {
+				h.code(15)
+			}
+			h.out.write(charCode(modeUpper, data[i:i+1]), 5)
+		case actBinary:
+			h.binary(data[i : i+a.n])
+		case actFLG:
+			h.punct(0)
+			h.out.write(0, 3)
+		}
+		i += a.n
+	}
+	return h.out
+}
+
+// actionKind es una forma de escribir uno o más bytes
+type actionKind int
+
+const (
+	actChar       actionKind = iota // Un carácter, o un par de Punct, en la tabla to, con latch si hace falta
+	actPunctShift                   // Un carácter o un par de Punct con P/S
+	actUpperShift                   // Una mayúscula con U/S desde Lower o Digit
+	actBinary                       // n bytes con Binary Shift
+	actFLG                          // El separador GS1, como FLG(0)
+)
+
+// action es un paso del plan: cómo escribir los n bytes siguientes
+type action struct {
+	kind actionKind
+	to   mode
+	n    int
+}
+
+// latchBits[from][to] son los bits del latch de una tabla a otra
+var latchBits = func() (bits [5][5]int) {
+	for from := range bits {
+		for to := range bits[from] {
+			h := &highLevel{mode: mode(from)}
+			h.latch(mode(to))
+			bits[from][to] = len(h.out)
+		}
+	}
+	return bits
+}()
+
+// width es el ancho de los códigos de la tabla
+func width(m mode) int {
+	if m == modeDigit {
+		return 4
+	}
+	return 5
+}
+
+// plan elige cómo escribir data con la menor cantidad de bits: busca el
+// camino más corto entre los estados (bytes escritos, tabla actual),
+// probando cada tabla con su latch, los shifts a Punct y a Upper y los
+// tramos con Binary Shift, que convienen en los datos que cambian de tabla
+// a cada rato.
+func plan(data []byte, gs1 bool) []action {
+	type state struct {
+		bits int
+		prev mode
+		step action
+	}
+	const unreached = -1
+	states := make([][5]state, len(data)+1)
+	for i := range states {
+		for m := range states[i] {
+			states[i][m].bits = unreached
+		}
+	}
+	states[0][modeUpper].bits = 0
+	relax := func(i int, m mode, bits int, prev mode, step action) {
+		if s := &states[i][m]; s.bits == unreached || bits < s.bits {
+			*s = state{bits, prev, step}
+		}
+	}
+
+	for i, c := range data {
+		for from := range mode(5) {
+			bits := states[i][from].bits
+			if bits == unreached {
+				continue
+			}
+			if gs1 && c == gs {
+				flag := 5 + 3
+				if from != modePunct {
+					flag += width(from)
+				}
+				relax(i+1, from, bits+flag, from, action{kind: actFLG, n: 1})
+				continue
+			}
+			for to := range mode(5) {
+				if codes[to][c] != 0 {
+					relax(i+1, to, bits+latchBits[from][to]+width(to), from, action{kind: actChar, to: to, n: 1})
+				}
+			}
+			if i+1 < len(data) && pairCode(data[i:i+2]) != 0 {
+				relax(i+2, modePunct, bits+latchBits[from][modePunct]+5, from, action{kind: actChar, to: modePunct, n: 2})
+				if from != modePunct {
+					relax(i+2, from, bits+width(from)+5, from, action{kind: actPunctShift, n: 2})
+				}
+			}
+			if codes[modePunct][c] != 0 && from != modePunct {
+				relax(i+1, from, bits+width(from)+5, from, action{kind: actPunctShift, n: 1})
+			}
+			if codes[modeUpper][c] != 0 && (from == modeLower || from == modeDigit) {
+				relax(i+1, from, bits+width(from)+5, from, action{kind: actUpperShift, n: 1})
+			}
+
+			// Binary Shift: desde Digit y Punct hay que pasar antes a Upper
+			to, cost := from, bits
+			if from == modeDigit || from == modePunct {
+				to, cost = modeUpper, bits+latchBits[from][modeUpper]
+			}
+			cost += width(to) + 5
+			for n := 1; n <= 2078 && i+n <= len(data) && !(gs1 && data[i+n-1] == gs); n++ {
+				if n == 32 {
+					cost += 11
+				}
+				relax(i+n, to, cost+8*n, from, action{kind: actBinary, n: n})
+			}
+		}
+	}
+
+	// Se reconstruye el camino desde la tabla final más barata
+	last := mode(-1)
+	for m := range mode(5) {
+		if s := states[len(data)][m].bits; s != unreached && (last < 0 || s < states[len(data)][last].bits) {
+			last = m
+		}
+	}
+	var steps []action
+	for i, m := len(data), last; i > 0; {
+		s := states[i][m]
+		steps = append(steps, s.step)
+		i, m = i-s.step.n, s.prev
+	}
+	slices.Reverse(steps)
+	return steps
+}
+
+// charCode es el código de un carácter, o de un par de Punct, en la tabla m
+func charCode(m mode, text []byte) int {
+	if len(text) == 2 {
+		return pairCode(text)
+	}
+	return codes[m][text[0]] - 1
+}
+
+// pairCode devuelve el código de Punct de un par como ". " o CR LF; 0 si no lo es
+func pairCode(pair []byte) int {
+	for code := 2; code <= 5; code++ {
+		if tables[modePunct][code] == string(pair) {
+			return code
+		}
+	}
+	return 0
+}
+
+// bitReader lee el flujo de bits del mensaje
+type bitReader struct {
+	bits []bool
+	pos  int
+}
+
+func (r *bitReader) left() int {
+	return len(r.bits) - r.pos
+}
+
+func (r *bitReader) read(n int) int {
+	value := 0
+	for range n {
+		value <<= 1
+		if r.bits[r.pos] {
+			value |= 1
+		}
+		r.pos++
+	}
+	return value
+}
+
+// decodeHighLevel interpreta el flujo de bits del mensaje. Los bits de
+// relleno del final no llegan a formar un carácter y se descartan.
+func decodeHighLevel(bits []bool, result *Result) error {
+	r := &bitReader{bits: bits}
+	latched, current := modeUpper, modeUpper
+	for {
+		size := 5
+		if current == modeDigit {
+			size = 4
+		}
+		if r.left() < size {
+			return nil
+		}
+		code := r.read(size)
+		if text := tables[current][code]; text != "" {
+			result.Data = append(result.Data, text...)
+			current = latched
+			continue
+		}
+
+		switch {
+		case current == modePunct && code == 0:
+			// FLG(n): FNC1 con n = 0, ECI de n dígitos con 1 a 6
+			if r.left() < 3 {
+				return nil
+			}
+			n := r.read(3)
+			switch {
+			case n == 0 && len(result.Data) == 0 && !result.GS1:
+				result.GS1 = true
+			case n == 0:
+				result.Data = append(result.Data, gs)
+			case n == 7:
+				return i18n.NewError("invalid FLG(7)")
+			default:
+				if r.left() < 4*n {
+					return i18n.NewError("truncated ECI designator")
+				}
+				eci := 0
+				for range n {
+					eci = eci*10 + r.read(4) - 2
+				}
+				result.ECI = eci
+			}
+			current = latched
+		case code == codePS:
+			current = modePunct
+		case current != modeDigit && current != modePunct && code == codeBS:
+			if r.left() < 5 {
+				return nil
+			}
+			n := r.read(5)
+			if n == 0 {
+				if r.left() < 11 {
+					return nil
+				}
+				n = r.read(11) + 31
+			}
+			for range n {
+				if r.left() < 8 {
+					return nil
+				}
+				result.Data = append(result.Data, byte(r.read(8)))
+			}
+			current = latched
+		default:
+			next, latch := controlTarget(current, code)
+			if latch {
+				latched = next
+			}
+			current = next
+		}
+	}
+}
+
+// controlTarget devuelve la tabla a la que lleva un código de control y si
+// es un latch (o un shift, que vale para un solo carácter)
+func controlTarget(m mode, code int) (mode, bool) {
+	switch m {
+	case modeUpper:
+		return [...]mode{28: modeLower, 29: modeMixed, 30: modeDigit}[code], true
+	case modeLower:
+		if code == 28 {
+			return modeUpper, false
+		}
+		return [...]mode{29: modeMixed, 30: modeDigit}[code], true
+	case modeMixed:
+		return [...]mode{28: modeLower, 29: modeUpper, 30: modePunct}[code], true
+	case modeDigit:
+		return modeUpper, code == 14
+	}
+	// Punct: 31 es U/L
+	return modeUpper, true
+}
@@ -0,0 +1,180 @@
+package aztec
+
+// point es un módulo: columna y fila
+type point struct{ x, y int }
+
+// alignment convierte una coordenada sin grilla de referencia a la del
+// símbolo: en los completos se saltea una línea cada 15 módulos desde el centro
+func (s Size) alignment() []int {
+	base := s.baseModules()
+	align := make([]int, base)
+	if s.Compact {
+		for i := range align {
+			align[i] = i
+		}
+		return align
+	}
+	origCenter, center := base/2, s.Modules()/2
+	for i := 0; i < origCenter; i++ {
+		offset := i + i/15
+		align[origCenter-i-1] = center - offset - 1
+		align[origCenter+i] = center + offset + 1
+	}
+	return align
+}
+
+// dataPositions devuelve el módulo de cada bit de las capas de datos: cada
+// capa tiene dos módulos de ancho y se recorre en espiral, de la exterior
+// a la interior
+func (s Size) dataPositions() []point {
+	align := s.alignment()
+	base := s.baseModules()
+	positions := make([]point, s.totalBits())
+	rowOffset := 0
+	for i := 0; i < s.Layers; i++ {
+		rowSize := (s.Layers-i)*4 + 9
+		if !s.Compact {
+			rowSize += 3
+		}
+		for j := 0; j < rowSize; j++ {
+			column := j * 2
+			for k := 0; k < 2; k++ {
+				positions[rowOffset+column+k] = point{align[i*2+k], align[i*2+j]}
+				positions[rowOffset+rowSize*2+column+k] = point{align[i*2+j], align[base-1-i*2-k]}
+				positions[rowOffset+rowSize*4+column+k] = point{align[base-1-i*2-k], align[base-1-i*2-j]}
+				positions[rowOffset+rowSize*6+column+k] = point{align[base-1-i*2-j], align[i*2+k]}
+			}
+		}
+		rowOffset += rowSize * 8
+	}
+	return positions
+}
+
+// modePositions devuelve los módulos del mensaje de modo, relativos al
+// centro: 28 bits alrededor del ojo de buey de los compactos y 40 en los completos
+func modePositions(compact bool) []point {
+	if compact {
+		positions := make([]point, 28)
+		for i := 0; i < 7; i++ {
+			offset := -3 + i
+			positions[i] = point{offset, -5}
+			positions[i+7] = point{5, offset}
+			positions[20-i] = point{offset, 5}
+			positions[27-i] = point{-5, offset}
+		}
+		return positions
+	}
+	positions := make([]point, 40)
+	for i := 0; i < 10; i++ {
+		offset := -5 + i + i/5
+		positions[i] = point{offset, -7}
+		positions[i+10] = point{7, offset}
+		positions[29-i] = point{offset, 7}
+		positions[39-i] = point{-7, offset}
+	}
+	return positions
+}
+
+// modeMessage arma el mensaje de modo: capas y palabras de datos, con su
+// corrección en GF(16)
+func modeMessage(size Size, dataWords int) []bool {
+	var w bitWriter
+	if size.Compact {
+		w.write(size.Layers-1, 2)
+		w.write(dataWords-1, 6)
+	} else {
+		w.write(size.Layers-1, 5)
+		w.write(dataWords-1, 11)
+	}
+	words := toWords(w, 4)
+	ecc := 5
+	if !size.Compact {
+		ecc = 6
+	}
+	return fromWords(append(words, fields[4].Encode(words, ecc)...), 4, 0)
+}
+
+// toWords agrupa los bits en palabras de wordSize bits
+func toWords(bits []bool, wordSize int) []int {
+	words := make([]int, len(bits)/wordSize)
+	for i, b := range bits[:len(words)*wordSize] {
+		if b {
+			words[i/wordSize] |= 1 << (wordSize - 1 - i%wordSize)
+		}
+	}
+	return words
+}
+
+// fromWords escribe las palabras como bits, después de pad bits en 0
+func fromWords(words []int, wordSize, pad int) []bool {
+	var w bitWriter
+	w.write(0, pad)
+	for _, word := range words {
+		w.write(word, wordSize)
+	}
+	return w
+}
+
+// render dibuja el símbolo: las capas de datos con su corrección, el
+// mensaje de modo, el ojo de buey con sus marcas de orientación y, en los
+// completos, la grilla de referencia
+func render(size Size, words []int) [][]bool {
+	n := size.Modules()
+	matrix := make([][]bool, n)
+	for y := range matrix {
+		matrix[y] = make([]bool, n)
+	}
+	set := func(x, y int) { matrix[y][x] = true }
+
+	ws := size.wordSize()
+	total := size.totalBits() / ws
+	message := append(append([]int(nil), words...), fields[ws].Encode(words, total-len(words))...)
+	bits := fromWords(message, ws, size.totalBits()%ws)
+	for i, p := range size.dataPositions() {
+		if bits[i] {
+			set(p.x, p.y)
+		}
+	}
+
+	center := n / 2
+	mode := modeMessage(size, len(words))
+	for i, p := range modePositions(size.Compact) {
+		if mode[i] {
+			set(center+p.x, center+p.y)
+		}
+	}
+
+	eye := 7
+	if size.Compact {
+		eye = 5
+	}
+	for i := 0; i < eye; i += 2 {
+		for j := center - i; j <= center+i; j++ {
+			set(j, center-i)
+			set(j, center+i)
+			set(center-i, j)
+			set(center+i, j)
+		}
+	}
+	// Marcas de orientación en las esquinas del mensaje de modo
+	set(center-eye, center-eye)
+	set(center-eye+1, center-eye)
+	set(center-eye, center-eye+1)
+	set(center+eye, center-eye)
+	set(center+eye, center-eye+1)
+	set(center+eye, center+eye-1)
+
+	// Grilla de referencia de los completos: líneas alternadas cada 16
+	// módulos desde el centro, en fase con el ojo de buey
+	if !size.Compact {
+		for i, j := 0, 0; i < size.baseModules()/2-1; i, j = i+15, j+16 {
+			for k := center & 1; k < n; k += 2 {
+				set(center-j, k)
+				set(center+j, k)
+				set(k, center-j)
+				set(k, center+j)
+			}
+		}
+	}
+	return matrix
+}
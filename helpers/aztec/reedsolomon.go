@@ -0,0 +1,13 @@
+package aztec
+
+import "qrgenerator_cli/helpers/reedsolomon"
+
+// Campos del estándar: el del mensaje de modo y los de 6, 8, 10 y 12 bits,
+// con el generador desde alfa^1
+var fields = map[int]*reedsolomon.Field{
+	4:  reedsolomon.NewField(0x13, 16, 1),
+	6:  reedsolomon.NewField(0x43, 64, 1),
+	8:  reedsolomon.NewField(0x12d, 256, 1),
+	10: reedsolomon.NewField(0x409, 1024, 1),
+	12: reedsolomon.NewField(0x1069, 4096, 1),
+}
@@ -0,0 +1,86 @@
+package backupcodes
+
+import (
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/pdf"
+)
+
+// Medidas de la hoja en puntos: diez códigos por hoja en dos columnas y el
+// QR maestro abajo, en la última
+const (
+	margin       = 40
+	codeSide     = 72
+	rowHeight    = 96
+	codesPerPage = 10
+	masterSide   = 170
+)
+
+// Sheet describe la hoja de códigos de recuperación a imprimir
+type Sheet struct {
+	Title   string    // Servicio o sistema de los códigos
+	Account string    // Usuario al que se entregan
+	Created time.Time // Fecha de emisión
+	Codes   []Code
+
+	Master     [][]bool // Módulos del QR con todos los códigos cifrados
+	MasterNote string   // Cómo descifrar el QR maestro
+}
+
+// Code es un código de recuperación, como texto y como QR
+type Code struct {
+	Text    string
+	Modules [][]bool
+}
+
+// Layout arma el PDF: cada hoja lleva el encabezado y hasta diez códigos
+// numerados, cada uno con su QR; la última agrega el QR maestro
+func Layout(sheet Sheet) *pdf.Document {
+	doc := &pdf.Document{}
+	pages := max(1, (len(sheet.Codes)+codesPerPage-1)/codesPerPage)
+	column := (pdf.A4Width - 2*margin) / 2
+
+	for p := 0; p < pages; p++ {
+		page := doc.AddPage()
+		y := pdf.A4Height - margin - 18
+		title := i18n.T("Backup codes")
+		if sheet.Title != "" {
+			title = i18n.Sprintf("%s backup codes", sheet.Title)
+		}
+		page.Text(margin, y, 18, true, title)
+		y -= 18
+		if sheet.Account != "" {
+			page.Text(margin, y, 9, false, i18n.Sprintf("Account: %s - issued %s", sheet.Account, sheet.Created.Format("2006-01-02")))
+		} else {
+			page.Text(margin, y, 9, false, i18n.Sprintf("Issued %s", sheet.Created.Format("2006-01-02")))
+		}
+		y -= 12
+		page.Text(margin, y, 9, false, i18n.T("Each code works once: cross it out after using it. Keep this sheet somewhere safe."))
+
+		for slot := 0; slot < codesPerPage; slot++ {
+			i := p*codesPerPage + slot
+			if i >= len(sheet.Codes) {
+				break
+			}
+			x := margin + float64(slot%2)*column
+			bottom := y - 10 - float64(slot/2+1)*rowHeight
+			page.Bitmap(x, bottom+(rowHeight-codeSide)/2, codeSide, sheet.Codes[i].Modules)
+			page.Text(x+codeSide+12, bottom+rowHeight/2-5, 14, true, i18n.Sprintf("%d. %s", i+1, sheet.Codes[i].Text))
+		}
+
+		if p == pages-1 && sheet.Master != nil {
+			page.Bitmap(margin, margin, masterSide, sheet.Master)
+			x := float64(margin + masterSide + 16)
+			my := margin + masterSide - 10.0
+			page.Text(x, my, 11, true, i18n.T("Master QR: all codes, encrypted"))
+			my -= 16
+			for _, line := range pdf.Wrap(sheet.MasterNote, 8, pdf.A4Width-margin-x) {
+				page.Text(x, my, 8, false, line)
+				my -= 10
+			}
+		}
+		page.Text(margin, margin/2, 8, false, i18n.Sprintf("Page %d of %d", p+1, pages))
+	}
+	return doc
+}
@@ -0,0 +1,219 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Directorios del paquete: el binario, los archivos de configuración y los
+// recursos (fuentes, logos, temas) que usan
+const (
+	BinaryDir    = "bin"
+	ConfigDir    = "config"
+	AssetsDir    = "assets"
+	ManifestName = "manifest.json"
+)
+
+// maxFile limita el tamaño de cada archivo al leer un paquete
+const maxFile = 512 << 20
+
+// ErrInvalid es el error base de un paquete dañado o manipulado
+var ErrInvalid = i18n.NewError("invalid bundle")
+
+// Entry es un archivo del paquete con su checksum
+type Entry struct {
+	Path   string      `json:"path"`
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	SHA256 string      `json:"sha256"`
+}
+
+// Manifest describe el contenido del paquete; va primero en el tarball
+type Manifest struct {
+	Version  string    `json:"version"`  // Versión del binario incluido
+	Platform string    `json:"platform"` // GOOS/GOARCH del binario incluido
+	Created  time.Time `json:"created"`
+	Files    []Entry   `json:"files"`
+}
+
+// File es un archivo a empaquetar: su ruta en disco y su ruta en el paquete
+type File struct {
+	Source string
+	Path   string
+}
+
+// Collect arma la lista de archivos de un recurso: un archivo o, si es un
+// directorio, todos los archivos que contiene, bajo dir/<nombre del recurso>
+func Collect(dir, source string) ([]File, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	base := filepath.Base(filepath.Clean(source))
+	if !info.IsDir() {
+		return []File{{Source: source, Path: path.Join(dir, base)}}, nil
+	}
+	var files []File
+	err = filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Source: p, Path: path.Join(dir, base, filepath.ToSlash(rel))})
+		return nil
+	})
+	return files, err
+}
+
+// Write escribe el paquete (tar.gz) con el manifiesto y los archivos. Dos
+// archivos con la misma ruta en el paquete son un error.
+func Write(w io.Writer, manifest Manifest, files []File) error {
+	seen := map[string]bool{}
+	contents := make([][]byte, len(files))
+	manifest.Files = nil
+	for i, f := range files {
+		if seen[f.Path] {
+			return i18n.Errorf("%s appears twice in the bundle", f.Path)
+		}
+		seen[f.Path] = true
+		info, err := os.Stat(f.Source)
+		if err != nil {
+			return err
+		}
+		if contents[i], err = os.ReadFile(f.Source); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(contents[i])
+		manifest.Files = append(manifest.Files, Entry{Path: f.Path, Size: int64(len(contents[i])), Mode: info.Mode().Perm(), SHA256: hex.EncodeToString(sum[:])})
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	add := func(name string, mode fs.FileMode, data []byte) error {
+		header := &tar.Header{Name: name, Mode: int64(mode), Size: int64(len(data)), ModTime: manifest.Created, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := add(ManifestName, 0o644, append(data, '\n')); err != nil {
+		return err
+	}
+	for i, entry := range manifest.Files {
+		if err := add(entry.Path, entry.Mode, contents[i]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read lee un paquete y verifica que tenga exactamente los archivos del
+// manifiesto, con sus checksums. Devuelve el contenido por ruta.
+func Read(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, i18n.Errorf("%w: %w", ErrInvalid, err)
+	}
+	tr := tar.NewReader(gz)
+
+	var manifest *Manifest
+	contents := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, i18n.Errorf("%w: %w", ErrInvalid, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, nil, i18n.Errorf("%w: %s is not a regular file", ErrInvalid, header.Name)
+		}
+		if !safePath(header.Name) {
+			return nil, nil, i18n.Errorf("%w: unsafe path %q", ErrInvalid, header.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxFile+1))
+		if err != nil {
+			return nil, nil, i18n.Errorf("%w: %w", ErrInvalid, err)
+		}
+		if len(data) > maxFile {
+			return nil, nil, i18n.Errorf("%w: %s is larger than %d MB", ErrInvalid, header.Name, maxFile>>20)
+		}
+
+		if manifest == nil {
+			// El manifiesto va primero: sin él no hay contra qué verificar
+			if header.Name != ManifestName {
+				return nil, nil, i18n.Errorf("%w: %s must come first", ErrInvalid, ManifestName)
+			}
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, i18n.Errorf("%w: %s: %w", ErrInvalid, ManifestName, err)
+			}
+			continue
+		}
+		if _, dup := contents[header.Name]; dup {
+			return nil, nil, i18n.Errorf("%w: %s appears twice in the bundle", ErrInvalid, header.Name)
+		}
+		contents[header.Name] = data
+	}
+	if manifest == nil {
+		return nil, nil, i18n.Errorf("%w: %s is missing", ErrInvalid, ManifestName)
+	}
+
+	if len(contents) != len(manifest.Files) {
+		return nil, nil, i18n.Errorf("%w: the bundle has %d files but its manifest lists %d", ErrInvalid, len(contents), len(manifest.Files))
+	}
+	for _, entry := range manifest.Files {
+		data, ok := contents[entry.Path]
+		if !ok {
+			return nil, nil, i18n.Errorf("%w: %s is listed in the manifest but missing", ErrInvalid, entry.Path)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != strings.ToLower(entry.SHA256) {
+			return nil, nil, i18n.Errorf("%w: checksum mismatch for %s", ErrInvalid, entry.Path)
+		}
+	}
+	return manifest, contents, nil
+}
+
+// safePath rechaza las rutas absolutas o que salen del paquete con ".."
+func safePath(name string) bool {
+	if name == "" || path.IsAbs(name) || strings.Contains(name, `\`) || strings.Contains(name, ":") {
+		return false
+	}
+	clean := path.Clean(name)
+	return clean == name && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// Target es la ruta de instalación de un archivo del paquete: el binario en
+// binDir y el resto en dataDir, conservando config/ y assets/
+func Target(name, binDir, dataDir string) string {
+	if dir, file, _ := strings.Cut(name, "/"); dir == BinaryDir {
+		return filepath.Join(binDir, filepath.FromSlash(file))
+	}
+	return filepath.Join(dataDir, filepath.FromSlash(name))
+}
@@ -0,0 +1,120 @@
+package checkdigit
+
+import (
+	"fmt"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Esquemas de caracteres de control para los números de serie impresos, con
+// los que quien los carga a mano puede validar lo que tipeó
+const (
+	SchemeLuhn = "luhn" // Un carácter: Luhn mod 10 para series numéricas, mod 36 si tienen letras
+	SchemeCRC  = "crc"  // Cuatro dígitos hexadecimales del CRC-16/CCITT de la serie
+)
+
+// alphabet son los caracteres de Luhn mod 36, en orden de valor
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// CheckScheme revisa que el esquema exista
+func CheckScheme(scheme string) error {
+	switch scheme {
+	case SchemeLuhn, SchemeCRC:
+		return nil
+	}
+	return i18n.Errorf("unsupported check digit scheme %q (luhn or crc)", scheme)
+}
+
+// Serial separa el número de serie del final del texto: la última tira de
+// letras y dígitos ASCII, como "A1234" en "https://example.com/asset/A1234"
+func Serial(text string) (prefix, serial string) {
+	i := len(text)
+	for i > 0 && strings.IndexByte(alphabet, upper(text[i-1])) >= 0 {
+		i--
+	}
+	return text[:i], text[i:]
+}
+
+// Compute devuelve los caracteres de control de la serie
+func Compute(serial, scheme string) (string, error) {
+	if err := CheckScheme(scheme); err != nil {
+		return "", err
+	}
+	if serial == "" {
+		return "", i18n.Errorf("no serial number to check")
+	}
+	if scheme == SchemeCRC {
+		return fmt.Sprintf("%04X", crc16(serial)), nil
+	}
+	return luhn(serial), nil
+}
+
+// Verify revisa los caracteres de control del número de serie del final del
+// texto y devuelve la serie sin ellos
+func Verify(text, scheme string) (serial string, ok bool, err error) {
+	if err := CheckScheme(scheme); err != nil {
+		return "", false, err
+	}
+	_, serial = Serial(text)
+	size := 1
+	if scheme == SchemeCRC {
+		size = 4
+	}
+	if len(serial) <= size {
+		return serial, false, nil
+	}
+	serial, check := serial[:len(serial)-size], serial[len(serial)-size:]
+	want, err := Compute(serial, scheme)
+	if err != nil {
+		return "", false, err
+	}
+	return serial, strings.EqualFold(check, want), nil
+}
+
+// luhn calcula el dígito de Luhn: mod 10 si la serie es solo de dígitos y
+// mod 36 (Luhn mod N), sin distinguir mayúsculas, si también tiene letras
+func luhn(serial string) string {
+	base := 10
+	for i := 0; i < len(serial); i++ {
+		if serial[i] > '9' {
+			base = len(alphabet)
+		}
+	}
+	// Desde la derecha, se duplica uno de cada dos valores empezando por el último
+	sum, double := 0, true
+	for i := len(serial) - 1; i >= 0; i-- {
+		v := strings.IndexByte(alphabet, upper(serial[i]))
+		if double {
+			v *= 2
+			v = v/base + v%base
+		}
+		sum += v
+		double = !double
+	}
+	return string(alphabet[(base-sum%base)%base])
+}
+
+// crc16 es el CRC-16/CCITT-FALSE (polinomio 0x1021, inicio 0xFFFF)
+func crc16(text string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(text); i++ {
+		crc ^= uint16(text[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// upper pasa una letra ASCII a mayúscula
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
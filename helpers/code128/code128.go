@@ -0,0 +1,166 @@
+// Package code128 codifica y decodifica códigos de barras Code 128 (ISO/IEC
+// 15417), los de los SKU y los números de seguimiento: tres juegos de
+// caracteres (A con los de control, B con las minúsculas y C con pares de
+// dígitos) entre los que el codificador elige el camino más corto, y un
+// dígito de control módulo 103.
+package code128
+
+import "qrgenerator_cli/helpers/i18n"
+
+// ErrInvalidData indica un byte que Code 128 no puede escribir
+var ErrInvalidData = i18n.NewError("Code 128 only encodes ASCII characters")
+
+// QuietZone es la zona de silencio que pide el estándar a cada lado, en módulos
+const QuietZone = 10
+
+// Valores de los caracteres de control
+const (
+	valShift  = 98  // El próximo carácter es del otro juego, entre A y B
+	valCodeC  = 99  // Cambio al juego C
+	valCodeB  = 100 // Cambio al juego B; FNC4 en el juego B
+	valCodeA  = 101 // Cambio al juego A; FNC4 en el juego A
+	valFNC1   = 102 // FNC1: al comienzo marca una cadena GS1 y después separa los campos
+	valStartA = 103 // Inicio en el juego A; B y C siguen en orden
+	valStop   = 106
+)
+
+// gs es el separador de campos GS1 dentro de los datos
+const gs = 0x1d
+
+// set es uno de los tres juegos de caracteres
+type set int
+
+const (
+	setA set = iota
+	setB
+	setC
+)
+
+// Options son los parámetros de codificación
+type Options struct {
+	GS1 bool // Los datos son una cadena GS1: FNC1 inicial y GS como separador
+}
+
+// Symbol es un Code 128 codificado
+type Symbol struct {
+	Modules    []bool // Barras y espacios de un módulo, sin zona de silencio; true es barra
+	Characters int    // Caracteres de símbolo entre el inicio y el dígito de control
+}
+
+// Encode codifica data con la menor cantidad de caracteres de símbolo
+func Encode(data []byte, opts Options) (*Symbol, error) {
+	for _, c := range data {
+		if c > 127 {
+			return nil, ErrInvalidData
+		}
+	}
+
+	// cost[i][s] es lo que ocupa data[i:] estando en el juego s, y via[i][s]
+	// el juego en que conviene escribir data[i], cambiando antes si difiere
+	const unreachable = 1 << 30
+	n := len(data)
+	cost := make([][3]int, n+1)
+	via := make([][3]set, n+1)
+	direct := make([][3]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		for s := setA; s <= setC; s++ {
+			direct[i][s] = unreachable
+			if k, values := step(data[i:], s, opts.GS1); k > 0 {
+				direct[i][s] = len(values) + cost[i+k][s]
+			}
+		}
+		for s := setA; s <= setC; s++ {
+			cost[i][s], via[i][s] = direct[i][s], s
+			for t := setA; t <= setC; t++ {
+				if 1+direct[i][t] < cost[i][s] {
+					cost[i][s], via[i][s] = 1+direct[i][t], t
+				}
+			}
+		}
+	}
+
+	// El carácter de inicio elige el juego sin costo extra
+	current := setB
+	if n > 0 {
+		for s := setA; s <= setC; s++ {
+			if direct[0][s] < direct[0][current] {
+				current = s
+			}
+		}
+	}
+	values := []int{valStartA + int(current)}
+	if opts.GS1 {
+		values = append(values, valFNC1)
+	}
+	for i := 0; i < n; {
+		if next := via[i][current]; next != current {
+			values = append(values, [3]int{valCodeA, valCodeB, valCodeC}[next])
+			current = next
+		}
+		k, written := step(data[i:], current, opts.GS1)
+		values = append(values, written...)
+		i += k
+	}
+
+	checksum := values[0]
+	for i, v := range values[1:] {
+		checksum += (i + 1) * v
+	}
+	symbol := &Symbol{Characters: len(values) - 1}
+	for _, v := range append(values, checksum%103, valStop) {
+		for i, w := range patterns[v] {
+			for range int(w - '0') {
+				symbol.Modules = append(symbol.Modules, i%2 == 0)
+			}
+		}
+	}
+	return symbol, nil
+}
+
+// step devuelve cuántos bytes de data escribe el juego s sin cambiar de
+// juego y los valores que usa; 0 si no puede
+func step(data []byte, s set, gs1 bool) (int, []int) {
+	c := data[0]
+	if gs1 && c == gs {
+		return 1, []int{valFNC1}
+	}
+	switch s {
+	case setC:
+		if len(data) >= 2 && isDigit(data[0]) && isDigit(data[1]) {
+			return 2, []int{int(data[0]-'0')*10 + int(data[1]-'0')}
+		}
+		return 0, nil
+	case setA:
+		if v := valueA(c); v >= 0 {
+			return 1, []int{v}
+		}
+		return 1, []int{valShift, valueB(c)}
+	}
+	if v := valueB(c); v >= 0 {
+		return 1, []int{v}
+	}
+	return 1, []int{valShift, valueA(c)}
+}
+
+// valueA es el valor de c en el juego A (control, mayúsculas y dígitos); -1 si no está
+func valueA(c byte) int {
+	switch {
+	case c < 32:
+		return int(c) + 64
+	case c < 96:
+		return int(c) - 32
+	}
+	return -1
+}
+
+// valueB es el valor de c en el juego B (imprimibles y minúsculas); -1 si no está
+func valueB(c byte) int {
+	if c >= 32 && c < 128 {
+		return int(c) - 32
+	}
+	return -1
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
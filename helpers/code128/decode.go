@@ -0,0 +1,182 @@
+package code128
+
+import (
+	"math"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un Code 128 reconocible
+var errNotFound = i18n.NewError("no Code 128 barcode found in the image")
+
+// Result es el contenido decodificado de un Code 128
+type Result struct {
+	Data       []byte // Datos, con GS entre los campos de una cadena GS1
+	GS1        bool   // El símbolo empieza con FNC1
+	Characters int    // Caracteres de símbolo entre el inicio y el dígito de control
+}
+
+// values lleva los anchos de cada patrón a su valor
+var values = func() map[string]int {
+	m := make(map[string]int, len(patterns))
+	for v, p := range patterns {
+		m[p] = v
+	}
+	return m
+}()
+
+// DecodeModules decodifica una línea de módulos sin zona de silencio, de la
+// primera barra a la última
+func DecodeModules(line []bool) (*Result, error) {
+	var widths []byte
+	for i := 0; i < len(line); {
+		j := i
+		for j < len(line) && line[j] == line[i] {
+			j++
+		}
+		widths = append(widths, byte('0'+min(j-i, 9)))
+		i = j
+	}
+	if len(line) == 0 || !line[0] || len(widths) < 19 || (len(widths)-7)%6 != 0 {
+		return nil, errNotFound
+	}
+	if string(widths[len(widths)-7:]) != patterns[valStop] {
+		return nil, errNotFound
+	}
+	codes := make([]int, 0, (len(widths)-7)/6)
+	for i := 0; i+7 < len(widths); i += 6 {
+		v, ok := values[string(widths[i:i+6])]
+		if !ok {
+			return nil, errNotFound
+		}
+		codes = append(codes, v)
+	}
+	if codes[0] < valStartA || codes[0] > valStartA+2 {
+		return nil, errNotFound
+	}
+	checksum := codes[0]
+	for i, v := range codes[1 : len(codes)-1] {
+		checksum += (i + 1) * v
+	}
+	if checksum%103 != codes[len(codes)-1] {
+		return nil, i18n.NewError("Code 128 check digit does not match")
+	}
+
+	result := &Result{Characters: len(codes) - 2}
+	current := set(codes[0] - valStartA)
+	for i := 1; i < len(codes)-1; i++ {
+		v, s := codes[i], current
+		if v == valShift && s != setC && i+1 < len(codes)-1 {
+			// Un carácter del otro juego, entre A y B
+			i++
+			v, s = codes[i], setB-s
+		}
+		switch {
+		case v == valFNC1:
+			if i == 1 {
+				result.GS1 = true
+			} else {
+				result.Data = append(result.Data, gs)
+			}
+		case s == setC && v < 100:
+			result.Data = append(result.Data, byte('0'+v/10), byte('0'+v%10))
+		case v == valCodeC && s != setC:
+			current = setC
+		case v == valCodeB && s != setB:
+			current = setB
+		case v == valCodeA && s != setA:
+			current = setA
+		case v >= 96:
+			// FNC2, FNC3 y FNC4 no se usan con datos ASCII
+			return nil, i18n.Errorf("unsupported Code 128 function character %d", v)
+		case s == setA && v >= 64:
+			result.Data = append(result.Data, byte(v-64))
+		default:
+			result.Data = append(result.Data, byte(v+32))
+		}
+	}
+	return result, nil
+}
+
+// Detect busca un Code 128 horizontal en una imagen binarizada: recorre las
+// filas y lee los anchos de barras y espacios entre la primera barra y la
+// última, normalizados a los 11 módulos de cada carácter. Devuelve los
+// módulos de la primera fila que se decodifica.
+func Detect(dark func(x, y int) bool, width, height int) ([]bool, error) {
+	for y := 0; y < height; y++ {
+		var runs []int
+		for x := 0; x < width; x++ {
+			switch {
+			case len(runs) == 0 && !dark(x, y):
+			case (len(runs)%2 == 1) == dark(x, y):
+				runs[len(runs)-1]++
+			default:
+				runs = append(runs, 1)
+			}
+		}
+		// El último tramo es claro: la zona de silencio
+		if n := len(runs); n > 0 && n%2 == 0 {
+			runs = runs[:len(runs)-1]
+		}
+		if len(runs) < 19 || (len(runs)-7)%6 != 0 {
+			continue
+		}
+		if line := readLine(runs); line != nil {
+			if _, err := DecodeModules(line); err == nil {
+				return line, nil
+			}
+		}
+	}
+	return nil, errNotFound
+}
+
+// readLine convierte los tramos de píxeles en módulos: cada carácter toma
+// el patrón más parecido a sus anchos; nil si alguno no se parece a ninguno.
+// Compara las sumas de cada barra con el espacio siguiente y de cada espacio
+// con la barra siguiente, que no cambian si la tinta engorda las barras (la
+// medición de borde a borde del estándar); son distintas en cada patrón.
+func readLine(runs []int) []bool {
+	var line []bool
+	for i := 0; i < len(runs); i += 6 {
+		group := runs[i:min(i+6, len(runs))]
+		if len(runs)-i == 7 {
+			group = runs[i:]
+		}
+		total := 0
+		for _, r := range group {
+			total += r
+		}
+		modules := 11.0
+		if len(group) == 7 {
+			modules = 13
+		}
+		unit := float64(total) / modules
+
+		best, bestDistance := "", math.Inf(1)
+		for _, p := range patterns {
+			if len(p) != len(group) {
+				continue
+			}
+			distance := 0.0
+			for j := 1; j < len(p); j++ {
+				pair := float64(group[j-1]+group[j]) / unit
+				distance += math.Abs(pair - float64(p[j-1]-'0'+p[j]-'0'))
+			}
+			if distance < bestDistance {
+				best, bestDistance = p, distance
+			}
+		}
+		if bestDistance > 3 {
+			return nil
+		}
+		for j, w := range best {
+			for range int(w - '0') {
+				line = append(line, j%2 == 0)
+			}
+		}
+		if len(group) == 7 {
+			break
+		}
+	}
+	return line
+}
@@ -0,0 +1,105 @@
+// Package code39 codifica y decodifica códigos de barras Code 39 (ISO/IEC
+// 16388), los de los inventarios y las credenciales más viejos: 43
+// caracteres (dígitos, mayúsculas, el espacio y - . $ / + %) de cinco barras
+// y cuatro espacios, tres de ellos anchos, entre asteriscos de inicio y fin,
+// con un dígito de control módulo 43 opcional.
+package code39
+
+import "qrgenerator_cli/helpers/i18n"
+
+// ErrInvalidData indica un carácter que Code 39 no puede escribir
+var ErrInvalidData = i18n.NewError("Code 39 only encodes digits, uppercase letters, space and - . $ / + %")
+
+// Medidas del símbolo, en módulos
+const (
+	QuietZone = 10 // Zona de silencio que pide el estándar a cada lado
+	wide      = 3  // Ancho de las barras y los espacios anchos; el estándar admite de 2 a 3
+)
+
+// charset son los caracteres en el orden de su valor, que suma el dígito de control
+const charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+
+// startStop es el asterisco de inicio y de fin
+const startStop = '*'
+
+// patterns marca con 1 los elementos anchos de cada carácter: barra,
+// espacio, barra, espacio, barra, espacio, barra, espacio y barra
+var patterns = map[byte]string{
+	'0': "000110100", '1': "100100001", '2': "001100001", '3': "101100000", '4': "000110001",
+	'5': "100110000", '6': "001110000", '7': "000100101", '8': "100100100", '9': "001100100",
+	'A': "100001001", 'B': "001001001", 'C': "101001000", 'D': "000011001", 'E': "100011000",
+	'F': "001011000", 'G': "000001101", 'H': "100001100", 'I': "001001100", 'J': "000011100",
+	'K': "100000011", 'L': "001000011", 'M': "101000010", 'N': "000010011", 'O': "100010010",
+	'P': "001010010", 'Q': "000000111", 'R': "100000110", 'S': "001000110", 'T': "000010110",
+	'U': "110000001", 'V': "011000001", 'W': "111000000", 'X': "010010001", 'Y': "110010000",
+	'Z': "011010000", '-': "010000101", '.': "110000100", ' ': "011000100", '$': "010101000",
+	'/': "010100010", '+': "010001010", '%': "000101010", '*': "010010100",
+}
+
+// Options son los parámetros de codificación
+type Options struct {
+	Checksum bool // Agregar el dígito de control módulo 43 después de los datos
+}
+
+// Symbol es un Code 39 codificado
+type Symbol struct {
+	Modules    []bool // Barras y espacios de un módulo, sin zona de silencio; true es barra
+	Characters int    // Caracteres entre los asteriscos, con el de control
+}
+
+// Checksum calcula el dígito de control módulo 43: el carácter cuyo valor
+// es la suma de los valores de data
+func Checksum(data []byte) (byte, error) {
+	sum := 0
+	for _, c := range data {
+		v := indexOf(c)
+		if v < 0 {
+			return 0, ErrInvalidData
+		}
+		sum += v
+	}
+	return charset[sum%len(charset)], nil
+}
+
+// Encode codifica data entre los asteriscos, con un espacio angosto entre
+// los caracteres
+func Encode(data []byte, opts Options) (*Symbol, error) {
+	for _, c := range data {
+		if indexOf(c) < 0 {
+			return nil, ErrInvalidData
+		}
+	}
+	chars := append([]byte{startStop}, data...)
+	if opts.Checksum {
+		check, _ := Checksum(data)
+		chars = append(chars, check)
+	}
+	chars = append(chars, startStop)
+
+	symbol := &Symbol{Characters: len(chars) - 2}
+	for i, c := range chars {
+		if i > 0 {
+			symbol.Modules = append(symbol.Modules, false)
+		}
+		for j, w := range patterns[c] {
+			n := 1
+			if w == '1' {
+				n = wide
+			}
+			for range n {
+				symbol.Modules = append(symbol.Modules, j%2 == 0)
+			}
+		}
+	}
+	return symbol, nil
+}
+
+// indexOf es el valor de c; -1 si Code 39 no lo escribe
+func indexOf(c byte) int {
+	for i := 0; i < len(charset); i++ {
+		if charset[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,101 @@
+package code39
+
+import (
+	"slices"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un Code 39 reconocible
+var errNotFound = i18n.NewError("no Code 39 barcode found in the image")
+
+// characters lleva los anchos de cada patrón a su carácter
+var characters = func() map[string]byte {
+	m := make(map[string]byte, len(patterns))
+	for c, p := range patterns {
+		m[p] = c
+	}
+	return m
+}()
+
+// DecodeModules decodifica una línea de módulos sin zona de silencio, de la
+// primera barra a la última, y devuelve los caracteres entre los asteriscos
+func DecodeModules(line []bool) ([]byte, error) {
+	var runs []int
+	for i := 0; i < len(line); {
+		j := i
+		for j < len(line) && line[j] == line[i] {
+			j++
+		}
+		runs = append(runs, j-i)
+		i = j
+	}
+	if len(line) == 0 || !line[0] {
+		return nil, errNotFound
+	}
+	return decodeRuns(runs)
+}
+
+// Detect busca un Code 39 horizontal en una imagen binarizada: recorre las
+// filas y lee los anchos de barras y espacios entre la primera barra y la
+// última. El dígito de control no se puede distinguir de los datos, así que
+// queda con ellos.
+func Detect(dark func(x, y int) bool, width, height int) ([]byte, error) {
+	for y := 0; y < height; y++ {
+		var runs []int
+		for x := 0; x < width; x++ {
+			switch {
+			case len(runs) == 0 && !dark(x, y):
+			case (len(runs)%2 == 1) == dark(x, y):
+				runs[len(runs)-1]++
+			default:
+				runs = append(runs, 1)
+			}
+		}
+		// El último tramo es claro: la zona de silencio
+		if n := len(runs); n > 0 && n%2 == 0 {
+			runs = runs[:len(runs)-1]
+		}
+		if data, err := decodeRuns(runs); err == nil {
+			return data, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// decodeRuns lee los caracteres de los anchos de barras y espacios: nueve
+// por carácter y un espacio entre caracteres, de cualquier ancho. En cada
+// carácter los tres tramos más anchos son los anchos, lo que no depende de
+// la relación entre anchos y angostos ni de cuánto engorde la tinta las
+// barras mientras los anchos sigan siendo más anchos.
+func decodeRuns(runs []int) ([]byte, error) {
+	if len(runs) < 29 || len(runs)%10 != 9 {
+		return nil, errNotFound
+	}
+	var chars []byte
+	for i := 0; i < len(runs); i += 10 {
+		group := runs[i : i+9]
+		sorted := slices.Sorted(slices.Values(group))
+		threshold := sorted[len(sorted)-3]
+		if sorted[len(sorted)-4] == threshold {
+			return nil, errNotFound
+		}
+		var pattern [9]byte
+		for j, r := range group {
+			pattern[j] = '0'
+			if r >= threshold {
+				pattern[j] = '1'
+			}
+		}
+		c, ok := characters[string(pattern[:])]
+		if !ok {
+			return nil, errNotFound
+		}
+		chars = append(chars, c)
+	}
+	last := len(chars) - 1
+	if chars[0] != startStop || chars[last] != startStop || slices.Contains(chars[1:last], startStop) {
+		return nil, errNotFound
+	}
+	return chars[1:last], nil
+}
@@ -0,0 +1,133 @@
+package compress
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Métodos de compresión: zlib y luego base64 o base45
+const (
+	MethodBase64 = "base64" // Más compatible; el QR la codifica en modo byte
+	MethodBase45 = "base45" // Usa solo el alfabeto del modo alfanumérico del QR (RFC 9285), más denso
+)
+
+// Prefijos que identifican un payload comprimido, para descomprimirlo al leerlo
+const (
+	PrefixBase64 = "ZLIB64:"
+	PrefixBase45 = "ZLIB45:"
+)
+
+// maxInflated es el tamaño máximo descomprimido, para no agotar la memoria
+// con un payload malicioso
+const maxInflated = 16 << 20
+
+// CheckMethod revisa que el método exista
+func CheckMethod(method string) error {
+	switch method {
+	case MethodBase64, MethodBase45:
+		return nil
+	}
+	return i18n.Errorf("unsupported compression %q (base64 or base45)", method)
+}
+
+// Compress comprime el texto con zlib y lo codifica con el método indicado, con su prefijo
+func Compress(text, method string) (string, error) {
+	if err := CheckMethod(method); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w, _ := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+	w.Write([]byte(text))
+	w.Close()
+
+	if method == MethodBase45 {
+		return PrefixBase45 + EncodeBase45(buf.Bytes()), nil
+	}
+	return PrefixBase64 + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Decompress descomprime un payload generado por Compress; si no tiene uno de
+// los prefijos lo devuelve sin cambios y compressed en false
+func Decompress(payload string) (text string, compressed bool, err error) {
+	var data []byte
+	switch {
+	case strings.HasPrefix(payload, PrefixBase64):
+		data, err = base64.StdEncoding.DecodeString(payload[len(PrefixBase64):])
+	case strings.HasPrefix(payload, PrefixBase45):
+		data, err = DecodeBase45(payload[len(PrefixBase45):])
+	default:
+		return payload, false, nil
+	}
+	if err != nil {
+		return "", true, i18n.Errorf("invalid compressed payload: %w", err)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", true, i18n.Errorf("invalid compressed payload: %w", err)
+	}
+	defer r.Close()
+	inflated, err := io.ReadAll(io.LimitReader(r, maxInflated+1))
+	if err != nil {
+		return "", true, i18n.Errorf("invalid compressed payload: %w", err)
+	}
+	if len(inflated) > maxInflated {
+		return "", true, i18n.Errorf("compressed payload expands beyond %d MiB", maxInflated>>20)
+	}
+	return string(inflated), true, nil
+}
+
+// base45Alphabet es el alfabeto de RFC 9285, el mismo del modo alfanumérico del QR
+const base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// EncodeBase45 codifica en base45 cada par de bytes en tres caracteres y un
+// byte suelto en dos
+func EncodeBase45(data []byte) string {
+	out := make([]byte, 0, (len(data)+1)/2*3)
+	for i := 0; i+1 < len(data); i += 2 {
+		n := int(data[i])<<8 | int(data[i+1])
+		out = append(out, base45Alphabet[n%45], base45Alphabet[n/45%45], base45Alphabet[n/2025])
+	}
+	if len(data)%2 == 1 {
+		n := int(data[len(data)-1])
+		out = append(out, base45Alphabet[n%45], base45Alphabet[n/45])
+	}
+	return string(out)
+}
+
+// DecodeBase45 invierte EncodeBase45 y rechaza los grupos fuera de rango
+func DecodeBase45(text string) ([]byte, error) {
+	if len(text)%3 == 1 {
+		return nil, i18n.Errorf("base45 length %d is not valid", len(text))
+	}
+	values := make([]int, len(text))
+	for i := range text {
+		v := strings.IndexByte(base45Alphabet, text[i])
+		if v < 0 {
+			return nil, i18n.Errorf("invalid base45 character %q", text[i])
+		}
+		values[i] = v
+	}
+	out := make([]byte, 0, len(text)/3*2+1)
+	for i := 0; i < len(values); i += 3 {
+		if i+2 < len(values) {
+			n := values[i] + values[i+1]*45 + values[i+2]*2025
+			if n > 0xffff {
+				return nil, i18n.Errorf("invalid base45 group %q", text[i:i+3])
+			}
+			out = append(out, byte(n>>8), byte(n))
+		} else {
+			n := values[i] + values[i+1]*45
+			if n > 0xff {
+				return nil, i18n.Errorf("invalid base45 group %q", text[i:i+2])
+			}
+			out = append(out, byte(n))
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsConfigFile indica si la extensión del archivo corresponde a un archivo de configuración
+func IsConfigFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// Load lee un archivo YAML (o JSON) y devuelve sus valores indexados por nombre
+// de flag. Las claves anidadas se unen con guiones (jpeg: {progressive: true}
+// equivale a jpeg-progressive) y las listas se unen con comas.
+func Load(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read config: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, i18n.Errorf("invalid config in %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", raw, values); err != nil {
+		return nil, i18n.Errorf("invalid config in %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flatten aplana los mapas anidados en claves separadas por guiones
+func flatten(prefix string, raw map[string]any, values map[string]string) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "-" + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flatten(key, v, values); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				if _, ok := item.(map[string]any); ok {
+					return i18n.Errorf("list %q can only contain plain values", key)
+				}
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// Apply asigna los valores de la configuración a los flags que no se pasaron
+// por línea de comandos, de modo que los flags explícitos siempre ganan
+func Apply(flags *flag.FlagSet, values map[string]string) error {
+	explicit := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	// Orden estable para que los errores sean reproducibles
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if flags.Lookup(key) == nil {
+			return i18n.Errorf("unknown option %q in config", key)
+		}
+		if explicit[key] {
+			continue
+		}
+		if err := flags.Set(key, values[key]); err != nil {
+			return i18n.Errorf("invalid value for %q in config: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package datamatrix codifica y decodifica símbolos Data Matrix ECC 200
+// (ISO/IEC 16022), la simbología 2D del etiquetado industrial: datos en
+// ASCII o Base256, corrección Reed-Solomon y los 30 tamaños del estándar,
+// cuadrados y rectangulares.
+package datamatrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ErrDataTooLong indica que los datos no entran en ningún tamaño permitido
+var ErrDataTooLong = i18n.NewError("data too long for a Data Matrix symbol")
+
+// Size es uno de los tamaños de ECC 200
+type Size struct {
+	Rows, Cols             int // Módulos del símbolo, con los patrones de borde
+	RegionRows, RegionCols int // Módulos de datos de cada región
+	Data, ECC              int // Codewords de datos y de corrección
+	Blocks                 int // Bloques Reed-Solomon intercalados
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Rows, s.Cols)
+}
+
+// Square indica si el tamaño es cuadrado
+func (s Size) Square() bool {
+	return s.Rows == s.Cols
+}
+
+// regions devuelve cuántas regiones de datos hay en vertical y horizontal
+func (s Size) regions() (int, int) {
+	return s.Rows / (s.RegionRows + 2), s.Cols / (s.RegionCols + 2)
+}
+
+// sizes es la tabla 7 del estándar: primero los cuadrados, después los
+// rectangulares, cada grupo de menor a mayor
+var sizes = []Size{
+	{10, 10, 8, 8, 3, 5, 1},
+	{12, 12, 10, 10, 5, 7, 1},
+	{14, 14, 12, 12, 8, 10, 1},
+	{16, 16, 14, 14, 12, 12, 1},
+	{18, 18, 16, 16, 18, 14, 1},
+	{20, 20, 18, 18, 22, 18, 1},
+	{22, 22, 20, 20, 30, 20, 1},
+	{24, 24, 22, 22, 36, 24, 1},
+	{26, 26, 24, 24, 44, 28, 1},
+	{32, 32, 14, 14, 62, 36, 1},
+	{36, 36, 16, 16, 86, 42, 1},
+	{40, 40, 18, 18, 114, 48, 1},
+	{44, 44, 20, 20, 144, 56, 1},
+	{48, 48, 22, 22, 174, 68, 1},
+	{52, 52, 24, 24, 204, 84, 2},
+	{64, 64, 14, 14, 280, 112, 2},
+	{72, 72, 16, 16, 368, 144, 4},
+	{80, 80, 18, 18, 456, 192, 4},
+	{88, 88, 20, 20, 576, 224, 4},
+	{96, 96, 22, 22, 696, 272, 4},
+	{104, 104, 24, 24, 816, 336, 6},
+	{120, 120, 18, 18, 1050, 408, 6},
+	{132, 132, 20, 20, 1304, 496, 8},
+	{144, 144, 22, 22, 1558, 620, 10},
+	{8, 18, 6, 16, 5, 7, 1},
+	{8, 32, 6, 14, 10, 11, 1},
+	{12, 26, 10, 24, 16, 14, 1},
+	{12, 36, 10, 16, 22, 18, 1},
+	{16, 36, 14, 16, 32, 24, 1},
+	{16, 48, 14, 22, 49, 28, 1},
+}
+
+// Sizes devuelve los tamaños en el orden de la tabla del estándar
+func Sizes() []Size {
+	return append([]Size(nil), sizes...)
+}
+
+// SizeIndex devuelve la posición del tamaño en Sizes, desde 1; 0 si no es
+// un tamaño de ECC 200
+func SizeIndex(size Size) int {
+	for i, s := range sizes {
+		if s == size {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ParseSize interpreta un tamaño como 16x48 (filas x columnas)
+func ParseSize(name string) (Size, error) {
+	rows, cols, ok := strings.Cut(strings.ToLower(strings.TrimSpace(name)), "x")
+	r, errRows := strconv.Atoi(rows)
+	c, errCols := strconv.Atoi(cols)
+	if ok && errRows == nil && errCols == nil {
+		for _, s := range sizes {
+			if s.Rows == r && s.Cols == c {
+				return s, nil
+			}
+		}
+	}
+	return Size{}, i18n.Errorf("unknown Data Matrix size %s", name)
+}
+
+// Shape restringe los tamaños que se prueban
+type Shape int
+
+// Formas admitidas
+const (
+	ShapeSquare    Shape = iota // Solo cuadrados (el valor por defecto)
+	ShapeRectangle              // Solo rectangulares
+)
+
+// Options son los parámetros de codificación
+type Options struct {
+	Shape Shape
+	Size  Size // Tamaño fijo; el cero elige el más chico de Shape que alcance
+	GS1   bool // Los datos son una cadena GS1: FNC1 inicial y GS como separador
+	ECI   int  // Designador ECI; 0 no lo declara
+}
+
+// candidates devuelve los tamaños que admiten las opciones, de menor a mayor
+func (o Options) candidates() []Size {
+	if o.Size != (Size{}) {
+		return []Size{o.Size}
+	}
+	var list []Size
+	for _, s := range sizes {
+		if s.Square() == (o.Shape == ShapeSquare) {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// Largest devuelve el tamaño más grande que admiten las opciones
+func (o Options) Largest() Size {
+	list := o.candidates()
+	return list[len(list)-1]
+}
+
+// Symbol es un Data Matrix codificado
+type Symbol struct {
+	Matrix [][]bool // Filas de módulos, true es oscuro; sin zona de silencio
+	Size   Size
+}
+
+// Encode codifica data en el tamaño más chico que lo contenga. Si no entra
+// en ninguno devuelve ErrDataTooLong.
+func Encode(data []byte, opts Options) (*Symbol, error) {
+	codewords := encodeData(data, opts)
+	for _, size := range opts.candidates() {
+		if len(codewords) <= size.Data {
+			return &Symbol{Matrix: render(size, withECC(size, pad(codewords, size.Data))), Size: size}, nil
+		}
+	}
+	return nil, ErrDataTooLong
+}
+
+// Codewords devuelve cuántos codewords de datos ocupa data con las opciones
+// dadas, para compararlo con Size.Data
+func Codewords(data []byte, opts Options) int {
+	return len(encodeData(data, opts))
+}
@@ -0,0 +1,196 @@
+package datamatrix
+
+import (
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un Data Matrix reconocible
+var errNotFound = i18n.NewError("no Data Matrix code found in the image")
+
+// Result es el contenido decodificado de un Data Matrix
+type Result struct {
+	Data      []byte // Datos, con GS entre los campos de una cadena GS1
+	Size      Size
+	ECI       int  // Designador ECI, 0 si no se declaró
+	GS1       bool // El símbolo empieza con FNC1
+	Corrected int  // Codewords corregidos por Reed-Solomon
+}
+
+// DecodeMatrix decodifica una grilla de módulos ya muestreada, sin zona de
+// silencio. Lee las codificaciones ASCII y Base256, las que usa Encode.
+func DecodeMatrix(matrix [][]bool) (*Result, error) {
+	if len(matrix) == 0 {
+		return nil, errNotFound
+	}
+	var size Size
+	for _, s := range sizes {
+		if s.Rows == len(matrix) && s.Cols == len(matrix[0]) {
+			size = s
+		}
+	}
+	if size == (Size{}) {
+		return nil, i18n.Errorf("invalid Data Matrix size: %dx%d", len(matrix), len(matrix[0]))
+	}
+	data, corrected, err := correct(size, readCodewords(size, matrix))
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Size: size, Corrected: corrected}
+	if err := parseData(data, result); err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	return result, nil
+}
+
+// parseData interpreta los codewords de datos
+func parseData(data []byte, result *Result) error {
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c >= 1 && c <= 128:
+			result.Data = append(result.Data, c-1)
+		case c == cwPad:
+			return nil
+		case c >= 130 && c <= 229:
+			result.Data = append(result.Data, '0'+(c-130)/10, '0'+(c-130)%10)
+		case c == cwFNC1:
+			if i == 0 {
+				result.GS1 = true
+			} else {
+				result.Data = append(result.Data, gs)
+			}
+		case c == cwUpperShift:
+			if i+1 >= len(data) {
+				return i18n.NewError("truncated upper shift")
+			}
+			i++
+			result.Data = append(result.Data, data[i]+127)
+		case c == cwECI:
+			eci, n, err := readECI(data[i+1:])
+			if err != nil {
+				return err
+			}
+			result.ECI = eci
+			i += n
+		case c == cwBase256:
+			n, err := readBase256(data, i+1, result)
+			if err != nil {
+				return err
+			}
+			i += n
+		default:
+			return i18n.Errorf("unsupported Data Matrix encodation %d", c)
+		}
+	}
+	return nil
+}
+
+// readECI lee el designador que sigue a un codeword ECI y cuántos codewords ocupa
+func readECI(data []byte) (int, int, error) {
+	switch {
+	case len(data) >= 1 && data[0] <= 127:
+		return int(data[0]) - 1, 1, nil
+	case len(data) >= 2 && data[0] <= 191:
+		return (int(data[0])-128)*254 + int(data[1]) - 1 + 127, 2, nil
+	case len(data) >= 3:
+		return (int(data[0])-192)*64516 + (int(data[1])-1)*254 + int(data[2]) - 1 + 16383, 3, nil
+	}
+	return 0, 0, i18n.NewError("truncated ECI designator")
+}
+
+// readBase256 lee un segmento Base256 que empieza en la posición start y
+// devuelve cuántos codewords ocupa
+func readBase256(data []byte, start int, result *Result) (int, error) {
+	pos := start
+	next := func() (byte, bool) {
+		if pos >= len(data) {
+			return 0, false
+		}
+		b := unrandomize255(data[pos], pos+1)
+		pos++
+		return b, true
+	}
+	first, ok := next()
+	if !ok {
+		return 0, i18n.NewError("truncated Base256 length")
+	}
+	length := int(first)
+	if first == 0 {
+		// Sin largo: el segmento llega hasta el final del símbolo
+		length = len(data) - pos
+	} else if first > 249 {
+		second, ok := next()
+		if !ok {
+			return 0, i18n.NewError("truncated Base256 length")
+		}
+		length = 250*(int(first)-249) + int(second)
+	}
+	if pos+length > len(data) {
+		return 0, i18n.NewError("truncated Base256 segment")
+	}
+	for range length {
+		b, _ := next()
+		result.Data = append(result.Data, b)
+	}
+	return pos - start, nil
+}
+
+// Detect busca un Data Matrix derecho en una imagen binarizada: la L sólida
+// da el recuadro del símbolo y los relojes de arriba y de la derecha la
+// cantidad de columnas y filas. Devuelve la grilla de módulos muestreada.
+func Detect(dark func(x, y int) bool, width, height int) ([][]bool, error) {
+	minX, minY, maxX, maxY := width, height, -1, -1
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if dark(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return nil, errNotFound
+	}
+
+	// El módulo de arriba a la izquierda es oscuro y el siguiente claro
+	module := 0
+	for x := minX; x <= maxX && dark(x, minY); x++ {
+		module++
+	}
+	cols := countRuns(func(i int) bool { return dark(i, minY+module/2) }, minX, maxX)
+	rows := countRuns(func(i int) bool { return dark(maxX-module/2, i) }, minY, maxY)
+	var size Size
+	for _, s := range sizes {
+		if s.Rows == rows && s.Cols == cols {
+			size = s
+		}
+	}
+	if size == (Size{}) || !dark(minX, maxY) || !dark(maxX, maxY) {
+		return nil, errNotFound
+	}
+
+	stepX := float64(maxX-minX+1) / float64(size.Cols)
+	stepY := float64(maxY-minY+1) / float64(size.Rows)
+	matrix := make([][]bool, size.Rows)
+	for row := range matrix {
+		matrix[row] = make([]bool, size.Cols)
+		for col := range matrix[row] {
+			px := float64(minX) + (float64(col)+0.5)*stepX
+			py := float64(minY) + (float64(row)+0.5)*stepY
+			matrix[row][col] = dark(int(px), int(py))
+		}
+	}
+	return matrix, nil
+}
+
+// countRuns cuenta los tramos de un mismo color entre from y to: en un reloj
+// cada módulo es un tramo
+func countRuns(dark func(i int) bool, from, to int) int {
+	runs := 0
+	for i := from; i <= to; i++ {
+		if i == from || dark(i) != dark(i-1) {
+			runs++
+		}
+	}
+	return runs
+}
@@ -0,0 +1,116 @@
+package datamatrix
+
+// Codewords de control de la codificación ASCII
+const (
+	cwPad        = 129
+	cwBase256    = 231
+	cwFNC1       = 232
+	cwUpperShift = 235
+	cwECI        = 241
+)
+
+// gs es el separador de campos GS1 dentro de los datos
+const gs = 0x1d
+
+// encodeData convierte data en codewords: el prefijo FNC1 o ECI y después
+// ASCII (pares de dígitos en un codeword) o Base256, lo que ocupe menos. Las
+// cadenas GS1 van siempre en ASCII, donde FNC1 separa los campos.
+func encodeData(data []byte, opts Options) []byte {
+	var prefix []byte
+	if opts.GS1 {
+		prefix = append(prefix, cwFNC1)
+	}
+	if opts.ECI > 0 {
+		prefix = append(prefix, cwECI)
+		prefix = append(prefix, eciCodewords(opts.ECI)...)
+	}
+	ascii := encodeASCII(prefix, data, opts.GS1)
+	if opts.GS1 {
+		return ascii
+	}
+	if binary := encodeBase256(prefix, data); len(binary) < len(ascii) {
+		return binary
+	}
+	return ascii
+}
+
+// eciCodewords codifica el designador ECI en uno, dos o tres codewords
+func eciCodewords(eci int) []byte {
+	switch {
+	case eci <= 126:
+		return []byte{byte(eci + 1)}
+	case eci <= 16382:
+		eci -= 127
+		return []byte{byte(eci/254 + 128), byte(eci%254 + 1)}
+	}
+	eci -= 16383
+	return []byte{byte(eci/64516 + 192), byte(eci/254%254 + 1), byte(eci%254 + 1)}
+}
+
+// encodeASCII agrega data a prefix en la codificación ASCII
+func encodeASCII(prefix, data []byte, gs1 bool) []byte {
+	out := append([]byte(nil), prefix...)
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case isDigit(c) && i+1 < len(data) && isDigit(data[i+1]):
+			out = append(out, 130+(c-'0')*10+data[i+1]-'0')
+			i++
+		case gs1 && c == gs:
+			out = append(out, cwFNC1)
+		case c < 128:
+			out = append(out, c+1)
+		default:
+			out = append(out, cwUpperShift, c-127)
+		}
+	}
+	return out
+}
+
+// encodeBase256 agrega data a prefix en un único segmento Base256, con su
+// largo y los bytes aleatorizados según su posición
+func encodeBase256(prefix, data []byte) []byte {
+	out := append([]byte(nil), prefix...)
+	out = append(out, cwBase256)
+	length := []byte{byte(len(data))}
+	if len(data) > 249 {
+		length = []byte{byte(len(data)/250 + 249), byte(len(data) % 250)}
+	}
+	for _, b := range append(length, data...) {
+		out = append(out, randomize255(b, len(out)+1))
+	}
+	return out
+}
+
+// randomize255 aplica el algoritmo de 255 estados a un codeword Base256 en
+// la posición pos (desde 1)
+func randomize255(b byte, pos int) byte {
+	return byte((int(b) + 149*pos%255 + 1) % 256)
+}
+
+// unrandomize255 deshace randomize255
+func unrandomize255(b byte, pos int) byte {
+	return byte((int(b) - 149*pos%255 - 1 + 256) % 256)
+}
+
+// pad completa los codewords hasta capacity: el primer relleno es 129 y los
+// demás se aleatorizan con el algoritmo de 253 estados
+func pad(codewords []byte, capacity int) []byte {
+	out := append([]byte(nil), codewords...)
+	if len(out) < capacity {
+		out = append(out, cwPad)
+	}
+	for len(out) < capacity {
+		v := cwPad + 149*(len(out)+1)%253 + 1
+		if v > 254 {
+			v -= 254
+		}
+		out = append(out, byte(v))
+	}
+	return out
+}
+
+// isDigit indica si el byte es un dígito ASCII
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
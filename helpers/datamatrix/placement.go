@@ -0,0 +1,174 @@
+package datamatrix
+
+// cell es un módulo del área de datos: fila y columna sin los patrones de borde
+type cell struct{ row, col int }
+
+// placement es el recorrido del anexo F del estándar sobre el área de datos
+type placement struct {
+	nrow, ncol int
+	used       []bool
+	bits       [][8]cell // Módulos de cada codeword, del bit más alto al más bajo
+}
+
+// layout devuelve dónde va cada bit de cada codeword en el área de datos y
+// si el rincón inferior derecho queda libre (y se rellena en damero)
+func layout(size Size) ([][8]cell, bool) {
+	vertical, horizontal := size.regions()
+	p := &placement{nrow: vertical * size.RegionRows, ncol: horizontal * size.RegionCols}
+	p.used = make([]bool, p.nrow*p.ncol)
+
+	row, col := 4, 0
+	for {
+		if row == p.nrow && col == 0 {
+			p.corner1()
+		}
+		if row == p.nrow-2 && col == 0 && p.ncol%4 != 0 {
+			p.corner2()
+		}
+		if row == p.nrow-2 && col == 0 && p.ncol%8 == 4 {
+			p.corner3()
+		}
+		if row == p.nrow+4 && col == 2 && p.ncol%8 == 0 {
+			p.corner4()
+		}
+		// Diagonal hacia arriba y a la derecha
+		for {
+			if row < p.nrow && col >= 0 && !p.used[row*p.ncol+col] {
+				p.utah(row, col)
+			}
+			row, col = row-2, col+2
+			if row < 0 || col >= p.ncol {
+				break
+			}
+		}
+		row, col = row+1, col+3
+		// Diagonal hacia abajo y a la izquierda
+		for {
+			if row >= 0 && col < p.ncol && !p.used[row*p.ncol+col] {
+				p.utah(row, col)
+			}
+			row, col = row+2, col-2
+			if row >= p.nrow || col < 0 {
+				break
+			}
+		}
+		row, col = row+3, col+1
+		if row >= p.nrow && col >= p.ncol {
+			break
+		}
+	}
+	return p.bits, !p.used[p.nrow*p.ncol-1]
+}
+
+// module ubica un bit, envolviendo las coordenadas que salen del área
+func (p *placement) module(row, col int) cell {
+	if row < 0 {
+		row += p.nrow
+		col += 4 - (p.nrow+4)%8
+	}
+	if col < 0 {
+		col += p.ncol
+		row += 4 - (p.ncol+4)%8
+	}
+	p.used[row*p.ncol+col] = true
+	return cell{row, col}
+}
+
+// add registra un codeword con sus ocho módulos
+func (p *placement) add(coords [8][2]int) {
+	var bits [8]cell
+	for i, c := range coords {
+		bits[i] = p.module(c[0], c[1])
+	}
+	p.bits = append(p.bits, bits)
+}
+
+// utah es la forma normal de un codeword, con el bit más bajo en (row, col)
+func (p *placement) utah(row, col int) {
+	p.add([8][2]int{
+		{row - 2, col - 2}, {row - 2, col - 1}, {row - 1, col - 2}, {row - 1, col - 1},
+		{row - 1, col}, {row, col - 2}, {row, col - 1}, {row, col},
+	})
+}
+
+func (p *placement) corner1() {
+	n, m := p.nrow, p.ncol
+	p.add([8][2]int{{n - 1, 0}, {n - 1, 1}, {n - 1, 2}, {0, m - 2}, {0, m - 1}, {1, m - 1}, {2, m - 1}, {3, m - 1}})
+}
+
+func (p *placement) corner2() {
+	n, m := p.nrow, p.ncol
+	p.add([8][2]int{{n - 3, 0}, {n - 2, 0}, {n - 1, 0}, {0, m - 4}, {0, m - 3}, {0, m - 2}, {0, m - 1}, {1, m - 1}})
+}
+
+func (p *placement) corner3() {
+	n, m := p.nrow, p.ncol
+	p.add([8][2]int{{n - 3, 0}, {n - 2, 0}, {n - 1, 0}, {0, m - 2}, {0, m - 1}, {1, m - 1}, {2, m - 1}, {3, m - 1}})
+}
+
+func (p *placement) corner4() {
+	n, m := p.nrow, p.ncol
+	p.add([8][2]int{{n - 1, 0}, {n - 1, m - 1}, {0, m - 3}, {0, m - 2}, {0, m - 1}, {1, m - 3}, {1, m - 2}, {1, m - 1}})
+}
+
+// symbolCell convierte una celda del área de datos a fila y columna del
+// símbolo, salteando los patrones de borde de cada región
+func (s Size) symbolCell(c cell) (int, int) {
+	return c.row/s.RegionRows*(s.RegionRows+2) + 1 + c.row%s.RegionRows,
+		c.col/s.RegionCols*(s.RegionCols+2) + 1 + c.col%s.RegionCols
+}
+
+// render dibuja el símbolo: los patrones de borde de cada región (la L
+// sólida a la izquierda y abajo, el reloj arriba y a la derecha) y los
+// codewords según layout
+func render(size Size, codewords []byte) [][]bool {
+	matrix := make([][]bool, size.Rows)
+	for y := range matrix {
+		matrix[y] = make([]bool, size.Cols)
+	}
+	vertical, horizontal := size.regions()
+	for ry := 0; ry < vertical; ry++ {
+		for rx := 0; rx < horizontal; rx++ {
+			top, left := ry*(size.RegionRows+2), rx*(size.RegionCols+2)
+			bottom, right := top+size.RegionRows+1, left+size.RegionCols+1
+			for y := top; y <= bottom; y++ {
+				matrix[y][left] = true
+				matrix[y][right] = (y-top)%2 == 1
+			}
+			for x := left; x <= right; x++ {
+				matrix[bottom][x] = true
+				matrix[top][x] = (x-left)%2 == 0
+			}
+		}
+	}
+
+	bits, corner := layout(size)
+	for i, cw := range codewords {
+		for b, c := range bits[i] {
+			y, x := size.symbolCell(c)
+			matrix[y][x] = cw&(0x80>>b) != 0
+		}
+	}
+	if corner {
+		nrow, ncol := vertical*size.RegionRows, horizontal*size.RegionCols
+		y, x := size.symbolCell(cell{nrow - 1, ncol - 1})
+		matrix[y][x] = true
+		y, x = size.symbolCell(cell{nrow - 2, ncol - 2})
+		matrix[y][x] = true
+	}
+	return matrix
+}
+
+// readCodewords lee los codewords de un símbolo ya muestreado
+func readCodewords(size Size, matrix [][]bool) []byte {
+	bits, _ := layout(size)
+	codewords := make([]byte, len(bits))
+	for i := range bits {
+		for b, c := range bits[i] {
+			if y, x := size.symbolCell(c); matrix[y][x] {
+				codewords[i] |= 0x80 >> b
+			}
+		}
+	}
+	return codewords
+}
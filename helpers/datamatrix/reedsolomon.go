@@ -0,0 +1,54 @@
+package datamatrix
+
+import "qrgenerator_cli/helpers/reedsolomon"
+
+// field es GF(256) con el polinomio primitivo 0x12d, el de Data Matrix (el QR
+// usa 0x11d), y el generador desde alfa^1
+var field = reedsolomon.NewField(0x12d, 256, 1)
+
+// withECC intercala los datos en los bloques del tamaño y agrega la
+// corrección de cada uno: el codeword i va al bloque i % Blocks
+func withECC(size Size, data []byte) []byte {
+	perBlock := size.ECC / size.Blocks
+	out := append([]byte(nil), data...)
+	out = append(out, make([]byte, size.ECC)...)
+	for b := 0; b < size.Blocks; b++ {
+		var block []byte
+		for i := b; i < len(data); i += size.Blocks {
+			block = append(block, data[i])
+		}
+		for j, c := range field.EncodeBytes(block, perBlock) {
+			out[len(data)+j*size.Blocks+b] = c
+		}
+	}
+	return out
+}
+
+// correct corrige en el lugar los codewords leídos de un símbolo y devuelve
+// los datos y la cantidad de codewords corregidos
+func correct(size Size, codewords []byte) ([]byte, int, error) {
+	perBlock := size.ECC / size.Blocks
+	total := 0
+	for b := 0; b < size.Blocks; b++ {
+		var positions []int
+		for i := b; i < size.Data; i += size.Blocks {
+			positions = append(positions, i)
+		}
+		for j := 0; j < perBlock; j++ {
+			positions = append(positions, size.Data+j*size.Blocks+b)
+		}
+		block := make([]byte, len(positions))
+		for i, p := range positions {
+			block[i] = codewords[p]
+		}
+		n, err := field.CorrectBytes(block, perBlock)
+		if err != nil {
+			return nil, 0, err
+		}
+		total += n
+		for i, p := range positions {
+			codewords[p] = block[i]
+		}
+	}
+	return codewords[:size.Data], total, nil
+}
@@ -0,0 +1,141 @@
+package ean
+
+import (
+	"math"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un EAN-13 reconocible
+var errNotFound = i18n.NewError("no EAN-13 or UPC-A barcode found in the image")
+
+// runsPerSymbol son las barras y los espacios entre la primera barra y la
+// última: 3 de cada guarda de los costados, 5 de la del medio y 4 por dígito
+const runsPerSymbol = 3 + 6*4 + 5 + 6*4 + 3
+
+// DecodeModules decodifica los 95 módulos de un EAN-13, sin zona de
+// silencio, y devuelve sus 13 dígitos
+func DecodeModules(line []bool) (string, error) {
+	var runs []int
+	for i := 0; i < len(line); {
+		j := i
+		for j < len(line) && line[j] == line[i] {
+			j++
+		}
+		runs = append(runs, j-i)
+		i = j
+	}
+	if len(line) != Modules || !line[0] || len(runs) != runsPerSymbol {
+		return "", errNotFound
+	}
+	return readRuns(runs)
+}
+
+// Detect busca un EAN-13 horizontal en una imagen binarizada: recorre las
+// filas y lee los anchos de barras y espacios entre la primera barra y la
+// última. Devuelve los 13 dígitos de la primera fila que se decodifica.
+func Detect(dark func(x, y int) bool, width, height int) (string, error) {
+	for y := 0; y < height; y++ {
+		var runs []int
+		for x := 0; x < width; x++ {
+			switch {
+			case len(runs) == 0 && !dark(x, y):
+			case (len(runs)%2 == 1) == dark(x, y):
+				runs[len(runs)-1]++
+			default:
+				runs = append(runs, 1)
+			}
+		}
+		// El último tramo es claro: la zona de silencio
+		if n := len(runs); n > 0 && n%2 == 0 {
+			runs = runs[:n-1]
+		}
+		if len(runs) != runsPerSymbol {
+			continue
+		}
+		if digits, err := readRuns(runs); err == nil {
+			return digits, nil
+		}
+	}
+	return "", errNotFound
+}
+
+// readRuns lee los dígitos de los anchos de las barras y los espacios: cada
+// dígito toma el patrón más parecido a sus cuatro anchos, y los juegos de
+// los seis de la izquierda dan el primero. Las guardas, de un módulo cada
+// barra y cada espacio, miden cuánto engordó la tinta las barras, que
+// distingue el 1 del 7 y el 2 del 8.
+func readRuns(runs []int) (string, error) {
+	var bars, spaces float64
+	for _, i := range []int{0, 2, 28, 30, 56, 58} {
+		bars += float64(runs[i])
+	}
+	for _, i := range []int{1, 27, 29, 31, 57} {
+		spaces += float64(runs[i])
+	}
+	gain := (bars/6 - spaces/5) / 2
+	widths := make([]float64, len(runs))
+	for i, r := range runs {
+		if i%2 == 0 {
+			widths[i] = float64(r) - gain
+		} else {
+			widths[i] = float64(r) + gain
+		}
+	}
+
+	digits := make([]byte, 13)
+	var parity []byte
+	for i := range 12 {
+		start := 3 + 4*i
+		if i >= 6 {
+			start += 5
+		}
+		group := widths[start : start+4]
+		best, bestParity, bestDistance := 0, byte('L'), math.Inf(1)
+		for d, pattern := range patterns {
+			if distance := patternDistance(group, pattern); distance < bestDistance {
+				best, bestParity, bestDistance = d, 'L', distance
+			}
+			// Los de la derecha son siempre del juego R, con los anchos del L
+			if distance := patternDistance(group, reverse(pattern)); i < 6 && distance < bestDistance {
+				best, bestParity, bestDistance = d, 'G', distance
+			}
+		}
+		if bestDistance > 2 {
+			return "", errNotFound
+		}
+		digits[i+1] = byte('0' + best)
+		if i < 6 {
+			parity = append(parity, bestParity)
+		}
+	}
+
+	first := -1
+	for d, p := range parities {
+		if p == string(parity) {
+			first = d
+		}
+	}
+	if first < 0 {
+		return "", errNotFound
+	}
+	digits[0] = byte('0' + first)
+	if CheckDigit(string(digits[:12])) != digits[12] {
+		return "", i18n.NewError("EAN-13 check digit does not match")
+	}
+	return string(digits), nil
+}
+
+// patternDistance compara los anchos de un dígito, escalados a sus 7
+// módulos, con los del patrón
+func patternDistance(group []float64, pattern string) float64 {
+	total := 0.0
+	for _, w := range group {
+		total += w
+	}
+	distance := 0.0
+	for j, w := range group {
+		distance += math.Abs(w*digitWidth/total - float64(pattern[j]-'0'))
+	}
+	return distance
+}
@@ -0,0 +1,191 @@
+// Package ean codifica y decodifica los códigos de barras de los productos
+// (ISO/IEC 15420): el EAN-13, el UPC-A, que es un EAN-13 que empieza con 0,
+// y el ISBN de los libros, un EAN-13 que empieza con 978 o 979. Los números
+// llevan un dígito de control módulo 10, y las barras, guardas al comienzo,
+// en el medio y al final.
+package ean
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Medidas del símbolo, en módulos
+const (
+	Modules      = 95 // Ancho de las barras: 3 + 6×7 + 5 + 6×7 + 3
+	QuietZone    = 11 // Zona de silencio que pide el estándar a la izquierda del EAN-13; a la derecha alcanza con 7
+	UPCQuietZone = 9  // Zona de silencio del UPC-A a cada lado
+)
+
+// Posiciones de las guardas y de las mitades
+const (
+	leftStart  = 3  // Primer módulo del primer dígito de la izquierda
+	rightStart = 50 // Primer módulo del primer dígito de la derecha
+	digitWidth = 7
+)
+
+// patterns son los anchos de espacio, barra, espacio y barra de cada dígito
+// en el juego L; el juego R usa los mismos empezando por una barra y el G
+// los mismos al revés
+var patterns = [10]string{"3211", "2221", "2122", "1411", "1132", "1231", "1114", "1312", "1213", "3112"}
+
+// parities son los juegos (L o G) de los seis dígitos de la izquierda, que
+// escriben el primer dígito del número
+var parities = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+
+// CheckDigit calcula el dígito de control mod 10 de GS1: desde la derecha,
+// los dígitos se multiplican alternadamente por 3 y por 1
+func CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// EAN13 valida un EAN-13 y devuelve sus 13 dígitos; con 12 le agrega el
+// dígito de control
+func EAN13(number string) (string, error) {
+	digits := compact(number)
+	if !isDigits(digits) || (len(digits) != 12 && len(digits) != 13) {
+		return "", i18n.Errorf("invalid EAN-13 %q; use 12 digits, or 13 with the check digit", number)
+	}
+	return withCheckDigit(digits, 13)
+}
+
+// UPCA valida un UPC-A y devuelve los 13 dígitos del EAN-13 equivalente;
+// con 11 le agrega el dígito de control
+func UPCA(number string) (string, error) {
+	digits := compact(number)
+	if !isDigits(digits) || (len(digits) != 11 && len(digits) != 12) {
+		return "", i18n.Errorf("invalid UPC-A %q; use 11 digits, or 12 with the check digit", number)
+	}
+	digits, err := withCheckDigit(digits, 12)
+	return "0" + digits, err
+}
+
+// ISBN valida un ISBN-10 o un ISBN-13, con o sin guiones ni el prefijo
+// "ISBN", y devuelve los 13 dígitos del EAN-13: los ISBN-10 pasan a 978 con
+// un dígito de control nuevo
+func ISBN(number string) (string, error) {
+	digits := strings.TrimPrefix(strings.ToUpper(compact(number)), "ISBN")
+	digits = strings.TrimPrefix(digits, ":")
+	switch {
+	case len(digits) == 10 && isDigits(digits[:9]) && (isDigits(digits[9:]) || digits[9] == 'X'):
+		if want := isbn10CheckDigit(digits[:9]); digits[9] != want {
+			return "", i18n.Errorf("ISBN %s has a wrong check digit; it should end in %c", number, want)
+		}
+		digits = "978" + digits[:9]
+		return digits + string(CheckDigit(digits)), nil
+	case len(digits) == 13 && isDigits(digits):
+		if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+			return "", i18n.Errorf("invalid ISBN %q; an ISBN-13 starts with 978 or 979", number)
+		}
+		return withCheckDigit(digits, 13)
+	}
+	return "", i18n.Errorf("invalid ISBN %q; use an ISBN-10 or an ISBN-13", number)
+}
+
+// Encode dibuja los 95 módulos de un EAN-13 de 13 dígitos; true es barra
+func Encode(digits string) ([]bool, error) {
+	if len(digits) != 13 || !isDigits(digits) {
+		return nil, i18n.Errorf("invalid EAN-13 %q; use 12 digits, or 13 with the check digit", digits)
+	}
+	modules := make([]bool, 0, Modules)
+	bars := func(pattern string, bar bool) {
+		for _, w := range pattern {
+			for range int(w - '0') {
+				modules = append(modules, bar)
+			}
+			bar = !bar
+		}
+	}
+	bars("111", true)
+	parity := parities[digits[0]-'0']
+	for i := 1; i <= 6; i++ {
+		pattern := patterns[digits[i]-'0']
+		if parity[i-1] == 'G' {
+			pattern = reverse(pattern)
+		}
+		bars(pattern, false)
+	}
+	bars("11111", false)
+	for i := 7; i <= 12; i++ {
+		bars(patterns[digits[i]-'0'], true)
+	}
+	bars("111", true)
+	return modules, nil
+}
+
+// IsGuard indica si el módulo x es de las guardas, que en el dibujo
+// estándar bajan más que las barras de los dígitos
+func IsGuard(x int) bool {
+	return x < leftStart || (x >= leftStart+6*digitWidth && x < rightStart) || x >= rightStart+6*digitWidth
+}
+
+// IsUPCGuard es IsGuard en los UPC-A, que también bajan las barras del
+// primer dígito y del último
+func IsUPCGuard(x int) bool {
+	return IsGuard(x) || x < leftStart+digitWidth || x >= rightStart+5*digitWidth
+}
+
+// DigitCenter es el centro del dígito i (1 a 12) del número, en módulos
+// desde la primera barra; el dígito 0 va a la izquierda de las barras
+func DigitCenter(i int) float64 {
+	if i <= 6 {
+		return leftStart + float64(i-1)*digitWidth + digitWidth/2.0
+	}
+	return rightStart + float64(i-7)*digitWidth + digitWidth/2.0
+}
+
+// withCheckDigit agrega el dígito de control a un número de n-1 dígitos o
+// revisa el de uno de n
+func withCheckDigit(digits string, n int) (string, error) {
+	want := CheckDigit(digits[:n-1])
+	if len(digits) == n-1 {
+		return digits + string(want), nil
+	}
+	if digits[n-1] != want {
+		return "", i18n.Errorf("%s has a wrong check digit; it should end in %c", digits, want)
+	}
+	return digits, nil
+}
+
+// isbn10CheckDigit calcula el dígito de control mod 11 de un ISBN-10
+func isbn10CheckDigit(digits string) byte {
+	sum := 0
+	for i := range 9 {
+		sum += (10 - i) * int(digits[i]-'0')
+	}
+	if check := (11 - sum%11) % 11; check < 10 {
+		return byte('0' + check)
+	}
+	return 'X'
+}
+
+// compact saca los espacios y los guiones con que se suelen escribir los números
+func compact(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(number))
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
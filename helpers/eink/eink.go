@@ -0,0 +1,229 @@
+package eink
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/mqtt"
+)
+
+// Format es la codificación de la imagen que se envía al display
+type Format string
+
+// Codificaciones soportadas
+const (
+	FormatRaw Format = "raw" // 1 bit por píxel, el más significativo primero, filas alineadas a byte, 1 = negro
+	FormatPBM Format = "pbm" // Netpbm binario (P4)
+	FormatBMP Format = "bmp" // BMP monocromo de 1 bit
+	FormatPNG Format = "png" // PNG con paleta de dos colores
+)
+
+// contentTypes es el Content-Type con el que se envía cada codificación por HTTP
+var contentTypes = map[Format]string{
+	FormatRaw: "application/octet-stream",
+	FormatPBM: "image/x-portable-bitmap",
+	FormatBMP: "image/bmp",
+	FormatPNG: "image/png",
+}
+
+// DefaultTimeout es el tiempo máximo para entregar la imagen
+const DefaultTimeout = 10 * time.Second
+
+// ParseFormat interpreta el nombre de una codificación
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(name))
+	if _, ok := contentTypes[format]; !ok {
+		return "", i18n.Errorf("unsupported display format: %s (raw, pbm, bmp or png)", name)
+	}
+	return format, nil
+}
+
+// ParseCanvas interpreta la resolución del display, por ejemplo "296x128"
+func ParseCanvas(value string) (width, height int, err error) {
+	w, h, ok := strings.Cut(strings.ToLower(value), "x")
+	if ok {
+		width, err = strconv.Atoi(w)
+		if err == nil {
+			height, err = strconv.Atoi(h)
+		}
+	}
+	if !ok || err != nil || width <= 0 || height <= 0 {
+		return 0, 0, i18n.Errorf("invalid display resolution %q; use WIDTHxHEIGHT, e.g. 296x128", value)
+	}
+	return width, height, nil
+}
+
+// Bitmap es una imagen de 1 bit, como la que muestran los displays e-ink
+type Bitmap struct {
+	Width, Height int
+	black         []bool
+}
+
+// NewBitmap umbraliza la imagen y la centra sobre un lienzo blanco del tamaño
+// del display; con width o height en 0 se usa el tamaño de la imagen
+func NewBitmap(img image.Image, width, height int) (*Bitmap, error) {
+	bounds := img.Bounds()
+	if width == 0 || height == 0 {
+		width, height = bounds.Dx(), bounds.Dy()
+	}
+	if bounds.Dx() > width || bounds.Dy() > height {
+		return nil, i18n.Errorf("the %dpx QR does not fit the %dx%d display; lower -size", bounds.Dx(), width, height)
+	}
+
+	b := &Bitmap{Width: width, Height: height, black: make([]bool, width*height)}
+	offsetX, offsetY := (width-bounds.Dx())/2, (height-bounds.Dy())/2
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := (offsetY + y - bounds.Min.Y) * width
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			b.black[row+offsetX+x-bounds.Min.X] = gray.Y < 128
+		}
+	}
+	return b, nil
+}
+
+// packed empaqueta las filas con el bit más significativo primero; cada fila
+// empieza en un byte nuevo y se completa hasta un múltiplo de align bytes
+func (b *Bitmap) packed(set bool, align int, bottomUp bool) []byte {
+	stride := (b.Width + 7) / 8
+	stride = (stride + align - 1) / align * align
+	data := make([]byte, stride*b.Height)
+	for y := 0; y < b.Height; y++ {
+		row := y
+		if bottomUp {
+			row = b.Height - 1 - y
+		}
+		for x := 0; x < b.Width; x++ {
+			if b.black[y*b.Width+x] == set {
+				data[row*stride+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return data
+}
+
+// Encode codifica el bitmap. invert solo afecta a raw: 1 pasa a ser blanco,
+// como esperan algunos controladores de paneles.
+func (b *Bitmap) Encode(format Format, invert bool) ([]byte, error) {
+	switch format {
+	case FormatRaw:
+		return b.packed(!invert, 1, false), nil
+	case FormatPBM:
+		header := "P4\n" + strconv.Itoa(b.Width) + " " + strconv.Itoa(b.Height) + "\n"
+		return append([]byte(header), b.packed(true, 1, false)...), nil
+	case FormatBMP:
+		return b.bmp(), nil
+	case FormatPNG:
+		img := image.NewPaletted(image.Rect(0, 0, b.Width, b.Height), color.Palette{color.White, color.Black})
+		for i, black := range b.black {
+			if black {
+				img.Pix[i] = 1
+			}
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, i18n.Errorf("unsupported display format: %s (raw, pbm, bmp or png)", format)
+}
+
+// bmp escribe un BMP de 1 bit con paleta blanco/negro; las filas van de abajo
+// hacia arriba y alineadas a 4 bytes, como pide el formato
+func (b *Bitmap) bmp() []byte {
+	pixels := b.packed(true, 4, true)
+	const headerSize = 14 + 40 + 8
+	var buf bytes.Buffer
+	le := func(v any) { binary.Write(&buf, binary.LittleEndian, v) }
+
+	buf.WriteString("BM")
+	le(uint32(headerSize + len(pixels)))
+	le(uint32(0))
+	le(uint32(headerSize))
+
+	le(uint32(40))
+	le(int32(b.Width))
+	le(int32(b.Height))
+	le(uint16(1)) // Planos
+	le(uint16(1)) // Bits por píxel
+	le(uint32(0)) // Sin compresión
+	le(uint32(len(pixels)))
+	le(int32(2835)) // 72 dpi en píxeles por metro
+	le(int32(2835))
+	le(uint32(2)) // Colores de la paleta
+	le(uint32(2))
+
+	buf.Write([]byte{0xff, 0xff, 0xff, 0, 0, 0, 0, 0}) // Índice 0 blanco, 1 negro
+	buf.Write(pixels)
+	return buf.Bytes()
+}
+
+// CheckTarget revisa que el destino sea una URL http(s) o un tema mqtt(s)
+func CheckTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return i18n.Errorf("invalid display URL: %w", err)
+	}
+	switch u.Scheme {
+	case "mqtt", "mqtts":
+		_, err := mqtt.ParseTarget(target)
+		return err
+	case "http", "https":
+		return nil
+	}
+	return i18n.Errorf("unsupported display URL %q (http, https, mqtt or mqtts)", Redact(target))
+}
+
+// Redact oculta la contraseña de una URL para poder mostrarla
+func Redact(target string) string {
+	if u, err := url.Parse(target); err == nil {
+		return u.Redacted()
+	}
+	return target
+}
+
+// Push envía la imagen al display. Las URL http(s) reciben un POST con la
+// imagen como cuerpo y su tamaño en X-Image-Width/X-Image-Height; las
+// mqtt(s)://host/tema la publican en el tema.
+func Push(ctx context.Context, target string, data []byte, format Format, width, height int) error {
+	if err := CheckTarget(target); err != nil {
+		return err
+	}
+	if strings.HasPrefix(target, "mqtt") {
+		broker, err := mqtt.ParseTarget(target)
+		if err != nil {
+			return err
+		}
+		return mqtt.Publish(ctx, broker, data, mqtt.Options{})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return i18n.Errorf("invalid display URL: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypes[format])
+	req.Header.Set("X-Image-Width", strconv.Itoa(width))
+	req.Header.Set("X-Image-Height", strconv.Itoa(height))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 300 {
+		return i18n.Errorf("display answered %d", resp.StatusCode)
+	}
+	return nil
+}
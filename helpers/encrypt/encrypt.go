@@ -0,0 +1,111 @@
+package encrypt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Programas de cifrado. No hay implementaciones de age ni de OpenPGP en la
+// biblioteca estándar, así que se usan los del sistema si están instalados.
+const (
+	ageCommand = "age"
+	gpgCommand = "gpg"
+)
+
+// Encabezados de la armadura ASCII de cada formato, para reconocer un payload
+// cifrado al leerlo
+const (
+	AgeHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+	PGPHeader = "-----BEGIN PGP MESSAGE-----"
+)
+
+// ToolFor devuelve el programa que cifra para el destinatario: age para las
+// claves age1... y las claves públicas SSH, gpg para cualquier otra (ID,
+// huella o correo de una clave del llavero)
+func ToolFor(recipient string) string {
+	for _, prefix := range []string{"age1", "ssh-ed25519 ", "ssh-rsa "} {
+		if strings.HasPrefix(recipient, prefix) {
+			return ageCommand
+		}
+	}
+	return gpgCommand
+}
+
+// Available informa si el programa que cifra para el destinatario está instalado
+func Available(recipient string) error {
+	tool := ToolFor(recipient)
+	if _, err := exec.LookPath(tool); err != nil {
+		return i18n.Errorf("encrypting to %s needs %s; install it or choose another recipient", recipient, tool)
+	}
+	return nil
+}
+
+// Encrypt cifra el texto para el destinatario y lo devuelve con armadura ASCII
+func Encrypt(text, recipient string) (string, error) {
+	if err := Available(recipient); err != nil {
+		return "", err
+	}
+	tool := ToolFor(recipient)
+	args := []string{"--encrypt", "--armor", "--recipient", recipient}
+	if tool == gpgCommand {
+		// Sin --batch, gpg preguntaría por la confianza en la clave en la terminal
+		args = append([]string{"--batch", "--yes"}, args...)
+	}
+	out, err := run(tool, text, args...)
+	if err != nil {
+		return "", i18n.Errorf("cannot encrypt to %s: %w", recipient, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Decrypt descifra un mensaje generado por Encrypt. Con age usa el archivo de
+// identidad indicado; gpg busca la clave en el llavero y pide la frase con su
+// agente. Si el texto no está cifrado lo devuelve sin cambios y encrypted en false.
+func Decrypt(text, identity string) (plain string, encrypted bool, err error) {
+	armored := strings.TrimSpace(text)
+	var tool string
+	var args []string
+	switch {
+	case strings.HasPrefix(armored, AgeHeader):
+		if identity == "" {
+			return "", true, i18n.Errorf("the payload is encrypted with age; pass the identity file with -identity")
+		}
+		tool, args = ageCommand, []string{"--decrypt", "--identity", identity}
+	case strings.HasPrefix(armored, PGPHeader):
+		tool, args = gpgCommand, []string{"--quiet", "--decrypt"}
+	default:
+		return text, false, nil
+	}
+
+	if _, err := exec.LookPath(tool); err != nil {
+		return "", true, i18n.Errorf("the payload is encrypted; decrypting it needs %s", tool)
+	}
+	plain, err = run(tool, armored+"\n", args...)
+	if err != nil {
+		return "", true, i18n.Errorf("cannot decrypt the payload: %w", err)
+	}
+	return plain, true, nil
+}
+
+// run ejecuta el programa con input en la entrada estándar y devuelve su
+// salida; si falla, el error incluye lo que escribió en la salida de errores
+func run(tool, input string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(tool, args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			// gpg y age ya anteponen su nombre a los mensajes
+			return "", errors.New(msg)
+		}
+		return "", fmt.Errorf("%s: %w", tool, err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,104 @@
+package encrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/paperkey"
+)
+
+// PassphrasePrefix identifica un payload cifrado con frase de contraseña: el
+// sobre de EncryptPassphrase en base45, que el QR codifica en el modo
+// alfanumérico
+const PassphrasePrefix = "QRSC45:"
+
+// passphraseMagic encabeza el sobre. El sobre es passphraseMagic + log2(N),
+// r y p de scrypt (1 byte cada uno) + sal (16) + nonce (12) + AES-256-GCM del
+// texto; todo lo anterior al nonce es el dato adicional autenticado.
+const passphraseMagic = "QRSC1"
+
+// Parámetros de scrypt con los que se cifra: N=2^15, r=8, p=1 (32 MiB)
+const (
+	scryptLogN = 15
+	scryptR    = 8
+	scryptP    = 1
+	saltSize   = 16
+	nonceSize  = 12
+	paramsSize = len(passphraseMagic) + 3 + saltSize
+	headerSize = paramsSize + nonceSize
+)
+
+// ErrPassphrase indica que la frase no abre el payload o que está dañado
+var ErrPassphrase = i18n.NewError("wrong passphrase or damaged payload")
+
+// EncryptPassphrase cifra el texto con una clave derivada de la frase con scrypt
+func EncryptPassphrase(text, passphrase string) (string, error) {
+	if len([]rune(passphrase)) < paperkey.MinPassphrase {
+		return "", i18n.Errorf("the passphrase needs at least %d characters", paperkey.MinPassphrase)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, passphraseMagic)
+	header[len(passphraseMagic)] = scryptLogN
+	header[len(passphraseMagic)+1] = scryptR
+	header[len(passphraseMagic)+2] = scryptP
+	rand.Read(header[paramsSize-saltSize : paramsSize])
+	rand.Read(header[paramsSize:])
+
+	aead, err := passphraseAEAD(passphrase, header)
+	if err != nil {
+		return "", err
+	}
+	envelope := aead.Seal(header, header[paramsSize:], []byte(text), header[:paramsSize])
+	return PassphrasePrefix + compress.EncodeBase45(envelope), nil
+}
+
+// IsPassphraseEncrypted informa si el payload lo generó EncryptPassphrase
+func IsPassphraseEncrypted(text string) bool {
+	return strings.HasPrefix(text, PassphrasePrefix)
+}
+
+// DecryptPassphrase descifra un payload de EncryptPassphrase. Una frase
+// equivocada o un payload dañado devuelven ErrPassphrase.
+func DecryptPassphrase(text, passphrase string) (string, error) {
+	envelope, err := compress.DecodeBase45(strings.TrimPrefix(text, PassphrasePrefix))
+	if err != nil {
+		return "", i18n.Errorf("the encrypted payload is damaged: %w", err)
+	}
+	if len(envelope) < headerSize || !bytes.HasPrefix(envelope, []byte(passphraseMagic)) {
+		return "", ErrPassphrase
+	}
+	aead, err := passphraseAEAD(passphrase, envelope[:headerSize])
+	if err != nil {
+		return "", ErrPassphrase
+	}
+	plain, err := aead.Open(nil, envelope[paramsSize:headerSize], envelope[headerSize:], envelope[:paramsSize])
+	if err != nil {
+		return "", ErrPassphrase
+	}
+	return string(plain), nil
+}
+
+// passphraseAEAD deriva la clave AES-256 con los parámetros y la sal del
+// encabezado. Los parámetros se acotan para que un payload armado a mano no
+// pida gigas de memoria al descifrarlo.
+func passphraseAEAD(passphrase string, header []byte) (cipher.AEAD, error) {
+	params := header[len(passphraseMagic):paramsSize]
+	logN, r, p := int(params[0]), int(params[1]), int(params[2])
+	if logN < 10 || logN > 20 || r < 1 || r > 16 || p < 1 || p > 4 {
+		return nil, ErrPassphrase
+	}
+	key, err := scrypt.Key([]byte(passphrase), params[3:], 1<<logN, r, p, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := aes.NewCipher(key)
+	return cipher.NewGCM(block)
+}
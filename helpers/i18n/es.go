@@ -0,0 +1,1179 @@
+package i18n
+
+// spanish es el catálogo en español, indexado por el mensaje original en inglés
+var spanish = map[string]string{
+	// Librería
+	"generate: %w":                                          "generación: %w",
+	"decode: %w":                                            "decodificación: %w",
+	"content %q, expected %q":                               "contenido %q, se esperaba %q",
+	"version %d, expected %d":                               "versión %d, se esperaba %d",
+	"the CSS contains no pixels":                            "el CSS no contiene píxeles",
+	"cannot read config: %w":                                "no se pudo leer la configuración: %w",
+	"invalid config in %s: %w":                              "configuración inválida en %s: %w",
+	"list %q can only contain plain values":                 "la lista %q solo puede contener valores simples",
+	"unknown option %q in config":                           "opción desconocida %q en la configuración",
+	"invalid value for %q in config: %w":                    "valor inválido para %q en la configuración: %w",
+	"unsupported subsampling: %s (444, 422 or 420)":         "submuestreo no soportado: %s (444, 422 o 420)",
+	"invalid image dimensions for JPEG":                     "dimensiones de imagen inválidas para JPEG",
+	"cannot open %s with %s: %w":                            "no se pudo abrir %s con %s: %w",
+	"no QR code found in the image":                         "no se encontró un código QR en la imagen",
+	"error decoding image: %w":                              "error decodificando imagen: %w",
+	"error reading SVG: %w":                                 "error leyendo SVG: %w",
+	"SVG has no dimensions":                                 "SVG sin dimensiones",
+	"unreadable format information":                         "información de formato ilegible",
+	"unreadable version information":                        "información de versión ilegible",
+	"block %d: %w":                                          "bloque %d: %w",
+	"invalid symbol size: %d":                               "tamaño de símbolo inválido: %d",
+	"version %d does not match size %d":                     "la versión %d no coincide con el tamaño %d",
+	"corrupted data: %w":                                    "datos corruptos: %w",
+	"too many errors to correct":                            "demasiados errores para corregir",
+	"truncated data stream":                                 "flujo de datos truncado",
+	"unknown data mode: %d":                                 "modo de datos desconocido: %d",
+	"invalid ECI designator":                                "designador ECI inválido",
+	"invalid kanji: %w":                                     "kanji inválido: %w",
+	"%q is not allowed in a numeric segment":                "%q no está permitido en un segmento numérico",
+	"%q is not allowed in an alphanumeric segment":          "%q no está permitido en un segmento alfanumérico",
+	"cannot encode %s segments":                             "no se pueden codificar segmentos %s",
+	"destination answered %d":                               "el destino respondió %d",
+	"image answered %d":                                     "la imagen respondió %d",
+	"error serializing alert: %v":                           "error serializando alerta: %v",
+	"invalid webhook: %v":                                   "webhook inválido: %v",
+	"error sending alert: %v":                               "error enviando alerta: %v",
+	"webhook answered %d":                                   "el webhook respondió %d",
+	"%s alert sent for %s":                                  "alerta %s enviada para %s",
+	"%w: AVIF supports up to %dpx per side; use PNG or SVG": "%w: AVIF admite hasta %dpx por lado; usá PNG o SVG",
+	"%w: error creating AVIF file: %w":                      "%w: error creando archivo AVIF: %w",
+	"%w: error encoding AVIF: %w":                           "%w: error codificando AVIF: %w",
+	"%w: AVIF quality %d out of range (1-100)":              "%w: calidad AVIF %d fuera de rango (1-100)",
+	"AVIF quality %d is lossy and blurs module edges; omit -quality for lossless encoding": "AVIF con calidad %d es con pérdida y desdibuja los bordes de los módulos; omití -quality para codificar sin pérdida",
+	"%w: error generating QR: %w":                            "%w: error generando QR: %w",
+	"error overlaying logo: %w":                              "error superponiendo logo: %w",
+	"error opening image: %w":                                "error abriendo imagen: %w",
+	"unsupported logo format: %s":                            "formato de logo no soportado: %s",
+	"%w: error creating PNG file: %w":                        "%w: error creando archivo PNG: %w",
+	"%w: error encoding PNG: %w":                             "%w: error codificando PNG: %w",
+	"%w: JPEG supports up to %dpx per side; use PNG or SVG":  "%w: JPEG admite hasta %dpx por lado; usá PNG o SVG",
+	"%w: progressive JPEG supports up to %dpx per side":      "%w: JPEG progresivo admite hasta %dpx por lado",
+	"%w: error creating JPEG file: %w":                       "%w: error creando archivo JPEG: %w",
+	"%w: error encoding JPEG: %w":                            "%w: error codificando JPEG: %w",
+	"%w: JPEG quality %d out of range (1-100)":               "%w: calidad JPEG %d fuera de rango (1-100)",
+	"%w: unsupported JPEG subsampling: %s (444, 422 or 420)": "%w: submuestreo JPEG no soportado: %s (444, 422 o 420)",
+	"JPEG quality %d blurs module edges and can make the code harder to scan; use %d or higher, or PNG/SVG": "calidad JPEG %d desdibuja los bordes de los módulos y puede dificultar el escaneo; usá %d o más, o PNG/SVG",
+	"chroma subsampling %s softens module edges; 444 keeps them sharp":                                      "el submuestreo de croma %s suaviza los bordes de los módulos; 444 los mantiene nítidos",
+	"%w: error creating SVG file: %w":      "%w: error creando archivo SVG: %w",
+	"%w: error creating CSS file: %w":      "%w: error creando archivo CSS: %w",
+	"%w: error writing %s: %w":             "%w: error escribiendo %s: %w",
+	"%w: error closing %s: %w":             "%w: error cerrando %s: %w",
+	"%w: URL is required":                  "%w: URL es requerida",
+	"%w: size %dpx out of range (%d-%dpx)": "%w: tamaño %dpx fuera de rango (%d-%dpx)",
+	"%w: unsupported format: %s":           "%w: formato no soportado: %s",
+	"invalid input":                        "entrada inválida",
+	"QR capacity exceeded":                 "capacidad del QR excedida",
+	"encoding error":                       "error de codificación",
+	"input/output error":                   "error de entrada/salida",
+	"%w: HEIF output needs %s (libheif); install libheif-examples or use AVIF":             "%w: la salida HEIF necesita %s (libheif); instalá libheif-examples o usá AVIF",
+	"%w: HEIF supports up to %dpx per side; use PNG or SVG":                                "%w: HEIF admite hasta %dpx por lado; usá PNG o SVG",
+	"%w: error creating temporary file: %w":                                                "%w: error creando archivo temporal: %w",
+	"%w: error encoding HEIF: %w: %s":                                                      "%w: error codificando HEIF: %w: %s",
+	"%w: HEIF quality %d out of range (1-100)":                                             "%w: calidad HEIF %d fuera de rango (1-100)",
+	"HEIF quality %d is lossy and blurs module edges; omit -quality for lossless encoding": "HEIF con calidad %d es con pérdida y desdibuja los bordes de los módulos; omití -quality para codificar sin pérdida",
+	"output extension %s does not match --format %s":                                       "la extensión de salida %s no coincide con --format %s",
+	"%w: output path %q has no extension; add one (.png, .svg, .jpg...) or pass --format":  "%w: la ruta de salida %q no tiene extensión; agregá una (.png, .svg, .jpg...) o usá --format",
+	"unknown output extension %s; writing JPEG":                                            "extensión de salida desconocida %s; se escribe JPEG",
+	"update verification failed":                                                           "falló la verificación de la actualización",
+	"no binary for this platform":                                                          "no hay binario para esta plataforma",
+	"download failed":                                                                      "falló la descarga",
+	"%w: this build has no release signing key":                                            "%w: este binario no tiene clave de firma de releases",
+	"%w: invalid embedded public key":                                                      "%w: la clave pública incorporada es inválida",
+	"%w: invalid release response: %w":                                                     "%w: respuesta de release inválida: %w",
+	"%w: release without tag":                                                              "%w: release sin tag",
+	"%w: %s has no %s":                                                                     "%w: %s no tiene %s",
+	"%w: %s answered %d":                                                                   "%w: %s respondió %d",
+	"%w: %s is larger than %d MB":                                                          "%w: %s supera los %d MB",
+	"%w: malformed checksum for %s":                                                        "%w: checksum mal formado para %s",
+	"%w: checksum mismatch for %s":                                                         "%w: el checksum de %s no coincide",
+	"%w: %s is not listed in %s":                                                           "%w: %s no figura en %s",
+	"%w: malformed signature":                                                              "%w: firma mal formada",
+	"%w: invalid signature for %s":                                                         "%w: firma inválida para %s",
+	"cannot write to %s: %w":                                                               "no se puede escribir en %s: %w",
+	"TIFF needs at least one page":                                                         "un TIFF necesita al menos una página",
+	"unsupported TIFF compression: %d":                                                     "compresión TIFF no soportada: %d",
+	"invalid image dimensions for TIFF":                                                    "dimensiones de imagen inválidas para TIFF",
+	"%w: error creating TIFF file: %w":                                                     "%w: error creando archivo TIFF: %w",
+	"%w: error encoding TIFF: %w":                                                          "%w: error codificando TIFF: %w",
+	"%w: unsupported TIFF compression: %s (g4 or lzw)":                                     "%w: compresión TIFF no soportada: %s (g4 o lzw)",
+	"%w: the batch has no payloads":                                                        "%w: el lote no tiene payloads",
+	"payload %d: %w":                                                                       "payload %d: %w",
+
+	"%w: no output to write":                 "%w: no hay ninguna salida para escribir",
+	"%w: output %s is listed more than once": "%w: la salida %s aparece más de una vez",
+
+	"%w: the module matrix is not available for this image":            "%w: la matriz de módulos no está disponible para esta imagen",
+	"%w: error creating matrix file: %w":                               "%w: error creando archivo de matriz: %w",
+	"%w: unsupported matrix encoding: %s (json, pbm, bits, c or rust)": "%w: codificación de matriz no soportada: %s (json, pbm, bits, c o rust)",
+	"the matrix is empty":                                              "la matriz está vacía",
+
+	"unknown payload type %q (%s)":                                  "tipo de payload desconocido %q (%s)",
+	"--%s is not used by --type %s":                                 "--%s no se usa con --type %s",
+	"--type wifi needs --ssid":                                      "--type wifi necesita --ssid",
+	"unsupported Wi-Fi security: %s (wpa2, wpa3, wpa, wep or none)": "seguridad Wi-Fi no soportada: %s (wpa2, wpa3, wpa, wep o none)",
+	"an open network (--security none) takes no --password":         "una red abierta (--security none) no lleva --password",
+	"--security %s needs --password":                                "--security %s necesita --password",
+	"WPA passwords are 8 to 63 characters (or 64 hex digits)":       "las contraseñas WPA tienen de 8 a 63 caracteres (o 64 dígitos hexadecimales)",
+
+	"invalid MQTT URL: %w":                                             "URL MQTT inválida: %w",
+	"unsupported MQTT scheme %q (mqtt or mqtts)":                       "esquema MQTT no soportado %q (mqtt o mqtts)",
+	"MQTT URL %q has no host":                                          "la URL MQTT %q no tiene host",
+	"MQTT URL %q has no topic; add it as the path (mqtt://host/topic)": "la URL MQTT %q no tiene tema; agregalo como ruta (mqtt://host/tema)",
+	"cannot connect to MQTT broker %s: %w":                             "no se puede conectar al broker MQTT %s: %w",
+	"TLS handshake with %s failed: %w":                                 "falló el handshake TLS con %s: %w",
+	"error sending to MQTT broker: %w":                                 "error enviando al broker MQTT: %w",
+	"no answer from MQTT broker: %w":                                   "el broker MQTT no respondió: %w",
+	"unexpected MQTT packet %d instead of CONNACK":                     "paquete MQTT %d inesperado en lugar de CONNACK",
+	"MQTT broker refused the connection: %s":                           "el broker MQTT rechazó la conexión: %s",
+	"unacceptable protocol version":                                    "versión de protocolo no aceptada",
+	"client identifier rejected":                                       "identificador de cliente rechazado",
+	"server unavailable":                                               "servidor no disponible",
+	"bad user name or password":                                        "usuario o contraseña incorrectos",
+	"not authorized":                                                   "no autorizado",
+	"unknown error":                                                    "error desconocido",
+	"malformed MQTT packet length":                                     "largo de paquete MQTT mal formado",
+	"unsupported display format: %s (raw, pbm, bmp or png)":            "formato de display no soportado: %s (raw, pbm, bmp o png)",
+	"invalid display resolution %q; use WIDTHxHEIGHT, e.g. 296x128":    "resolución de display inválida %q; usá ANCHOxALTO, por ejemplo 296x128",
+	"the %dpx QR does not fit the %dx%d display; lower -size":          "el QR de %dpx no entra en el display de %dx%d; bajá -size",
+	"invalid display URL: %w":                                          "URL de display inválida: %w",
+	"unsupported display URL %q (http, https, mqtt or mqtts)":          "URL de display no soportada %q (http, https, mqtt o mqtts)",
+	"display answered %d":                                              "el display respondió %d",
+
+	"--type %s needs --name":                    "--type %s necesita --name",
+	"invalid phone number %q":                   "número de teléfono inválido %q",
+	"invalid email address %q":                  "dirección de email inválida %q",
+	"unsupported vCard version %s (3.0 or 4.0)": "versión de vCard no soportada %s (3.0 o 4.0)",
+
+	"invalid MQTT QoS %d (0, 1 or 2)":                          "QoS MQTT inválido %d (0, 1 o 2)",
+	"the MQTT broker did not confirm the message: %w":          "el broker MQTT no confirmó el mensaje: %w",
+	"unexpected MQTT packet %d while waiting for confirmation": "paquete MQTT %d inesperado mientras se esperaba la confirmación",
+
+	"%w: unknown preset %s (%s)":                                         "%w: preset desconocido %s (%s)",
+	"%w: preset %s needs opaque colors":                                  "%w: el preset %s necesita colores opacos",
+	"%w: color #%02x%02x%02x is too close to a chroma key green or blue": "%w: el color #%02x%02x%02x está demasiado cerca del verde o azul de croma",
+
+	"--type sms needs --to": "--type sms necesita --to",
+	"Country of phone numbers written without +, as a two-letter code such as US, ES or AR; the number is checked and written in international (E.164) form (--type tel or sms)": "País de los teléfonos escritos sin +, como código de dos letras tal como US, ES o AR; el número se revisa y se escribe en forma internacional (E.164) (--type tel o sms)",
+	"unknown phone region %q; use a two-letter country code such as US, ES or AR":                                                                                                "región de teléfono desconocida %q; usá un código de país de dos letras como US, ES o AR",
+	"invalid phone number %q: it has no country code after +":                                                                                                                    "número de teléfono inválido %q: no tiene código de país después del +",
+	"invalid phone number %q: international numbers have at most %d digits":                                                                                                      "número de teléfono inválido %q: los números internacionales tienen como mucho %d dígitos",
+	"invalid phone number %q: %s numbers have %s digits after +%s, not %d":                                                                                                       "número de teléfono inválido %q: los números de %s tienen %s dígitos después de +%s, no %d",
+	"invalid phone number %q: area code %s starts with %c; North American area codes cannot start with 0 or 1":                                                                   "número de teléfono inválido %q: el código de área %s empieza con %c; en América del Norte los códigos de área no pueden empezar con 0 ni 1",
+	"invalid phone number %q: exchange %s starts with %c; North American exchanges cannot start with 0 or 1":                                                                     "número de teléfono inválido %q: la central %s empieza con %c; en América del Norte las centrales no pueden empezar con 0 ni 1",
+
+	"%w: unknown corner %s (%s)":                                             "%w: esquina desconocida %s (%s)",
+	"%w: the caption must be a single line":                                  "%w: el texto debe ser de una sola línea",
+	"%w: --corner, --caption and --duplicate need --preset %s or %s":         "%w: --corner, --caption y --duplicate necesitan --preset %s o %s",
+	"%w: preset %s writes a transparent PNG; use a .png output":              "%w: el preset %s escribe un PNG transparente; usá una salida .png",
+	"%w: the %dpx QR does not fit the %dx%d overlay; lower -size":            "%w: el QR de %dpx no entra en el overlay de %dx%d; bajá -size",
+	"%w: error loading the caption font: %w":                                 "%w: error al cargar la fuente del texto: %w",
+	"%w: the caption is too wide for the overlay; shorten it or lower -size": "%w: el texto es demasiado ancho para el overlay; acortalo o bajá -size",
+
+	"Phone number to call, e.g. +1 415 555 0132 (--type tel)": "Número de teléfono al que llamar, por ejemplo +1 415 555 0132 (--type tel)",
+	"--type tel needs --number":                               "--type tel necesita --number",
+
+	"connection closed by OBS (%d %s)":                             "OBS cerró la conexión (%d %s)",
+	"cannot connect to OBS at %s: %w":                              "no se pudo conectar a OBS en %s: %w",
+	"error sending to OBS: %w":                                     "error al enviar a OBS: %w",
+	"no answer from OBS: %w":                                       "OBS no respondió: %w",
+	"%s is not an obs-websocket server (HTTP %d)":                  "%s no es un servidor obs-websocket (HTTP %d)",
+	"OBS message too large":                                        "mensaje de OBS demasiado grande",
+	"unexpected WebSocket frame %d from OBS":                       "frame WebSocket %d inesperado de OBS",
+	"authentication failed; check --obs-password":                  "falló la autenticación; revisá --obs-password",
+	"unsupported obs-websocket version; OBS 28 or newer is needed": "versión de obs-websocket no soportada; se necesita OBS 28 o posterior",
+	"invalid OBS address: %w":                                      "dirección de OBS inválida: %w",
+	"unsupported OBS address %q; use host:port or ws://host:port":  "dirección de OBS no soportada %q; usá host:puerto o ws://host:puerto",
+	"OBS address %q has no host":                                   "la dirección de OBS %q no tiene host",
+	"OBS source %q is a %s; use an image or browser source":        "la fuente de OBS %q es de tipo %s; usá una fuente de imagen o de navegador",
+	"OBS requires a password; pass --obs-password":                 "OBS pide contraseña; pasá --obs-password",
+	"OBS has no source named %q":                                   "OBS no tiene una fuente llamada %q",
+	"OBS rejected %s (%d): %s":                                     "OBS rechazó %s (%d): %s",
+	"unexpected answer from OBS: %w":                               "respuesta inesperada de OBS: %w",
+	"OBS closed the connection: %s":                                "OBS cerró la conexión: %s",
+
+	"Recipient: a phone number such as +14155550132 (--type sms) or comma-separated email addresses (--type email)": "Destinatario: un teléfono como +14155550132 (--type sms) o emails separados por comas (--type email)",
+	"Message text (--type sms or email)": "Texto del mensaje (--type sms o email)",
+	"Email subject (--type email)":       "Asunto del email (--type email)",
+	"--type email needs --to":            "--type email necesita --to",
+
+	"%w: invalid frame rate %q (e.g. 25, 29.97 or 30000/1001)": "%w: cadencia inválida %q (por ejemplo 25, 29.97 o 30000/1001)",
+	"%w: line %d: cue after %s":                                "%w: línea %d: cue después de %s",
+	"%w: line %d: %w":                                          "%w: línea %d: %w",
+	"%w: line %d: %s is not after the previous cue":            "%w: línea %d: %s no es posterior al cue anterior",
+	"%w: line %d: missing payload (use - to hide the QR)":      "%w: línea %d: falta el payload (usá - para ocultar el QR)",
+	"%w: no cues": "%w: no hay cues",
+	"%w: the cue list needs a last line \"<time> %s\" with the end of the video":    "%w: la lista de cues necesita una última línea \"<instante> %s\" con el final del video",
+	"invalid time %q (seconds, HH:MM:SS.mmm or HH:MM:SS:FF)":                        "instante inválido %q (segundos, HH:MM:SS.mmm o HH:MM:SS:FF)",
+	"%w: ProRes output needs %s; install it or write a PNG sequence to a directory": "%w: la salida ProRes necesita %s; instalalo o escribí una secuencia PNG en un directorio",
+	"%w: cannot start %s: %w":           "%w: no se pudo iniciar %s: %w",
+	"%w: error encoding ProRes: %w":     "%w: error al codificar ProRes: %w",
+	"%w: error encoding ProRes: %w: %s": "%w: error al codificar ProRes: %w: %s",
+
+	"Latitude in decimal degrees, -90 to 90 (--type geo)":            "Latitud en grados decimales, de -90 a 90 (--type geo)",
+	"Longitude in decimal degrees, -180 to 180 (--type geo)":         "Longitud en grados decimales, de -180 a 180 (--type geo)",
+	"Altitude in meters (--type geo)":                                "Altitud en metros (--type geo)",
+	"Place name shown on the pin (--type geo)":                       "Nombre del lugar que se muestra en el pin (--type geo)",
+	"Write a maps link instead of geo: google or apple (--type geo)": "Escribe un enlace de mapas en lugar de geo: google o apple (--type geo)",
+	"--alt is only used by geo: URIs, not by --maps links":           "--alt solo se usa en las URI geo:, no en los enlaces de --maps",
+	"unsupported maps service %q (google or apple)":                  "servicio de mapas no soportado %q (google o apple)",
+	"invalid --alt %q; use meters, e.g. 25.5":                        "--alt inválido %q; usá metros, por ejemplo 25.5",
+	"--type geo needs --%s":                                          "--type geo necesita --%s",
+	"invalid --%s %q; use decimal degrees between -%g and %g":        "--%s inválido %q; usá grados decimales entre -%g y %g",
+
+	"Contact job title (--type vcard) or event title (--type event)":                                                                  "Cargo del contacto (--type vcard) o título del evento (--type event)",
+	`Event start: "2026-03-14 18:00", with an optional offset ("2026-03-14T18:00-03:00") or a date for all-day events (--type event)`: `Inicio del evento: "2026-03-14 18:00", con un huso opcional ("2026-03-14T18:00-03:00") o una fecha para eventos de todo el día (--type event)`,
+	"Event end, like --start (default one hour later, or the next day for all-day events) (--type event)":                             "Fin del evento, como --start (por defecto una hora después, o el día siguiente en eventos de todo el día) (--type event)",
+	"Event location (--type event)":    "Lugar del evento (--type event)",
+	"Event description (--type event)": "Descripción del evento (--type event)",
+	"Read the first event of an .ics file; the other event flags override its values (--type event)": "Lee el primer evento de un archivo .ics; los demás flags del evento reemplazan sus valores (--type event)",
+	"--end needs --start": "--end necesita --start",
+	"--start and --end must both be dates or both have a time":                                "--start y --end tienen que ser ambos fechas o ambos tener hora",
+	"the event must end after it starts":                                                      "el evento tiene que terminar después de empezar",
+	"--type event needs --start (or an --ics file)":                                           "--type event necesita --start (o un archivo --ics)",
+	"--type event needs --title":                                                              "--type event necesita --title",
+	`invalid event time %q; use "2026-03-14 18:00", "2026-03-14T18:00-03:00" or "2026-03-14"`: `hora de evento inválida %q; usá "2026-03-14 18:00", "2026-03-14T18:00-03:00" o "2026-03-14"`,
+	"cannot read --ics: %w":                                                                   "no se pudo leer --ics: %w",
+	"%s has no VEVENT":                                                                        "%s no tiene ningún VEVENT",
+	"%s: unterminated VEVENT":                                                                 "%s: VEVENT sin terminar",
+
+	"invalid link %q; use lang=URL, e.g. es=https://example.com/es": "enlace inválido %q; usá idioma=URL, por ejemplo es=https://example.com/es",
+	"unknown language %q: %w":              "idioma desconocido %q: %w",
+	"invalid URL for %s: %q":               "URL inválida para %s: %q",
+	"language %s is listed more than once": "el idioma %s aparece más de una vez",
+	"no links; use lang=URL pairs, e.g. es=https://example.com/es,en=https://example.com/en": "no hay enlaces; usá pares idioma=URL, por ejemplo es=https://example.com/es,en=https://example.com/en",
+
+	"unsupported compression %q (base64 or base45)": "compresión no soportada %q (base64 o base45)",
+	"invalid compressed payload: %w":                "payload comprimido inválido: %w",
+	"compressed payload expands beyond %d MiB":      "el payload comprimido se expande a más de %d MiB",
+	"base45 length %d is not valid":                 "el largo base45 %d no es válido",
+	"invalid base45 character %q":                   "carácter base45 inválido %q",
+	"invalid base45 group %q":                       "grupo base45 inválido %q",
+	"--compress does not shrink this payload (%d -> %d bytes); encoding it uncompressed": "--compress no achica este payload (%d -> %d bytes); se codifica sin comprimir",
+
+	"Service name shown by the authenticator app (--type totp)":                                                 "Nombre del servicio que muestra la app de autenticación (--type totp)",
+	"Account name, usually the user's email (--type totp)":                                                      "Nombre de la cuenta, normalmente el email del usuario (--type totp)",
+	`Base32 shared secret; "-" reads it from standard input so it stays out of the shell history (--type totp)`: `Secreto compartido en base32; "-" lo lee de la entrada estándar para que no quede en el historial de la shell (--type totp)`,
+	"Code length: 6 (default) or 8 (--type totp)":                                                               "Largo del código: 6 (por defecto) u 8 (--type totp)",
+	"Seconds each code is valid (default 30) (--type totp)":                                                     "Segundos que dura cada código (por defecto 30) (--type totp)",
+	"HMAC algorithm: sha1 (default, the only one every app supports), sha256 or sha512 (--type totp)":           "Algoritmo HMAC: sha1 (por defecto, el único que soportan todas las apps), sha256 o sha512 (--type totp)",
+	"--type totp needs --account":                                                                               "--type totp necesita --account",
+	"--issuer and --account cannot contain ':', which separates them in the label":                              "--issuer y --account no pueden contener ':', que los separa en la etiqueta",
+	"unsupported TOTP algorithm: %s (sha1, sha256 or sha512)":                                                   "algoritmo TOTP no soportado: %s (sha1, sha256 o sha512)",
+	"--digits must be 6 or 8":                                                                                   "--digits debe ser 6 u 8",
+	"--period must be a positive number of seconds":                                                             "--period debe ser una cantidad positiva de segundos",
+	"cannot read --secret from standard input: %w":                                                              "no se pudo leer --secret de la entrada estándar: %w",
+	"--type totp needs --secret":                                                                                "--type totp necesita --secret",
+	"--secret is not valid base32 (letters A-Z and digits 2-7)":                                                 "--secret no es base32 válido (letras A-Z y dígitos 2-7)",
+	"--secret is %d bits; authenticator secrets need at least %d":                                               "--secret tiene %d bits; los secretos de autenticación necesitan al menos %d",
+
+	"Payee name shown by the wallet (--type bitcoin)":          "Nombre del destinatario que muestra la billetera (--type bitcoin)",
+	"Payment description shown by the wallet (--type bitcoin)": "Descripción del pago que muestra la billetera (--type bitcoin)",
+	"--type bitcoin needs --address":                           "--type bitcoin necesita --address",
+	"invalid --amount %q; use a decimal number such as 12.50":  "--amount inválido %q; usá un número decimal como 12.50",
+	"--amount %q has more than %d decimals":                    "--amount %q tiene más de %d decimales",
+	"--amount must be greater than zero":                       "--amount debe ser mayor que cero",
+	"--amount %q is above the %d limit":                        "--amount %q supera el límite de %d",
+	"invalid Bitcoin address %q: %q is not a base58 character": "dirección Bitcoin inválida %q: %q no es un carácter base58",
+	"invalid Bitcoin address %q: not a P2PKH or P2SH address":  "dirección Bitcoin inválida %q: no es una dirección P2PKH ni P2SH",
+	"invalid Bitcoin address %q: checksum mismatch (typo?)":    "dirección Bitcoin inválida %q: el checksum no coincide (¿error de tipeo?)",
+	"invalid Bitcoin address %q: mixes upper and lower case":   "dirección Bitcoin inválida %q: mezcla mayúsculas y minúsculas",
+	"invalid Bitcoin address %q: wrong length":                 "dirección Bitcoin inválida %q: largo incorrecto",
+	"invalid Bitcoin address %q: %q is not a bech32 character": "dirección Bitcoin inválida %q: %q no es un carácter bech32",
+	"invalid Bitcoin address %q: malformed witness program":    "dirección Bitcoin inválida %q: programa witness mal formado",
+
+	"chunk size must be between 1 and %d bytes":       "el tamaño de los trozos debe estar entre 1 y %d bytes",
+	"the file is empty":                               "el archivo está vacío",
+	"not part of a file transfer":                     "no es parte de una transferencia de archivo",
+	"malformed transfer chunk":                        "trozo de transferencia mal formado",
+	"chunk %d: %w":                                    "trozo %d: %w",
+	"chunk %d: checksum mismatch":                     "trozo %d: el checksum no coincide",
+	"chunk %d/%d belongs to a different file":         "el trozo %d/%d es de otro archivo",
+	"no transfer chunks found":                        "no se encontraron trozos de una transferencia",
+	"missing %d of %d chunks: %s":                     "faltan %d de %d trozos: %s",
+	"the reassembled file does not match its SHA-256": "el archivo rearmado no coincide con su SHA-256",
+
+	"Receiving address: Bitcoin legacy (1..., 3...) or bech32 (bc1...) (--type bitcoin), or 0x... (--type ethereum)": "Dirección de destino: Bitcoin legacy (1..., 3...) o bech32 (bc1...) (--type bitcoin), o 0x... (--type ethereum)",
+	"EVM chain ID: 1 Ethereum mainnet, 137 Polygon, 8453 Base... (--type ethereum)":                                  "ID de la cadena EVM: 1 Ethereum mainnet, 137 Polygon, 8453 Base... (--type ethereum)",
+	"ERC-20 contract address; requests a token transfer instead of ether (--type ethereum)":                          "Dirección del contrato ERC-20; pide una transferencia del token en lugar de ether (--type ethereum)",
+	"Decimals of the --token, e.g. 6 for USDC; required with --token and --amount (--type ethereum)":                 "Decimales del --token, por ejemplo 6 para USDC; obligatorio con --token y --amount (--type ethereum)",
+	"--decimals is only used with --token":                                                                           "--decimals solo se usa con --token",
+	"--decimals must be between 0 and 36":                                                                            "--decimals debe estar entre 0 y 36",
+	"invalid --chain-id %q; use a positive integer such as 1":                                                        "--chain-id inválido %q; usá un entero positivo como 1",
+	"--token with --amount needs --decimals; tokens do not share a fixed number of decimals":                         "--token con --amount necesita --decimals; los tokens no tienen una cantidad fija de decimales",
+	"--type ethereum needs --%s":                                                                                     "--type ethereum necesita --%s",
+	"invalid --%s %q; use 0x and 40 hex digits":                                                                      "--%s inválido %q; usá 0x y 40 dígitos hexadecimales",
+	"invalid --%s %q: checksum mismatch (typo?)":                                                                     "--%s inválido %q: el checksum no coincide (¿error de tipeo?)",
+
+	"wrong passphrase or damaged backup":                      "frase de contraseña incorrecta o respaldo dañado",
+	"the passphrase needs at least %d characters":             "la frase de contraseña necesita al menos %d caracteres",
+	"paper backups hold up to %d KiB; this file has %d bytes": "los respaldos en papel admiten hasta %d KiB; este archivo tiene %d bytes",
+	"not a paperkey backup":                                   "no es un respaldo de paperkey",
+	"Paper key backup":                                        "Respaldo de clave en papel",
+	"File: %s - created %s":                                   "Archivo: %s - creado el %s",
+	"QR %d of %d":                                             "QR %d de %d",
+	"Page %d of %d":                                           "Página %d de %d",
+	"To restore, scan every QR of this backup flat and in good light (any order; repeats are fine) and run: qrgenerator_cli paperkey-restore -o FILE IMAGES... It asks for the passphrase chosen when the backup was made.":                                                                                                                                                                                                                            "Para restaurar, escaneá todos los QR de este respaldo planos y con buena luz (en cualquier orden; los repetidos no molestan) y ejecutá: qrgenerator_cli paperkey-restore -o ARCHIVO IMÁGENES... Pide la frase de contraseña elegida al hacer el respaldo.",
+	"Format, to restore without this tool: each QR holds QRF1:<n>/<total>:<SHA-256>:<CRC32>:<data in base45, RFC 9285>. Joining the data of QRs 1 to total gives QRPK1, a 16-byte salt, a 4-byte big-endian iteration count, a 12-byte nonce and the AES-256-GCM ciphertext, with \"QRPK1\" as additional data. The key is PBKDF2-HMAC-SHA256 of the passphrase and the salt. The plaintext is the file name length (2 bytes), the name and the file.": "Formato, para restaurar sin esta herramienta: cada QR contiene QRF1:<n>/<total>:<SHA-256>:<CRC32>:<datos en base45, RFC 9285>. Uniendo los datos de los QR 1 a total se obtiene QRPK1, una sal de 16 bytes, la cantidad de iteraciones en 4 bytes big endian, un nonce de 12 bytes y el texto cifrado con AES-256-GCM, con \"QRPK1\" como datos adicionales. La clave es PBKDF2-HMAC-SHA256 de la frase de contraseña y la sal. El texto plano es el largo del nombre del archivo (2 bytes), el nombre y el archivo.",
+
+	`Contact name; "Last, First" sets the family name explicitly (--type vcard or mecard), or payee name (--type epc, pix or upi)`:                                       `Nombre del contacto; "Apellido, Nombre" indica el apellido explícitamente (--type vcard o mecard), o nombre del beneficiario (--type epc, pix o upi)`,
+	"Amount to request: BTC up to 8 decimals (--type bitcoin), ether or --token units (--type ethereum), euros (--type epc), reais (--type pix), or rupees (--type upi)": "Importe a cobrar: BTC hasta 8 decimales (--type bitcoin), ether o unidades del --token (--type ethereum), euros (--type epc), reales (--type pix) o rupias (--type upi)",
+	"Payee UPI ID (virtual payment address) such as shop@okaxis (--type upi)":                                                                                            "ID UPI del beneficiario (dirección virtual de pago), como tienda@okaxis (--type upi)",
+	"Transaction note shown to the payer, up to 80 characters (--type upi)":                                                                                              "Nota de la transacción que ve quien paga, hasta 80 caracteres (--type upi)",
+	"--type upi needs --vpa":                                                                             "--type upi necesita --vpa",
+	"invalid --vpa %q; a UPI ID looks like name@bank":                                                    "--vpa inválido %q; un ID UPI tiene la forma nombre@banco",
+	"--type upi needs --name; payment apps show it to confirm the payee":                                 "--type upi necesita --name; las apps de pago lo muestran para confirmar el beneficiario",
+	"--%s has %d characters; UPI allows %d":                                                              "--%s tiene %d caracteres; UPI admite %d",
+	"Note shown to the payer: up to 70 characters (--type epc), or what fits next to --key (--type pix)": "Nota que ve quien paga: hasta 70 caracteres (--type epc), o lo que entre junto a --key (--type pix)",
+	"PIX key: CPF, CNPJ, +55 phone number, email or random key (--type pix)":                             "Clave PIX: CPF, CNPJ, teléfono +55, correo o clave aleatoria (--type pix)",
+	"Merchant city, up to 15 characters (--type pix)":                                                    "Ciudad del comercio, hasta 15 caracteres (--type pix)",
+	"Transaction ID, up to 25 letters and digits (--type pix)":                                           "Identificador de la transacción, hasta 25 letras y dígitos (--type pix)",
+	"--type pix needs --name and --city":                                                                 "--type pix necesita --name y --city",
+	"--info has %d characters; with this key only %d fit":                                                "--info tiene %d caracteres; con esta clave solo entran %d",
+	"invalid --txid %q; use up to %d letters and digits":                                                 "--txid inválido %q; usá hasta %d letras y dígitos",
+	"--type pix needs --key":                                                                             "--type pix necesita --key",
+	"invalid --key %q; a phone key is +55, the area code and the number":                                 "--key inválida %q; una clave de teléfono es +55, el código de área y el número",
+	"invalid --key %q; not an email address":                                                             "--key inválida %q; no es una dirección de correo",
+	"invalid --key %q; a random key is a UUID":                                                           "--key inválida %q; una clave aleatoria es un UUID",
+	"invalid --key %q: wrong CPF check digits (typo?)":                                                   "--key inválida %q: los dígitos verificadores del CPF no coinciden (¿error de tipeo?)",
+	"invalid --key %q: wrong CNPJ check digits (typo?)":                                                  "--key inválida %q: los dígitos verificadores del CNPJ no coinciden (¿error de tipeo?)",
+	"invalid --key %q; use a CPF, CNPJ, +55 phone number, email or random key":                           "--key inválida %q; usá un CPF, CNPJ, teléfono +55, correo o clave aleatoria",
+	"--%s must be a single line of plain text (--type pix)":                                              "--%s debe ser una sola línea de texto simple (--type pix)",
+	"--%s has %d characters; the PIX format allows %d":                                                   "--%s tiene %d caracteres; el formato PIX admite %d",
+	"Payee IBAN (--type epc)":                                                                            "IBAN del beneficiario (--type epc)",
+	"Payee bank BIC; optional inside the EEA (--type epc)":                                               "BIC del banco del beneficiario; opcional dentro del EEE (--type epc)",
+	"ISO 20022 purpose code, four letters such as GDDS (--type epc)":                                     "Código de propósito ISO 20022, cuatro letras como GDDS (--type epc)",
+	"Structured creditor reference, RF followed by check digits (--type epc)":                            "Referencia estructurada del acreedor, RF seguido de los dígitos de control (--type epc)",
+	"Unstructured remittance text, up to 140 characters; not combinable with --reference (--type epc)":   "Concepto libre, hasta 140 caracteres; no se combina con --reference (--type epc)",
+	"--type epc needs --name":                                                                            "--type epc necesita --name",
+	"--type epc needs --iban":                                                                            "--type epc necesita --iban",
+	"invalid --iban %q: wrong format or check digits (typo?)":                                            "--iban inválido %q: formato o dígitos de control incorrectos (¿error de tipeo?)",
+	"invalid --bic %q; use 8 or 11 characters such as DEUTDEFF500":                                       "--bic inválido %q; usá 8 u 11 caracteres como DEUTDEFF500",
+	"invalid --purpose %q; use a four-letter ISO 20022 code such as GDDS":                                "--purpose inválido %q; usá un código ISO 20022 de cuatro letras como GDDS",
+	"invalid --reference %q: not an RF creditor reference or wrong check digits":                         "--reference inválido %q: no es una referencia RF o los dígitos de control no coinciden",
+	"--reference and --remittance cannot be combined; the EPC format takes one or the other":             "--reference y --remittance no se pueden combinar; el formato EPC acepta uno u otro",
+	"the EPC payload is %d bytes; the standard allows %d, shorten --remittance or --info":                "el payload EPC tiene %d bytes; el estándar permite %d, acortá --remittance o --info",
+	"--%s must be a single line of text":                                                                 "--%s debe ser una sola línea de texto",
+	"--%s has %d characters; the EPC format allows %d":                                                   "--%s tiene %d caracteres; el formato EPC permite %d",
+
+	"shares need 2 <= threshold <= shares <= 255": "las partes necesitan 2 <= umbral <= partes <= 255",
+	"at least two shares are needed":              "hacen falta al menos dos partes",
+	"the shares are inconsistent":                 "las partes no son coherentes",
+	"a paperkey share is mixed with other data":   "una parte de paperkey está mezclada con otros datos",
+	"the shares belong to different backups":      "las partes son de respaldos distintos",
+	"restoring needs %d shares; found %d":         "restaurar necesita %d partes; se encontraron %d",
+	"Share %d of %d":                              "Parte %d de %d",
+	"This page holds one of %d shares; any %d of them, scanned together and with the passphrase, restore the key, and fewer reveal nothing. Shares are QRSS1, a 4-byte backup ID, the threshold, the share number and the Shamir values over GF(256) (AES polynomial), one per byte of the envelope below.": "Esta hoja tiene una de %d partes; cualesquiera %d de ellas, escaneadas juntas y con la frase de contraseña, restauran la clave, y menos no revelan nada. Las partes son QRSS1, un ID de respaldo de 4 bytes, el umbral, el número de parte y los valores de Shamir sobre GF(256) (polinomio de AES), uno por byte del sobre descrito abajo.",
+
+	"encrypting to %s needs %s; install it or choose another recipient": "cifrar para %s necesita %s; instalalo o elegí otro destinatario",
+	"cannot encrypt to %s: %w": "no se puede cifrar para %s: %w",
+	"the payload is encrypted with age; pass the identity file with -identity": "el payload está cifrado con age; pasá el archivo de identidad con -identity",
+	"the payload is encrypted; decrypting it needs %s":                         "el payload está cifrado; para descifrarlo hace falta %s",
+	"cannot decrypt the payload: %w":                                           "no se puede descifrar el payload: %w",
+
+	"Backup codes":            "Códigos de respaldo",
+	"%s backup codes":         "Códigos de respaldo de %s",
+	"Account: %s - issued %s": "Cuenta: %s - emitidos el %s",
+	"Issued %s":               "Emitidos el %s",
+	"Each code works once: cross it out after using it. Keep this sheet somewhere safe.": "Cada código sirve una sola vez: tachalo después de usarlo. Guardá esta hoja en un lugar seguro.",
+	"Master QR: all codes, encrypted": "QR maestro: todos los códigos, cifrados",
+
+	"%w: invalid --%s color %s; use #rrggbb, #rgb or a CSS color name such as navy":                                                        "%w: color de --%s inválido %s; usá #rrggbb, #rgb o un nombre de color de CSS como navy",
+	"%w: invalid --fg-gradient %s; use linear:FROM,TO[,ANGLEdeg] or radial:FROM,TO":                                                        "%w: --fg-gradient inválido %s; usá linear:DESDE,HASTA[,ÁNGULOdeg] o radial:DESDE,HASTA",
+	"%w: --fg and --fg-gradient cannot be combined; the gradient sets the module colors":                                                   "%w: --fg y --fg-gradient no se pueden combinar; el degradado define los colores de los módulos",
+	"%w: %s takes a single module color; use --fg, or PNG, JPEG, AVIF, HEIF or SVG for --fg-gradient":                                      "%w: %s admite un solo color de módulos; usá --fg, o PNG, JPEG, AVIF, HEIF o SVG para --fg-gradient",
+	"%w: unknown module style %s (%s)":                                                                                                     "%w: estilo de módulos desconocido %s (%s)",
+	"%w: %s draws square modules; use PNG, JPEG, AVIF, HEIF, TIFF or SVG for --%s":                                                         "%w: %s dibuja módulos cuadrados; usá PNG, JPEG, AVIF, HEIF, TIFF o SVG para --%s",
+	"%w: unknown eye style %s (%s)":                                                                                                        "%w: estilo de ojos desconocido %s (%s)",
+	"%w: %s has no transparency; use PNG, SVG, AVIF or HEIF with --bg transparent":                                                         "%w: %s no tiene transparencia; usá PNG, SVG, AVIF o HEIF con --bg transparent",
+	"%w: TIFF %s compression is black and white only; use --tiff-compression lzw with --fg and --bg":                                       "%w: la compresión %s de TIFF es solo en blanco y negro; usá --tiff-compression lzw con --fg y --bg",
+	"a TIFF palette needs two colors and LZW compression":                                                                                  "una paleta de TIFF necesita dos colores y compresión LZW",
+	"%w: inverted symbol (light modules on a dark background); many older scanner apps cannot read it, pass --allow-inverted to accept it": "%w: símbolo invertido (módulos claros sobre fondo oscuro); muchas apps de escaneo viejas no lo leen, pasá --allow-inverted para aceptarlo",
+	"%w: contrast %.1f:1 between modules and background is below %.0f:1; scanners cannot tell them apart":                                  "%w: el contraste %.1f:1 entre los módulos y el fondo es menor a %.0f:1; los lectores no pueden distinguirlos",
+	"contrast %.1f:1 between modules and background is below the recommended %.1f:1; the code may fail in poor light":                      "el contraste %.1f:1 entre los módulos y el fondo es menor al recomendado de %.1f:1; el código puede fallar con poca luz",
+
+	"unknown --locale %q; use a language tag such as es-AR, de or en-US": "--locale desconocido %q; usá una etiqueta de idioma como es-AR, de o en-US",
+	"invalid --amount %q for --locale %s; write it like %s":              "--amount inválido %q para --locale %s; escribilo como %s",
+	"invalid date %q for --locale %s; write it like %s":                  "fecha inválida %q para --locale %s; escribila como %s",
+	"invalid --birthday %q; use a date such as 1990-12-31":               "--birthday inválido %q; usá una fecha como 1990-12-31",
+
+	"Social network: telegram, instagram, x (or twitter), linkedin or youtube (--type social)":                        "Red social: telegram, instagram, x (o twitter), linkedin o youtube (--type social)",
+	"Profile handle, with or without @; linkedin also takes company/NAME, youtube a UC... channel ID (--type social)": "Nombre del perfil, con o sin @; linkedin acepta también company/NOMBRE y youtube un ID de canal UC... (--type social)",
+	"--type social needs --platform: %s":                      "--type social necesita --platform: %s",
+	"--type social needs --handle":                            "--type social necesita --handle",
+	"invalid YouTube channel ID %q":                           "ID de canal de YouTube inválido %q",
+	"invalid %s handle %q: %d to %d letters, digits or %s":    "nombre de %s inválido %q: de %d a %d letras, dígitos o %s",
+	"invalid telegram handle %q: it must start with a letter": "nombre de telegram inválido %q: tiene que empezar con una letra",
+
+	`IANA time zone of --start and --end, like "America/Argentina/Buenos_Aires"; the event keeps it and carries its rules (--type event)`: `Zona horaria IANA de --start y --end, como "America/Argentina/Buenos_Aires"; el evento la conserva y lleva sus reglas (--type event)`,
+	`unknown --tz %q; use an IANA time zone name such as "Europe/Madrid" or "America/New_York"`:                                           `--tz desconocida %q; usá el nombre IANA de una zona, como "Europe/Madrid" o "America/New_York"`,
+	"%s does not exist in %s: the clocks skip it when daylight saving time starts":                                                        "%s no existe en %s: los relojes la saltean al empezar el horario de verano",
+
+	`unsupported check digit scheme %q (luhn or crc)`:                `esquema de dígito de control no soportado %q (luhn o crc)`,
+	"no serial number to check":                                      "no hay número de serie para controlar",
+	"%w: --check-digit: the payload does not end in a serial number": "%w: --check-digit: el payload no termina en un número de serie",
+
+	"invalid --gtin %q; use a GTIN-8, 12 (UPC), 13 (EAN) or 14 with its check digit":     "--gtin inválido %q; usá un GTIN-8, 12 (UPC), 13 (EAN) o 14 con su dígito de control",
+	"--gtin %s has a wrong check digit; it should end in %c":                             "--gtin %s tiene mal el dígito de control; debería terminar en %c",
+	"--%s has %d characters; GS1 allows %d":                                              "--%s tiene %d caracteres; GS1 admite %d",
+	"--%s cannot contain %q; GS1 allows letters, digits and %s":                          "--%s no puede contener %q; GS1 admite letras, dígitos y %s",
+	`invalid --%s %q; use "2027-03-31", "2027-03" for the end of the month, or YYMMDD`:   `--%s inválido %q; usá "2027-03-31", "2027-03" para el fin de mes, o AAMMDD`,
+	"Product GTIN-8, 12 (UPC), 13 (EAN) or 14, with its check digit (--type gs1-link)":   "GTIN-8, 12 (UPC), 13 (EAN) o 14 del producto, con su dígito de control (--type gs1-link)",
+	"Batch or lot number, up to 20 characters (--type gs1-link)":                         "Número de lote, hasta 20 caracteres (--type gs1-link)",
+	"Serial number of the item, up to 20 characters (--type gs1-link)":                   "Número de serie de la unidad, hasta 20 caracteres (--type gs1-link)",
+	`Expiry date: "2027-03-31", or "2027-03" for the end of the month (--type gs1-link)`: `Fecha de vencimiento: "2027-03-31", o "2027-03" para el fin de mes (--type gs1-link)`,
+	"Resolver the link points to (default https://id.gs1.org) (--type gs1-link)":         "Resolvedor al que apunta el enlace (por defecto https://id.gs1.org) (--type gs1-link)",
+	"--type gs1-link needs --gtin":                                                       "--type gs1-link necesita --gtin",
+	"invalid --resolver %q; use an http or https URL such as %s":                         "--resolver inválido %q; usá una URL http o https como %s",
+
+	`GS1 element string such as "(01)09506000134352(17)260101(10)AB-123"; also the argument after the flags (--type gs1)`: `Cadena de elementos GS1 como "(01)09506000134352(17)260101(10)AB-123"; también el argumento después de los flags (--type gs1)`,
+	`--type gs1 needs an element string such as "(01)09506000134352(17)260101"`:                                           `--type gs1 necesita una cadena de elementos como "(01)09506000134352(17)260101"`,
+	`invalid GS1 element string %q; write each AI in parentheses, like "(01)09506000134352(10)AB-123"`:                    `cadena de elementos GS1 inválida %q; escribí cada AI entre paréntesis, como "(01)09506000134352(10)AB-123"`,
+	"unknown or unsupported GS1 AI (%s)":                           "AI de GS1 desconocido o no admitido (%s)",
+	"AI (%s) appears twice":                                        "el AI (%s) aparece dos veces",
+	"AI (%s) takes %d characters, not %d":                          "el AI (%s) lleva %d caracteres, no %d",
+	"AI (%s) takes %d to %d characters, not %d":                    "el AI (%s) lleva de %d a %d caracteres, no %d",
+	"AI (%s) takes only digits: %q":                                "el AI (%s) lleva solo dígitos: %q",
+	"AI (%s) cannot contain %q; GS1 allows letters, digits and %s": "el AI (%s) no puede contener %q; GS1 admite letras, dígitos y %s",
+	"AI (%s) %s has a wrong check digit; it should end in %c":      "el AI (%s) %s tiene un dígito de control incorrecto; debería terminar en %c",
+	"AI (%s) %s is not a valid YYMMDD date":                        "el AI (%s) %s no es una fecha AAMMDD válida",
+	"%w: --type gs1 cannot be combined with --%s":                  "%w: --type gs1 no se puede combinar con --%s",
+	"data too long for a QR code":                                  "datos demasiado largos para un código QR",
+
+	"unknown channel %q; use %s or %s": "canal desconocido %q; usá %s o %s",
+	"%w: %s has no releases":           "%w: %s no tiene releases",
+
+	"invalid bundle":                                        "paquete inválido",
+	"%s appears twice in the bundle":                        "%s aparece dos veces en el paquete",
+	"%w: %s appears twice in the bundle":                    "%w: %s aparece dos veces en el paquete",
+	"%w: %s is not a regular file":                          "%w: %s no es un archivo común",
+	"%w: unsafe path %q":                                    "%w: ruta insegura %q",
+	"%w: %s must come first":                                "%w: %s tiene que ir primero",
+	"%w: %s is missing":                                     "%w: falta %s",
+	"%w: the bundle has %d files but its manifest lists %d": "%w: el paquete tiene %d archivos pero su manifiesto lista %d",
+	"%w: %s is listed in the manifest but missing":          "%w: %s figura en el manifiesto pero falta",
+
+	"WireGuard client config file such as wg0.conf; also --config wg0.conf or the argument after the flags (--type wireguard)": "Archivo de configuración de un cliente de WireGuard como wg0.conf; también --config wg0.conf o el argumento después de los flags (--type wireguard)",
+	"--type wireguard needs a config file: --config wg0.conf":                                                                  "--type wireguard necesita un archivo de configuración: --config wg0.conf",
+	"cannot read the WireGuard config: %w":                                                                                     "no se puede leer la configuración de WireGuard: %w",
+	"%s:%d: unknown section [%s]; a WireGuard config has [Interface] and [Peer]":                                               "%s:%d: sección desconocida [%s]; una configuración de WireGuard tiene [Interface] y [Peer]",
+	"%s:%d: expected Key = Value inside [Interface] or [Peer]":                                                                 "%s:%d: se esperaba Clave = Valor dentro de [Interface] o [Peer]",
+	"%s:%d: %s is not supported by the WireGuard mobile apps":                                                                  "%s:%d: las apps móviles de WireGuard no admiten %s",
+	"%s:%d: %s appears twice in the section":                                                                                   "%s:%d: %s aparece dos veces en la sección",
+	"%s:%d: invalid %s %q":                                                                                                     "%s:%d: %s inválido %q",
+	"%s: the config must start with [Interface]":                                                                               "%s: la configuración tiene que empezar con [Interface]",
+	"%s:%d: only one [Interface] is allowed":                                                                                   "%s:%d: solo se admite un [Interface]",
+	"%s:%d: [%s] needs %s":                                                                                                     "%s:%d: [%s] necesita %s",
+	"%s:%d: the peer of line %d has the same PublicKey":                                                                        "%s:%d: el peer de la línea %d tiene la misma PublicKey",
+	"%s: the config has no [Peer] to connect to":                                                                               "%s: la configuración no tiene un [Peer] al que conectarse",
+
+	"SSH public key (.pub) or known_hosts file; also the argument after the flags (--type ssh-key, ssh-fingerprint)":              "Clave pública SSH (.pub) o archivo known_hosts; también el argumento después de los flags (--type ssh-key, ssh-fingerprint)",
+	"Host whose known_hosts entry to use, also for hashed entries; [host]:port for other ports (--type ssh-key, ssh-fingerprint)": "Host cuya entrada de known_hosts usar, también en entradas cifradas; [host]:puerto para otros puertos (--type ssh-key, ssh-fingerprint)",
+	"--type ssh-key and ssh-fingerprint need a key file: --public-key ~/.ssh/id_ed25519.pub":                                      "--type ssh-key y ssh-fingerprint necesitan un archivo de clave: --public-key ~/.ssh/id_ed25519.pub",
+	"%s has no known_hosts entry for %s":                        "%s no tiene una entrada de known_hosts para %s",
+	"%s has no SSH keys":                                        "%s no tiene claves SSH",
+	"%s has %d keys (lines %d and %d...); pick one with --host": "%s tiene %d claves (líneas %d y %d...); elegí una con --host",
+	"%s:%d: the key is marked @revoked":                         "%s:%d: la clave está marcada @revoked",
+	"cannot read the SSH key: %w":                               "no se puede leer la clave SSH: %w",
+	"%s:%d: unknown marker %s":                                  "%s:%d: marca desconocida %s",
+	"%s:%d: not an SSH public key; supported types: %s":         "%s:%d: no es una clave pública SSH; tipos admitidos: %s",
+	"%s:%d: the key is not valid base64":                        "%s:%d: la clave no es base64 válido",
+	"the %s key is truncated":                                   "la clave %s está truncada",
+	"the key data does not match its type %s":                   "los datos de la clave no coinciden con su tipo %s",
+	"the RSA key has %d bits; OpenSSH needs at least 1024":      "la clave RSA tiene %d bits; OpenSSH necesita al menos 1024",
+
+	"%s is damaged: %w": "%s está dañado: %w",
+
+	"%w: unknown EC level %s (%s)":         "%w: nivel de corrección desconocido %s (%s)",
+	"%w: no variants to compare":           "%w: no hay variantes para comparar",
+	"%w: error loading the label font: %w": "%w: error al cargar la fuente de las etiquetas: %w",
+	"v%d, %dx%d modules, mask %d":          "v%d, %dx%d módulos, máscara %d",
+	"%w: the comparison sheet would be %dx%dpx (max %dpx per side); compare fewer or smaller variants": "%w: la hoja de comparación mediría %dx%dpx (máximo %dpx por lado); compará menos variantes o más chicas",
+	"%w: the comparison sheet is written as PNG or JPEG, not %s":                                       "%w: la hoja de comparación se escribe como PNG o JPEG, no %s",
+
+	"--utm-* only apply to http and https URLs, not %q":    "--utm-* solo se aplican a URL http y https, no a %q",
+	"the query of %s is malformed: %w":                     "la query de %s está mal formada: %w",
+	"%s already has %s; drop it from the URL or drop --%s": "%s ya tiene %s; quitalo de la URL o quitá --%s",
+
+	"%w: thumbnail size %s out of range (%d-%dpx)":          "%w: tamaño de miniatura %s fuera de rango (%d-%dpx)",
+	"%w: error creating thumbnail: %w":                      "%w: error al crear la miniatura: %w",
+	"%w: error encoding thumbnail: %w":                      "%w: error al codificar la miniatura: %w",
+	"%w: the thumbnail of %s would overwrite the output %s": "%w: la miniatura de %s pisaría la salida %s",
+
+	"the URL %s has no host":             "la URL %s no tiene host",
+	"the URL %s has an invalid host: %w": "la URL %s tiene un host inválido: %w",
+	"the URL %s is malformed: %w":        "la URL %s está mal formada: %w",
+
+	"%w: a QR symbol has 21 to 177 modules per side in steps of 4, not %d": "%w: un símbolo QR tiene de 21 a 177 módulos por lado, de a 4, no %d",
+	"%w: the module matrix is not square":                                  "%w: la matriz de módulos no es cuadrada",
+	"%w: %s is not a module matrix: %w":                                    "%w: %s no es una matriz de módulos: %w",
+	"%w: %s has no modules":                                                "%w: %s no tiene módulos",
+	"%w: %s is not a text PBM (P1)":                                        "%w: %s no es un PBM en texto (P1)",
+	"%w: %s is not a square PBM":                                           "%w: %s no es un PBM cuadrado",
+	"%w: %s has %d pixels, not %dx%d":                                      "%w: %s tiene %d píxeles, no %dx%d",
+	"%w: %s is not a module matrix of 0 and 1":                             "%w: %s no es una matriz de módulos de 0 y 1",
+
+	"%s cannot carry a manifest; only PNG and SVG embed one": "%s no puede llevar un manifiesto; solo PNG y SVG lo embeben",
+	"%w: the manifest has no valid module matrix":            "%w: el manifiesto no tiene una matriz de módulos válida",
+	"%w: the embedded manifest is damaged: %w":               "%w: el manifiesto embebido está dañado: %w",
+
+	"%w: --input-file cannot be combined with --check-digit": "%w: --input-file no se puede combinar con --check-digit",
+
+	"%w: unknown duplicate placement %s (%s)":                                                            "%w: ubicación de la copia desconocida %s (%s)",
+	"%w: the two copies of the %dpx QR overlap in the %dx%d overlay; lower -size":                        "%w: las dos copias del QR de %dpx se superponen en el overlay de %dx%d; bajá -size",
+	"%w: the caption would cover the copy of the QR; shorten it, lower -size or use another --duplicate": "%w: el texto taparía la copia del QR; acortalo, bajá -size o usá otro --duplicate",
+
+	"%w: %q is not a length; use a positive number with mm, cm, m, in or ft":                               "%w: %q no es una longitud; usá un número positivo con mm, cm, m, in o ft",
+	"at %d DPI the %dpx code prints %s wide, but scanning from %s needs at least %s; use -size %d or more": "a %d DPI el código de %dpx se imprime de %s de ancho, pero para escanearlo desde %s necesita al menos %s; usá -size %d o más",
+
+	"invalid --template: %w": "--template inválido: %w",
+	"%s has no records":      "%s no tiene registros",
+	"record %d: %w":          "registro %d: %w",
+	"record %d: the template renders an empty payload":         "registro %d: la plantilla da un payload vacío",
+	"cannot read records: %w":                                  "no se pueden leer los registros: %w",
+	"%s: expected a JSON array of objects: %w":                 "%s: se esperaba un arreglo JSON de objetos: %w",
+	"%s:%d: expected a JSON object: %w":                        "%s:%d: se esperaba un objeto JSON: %w",
+	"%s: records must be .csv, .tsv, .json, .jsonl or .ndjson": "%s: los registros tienen que ser .csv, .tsv, .json, .jsonl o .ndjson",
+
+	"%w: error creating PDF file: %w": "%w: error creando archivo PDF: %w",
+
+	"Number of codes to generate, each with its own identifier, numbered like --batch; a CSV next to the output maps each identifier to its file (--type uuid, token)": "Cantidad de códigos a generar, cada uno con su identificador, numerados como con --batch; un CSV al lado de la salida relaciona cada identificador con su archivo (--type uuid, token)",
+	"Token length in characters, 8-64 (default 16, 80 random bits) (--type token)":                                                                                     "Largo del token en caracteres, 8-64 (16 por defecto, 80 bits aleatorios) (--type token)",
+	"cannot generate a random identifier: %w":                 "no se puede generar un identificador aleatorio: %w",
+	"--length must be a number from %d to %d":                 "--length tiene que ser un número de %d a %d",
+	"--count must be a number from 1 to %d":                   "--count tiene que ser un número de 1 a %d",
+	"cannot generate %d distinct identifiers; raise --length": "no se pueden generar %d identificadores distintos; subí --length",
+
+	"%d digits":                  "%d dígitos",
+	"%d alphanumeric characters": "%d caracteres alfanuméricos",
+	"%d bytes":                   "%d bytes",
+	"%v: the payload is %s and a QR code at EC level %s holds at most %d": "%v: el payload ocupa %s y un código QR con nivel de corrección %s admite como mucho %d",
+	"try %s":                            "probá %s",
+	"try %s or %s":                      "probá %s o %s",
+	"%s %s (holds %d)":                  "%s %s (admite %d)",
+	"--compress base45 (%d characters)": "--compress base45 (%d caracteres)",
+	"--split %d (one QR per %d bytes)":  "--split %d (un QR cada %d bytes)",
+	"a shorter URL (a redirect from your own domain)": "una URL más corta (una redirección desde tu propio dominio)",
+	"a shorter payload": "un payload más corto",
+
+	"%w: --fit-min-ec and --fit-max-version need --fit auto":                      "%w: --fit-min-ec y --fit-max-version necesitan --fit auto",
+	"%w: unknown fit strategy %s (%s)":                                            "%w: estrategia de ajuste desconocida %s (%s)",
+	"%w: --fit-min-ec %s is above the EC level %s":                                "%w: --fit-min-ec %s es mayor que el nivel de corrección %s",
+	"%w: --fit-max-version must be a number from 1 to 40":                         "%w: --fit-max-version tiene que ser un número de 1 a 40",
+	"%w: at EC level %s the payload needs version %d, above --fit-max-version %d": "%w: con nivel de corrección %s el payload necesita la versión %d, mayor que --fit-max-version %d",
+
+	"ASCII-armored OpenPGP public key, as written by gpg --export --armor; also the argument after the flags (--type pgp)":            "Clave pública OpenPGP en ASCII armor, como la escribe gpg --export --armor; también el argumento después de los flags (--type pgp)",
+	"Drop the armor headers (Version, Comment...) and the checksum line to save space; gpg imports the key without them (--type pgp)": "Quitar los encabezados del armor (Version, Comment...) y la línea de suma para ahorrar espacio; gpg importa la clave sin ellos (--type pgp)",
+	"Expected fingerprint of the primary key, in hex with or without spaces; fails if the file holds another key (--type pgp)":        "Huella esperada de la clave primaria, en hexadecimal con o sin espacios; falla si el archivo tiene otra clave (--type pgp)",
+	"--type pgp needs a key file: --key-file pub.asc (gpg --export --armor KEYID > pub.asc)":                                          "--type pgp necesita un archivo de clave: --key-file pub.asc (gpg --export --armor KEYID > pub.asc)",
+	"cannot read the PGP key: %w":                               "no se puede leer la clave PGP: %w",
+	"%s: the key data is not valid base64":                      "%s: los datos de la clave no son base64 válido",
+	"%s: the armor checksum does not match; the key is damaged": "%s: la suma del armor no coincide; la clave está dañada",
+	"%s: %w":                                "%s: %w",
+	"%s: the key fingerprint is %s, not %s": "%s: la huella de la clave es %s, no %s",
+	"%s is a private key; export the public one with gpg --export --armor":                 "%s es una clave privada; exportá la pública con gpg --export --armor",
+	"%s is not an ASCII-armored PGP public key; export it with gpg --export --armor KEYID": "%s no es una clave pública PGP en ASCII armor; exportala con gpg --export --armor KEYID",
+	"%s: the PGP key block is empty":                                                       "%s: el bloque de la clave PGP está vacío",
+	"%s: the PGP key block is truncated; %s is missing":                                    "%s: el bloque de la clave PGP está cortado; falta %s",
+	"the key block does not start with a public key packet":                                "el bloque de la clave no empieza con un paquete de clave pública",
+	"unsupported OpenPGP key version %d":                                                   "versión de clave OpenPGP no soportada: %d",
+	"the key data is truncated":                                                            "los datos de la clave están cortados",
+	"the key data is not an OpenPGP packet sequence":                                       "los datos de la clave no son una secuencia de paquetes OpenPGP",
+
+	"signature verification failed":                                                             "falló la verificación de la firma",
+	"unsupported ECDSA curve %s; use P-256, P-384 or P-521":                                     "curva ECDSA no soportada %s; usá P-256, P-384 o P-521",
+	"the RSA key has %d bits; JWS needs at least 2048":                                          "la clave RSA tiene %d bits; JWS necesita al menos 2048",
+	"unsupported key type %T; use an ECDSA, Ed25519 or RSA key":                                 "tipo de clave no soportado %T; usá una clave ECDSA, Ed25519 o RSA",
+	"cannot read the key: %w":                                                                   "no se puede leer la clave: %w",
+	"%s is not a PEM file":                                                                      "%s no es un archivo PEM",
+	"%s is encrypted; export it without a passphrase (openssl pkey -in key.pem -out plain.pem)": "%s está cifrada; exportala sin frase de contraseña (openssl pkey -in key.pem -out plain.pem)",
+	"%s holds a %s, not a private key":                                                          "%s tiene un %s, no una clave privada",
+	"%w: the payload is not signed":                                                             "%w: el payload no está firmado",
+	"%w: signed with %s, but the key uses %s":                                                   "%w: firmado con %s, pero la clave usa %s",
+	"%w: the JWS is not valid base64url":                                                        "%w: el JWS no es base64url válido",
+	"%w: --sign: %w":                                                                            "%w: --sign: %w",
+	"%w: --input-file cannot be combined with --sign":                                           "%w: --input-file no se puede combinar con --sign",
+
+	"wrong passphrase or damaged payload":                        "frase de contraseña equivocada o payload dañado",
+	"the encrypted payload is damaged: %w":                       "el payload cifrado está dañado: %w",
+	"%w: --encrypt: %w":                                          "%w: --encrypt: %w",
+	"%w: --encrypt: the passphrase needs at least %d characters": "%w: --encrypt: la frase de contraseña necesita al menos %d caracteres",
+	"%w: --type gs1 cannot be combined with --encrypt":           "%w: --type gs1 no se puede combinar con --encrypt",
+
+	"%v: the payload is %s and a version %d QR code at EC level %s holds at most %d": "%v: el payload ocupa %s y un código QR versión %d con nivel de corrección %s admite como mucho %d",
+	"--qr-version %d (holds %d)":                                 "--qr-version %d (admite %d)",
+	"%w: --fit-max-version cannot be combined with --qr-version": "%w: --fit-max-version no se puede combinar con --qr-version",
+	"%w: --qr-version must be a number from 1 to 40":             "%w: --qr-version tiene que ser un número de 1 a 40",
+
+	"the PDF contains no module image":  "el PDF no contiene una imagen de módulos",
+	"the PDF module image is truncated": "la imagen de módulos del PDF está cortada",
+
+	"%w: unknown mask %s (0-7 or auto)": "%w: máscara desconocida %s (0-7 o auto)",
+
+	"%w: unknown mode %s (%s)":                                                                    "%w: modo desconocido %s (%s)",
+	"%w: --mode kanji: %q has no Shift-JIS kanji code":                                            "%w: --mode kanji: %q no tiene código kanji de Shift-JIS",
+	"%w: --mode %s cannot be combined with --%s":                                                  "%w: --mode %s no se puede combinar con --%s",
+	"%w: --mode numeric: %q is not a digit":                                                       "%w: --mode numeric: %q no es un dígito",
+	"%w: --mode alphanumeric: %q is not allowed; the mode only has 0-9, A-Z, space and $%%*+-./:": "%w: --mode alphanumeric: %q no está permitido; el modo solo tiene 0-9, A-Z, espacio y $%%*+-./:",
+	"%d kanji characters":                                                                         "%d caracteres kanji",
+	"a kanji segment needs pairs of Shift-JIS bytes":                                              "un segmento kanji necesita pares de bytes Shift-JIS",
+	"0x%04X is not allowed in a kanji segment":                                                    "0x%04X no está permitido en un segmento kanji",
+
+	"%w: unknown charset %s (%s)":                        "%w: juego de caracteres desconocido %s (%s)",
+	"%w: --charset %s cannot encode %q":                  "%w: --charset %s no puede escribir %q",
+	"%w: --charset %s: %w":                               "%w: --charset %s: %w",
+	"%w: --input-file cannot be combined with --charset": "%w: --input-file no se puede combinar con --charset",
+	"invalid ECI designator %d":                          "designador ECI inválido %d",
+	"%s: ECI %d":                                         "%s: ECI %d",
+
+	"invalid structured append position %d of %d":                            "posición de structured append inválida: %d de %d",
+	"no structured append symbols":                                           "no hay símbolos de structured append",
+	"the symbols belong to different structured append sequences":            "los símbolos son de secuencias de structured append distintas",
+	"%d of %d structured append symbols missing":                             "faltan %d de %d símbolos de structured append",
+	"structured append parity mismatch: the symbols do not form one message": "la paridad de structured append no coincide: los símbolos no forman un mensaje",
+	"%w: structured append needs 2 to %d QR codes":                           "%w: structured append necesita de 2 a %d QR",
+	"QR %d of %d: %w": "QR %d de %d: %w",
+
+	"rMQR only has error correction levels M and H": "el rMQR solo tiene los niveles de corrección M y H",
+	"unknown rMQR shape %s":                         "forma de rMQR desconocida %s",
+	"invalid rMQR size: %dx%d":                      "tamaño de rMQR inválido: %dx%d",
+	"version %s does not match size %dx%d":          "la versión %s no coincide con el tamaño %dx%d",
+	"no rMQR code found in the image":               "no se encontró un código rMQR en la imagen",
+	"%w: unknown symbol %s (%s)":                    "%w: simbología desconocida %s (%s)",
+	"%w: unknown rMQR shape %s (R7x43 to R17x139, a height such as R13, or auto)": "%w: forma de rMQR desconocida %s (R7x43 a R17x139, un alto como R13, o auto)",
+	"%w: rMQR only has EC levels M and H":                                         "%w: el rMQR solo tiene los niveles de corrección M y H",
+	"%w: --symbol %s cannot be combined with --%s":                                "%w: --symbol %s no se puede combinar con --%s",
+	"%w: --shape needs --symbol rmqr or datamatrix":                               "%w: --shape necesita --symbol rmqr o datamatrix",
+	"%w: error generating rMQR: %w":                                               "%w: error generando rMQR: %w",
+	"%v: the payload is %s and an rMQR %s at EC level %s holds at most %d":        "%v: el payload ocupa %s y un rMQR %s con nivel de corrección %s admite como mucho %d",
+	"--shape %s (holds %d)":                                                       "--shape %s (admite %d)",
+	"--symbol qr (holds %d)":                                                      "--symbol qr (admite %d)",
+
+	"%w: invalid quiet zone %s (0 to %d modules)":                                                                    "%w: zona de silencio inválida %s (0 a %d módulos)",
+	"a quiet zone of %d modules is narrower than the %d the standard asks for; many scanners will not read the code": "una zona de silencio de %d módulos es más angosta que los %d que pide el estándar; muchos lectores no van a leer el código",
+
+	"%w: invalid scale %s (1 to %d pixels per module)":                                                        "%w: escala inválida %s (1 a %d píxeles por módulo)",
+	"%w: the code would be %dpx wide, over the %dpx limit (-max-size)":                                        "%w: el código mediría %dpx de ancho, más que el límite de %dpx (-max-size)",
+	"%w: the code would be %dpx wide, less than one pixel for each of its %d modules; raise --width or --dpi": "%w: el código mediría %dpx de ancho, menos de un píxel para cada uno de sus %d módulos; subí --width o --dpi",
+	"%w: invalid DPI %s (1 to %d)":                                                                            "%w: DPI inválido %s (1 a %d)",
+	"%w: --width %s is not a length; use a positive number with mm, cm, m, in or ft":                          "%w: --width %s no es una longitud; usá un número positivo con mm, cm, m, in o ft",
+	"%s cannot record the print resolution; print it at %d DPI":                                               "%s no puede guardar la resolución de impresión; imprimilo a %d DPI",
+
+	"data too long for a Data Matrix symbol": "los datos no entran en un símbolo Data Matrix",
+	"unknown Data Matrix size %s":            "tamaño de Data Matrix desconocido %s",
+	"no Data Matrix code found in the image": "no se encontró un código Data Matrix en la imagen",
+	"invalid Data Matrix size: %dx%d":        "tamaño de Data Matrix inválido: %dx%d",
+	"truncated upper shift":                  "cambio a la mitad superior truncado",
+	"unsupported Data Matrix encodation %d":  "codificación de Data Matrix no soportada %d",
+	"truncated ECI designator":               "designador ECI truncado",
+	"truncated Base256 length":               "largo de Base256 truncado",
+	"truncated Base256 segment":              "segmento Base256 truncado",
+	"%w: unknown Data Matrix shape %s (square, rectangle or a size such as 16x48)":            "%w: forma de Data Matrix desconocida %s (square, rectangle o un tamaño como 16x48)",
+	"%w: error generating Data Matrix: %w":                                                    "%w: error generando Data Matrix: %w",
+	"--shape %s (holds %d codewords)":                                                         "--shape %s (admite %d codewords)",
+	"%v: the payload is %s, %d codewords, and a Data Matrix %s holds at most %d":              "%v: el payload ocupa %s, %d codewords, y un Data Matrix %s admite como mucho %d",
+	"data too long for an Aztec code":                                                         "los datos no entran en un código Aztec",
+	"no Aztec code found in the image":                                                        "no se encontró un código Aztec en la imagen",
+	"invalid codeword":                                                                        "palabra de código inválida",
+	"invalid FLG(7)":                                                                          "FLG(7) inválido",
+	"%w: error generating Aztec code: %w":                                                     "%w: error generando el código Aztec: %w",
+	"%v: the payload is %s, %d bits, and an Aztec %s holds at most %d":                        "%v: el payload ocupa %s, %d bits, y un Aztec %s admite como mucho %d",
+	"data too long for a PDF417 symbol":                                                       "los datos no entran en un símbolo PDF417",
+	"no PDF417 code found in the image":                                                       "no se encontró un código PDF417 en la imagen",
+	"invalid PDF417 width: %d modules":                                                        "ancho de PDF417 inválido: %d módulos",
+	"PDF417 row indicators do not match the symbol size":                                      "los indicadores de fila del PDF417 no coinciden con el tamaño del símbolo",
+	"invalid PDF417 error correction level":                                                   "nivel de corrección del PDF417 inválido",
+	"invalid PDF417 length descriptor":                                                        "descriptor de largo del PDF417 inválido",
+	"truncated byte shift":                                                                    "cambio a byte truncado",
+	"unsupported PDF417 codeword %d":                                                          "codeword de PDF417 no soportada %d",
+	"invalid numeric group":                                                                   "grupo numérico inválido",
+	"%w: invalid PDF417 columns %s (1 to %d)":                                                 "%w: columnas de PDF417 inválidas %s (de 1 a %d)",
+	"%w: invalid PDF417 rows %s (%d to %d)":                                                   "%w: filas de PDF417 inválidas %s (de %d a %d)",
+	"%w: a PDF417 of %d columns and %d rows exceeds the %d codewords of the standard":         "%w: un PDF417 de %d columnas y %d filas supera las %d codewords del estándar",
+	"%w: invalid EC ratio %s (1%% to %d%% of the data codewords)":                             "%w: proporción de corrección inválida %s (del 1%% al %d%% de las codewords de datos)",
+	"%w: --%s needs --symbol pdf417":                                                          "%w: --%s necesita --symbol pdf417",
+	"%w: error generating PDF417: %w":                                                         "%w: error generando el PDF417: %w",
+	"%v: the payload is %s, %d codewords, and a PDF417 at security level %d holds at most %d": "%v: el payload ocupa %s, %d codewords, y un PDF417 con nivel de seguridad %d admite como mucho %d",
+	"dropping --columns and --rows (holds %d codewords)":                                      "sacar --columns y --rows (admite %d codewords)",
+	"Code 128 only encodes ASCII characters":                                                  "Code 128 solo codifica caracteres ASCII",
+	"no Code 128 barcode found in the image":                                                  "no se encontró un código Code 128 en la imagen",
+	"Code 128 check digit does not match":                                                     "el dígito de control del Code 128 no coincide",
+	"unsupported Code 128 function character %d":                                              "carácter de función de Code 128 no soportado %d",
+	"%w: %w; use a 2D symbol such as --symbol qr for other text":                              "%w: %w; usá un símbolo 2D como --symbol qr para otros textos",
+	"Code 39 only encodes digits, uppercase letters, space and - . $ / + %":                   "Code 39 solo codifica dígitos, mayúsculas, el espacio y - . $ / + %",
+	"no Code 39 barcode found in the image":                                                   "no se encontró un código Code 39 en la imagen",
+	"%w: %w; use --symbol code128 for lowercase and other text":                               "%w: %w; usá --symbol code128 para minúsculas y otros textos",
+	"%w: error generating Code 39: %w":                                                        "%w: error generando el Code 39: %w",
+	"%w: --mod43 needs --symbol code39":                                                       "%w: --mod43 necesita --symbol code39",
+	"%w: error generating Code 128: %w":                                                       "%w: error generando el Code 128: %w",
+	"%w: --show-text needs a barcode symbol (%s)":                                             "%w: --show-text necesita un código de barras (%s)",
+	"invalid EAN-13 %q; use 12 digits, or 13 with the check digit":                            "EAN-13 inválido %q; usá 12 dígitos, o 13 con el dígito de control",
+	"invalid UPC-A %q; use 11 digits, or 12 with the check digit":                             "UPC-A inválido %q; usá 11 dígitos, o 12 con el dígito de control",
+	"ISBN %s has a wrong check digit; it should end in %c":                                    "el ISBN %s tiene mal el dígito de control; debería terminar en %c",
+	"invalid ISBN %q; an ISBN-13 starts with 978 or 979":                                      "ISBN inválido %q; un ISBN-13 empieza con 978 o 979",
+	"invalid ISBN %q; use an ISBN-10 or an ISBN-13":                                           "ISBN inválido %q; usá un ISBN-10 o un ISBN-13",
+	"%s has a wrong check digit; it should end in %c":                                         "%s tiene mal el dígito de control; debería terminar en %c",
+	"no EAN-13 or UPC-A barcode found in the image":                                           "no se encontró un código EAN-13 o UPC-A en la imagen",
+	"EAN-13 check digit does not match":                                                       "el dígito de control del EAN-13 no coincide",
+	"%w: error generating %s: %w":                                                             "%w: error generando el %s: %w",
+	// CLI
+	"Url to go with QR":               "URL a codificar en el QR",
+	"QR size":                         "Tamaño del QR",
+	"Largest accepted size in pixels": "Tamaño máximo aceptado en píxeles",
+	"Output path and file with extension. Formats: jpg, png, svg, css, avif, heic, tiff, pdf, txt/json/pbm/h/rs (module matrix). Repeat it to write several files from one encode": "Ruta del archivo de salida con extensión. Formatos: jpg, png, svg, css, avif, heic, tiff, pdf, txt/json/pbm/h/rs (matriz de módulos). Repetilo para escribir varios archivos con una sola codificación",
+	"Module matrix encoding: json, pbm, bits, c or rust (default from the extension: .json, .pbm, .txt, .h/.c, .rs)":                                                               "Codificación de la matriz de módulos: json, pbm, bits, c o rust (por defecto según la extensión: .json, .pbm, .txt, .h/.c, .rs)",
+	"Comma-separated formats to write from one encode, named after -o (-o qr.png -formats png,svg,pdf)":                                                                            "Formatos separados por comas a escribir con una sola codificación, nombrados a partir de -o (-o qr.png -formats png,svg,pdf)",
+	"%w: --batch writes a single output; drop --formats or the extra -o":                                                                                                           "%w: --batch escribe una sola salida; quitá --formats o los -o de más",
+	"%w: --format and --formats cannot be combined":                                                                                                                                "%w: --format y --formats no se pueden combinar",
+	"%w: --formats takes a single -o path":                                                                                                                                         "%w: --formats admite una sola ruta en -o",
+	"JPEG quality 1-100 (default 90); AVIF/HEIF quality 1-100 (default 100, lossless)":                                                                                             "Calidad JPEG 1-100 (por defecto 90); calidad AVIF/HEIF 1-100 (por defecto 100, sin pérdida)",
+	"JPEG chroma subsampling: 444 (default, sharpest), 422 or 420":                                                                                                                 "Submuestreo de croma JPEG: 444 (por defecto, el más nítido), 422 o 420",
+	"Output format; overrides the extension of -o":                                                                                                                                 "Formato de salida; tiene prioridad sobre la extensión de -o",
+	"Validate everything before generating, report all problems at once and treat warnings as errors":                                                                              "Validar todo antes de generar, informar todos los problemas juntos y tratar las advertencias como errores",
+	"strict validation failed: %d problem(s)":                                                                                                                                      "la validación estricta falló: %d problema(s)",
+	"Only report whether an update is available":                                                                                                                                   "Solo informar si hay una actualización disponible",
+	"Reinstall even if the latest release is not newer, or if a package manager installed the binary":                                                                              "Reinstalar aunque la última release no sea más nueva, o aunque el binario lo haya instalado un gestor de paquetes",
+	"GitHub repository that publishes the releases":                                                                                                                                "Repositorio de GitHub que publica las releases",
+	"GitHub API base URL": "URL base de la API de GitHub",
+	"Install a release without verifying its signature when this build has no signing key": "Instalar una release sin verificar su firma cuando este binario no tiene clave de firma",
+	"HTTP timeout for the release query and downloads":                                     "Timeout HTTP para la consulta de releases y las descargas",
+	"already up to date (%s)":    "ya está actualizado (%s)",
+	"update available: %s -> %s": "actualización disponible: %s -> %s",
+	"this build has no release signing key; only the checksum is verified":                       "este binario no tiene clave de firma de releases; solo se verifica el checksum",
+	"this build has no release signing key; rebuild it with one or use -insecure-skip-signature": "este binario no tiene clave de firma de releases; compilalo con una o usá -insecure-skip-signature",
+	"downloading %s from %s": "descargando %s de %s",
+	"permission denied replacing %s; run again with sufficient privileges": "permiso denegado al reemplazar %s; ejecutá de nuevo con permisos suficientes",
+	"updated %s: %s -> %s":                                 "%s actualizado: %s -> %s",
+	"TIFF compression: g4 (default, CCITT Group 4) or lzw": "Compresión TIFF: g4 (por defecto, CCITT Grupo 4) o lzw",
+	"File with one payload per line; TIFF output gets one page per payload, other formats one numbered file each": "Archivo con un payload por línea; en TIFF cada payload es una página, en los demás formatos un archivo numerado",
+	"%w: cannot read batch file: %w":            "%w: no se pudo leer el archivo de lote: %w",
+	"batch: %d payloads from %s":                "lote: %d payloads de %s",
+	"%d pages written to %s":                    "%d páginas escritas en %s",
+	"Payload type to build instead of -url: %s": "Tipo de payload a armar en lugar de -url: %s",
+	"--%s needs --type (%s)":                    "--%s necesita --type (%s)",
+	"-url cannot be combined with --type; the payload is built from the type's fields":                                "-url no se puede combinar con --type; el payload se arma con los campos del tipo",
+	"--type cannot be combined with --batch":                                                                          "--type no se puede combinar con --batch",
+	"Wi-Fi network name (--type wifi)":                                                                                "Nombre de la red Wi-Fi (--type wifi)",
+	"Wi-Fi password (--type wifi)":                                                                                    "Contraseña de la red Wi-Fi (--type wifi)",
+	"Wi-Fi security (--type wifi): wpa2 (default with a password), wpa3, wpa, wep or none":                            "Seguridad de la red Wi-Fi (--type wifi): wpa2 (por defecto con contraseña), wpa3, wpa, wep o none",
+	"The Wi-Fi network does not broadcast its SSID (--type wifi)":                                                     "La red Wi-Fi no anuncia su SSID (--type wifi)",
+	"Send the 1-bit image to an e-ink display: an http(s) URL (POST) or mqtt(s)://host/topic":                         "Enviar la imagen de 1 bit a un display e-ink: una URL http(s) (POST) o mqtt(s)://host/tema",
+	"Image format for --push: raw (1 bit per pixel, 1 = black), pbm, bmp or png":                                      "Formato de imagen para --push: raw (1 bit por píxel, 1 = negro), pbm, bmp o png",
+	"Display resolution for --push, e.g. 296x128; the QR is centered on it":                                           "Resolución del display para --push, por ejemplo 296x128; el QR se centra en él",
+	"With --push-format raw, send 1 for white pixels":                                                                 "Con --push-format raw, enviar 1 para los píxeles blancos",
+	"--push cannot be combined with --batch":                                                                          "--push no se puede combinar con --batch",
+	"%w: cannot push to %s: %w":                                                                                       "%w: no se pudo enviar a %s: %w",
+	"push: %d bytes (%s, %dx%d) to %s":                                                                                "push: %d bytes (%s, %dx%d) a %s",
+	"QR pushed to %s":                                                                                                 "QR enviado a %s",
+	"Contact organization (--type vcard or mecard)":                                                                   "Organización del contacto (--type vcard o mecard)",
+	"Comma-separated phone numbers, optionally typed: work:+1 555 0100,cell:+1 555 0101 (--type vcard or mecard)":     "Teléfonos separados por comas, opcionalmente con tipo: work:+1 555 0100,cell:+1 555 0101 (--type vcard o mecard)",
+	"Comma-separated email addresses, optionally typed: work:jane@example.com (--type vcard or mecard)":               "Emails separados por comas, opcionalmente con tipo: work:jane@example.com (--type vcard o mecard)",
+	"Contact website URL (--type vcard or mecard)":                                                                    "URL del sitio web del contacto (--type vcard o mecard)",
+	"Street address (--type vcard or mecard)":                                                                         "Calle y número (--type vcard o mecard)",
+	"City (--type vcard or mecard)":                                                                                   "Ciudad (--type vcard o mecard)",
+	"State or province (--type vcard or mecard)":                                                                      "Provincia o estado (--type vcard o mecard)",
+	"Postal code (--type vcard or mecard)":                                                                            "Código postal (--type vcard o mecard)",
+	"Country (--type vcard or mecard)":                                                                                "País (--type vcard o mecard)",
+	"vCard version: 3.0 (default, widest support) or 4.0":                                                             "Versión de vCard: 3.0 (por defecto, la más compatible) o 4.0",
+	"Publish the QR to an MQTT topic: mqtt(s)://user:pass@host:port/topic":                                            "Publicar el QR en un tema MQTT: mqtt(s)://usuario:clave@host:puerto/tema",
+	"What --mqtt publishes: image (the generated file), base64 (the file in base64) or matrix (module matrix JSON)":   "Qué publica --mqtt: image (el archivo generado), base64 (el archivo en base64) o matrix (la matriz de módulos en JSON)",
+	"MQTT QoS: 0, 1 or 2":                                                                                             "QoS de MQTT: 0, 1 o 2",
+	"Ask the broker to retain the message for later subscribers":                                                      "Pedirle al broker que retenga el mensaje para los suscriptores posteriores",
+	"unsupported MQTT payload %q (image, base64 or matrix)":                                                           "contenido MQTT no soportado %q (image, base64 o matrix)",
+	"%w: --mqtt-payload %s publishes the generated file; add -o":                                                      "%w: --mqtt-payload %s publica el archivo generado; agregá -o",
+	"--mqtt cannot be combined with --batch":                                                                          "--mqtt no se puede combinar con --batch",
+	"mqtt: %d bytes (%s, QoS %d, retain %t) to %s":                                                                    "mqtt: %d bytes (%s, QoS %d, retain %t) a %s",
+	"%w: cannot publish to %s: %w":                                                                                    "%w: no se pudo publicar en %s: %w",
+	"QR published to %s":                                                                                              "QR publicado en %s",
+	"Module color: #rrggbb, #rgb or a CSS color name such as navy (default black); checked for contrast against --bg": "Color de los módulos: #rrggbb, #rgb o un nombre de color de CSS como navy (por defecto negro); se controla su contraste con --bg",
+	"Paint the modules with a gradient instead of --fg: linear:FROM,TO[,ANGLEdeg] (0deg left to right, 90deg top to bottom) or radial:FROM,TO (center to corners); PNG, JPEG, AVIF, HEIF and SVG":                       "Pintar los módulos con un degradado en lugar de --fg: linear:DESDE,HASTA[,ÁNGULOdeg] (0deg de izquierda a derecha, 90deg de arriba abajo) o radial:DESDE,HASTA (del centro a las esquinas); PNG, JPEG, AVIF, HEIF y SVG",
+	"Shape of the dark modules: square (default), rounded (round corners except where modules touch), dots or diamond for the data modules of QR codes; the finder patterns follow --eye-style; raster formats and SVG": "Forma de los módulos oscuros: square (por defecto), rounded (esquinas redondeadas salvo donde se tocan los módulos), dots o diamond para los módulos de datos de los QR; los patrones de búsqueda siguen --eye-style; formatos de mapa de bits y SVG",
+	"Shape of the three finder patterns (eyes) of QR codes: square (default), rounded, circle or leaf (two opposite corners pointed), drawn whole apart from --module-style; raster formats and SVG":                    "Forma de los tres patrones de búsqueda (ojos) de los QR: square (por defecto), rounded, circle o leaf (dos esquinas opuestas en punta), dibujados enteros aparte de --module-style; formatos de mapa de bits y SVG",
+	"Background color, including the quiet zone: #rrggbb, #rgb, a CSS color name or transparent (default white)":                                                                                                        "Color del fondo, incluida la zona de silencio: #rrggbb, #rgb, un nombre de color de CSS o transparent (por defecto blanco)",
+	"Styling preset: chromakey (opaque matte and no green or blue, for video keyed over a chroma background), lowerthird or lowerthird-4k (transparent 1080p or 4K PNG overlay with the QR in a corner)":                "Preset de estilo: chromakey (marco opaco y sin verde ni azul, para video sobre un fondo de croma), lowerthird o lowerthird-4k (overlay PNG transparente de 1080p o 4K con el QR en una esquina)",
+	"Corner of the lowerthird overlay: bottom-right (default), bottom-left, top-right or top-left":                                                                                                                      "Esquina del overlay lowerthird: bottom-right (por defecto), bottom-left, top-right o top-left",
+	"Text shown next to the QR in the lowerthird overlay":                                                         "Texto que se muestra junto al QR en el overlay lowerthird",
+	"Point an OBS image or browser source at the generated file via obs-websocket: host:port (default port 4455)": "Apunta una fuente de imagen o navegador de OBS al archivo generado vía obs-websocket: host:puerto (puerto por defecto 4455)",
+	"Name of the OBS source to update (with --obs)":                                                               "Nombre de la fuente de OBS a actualizar (con --obs)",
+	"obs-websocket password (default $%s)":                                                                        "Contraseña de obs-websocket (por defecto $%s)",
+	"--obs-source and --obs-password need --obs":                                                                  "--obs-source y --obs-password necesitan --obs",
+	"--obs needs --obs-source with the name of the source to update":                                              "--obs necesita --obs-source con el nombre de la fuente a actualizar",
+	"--obs cannot be combined with --batch":                                                                       "--obs no se puede combinar con --batch",
+	"%w: --obs shows the generated file; add -o":                                                                  "%w: --obs muestra el archivo generado; agregá -o",
+	"%w: cannot update OBS source %q: %w":                                                                         "%w: no se pudo actualizar la fuente de OBS %q: %w",
+	"OBS source %q updated":                                                                                       "Fuente de OBS %q actualizada",
+	"obs: source %q at %s -> %s":                                                                                  "obs: fuente %q en %s -> %s",
+	"File with one cue per line: time, payload and optionally \" | caption\"; the last line is \"<time> END\"":    "Archivo con un cue por línea: instante, payload y opcionalmente \" | texto\"; la última línea es \"<instante> END\"",
+	"Output: a .mov file (ProRes 4444 with alpha, needs ffmpeg) or a directory for a PNG sequence":                "Salida: un archivo .mov (ProRes 4444 con alfa, necesita ffmpeg) o un directorio para una secuencia PNG",
+	"Frame rate: 25, 29.97, 30, 59.94... or a fraction such as 30000/1001":                                        "Cadencia: 25, 29.97, 30, 59.94... o una fracción como 30000/1001",
+	"Overlay preset: lowerthird (1920x1080) or lowerthird-4k (3840x2160)":                                         "Preset del overlay: lowerthird (1920x1080) o lowerthird-4k (3840x2160)",
+	"QR size at 1080p": "Tamaño del QR a 1080p",
+	"Caption for the cues that do not set their own":                       "Texto para los cues que no indican uno propio",
+	"Usage: qrgenerator_cli video -cues cues.txt -o overlay.mov [flags]\n": "Uso: qrgenerator_cli video -cues cues.txt -o overlay.mov [flags]\n",
+	"-cues and -o are required":                                            "-cues y -o son obligatorios",
+	"video needs a transparent overlay preset: %s or %s":                   "video necesita un preset de overlay transparente: %s o %s",
+	"line %d: %w":                       "línea %d: %w",
+	"every cue hides the QR":            "todos los cues ocultan el QR",
+	"video: frames %d-%d: %q":           "video: cuadros %d-%d: %q",
+	"%d frames at %s fps written to %s": "%d cuadros a %s fps escritos en %s",
+	"Comma-separated lang=URL pairs; the first language is the default (es=https://example.com/es,en=https://example.com/en)": "Pares idioma=URL separados por comas; el primer idioma es el de por defecto (es=https://example.com/es,en=https://example.com/en)",
+	"URL where the page will be published; the QR points to it":                                                               "URL donde se va a publicar la página; el QR apunta a ella",
+	"Directory for index.html and the QR":                                                                                     "Directorio para index.html y el QR",
+	"Page title shown above the language buttons":                                                                             "Título de la página, arriba de los botones de idioma",
+	"QR file name inside --out-dir; the extension selects the format":                                                         "Nombre del archivo del QR dentro de --out-dir; la extensión elige el formato",
+	"Usage: qrgenerator_cli landing -links es=URL,en=URL -url PAGE_URL -out-dir DIR [flags]\n":                                "Uso: qrgenerator_cli landing -links es=URL,en=URL -url URL_PAGINA -out-dir DIR [flags]\n",
+	"-links, -url and -out-dir are required":                                                                                  "-links, -url y -out-dir son obligatorios",
+	"invalid page URL %q":                                                                                                     "URL de la página inválida %q",
+	"Choose your language":                                                                                                    "Elegí tu idioma",
+	"%w: cannot write the page: %w":                                                                                           "%w: no se pudo escribir la página: %w",
+	"page written to %s":                                                                                                      "página escrita en %s",
+	"Compress the payload with zlib and encode it as base64 or base45 (denser, QR alphanumeric mode); only this tool's decode subcommand restores it": "Comprime el payload con zlib y lo codifica en base64 o base45 (más denso, modo alfanumérico del QR); solo el subcomando decode de esta herramienta lo restaura",
+	"Usage: qrgenerator_cli decode [flags] image... (- reads standard input)\n":                                                                       "Uso: qrgenerator_cli decode [flags] imagen... (- lee la entrada estándar)\n",
+	"no images to decode":             "no hay imágenes para decodificar",
+	"%s: version %d, level %s":        "%s: versión %d, nivel %s",
+	"%s: decompressed %d -> %d bytes": "%s: descomprimido %d -> %d bytes",
+	"Output: a .png name, numbered per QR (file-001.png...), or a .gif animation":                 "Salida: un nombre .png, numerado por QR (archivo-001.png...), o una animación .gif",
+	"Bytes per QR (1-%d); smaller chunks give sparser codes that scan faster":                     "Bytes por QR (1-%d); los trozos más chicos dan códigos menos densos que se leen más rápido",
+	"Time each QR stays on screen in the GIF":                                                     "Tiempo que cada QR queda en pantalla en el GIF",
+	"Usage: qrgenerator_cli send-file -o chunks.png|chunks.gif [flags] file\n":                    "Uso: qrgenerator_cli send-file -o trozos.png|trozos.gif [flags] archivo\n",
+	"send-file needs one file and -o":                                                             "send-file necesita un archivo y -o",
+	"%w: send-file writes .png pages or a .gif animation":                                         "%w: send-file escribe páginas .png o una animación .gif",
+	"--delay must be positive":                                                                    "--delay debe ser positivo",
+	"%d bytes in %d chunks of up to %d bytes":                                                     "%d bytes en %d trozos de hasta %d bytes",
+	"%w: cannot write the GIF: %w":                                                                "%w: no se pudo escribir el GIF: %w",
+	"%d QR codes written to %s":                                                                   "%d códigos QR escritos en %s",
+	"%d QR codes written to %s ... %s":                                                            "%d códigos QR escritos en %s ... %s",
+	"lower -chunk":                                                                                "bajá -chunk",
+	"Path of the reassembled file":                                                                "Ruta del archivo rearmado",
+	"Usage: qrgenerator_cli receive-file -o file [flags] image... (PNG, JPEG, animated GIF...)\n": "Uso: qrgenerator_cli receive-file -o archivo [flags] imagen... (PNG, JPEG, GIF animado...)\n",
+	"receive-file needs -o and at least one image":                                                "receive-file necesita -o y al menos una imagen",
+	"%s: chunk %d/%d":                                                                             "%s: trozo %d/%d",
+	"%d bytes written to %s (SHA-256 %s)":                                                         "%d bytes escritos en %s (SHA-256 %s)",
+	"Output: a printable .pdf, or a .png name numbered per QR":                                    "Salida: un .pdf para imprimir, o un nombre .png numerado por QR",
+	"File whose first line is the passphrase (default: $%s or a prompt)":                          "Archivo cuya primera línea es la frase de contraseña (por defecto: $%s o se pide)",
+	"QR size for .png output":                                                                     "Tamaño del QR para la salida .png",
+	"Usage: qrgenerator_cli paperkey -o backup.pdf [flags] keyfile\n":                             "Uso: qrgenerator_cli paperkey -o respaldo.pdf [flags] archivo-de-clave\n",
+	"paperkey needs one key file and -o":                                                          "paperkey necesita un archivo de clave y -o",
+	"%w: paperkey writes a .pdf or .png pages":                                                    "%w: paperkey escribe un .pdf o páginas .png",
+	"%w: cannot write the PDF: %w":                                                                "%w: no se pudo escribir el PDF: %w",
+	"print it, then check the restore with paperkey-restore before deleting any copy":             "imprimilo y probá la restauración con paperkey-restore antes de borrar cualquier copia",
+	"Path of the restored file (default: the original name, in the current directory)":            "Ruta del archivo restaurado (por defecto: el nombre original, en el directorio actual)",
+	"Usage: qrgenerator_cli paperkey-restore [flags] image...\n":                                  "Uso: qrgenerator_cli paperkey-restore [flags] imagen...\n",
+	"%s already exists; choose another path with -o":                                              "%s ya existe; elegí otra ruta con -o",
+	"%d bytes written to %s":                                                                      "%d bytes escritos en %s",
+	"cannot read the passphrase: %w":                                                              "no se pudo leer la frase de contraseña: %w",
+	"Passphrase: ":                                                                                "Frase de contraseña: ",
+	"Repeat the passphrase: ":                                                                     "Repetí la frase de contraseña: ",
+	"the passphrases do not match":                                                                "las frases de contraseña no coinciden",
+	"Split the backup into this many Shamir shares, one file each (key-001.pdf...)":               "Reparte el respaldo en esta cantidad de partes de Shamir, un archivo cada una (clave-001.pdf...)",
+	"Shares needed to restore (2 to -shares)":                                                     "Partes necesarias para restaurar (de 2 a -shares)",
+	"-shares and -threshold go together":                                                          "-shares y -threshold van juntos",
+	"-threshold must be between 2 and -shares, and -shares at most 255":                           "-threshold debe estar entre 2 y -shares, y -shares ser como mucho 255",
+	"combining %d shares":                                                                         "combinando %d partes",
+	"the images belong to %d different files":                                                     "las imágenes son de %d archivos distintos",
+	"Encrypt the payload for this recipient before encoding: an age1... or SSH public key (needs age) or a GPG key ID, fingerprint or email (needs gpg); decode decrypts it": "Cifra el payload para este destinatario antes de codificarlo: una clave age1... o una clave pública SSH (necesita age) o un ID, huella o correo de una clave GPG (necesita gpg); decode lo descifra",
+	"Print payloads as stored in the symbol, without decrypting or decompressing them, and GS1 element strings with their GS separators instead of the (AI) form":            "Muestra los payloads tal como están en el símbolo, sin descifrarlos ni descomprimirlos, y las cadenas de elementos GS1 con sus separadores GS en lugar de la forma (AI)",
+	"age identity file to decrypt payloads encrypted to an age or SSH key; GPG payloads use the keyring":                                                                     "Archivo de identidad de age para descifrar payloads cifrados para una clave age o SSH; los de GPG usan el llavero",
+	"%s: decrypted %d -> %d bytes":                      "%s: descifrado %d -> %d bytes",
+	"Output .pdf sheet":                                 "Hoja .pdf de salida",
+	"Service the codes belong to, shown in the heading": "Servicio al que pertenecen los códigos, se muestra en el encabezado",
+	"User the codes are issued to":                      "Usuario al que se entregan los códigos",
+	"Encrypt the master QR for this age, SSH or GPG recipient instead of a passphrase":              "Cifra el QR maestro para este destinatario age, SSH o GPG en lugar de usar una frase",
+	"Usage: qrgenerator_cli backup-codes -o sheet.pdf [flags] codes.txt (- reads standard input)\n": "Uso: qrgenerator_cli backup-codes -o hoja.pdf [flags] codigos.txt (- lee la entrada estándar)\n",
+	"backup-codes needs one file of codes and -o":                                                   "backup-codes necesita un archivo de códigos y -o",
+	"%w: backup-codes writes a .pdf":                                                                "%w: backup-codes escribe un .pdf",
+	"%w: backup code %d has %d characters; at most %d fit on the sheet":                             "%w: el código de respaldo %d tiene %d caracteres; en la hoja entran como máximo %d",
+	"%w: no backup codes in %s":                                                                     "%w: no hay códigos de respaldo en %s",
+	"%w: %d backup codes; a sheet holds at most %d":                                                 "%w: %d códigos de respaldo; una hoja admite como máximo %d",
+	"Encrypted to %s. To recover the codes, scan it and run: qrgenerator_cli decode IMAGE":          "Cifrado para %s. Para recuperar los códigos, escanealo y ejecutá: qrgenerator_cli decode IMAGEN",
+	"%w: the codes do not fit in one master QR; issue fewer or shorter codes":                       "%w: los códigos no entran en un solo QR maestro; emití menos códigos o más cortos",
+	"Protected with the passphrase chosen when the sheet was issued. To recover the codes, scan it and run: qrgenerator_cli paperkey-restore IMAGE": "Protegido con la frase elegida al emitir la hoja. Para recuperar los códigos, escanealo y ejecutá: qrgenerator_cli paperkey-restore IMAGEN",
+	"%d backup codes written to %s": "%d códigos de respaldo escritos en %s",
+	"Accept inverted (light-on-dark) symbols, which many older scanner apps cannot read": "Acepta símbolos invertidos (claro sobre oscuro), que muchas apps de escaneo viejas no leen",
+	"Do not warn about inverted (light-on-dark) symbols":                                 "No advierte sobre los símbolos invertidos (claro sobre oscuro)",
+	"Accept amounts and dates of the payload fields written in this locale's convention, such as es-AR (1.234,56 and 31/12/2025 18:30) or en-US (12/31/2025 6:30 PM)": "Acepta los importes y las fechas de los campos del payload escritos con la convención de este locale, como es-AR (1.234,56 y 31/12/2025 18:30) o en-US (12/31/2025 6:30 PM)",
+	"--locale needs --type; it applies to the payload fields":                         "--locale necesita --type; se aplica a los campos del payload",
+	"Contact birthday: 1990-12-31, or in the --locale order (--type vcard or mecard)": "Cumpleaños del contacto: 1990-12-31, o en el orden de --locale (--type vcard o mecard)",
+	"Append check characters to the serial number at the end of the payload, and of the caption when it ends in the same serial: luhn (one character) or crc (four hex digits)": "Agregar caracteres de control al número de serie del final del payload, y del texto si termina en la misma serie: luhn (un carácter) o crc (cuatro dígitos hexadecimales)",
+	"Verify the check characters of the serial number at the end of each payload: luhn or crc, as in the generator":                                                             "Verificar los caracteres de control del número de serie del final de cada payload: luhn o crc, como en el generador",
+	"%s: the check characters of serial %q do not match": "%s: los caracteres de control de la serie %q no coinciden",
+	"%s: serial %s checked":                              "%s: serie %s controlada",
+	"Print 2FA backup codes on a PDF sheet, each with its QR, plus an encrypted master QR":                        "Imprimir códigos de recuperación 2FA en una hoja PDF, cada uno con su QR, más un QR maestro cifrado",
+	"Print the payload of QR images, decrypting and decompressing it":                                             "Mostrar el payload de imágenes QR, descifrándolo y descomprimiéndolo",
+	"Write a multilingual static landing page and the QR that points to it":                                       "Escribir una página de destino estática multilingüe y el QR que apunta a ella",
+	"Decode published QR images periodically and check that their destinations answer":                            "Decodificar periódicamente imágenes QR publicadas y revisar que sus destinos respondan",
+	"Encrypt a key file with a passphrase and print it as QR codes on a PDF":                                      "Cifrar un archivo de clave con una frase y imprimirlo como códigos QR en un PDF",
+	"Restore a key file from scans of its paperkey pages":                                                         "Restaurar un archivo de clave a partir de escaneos de sus páginas de paperkey",
+	"Rebuild a file from the QR sequence written by send-file":                                                    "Reconstruir un archivo a partir de la secuencia de QR escrita por send-file",
+	"Generate reference payloads in every format and decode them back":                                            "Generar payloads de referencia en todos los formatos y volver a decodificarlos",
+	"Replace the binary with the latest release":                                                                  "Reemplazar el binario por la última versión publicada",
+	"Split a file into a numbered sequence of QR codes to cross an air gap":                                       "Dividir un archivo en una secuencia numerada de códigos QR para cruzar un air gap",
+	"Export a transparent overlay video whose QR switches at given times":                                         "Exportar un video de overlay transparente cuyo QR cambia en momentos dados",
+	"Generate man pages or a markdown reference from the command and flag definitions":                            "Generar páginas de manual o una referencia en markdown a partir de las definiciones de comandos y flags",
+	"Generate QR codes in many image and data formats":                                                            "Generar códigos QR en muchos formatos de imagen y datos",
+	"\nCommands (%s COMMAND -h for their flags):\n":                                                               "\nComandos (%s COMANDO -h para ver sus flags):\n",
+	"Output directory for man pages (default: current directory) or file for markdown (default: standard output)": "Directorio de salida de las páginas de manual (por defecto: el directorio actual) o archivo del markdown (por defecto: la salida estándar)",
+	"Usage: qrgenerator_cli docs man|markdown [flags]\n":                                                          "Uso: qrgenerator_cli docs man|markdown [flags]\n",
+	"docs needs a format: man or markdown":                                                                        "docs necesita un formato: man o markdown",
+	"reference written to %s":                                                                                     "referencia escrita en %s",
+	"man page written to %s":                                                                                      "página de manual escrita en %s",
+	"%d man pages written to %s":                                                                                  "%d páginas de manual escritas en %s",
+	"User Commands":                                                                                               "Comandos de usuario",
+	"NAME":                                                                                                        "NOMBRE",
+	"SYNOPSIS":                                                                                                    "SINOPSIS",
+	"COMMANDS":                                                                                                    "COMANDOS",
+	"OPTIONS":                                                                                                     "OPCIONES",
+	"SEE ALSO":                                                                                                    "VÉASE TAMBIÉN",
+	"%s command reference":                                                                                        "Referencia de comandos de %s",
+	"Flag":                                                                                                        "Flag",
+	"Description":                                                                                                 "Descripción",
+	"(default: %s)":                                                                                               "(por defecto: %s)",
+	"--type %s takes no arguments; use its flags":                                                                 "--type %s no lleva argumentos; usá sus flags",
+	"--type %s takes a single argument; quote it if it has spaces":                                                "--type %s lleva un solo argumento; ponelo entre comillas si tiene espacios",
+	"--%s and an argument set the same field; use one of them":                                                    "--%s y un argumento llenan el mismo campo; usá uno de los dos",
+	"%s: GS1 data with unknown AIs; printed as transmitted":                                                       "%s: datos GS1 con AI desconocidos; se muestran tal como se transmitieron",
+	"Release channel to follow: stable or prerelease (also release candidates)":                                   "Canal de releases a seguir: stable o prerelease (incluye las release candidates)",
+	"%s was installed with %s; update it with %s (or use -force)":                                                 "%s se instaló con %s; actualizalo con %s (o usá -force)",
+	"Export the binary, config files and assets as one tarball, or install one on an offline machine":                                    "Exportar el binario, los archivos de configuración y los recursos en un tarball, o instalarlo en un equipo sin red",
+	"Bundle to write (export), such as site.tar.gz":                                                                                      "Paquete a escribir (export), como site.tar.gz",
+	"Binary to include (export; default: this executable), such as a build for the target platform":                                      "Binario a incluir (export; por defecto: este ejecutable), como una compilación para la plataforma de destino",
+	"Platform of the included binary, as GOOS/GOARCH (export)":                                                                           "Plataforma del binario incluido, como GOOS/GOARCH (export)",
+	"Config file to include (export); repeat it for several":                                                                             "Archivo de configuración a incluir (export); repetilo para varios",
+	"Directory for the binary (import; default: the directory of this executable)":                                                       "Directorio del binario (import; por defecto: el directorio de este ejecutable)",
+	"Directory for the config files and assets (import; default: the user config directory)":                                             "Directorio de los archivos de configuración y los recursos (import; por defecto: el directorio de configuración del usuario)",
+	"Only verify the bundle and list its files (import)":                                                                                 "Solo verificar el paquete y listar sus archivos (import)",
+	"Install a binary built for another platform (import)":                                                                               "Instalar un binario compilado para otra plataforma (import)",
+	"Usage: qrgenerator_cli bundle export -o site.tar.gz [flags] [asset...]\n       qrgenerator_cli bundle import [flags] site.tar.gz\n": "Uso: qrgenerator_cli bundle export -o site.tar.gz [flags] [recurso...]\n     qrgenerator_cli bundle import [flags] site.tar.gz\n",
+	"bundle needs export -o FILE, or import and one bundle":                                                                              "bundle necesita export -o ARCHIVO, o import y un paquete",
+	"%w: invalid --platform %q; use GOOS/GOARCH such as linux/amd64":                                                                     "%w: --platform %q inválida; usá GOOS/GOARCH como linux/amd64",
+	"bundled %s":             "empaquetado %s",
+	"%d files bundled in %s": "%d archivos empaquetados en %s",
+	"the bundle is for %s and this machine is %s; use -force to install it anyway":                   "el paquete es para %s y este equipo es %s; usá -force para instalarlo igual",
+	"permission denied writing %s; run again with sufficient privileges or choose another directory": "permiso denegado al escribir %s; volvé a ejecutarlo con permisos suficientes o elegí otro directorio",
+	"installed %s": "instalado %s",
+	"bundle %s installed: binary in %s, config and assets in %s":                                                                                   "paquete %s instalado: binario en %s, configuración y recursos en %s",
+	"Split the payload across this many QR codes, numbered like send-file, for long payloads such as SSH keys; receive-file puts it back together": "Partir el payload en esta cantidad de QR, numerados como send-file, para payloads largos como claves SSH; receive-file lo vuelve a armar",
+	"--split needs at least 2 QR codes":                                                                      "--split necesita al menos 2 QR",
+	"--split cannot be combined with --batch":                                                                "--split no se puede combinar con --batch",
+	"--split writes files; it cannot be combined with --push, --mqtt or --obs":                               "--split escribe archivos; no se puede combinar con --push, --mqtt ni --obs",
+	"--type gs1 cannot be combined with --split":                                                             "--type gs1 no se puede combinar con --split",
+	"%w: --split writes a single numbered output; drop --formats or the extra -o":                            "%w: --split escribe una sola salida numerada; quitá --formats o los -o de más",
+	"--split cannot be combined with --%s":                                                                   "--split no se puede combinar con --%s",
+	"split: %d bytes in %d QR codes":                                                                         "partido: %d bytes en %d QR",
+	"%w: the payload has %d bytes; it cannot be split into %d QR codes":                                      "%w: el payload tiene %d bytes; no se puede partir en %d QR",
+	"%w: the payload needs at least %d QR codes with --split":                                                "%w: el payload necesita al menos %d QR con --split",
+	"Do not count this run in the local usage metrics, even if telemetry is enabled":                         "No contar esta ejecución en las métricas de uso locales, aunque la telemetría esté activada",
+	"Opt in to local usage counts (formats, sizes, errors) and export a summary; off by default":             "Activar los contadores de uso locales (formatos, tamaños, errores) y exportar un resumen; desactivados por defecto",
+	"Summary file to write (export): .json, or .csv for spreadsheets (default: JSON on standard output)":     "Archivo de resumen a escribir (export): .json, o .csv para planillas (por defecto: JSON en la salida estándar)",
+	"Usage: qrgenerator_cli telemetry enable|disable|status|export|reset [flags]\n":                          "Uso: qrgenerator_cli telemetry enable|disable|status|export|reset [flags]\n",
+	"telemetry needs an action: enable, disable, status, export or reset":                                    "telemetry necesita una acción: enable, disable, status, export o reset",
+	"telemetry is off; enable it with: qrgenerator_cli telemetry enable":                                     "la telemetría está desactivada; activala con: qrgenerator_cli telemetry enable",
+	"telemetry is on since %s, stored in %s":                                                                 "la telemetría está activada desde %s, guardada en %s",
+	"telemetry on: usage counts are kept in %s and never sent; --no-telemetry or DO_NOT_TRACK=1 skip a run":  "telemetría activada: los contadores de uso se guardan en %s y nunca se envían; --no-telemetry o DO_NOT_TRACK=1 omiten una ejecución",
+	"telemetry off; the counts so far stay in %s until reset":                                                "telemetría desactivada; los contadores hasta ahora quedan en %s hasta hacer reset",
+	"telemetry counts cleared":                                                                               "contadores de telemetría borrados",
+	"Error correction level: L, M, Q or H (default H, the most damage-tolerant); preview-grid compares them": "Nivel de corrección de errores: L, M, Q o H (por defecto H, el que más daño tolera); preview-grid los compara",
+	"Render one payload with several EC levels, sizes and presets side by side on one comparison sheet":      "Dibujar un payload con varios niveles de corrección, tamaños y presets, uno al lado del otro en una hoja de comparación",
+	"Payload to compare; also the argument after the flags":                                                  "Payload a comparar; también el argumento después de los flags",
+	"Comma-separated EC levels to compare: L, M, Q, H":                                                       "Niveles de corrección a comparar, separados por comas: L, M, Q, H",
+	"Comma-separated sizes in pixels to compare":                                                             "Tamaños en píxeles a comparar, separados por comas",
+	"Comma-separated presets to compare; none for the plain QR":                                              "Presets a comparar, separados por comas; none para el QR simple",
+	"Variants per row (default: one per EC level)":                                                           "Variantes por fila (por defecto: una por nivel de corrección)",
+	"Comparison sheet to write, .png or .jpg":                                                                "Hoja de comparación a escribir, .png o .jpg",
+	"Usage: qrgenerator_cli preview-grid [flags] [payload]\n":                                                "Uso: qrgenerator_cli preview-grid [flags] [payload]\n",
+	"preview-grid takes a single payload; quote it if it has spaces":                                         "preview-grid lleva un solo payload; ponelo entre comillas si tiene espacios",
+	"-url and an argument set the same payload; use one of them":                                             "-url y un argumento indican el mismo payload; usá uno de los dos",
+	"preview-grid needs a payload: -url or an argument":                                                      "preview-grid necesita un payload: -url o un argumento",
+	"%w: -ec, -sizes and -presets need at least one value":                                                   "%w: -ec, -sizes y -presets necesitan al menos un valor",
+	"%w: invalid size %q":                        "%w: tamaño inválido %q",
+	"EC %s, %dpx":                                "EC %s, %dpx",
+	"%s: does not fit with these options":        "%s: no entra con estas opciones",
+	"%s: QR version %d (%dx%d modules), mask %d": "%s: QR versión %d (%dx%d módulos), máscara %d",
+	"%d variants compared in %s":                 "%d variantes comparadas en %s",
+	"Campaign source appended to URL payloads as utm_source, such as newsletter or poster":           "Origen de la campaña que se agrega a los payloads URL como utm_source, como newsletter o poster",
+	"Campaign medium appended to URL payloads as utm_medium, such as qr or print":                    "Medio de la campaña que se agrega a los payloads URL como utm_medium, como qr o print",
+	"Campaign name appended to URL payloads as utm_campaign":                                         "Nombre de la campaña que se agrega a los payloads URL como utm_campaign",
+	"Variant appended to URL payloads as utm_content, to tell apart placements of the same campaign": "Variante que se agrega a los payloads URL como utm_content, para distinguir ubicaciones de la misma campaña",
+	"%w: batch payload %d: %w": "%w: payload %d del lote: %w",
+	"Also write a PNG preview of at most this many pixels per side next to each output, named with a _thumb suffix (16-1024)": "Escribir también una vista previa PNG de hasta esta cantidad de píxeles por lado junto a cada salida, con el sufijo _thumb (16-1024)",
+	"thumbnail written to %s": "miniatura escrita en %s",
+	"Encode -url as given; by default https:// is added when missing, the host is lowercased and punycoded and default ports are dropped": "Codificar -url tal cual; por defecto se agrega https:// si falta, el host pasa a minúsculas y a punycode y se quitan los puertos por defecto",
+	"URL normalized to %s": "URL normalizada a %s",
+	"Re-render a generated QR in another format, from its module matrix or by decoding it":             "Volver a dibujar un QR generado en otro formato, a partir de su matriz de módulos o decodificándolo",
+	"Size of the output in pixels (default: the width of the input image, or 256 for a module matrix)": "Tamaño de la salida en píxeles (por defecto: el ancho de la imagen de entrada, o 256 para una matriz de módulos)",
+	"Output format; overrides the extension of the output":                                             "Formato de salida; tiene prioridad sobre la extensión de la salida",
+	"Usage: qrgenerator_cli convert [flags] input output\n":                                            "Uso: qrgenerator_cli convert [flags] entrada salida\n",
+	"convert needs an input and an output file":                                                        "convert necesita un archivo de entrada y uno de salida",
+	"%s: drawing the stored module matrix":                                                             "%s: dibujando la matriz de módulos guardada",
+	"%s: the symbol is inverted; the output has dark modules on a light background":                    "%s: el símbolo está invertido; la salida tiene módulos oscuros sobre fondo claro",
+	"%s: no module matrix; re-encoding the decoded payload (version %d, level %s)":                     "%s: sin matriz de módulos; volviendo a codificar el payload decodificado (versión %d, nivel %s)",
+	"%s converted to %s": "%s convertido a %s",
+	"Embed a manifest (payload hash, options, tool version and module matrix) in PNG and SVG outputs; inspect reads it back": "Embeber un manifiesto (hash del payload, opciones, versión de la herramienta y matriz de módulos) en las salidas PNG y SVG; inspect lo lee",
+	"Print the generation manifest embedded in PNG and SVG outputs by --manifest":                                            "Mostrar el manifiesto de generación que --manifest embebe en las salidas PNG y SVG",
+	"Print each manifest as a line of JSON":            "Mostrar cada manifiesto como una línea de JSON",
+	"Usage: qrgenerator_cli inspect [flags] file...\n": "Uso: qrgenerator_cli inspect [flags] archivo...\n",
+	"no files to inspect":                              "no hay archivos para inspeccionar",
+	"%s: no manifest; generate it with --manifest":     "%s: sin manifiesto; generalo con --manifest",
+	"%s: %v; decoding the image instead":               "%s: %v; se decodifica la imagen en su lugar",
+	"file":                                             "archivo",
+	"tool":                                             "programa",
+	"payload":                                          "payload",
+	"format":                                           "formato",
+	"symbol":                                           "símbolo",
+	"version %d, level %s, mask %d":                    "versión %d, nivel %s, máscara %d",
+	"options":                                          "opciones",
+	"Encode the bytes of this file as they are, in byte mode, instead of -url: certificates, keys, CBOR...": "Codificar los bytes de este archivo tal cual, en modo byte, en lugar de -url: certificados, claves, CBOR...",
+	"--type cannot be combined with --input-file":                                                           "--type no se puede combinar con --input-file",
+	"--input-file cannot be combined with --batch":                                                          "--input-file no se puede combinar con --batch",
+	"--utm-* cannot be combined with --input-file":                                                          "--utm-* no se puede combinar con --input-file",
+	"%w: cannot read input file: %w":                                                                        "%w: no se puede leer el archivo de entrada: %w",
+	"%w: %s is empty":                                                                                       "%w: %s está vacío",
+	"Write the raw bytes of each symbol, as encoded with --input-file, without reading them as text or adding a newline": "Escribir los bytes crudos de cada símbolo, como los codifica --input-file, sin leerlos como texto ni agregar un salto de línea",
+	"-binary cannot be combined with -check-digit": "-binary no se puede combinar con -check-digit",
+	"Place a second copy of the QR in the lowerthird overlay, for large screens seen at an angle: opposite (the diagonally opposite corner), horizontal (the other side) or vertical (the other edge)": "Ubicar una segunda copia del QR en el overlay lowerthird, para pantallas grandes vistas de costado: opposite (la esquina opuesta en diagonal), horizontal (el otro lado) o vertical (el otro borde)",
+	"Compute the printed and pixel size a QR needs to scan from a distance, or how far a printed size scans from":                                                                                      "Calcular el tamaño impreso y en píxeles que necesita un QR para escanearlo desde una distancia, o desde qué distancia se escanea un tamaño impreso",
+	"Warn when the QR, printed at --dpi (default 300), is too small to scan from this distance, such as 3m (see size-for)":                                                                             "Advertir si el QR, impreso a --dpi (por defecto 300), queda chico para escanearlo desde esta distancia, como 3m (ver size-for)",
+	"Scan distance, such as 3m or 10ft: prints the smallest code that scans from there":                                                                                                                "Distancia de escaneo, como 3m o 10ft: muestra el código más chico que se escanea desde ahí",
+	"Printed width, such as 5cm or 2in: prints the farthest distance it scans from":                                                                                                                    "Ancho impreso, como 5cm o 2in: muestra la distancia máxima desde la que se escanea",
+	"Printer resolution in dots per inch":                                      "Resolución de la impresora en puntos por pulgada",
+	"Payload whose symbol to measure; also the argument after the flags":       "Payload cuyo símbolo se mide; también el argumento después de los flags",
+	"QR version 1-40 to measure instead of a payload":                          "Versión de QR 1-40 a medir en lugar de un payload",
+	"Error correction level of the payload: L, M, Q or H (default H)":          "Nivel de corrección de errores del payload: L, M, Q o H (por defecto H)",
+	"Usage: qrgenerator_cli size-for -distance D|-width W [flags] [payload]\n": "Uso: qrgenerator_cli size-for -distance D|-width W [flags] [payload]\n",
+	"size-for needs either -distance or -width":                                "size-for necesita -distance o -width",
+	"%w: -dpi %d out of range (72-4800)":                                       "%w: -dpi %d fuera de rango (72-4800)",
+	"%w: QR versions go from 1 to 40, not %d":                                  "%w: las versiones de QR van de 1 a 40, no %d",
+	"size-for needs the payload or a -version to count the modules":            "size-for necesita el payload o una -version para contar los módulos",
+	"modules":                             "módulos",
+	"%d (version %d with the quiet zone)": "%d (versión %d con la zona de silencio)",
+	"module":                              "módulo",
+	"width":                               "ancho",
+	"%s (%.2fin) or more":                 "%s (%.2fin) o más",
+	"distance":                            "distancia",
+	"up to %s":                            "hasta %s",
+	"pixels":                              "píxeles",
+	"-size %d at %d DPI (%d dots per module), %s printed": "-size %d a %d DPI (%d puntos por módulo), %s impreso",
+	"Go text/template rendered once per record of --batch, which is then read as CSV, TSV, JSON or JSON Lines: \"https://ex.com/t/{{.ID}}?u={{.User | urlquery}}\"": "Plantilla de Go text/template que se aplica a cada registro de --batch, que entonces se lee como CSV, TSV, JSON o JSON Lines: \"https://ex.com/t/{{.ID}}?u={{.User | urlquery}}\"",
+	"--template needs --batch with the records (CSV, TSV, JSON or JSON Lines)":                                                                                      "--template necesita --batch con los registros (CSV, TSV, JSON o JSON Lines)",
+	"Directory for the outputs, created if missing; relative -o paths are placed inside it":                                                                         "Directorio de las salidas, se crea si no existe; las rutas relativas de -o quedan adentro",
+	"--count writes files; it cannot be combined with --push, --mqtt or --obs":                                                                                      "--count escribe archivos; no se puede combinar con --push, --mqtt ni --obs",
+	"--split cannot be combined with --count":                                                                                                                       "--split no se puede combinar con --count",
+	"%w: --count writes a single numbered output; drop --formats or the extra -o":                                                                                   "%w: --count escribe una sola salida numerada; sacá --formats o los -o de más",
+	"identifiers written to %s": "identificadores escritos en %s",
+	"%s: %d identifiers":        "%s: %d identificadores",
+	"Print the effective configuration (defaults, config file, environment and flags) with the origin of each value, as YAML or with =json as JSON, and exit": "Imprimir la configuración efectiva (valores por defecto, archivo de configuración, entorno y flags) con el origen de cada valor, en YAML o con =json en JSON, y salir",
+	"use yaml or json": "usá yaml o json",
+	"Generate a numbered series of QR codes starting at this number; each file is named after its number (qr-1000.png)": "Generar una serie numerada de QR desde este número; cada archivo lleva su número (qr-1000.png)",
+	"Number of QR codes in the --serial-start series":                                                                   "Cantidad de QR de la serie de --serial-start",
+	"printf format of each payload of the series, with one integer verb: \"ASSET-%06d\" or \"https://ex.com/a/%d\"":     "Formato printf de cada payload de la serie, con un verbo entero: \"ASSET-%06d\" o \"https://ex.com/a/%d\"",
+	"--serial-count and --serial-format need --serial-start":                                                            "--serial-count y --serial-format necesitan --serial-start",
+	"serial: %d QR codes from %d to %d":                                                                                 "serie: %d códigos QR del %d al %d",
+	"--serial-start cannot be negative":                                                                                 "--serial-start no puede ser negativo",
+	"--serial-count must be a number from 1 to %d":                                                                      "--serial-count tiene que ser un número de 1 a %d",
+	"--serial-format needs exactly one integer verb such as %%d or %%06d, not %q":                                       "--serial-format necesita exactamente un verbo entero como %%d o %%06d, no %q",
+	"--serial-start builds the payloads; it cannot be combined with --batch, --input-file or --type":                    "--serial-start arma los payloads; no se puede combinar con --batch, --input-file ni --type",
+	"-url cannot be combined with --serial-start; put the URL in --serial-format":                                       "-url no se puede combinar con --serial-start; poné la URL en --serial-format",
+	"--serial-start writes files; it cannot be combined with --push, --mqtt or --obs":                                   "--serial-start escribe archivos; no se puede combinar con --push, --mqtt ni --obs",
+	"--split cannot be combined with --serial-start":                                                                    "--split no se puede combinar con --serial-start",
+	"%w: serial %d: %w":                             "%w: número de serie %d: %w",
+	"serial %d: %w":                                 "número de serie %d: %w",
+	"deprecation: usage=%q replacement=%q":          "deprecation: usage=%q replacement=%q",
+	"deprecation: flag=%s replacement=%q":           "deprecation: flag=%s replacement=%q",
+	"%s=error turns these deprecations into errors": "%s=error convierte estos avisos de obsolescencia en errores",
+	"What to do when the payload does not fit: none (default, fail) or auto (step the EC level down until it fits, logging the level chosen)":              "Qué hacer cuando el payload no entra: none (por defecto, fallar) o auto (bajar el nivel de corrección hasta que entre, informando el nivel elegido)",
+	"Lowest EC level --fit auto may step down to (default L)":                                                                                              "Nivel de corrección más bajo al que puede bajar --fit auto (por defecto L)",
+	"Largest QR version (1-40) --fit auto accepts; a lower EC level is chosen to stay within it (default 40)":                                              "Versión de QR más grande (1-40) que acepta --fit auto; se elige un nivel de corrección más bajo para no pasarla (por defecto 40)",
+	"--fit auto: EC level %s does not fit; using %s (version %d)":                                                                                          "--fit auto: con nivel de corrección %s no entra; se usa %s (versión %d)",
+	"Sign the payload with this PEM private key (ECDSA P-256/384/521, Ed25519 or RSA) and encode it as a compact JWS; decode --verify-signature checks it": "Firmar el payload con esta clave privada PEM (ECDSA P-256/384/521, Ed25519 o RSA) y codificarlo como JWS compacto; decode --verify-signature lo comprueba",
+	"Verify the JWS signature of each payload, as written by --sign, with this PEM public key, certificate or private key, and print the signed payload":   "Verificar la firma JWS de cada payload, como la escribe --sign, con esta clave pública PEM, certificado o clave privada, e imprimir el payload firmado",
+	"-binary cannot be combined with -verify-signature":                                                                                                    "-binary no se puede combinar con -verify-signature",
+	"%s: signature verified": "%s: firma verificada",
+	"%s: signed payload printed as stored; --verify-signature checks it":                                                                                    "%s: payload firmado impreso tal como está; --verify-signature lo comprueba",
+	"Encrypt the payload with a passphrase (AES-256-GCM, key from scrypt), asked twice or read from --passphrase-file or $%s; decode --decrypt decrypts it": "Cifrar el payload con una frase de contraseña (AES-256-GCM, clave derivada con scrypt), que se pide dos veces o se lee de --passphrase-file o $%s; decode --decrypt lo descifra",
+	"--encrypt cannot be combined with --encrypt-to":                                                                                                        "--encrypt no se puede combinar con --encrypt-to",
+	"--split cannot be combined with --encrypt":                                                                                                             "--split no se puede combinar con --encrypt",
+	"--passphrase-file needs --encrypt":                                                                                                                     "--passphrase-file necesita --encrypt",
+	"--encrypt with --watch needs --passphrase-file or $%s":                                                                                                 "--encrypt con --watch necesita --passphrase-file o $%s",
+	"--encrypt: %w": "--encrypt: %w",
+	"Decrypt payloads encrypted with --encrypt, asking for the passphrase once": "Descifrar los payloads cifrados con --encrypt, pidiendo la frase una sola vez",
+	"the payload is encrypted with a passphrase; pass -decrypt":                 "el payload está cifrado con una frase de contraseña; pasá -decrypt",
+	"Encode every code in this QR version (1-40), so a print run has the same module count and density; fails if the payload does not fit (default: the smallest that fits)":                                         "Codificar todos los códigos en esta versión de QR (1-40), para que una tirada tenga la misma cantidad de módulos y densidad; falla si el payload no entra (por defecto: la más chica en que entra)",
+	"QR mask pattern: 0-7 to force one, for artistic codes or to reproduce a reference symbol, or auto (default, the one easiest to scan)":                                                                           "Patrón de máscara del QR: 0-7 para fijar uno, para códigos artísticos o para reproducir un símbolo de referencia, o auto (por defecto, el más fácil de escanear)",
+	"Data encoding mode: auto (default; mixes modes and puts Japanese text in kanji mode), or numeric, alphanumeric, byte or kanji to encode the whole payload in that mode, failing if a character does not fit it": "Modo de codificación de los datos: auto (por defecto; combina modos y pone el texto japonés en modo kanji), o numeric, alphanumeric, byte o kanji para codificar todo el payload en ese modo, con error si un carácter no entra en él",
+	"Declare the character set of the payload with an ECI header and convert the text to it: utf-8, iso-8859-1 or shift-jis, for readers that do not assume UTF-8 (default: no ECI)":                                 "Declarar el juego de caracteres del payload con un encabezado ECI y convertir el texto a él: utf-8, iso-8859-1 o shift-jis, para los lectores que no suponen UTF-8 (por defecto: sin ECI)",
+	"With --split, link the QR codes with the structured append header of the QR standard instead of the send-file format: up to 16 codes written as out_1of4.png..., which decode joins back":                       "Con --split, enlazar los QR con el encabezado de structured append del estándar QR en lugar del formato de send-file: hasta 16 códigos escritos como out_1of4.png..., que decode vuelve a juntar",
+	"--structured-append links at most %d QR codes":    "--structured-append enlaza como máximo %d QR",
+	"--structured-append needs --split":                "--structured-append necesita --split",
+	"structured append: %d bytes in %d QR codes":       "structured append: %d bytes en %d QR",
+	"%s: structured append, QR %d of %d":               "%s: structured append, QR %d de %d",
+	"%s: structured append: QR codes %s of %d missing": "%s: structured append: faltan los QR %s de %d",
+	"%s: %d structured append QR codes joined":         "%s: %d QR de structured append juntados",
+	"Symbology: qr (default); rmqr, the rectangular micro QR (ISO/IEC 23941) for narrow label stock such as cable labels and test tubes, with EC levels M and H only; datamatrix (ECC 200, ISO/IEC 16022) for industrial part and label marking; aztec (ISO/IEC 24778) for boarding passes and transit tickets; pdf417 (ISO/IEC 15438) for ID cards and shipping labels; code128 (ISO/IEC 15417), a 1D barcode for SKUs and tracking numbers; code39 (ISO/IEC 16388), the older barcode of inventory and badge systems; or the retail barcodes ean13, upca and isbn (ISO/IEC 15420), which take the product or book number and add the check digit": "Simbología: qr (por defecto); rmqr, el micro QR rectangular (ISO/IEC 23941) para etiquetas angostas como las de cables y tubos de ensayo, solo con los niveles de corrección M y H; datamatrix (ECC 200, ISO/IEC 16022) para el marcado industrial de piezas y etiquetas; aztec (ISO/IEC 24778) para pasajes de avión y boletos de transporte; pdf417 (ISO/IEC 15438) para documentos de identidad y etiquetas de envío; code128 (ISO/IEC 15417), un código de barras lineal para SKU y números de seguimiento; code39 (ISO/IEC 16388), el código de barras más viejo de los sistemas de inventario y credenciales; o los códigos de productos ean13, upca e isbn (ISO/IEC 15420), que toman el número del producto o del libro y agregan el dígito de control",
+	"Symbol shape. With --symbol rmqr: R7x43 to R17x139 (height x width in modules), a height such as R13 to take the narrowest width that fits, or auto (default, the smallest area). With --symbol datamatrix: square (default), rectangle (8x18 to 16x48) or a fixed size such as 16x48":                                                                                                                                                                                                                                                                                                                                                         "Forma del símbolo. Con --symbol rmqr: R7x43 a R17x139 (alto x ancho en módulos), un alto como R13 para tomar el ancho más angosto que alcance, o auto (por defecto, la de menor superficie). Con --symbol datamatrix: square (por defecto, cuadrado), rectangle (rectangular, 8x18 a 16x48) o un tamaño fijo como 16x48",
+	"--split cannot be combined with --symbol %s": "--split no se puede combinar con --symbol %s",
+	"rMQR %s, EC level %s":                        "rMQR %s, nivel de corrección %s",
+	"%s: rMQR %s, level %s":                       "%s: rMQR %s, nivel %s",
+	"Quiet zone around the code, in modules (0-40): the light margin scanners need to find the symbol; the standard asks for 4 (2 for rMQR and PDF417, 1 for Data Matrix, none for Aztec; 10 for Code 128 and Code 39, 11 for EAN-13 and ISBN and 9 for UPC-A, their defaults)": "Zona de silencio alrededor del código, en módulos (0-40): el margen claro que necesitan los lectores para encontrar el símbolo; el estándar pide 4 (2 en los rMQR y los PDF417, 1 en los Data Matrix, ninguna en los Aztec; 10 en los Code 128 y los Code 39, 11 en los EAN-13 y los ISBN y 9 en los UPC-A, sus valores por defecto)",
+	"Pixels per module (1-100) instead of -size: the image width follows the module count, so every module has the same whole number of pixels and the edges stay crisp":                                                                                                        "Píxeles por módulo (1-100) en lugar de -size: el ancho de la imagen sigue la cantidad de módulos, así cada módulo tiene la misma cantidad entera de píxeles y los bordes quedan nítidos",
+	"--scale sets the width from the module count; it cannot be combined with -size":                                                                    "--scale fija el ancho según la cantidad de módulos; no se puede combinar con -size",
+	"output: %s (format %s, %spx per module)":                                                                                                           "salida: %s (formato %s, %spx por módulo)",
+	"Printed width of the code with the quiet zone, such as 30mm or 1.5in, instead of -size: the pixel width follows from --dpi":                        "Ancho impreso del código con la zona de silencio, como 30mm o 1.5in, en lugar de -size: el ancho en píxeles sale de --dpi",
+	"Print resolution in dots per inch, recorded in PNG, JPEG, TIFF, SVG and PDF outputs so they print at the intended size (default 300 with --width)": "Resolución de impresión en puntos por pulgada, guardada en las salidas PNG, JPEG, TIFF, SVG y PDF para que se impriman al tamaño buscado (por defecto 300 con --width)",
+	"--width sets the size from the printed width; it cannot be combined with -size":                                                                    "--width fija el tamaño según el ancho impreso; no se puede combinar con -size",
+	"--width cannot be combined with --scale":                                                                                                           "--width no se puede combinar con --scale",
+	"output: %s (format %s, %s wide)":                                                                                                                   "salida: %s (formato %s, %s de ancho)",
+	"Data Matrix %s (ECC 200)":                                                                                                                          "Data Matrix %s (ECC 200)",
+	"%s: Data Matrix %s":                                                                                                                                "%s: Data Matrix %s",
+	"Aztec %s":                                                                                                                                          "Aztec %s",
+	"%s: Aztec %s":                                                                                                                                      "%s: Aztec %s",
+	"PDF417 %s (columns x rows), security level %s":                                                                                                     "PDF417 %s (columnas x filas), nivel de seguridad %s",
+	"%s: PDF417 %s (columns x rows), security level %d":                                                                                                 "%s: PDF417 %s (columnas x filas), nivel de seguridad %d",
+	"Code 128, %d symbol characters, %d modules wide":                                                                                                   "Code 128, %d caracteres de símbolo, %d módulos de ancho",
+	"Code 39, %d characters, %d modules wide":                                                                                                           "Code 39, %d caracteres, %d módulos de ancho",
+	"%s: Code 39, %d characters":                                                                                                                        "%s: Code 39, %d caracteres",
+	"With --symbol code39: append the mod-43 check character that some readers require":                                                                 "Con --symbol code39: agregar el carácter de control módulo 43 que piden algunos lectores",
+	"%s: Code 128, %d symbol characters":                                                                                                                "%s: Code 128, %d caracteres de símbolo",
+	"%s barcode, %d modules wide with guard bars":                                                                                                       "código %s, %d módulos de ancho con las guardas",
+	"%s: %s barcode": "%s: código %s",
+	"With --symbol code128 or code39: print the payload in human-readable text below the bars (ean13, upca and isbn always print their digits)":                                    "Con --symbol code128 o code39: escribir el payload en texto legible debajo de las barras (ean13, upca e isbn siempre escriben sus dígitos)",
+	"With --symbol pdf417: data columns, 1-30 (default: chosen for a symbol about three times wider than tall)":                                                                    "Con --symbol pdf417: columnas de datos, 1-30 (por defecto: las que dejan el símbolo unas tres veces más ancho que alto)",
+	"With --symbol pdf417: rows, 3-90 (default: as many as the payload needs)":                                                                                                     "Con --symbol pdf417: filas, 3-90 (por defecto: las que necesite el payload)",
+	"With --symbol pdf417: minimum error correction as a percentage of the data codewords, such as 25% (default: the security level the standard recommends for the payload size)": "Con --symbol pdf417: corrección mínima como porcentaje de las codewords de datos, como 25% (por defecto: el nivel de seguridad que recomienda el estándar para el tamaño del payload)",
+	"Write a progressive JPEG":                                                      "Escribir un JPEG progresivo",
+	"Open the generated file in the default viewer":                                 "Abrir el archivo generado en el visor predeterminado",
+	"YAML or JSON file with flag values; flags on the command line take precedence": "Archivo YAML o JSON con valores de los flags; los flags de la línea de comandos tienen prioridad",
+	"Comma-separated files to watch; regenerate the output whenever one changes. A .yaml/.yml/.json file is also used as --config": "Archivos a vigilar, separados por comas; regenera la salida cuando alguno cambia. Un archivo .yaml/.yml/.json también se usa como --config",
+	"%s: a config file cannot set config":                                    "%s: un archivo de configuración no puede definir config",
+	"payload: %q (%d bytes)":                                                 "contenido: %q (%d bytes)",
+	"output: %s (format %s, size %dpx)":                                      "salida: %s (formato %s, tamaño %dpx)",
+	"QR version %d (%dx%d modules), EC level %s, mask %d":                    "QR versión %d (%dx%d módulos), nivel de corrección %s, máscara %d",
+	"large output: pixels rendered on demand from the module matrix":         "salida grande: píxeles generados a demanda desde la matriz de módulos",
+	"encode %s, write %s":                                                    "codificación %s, escritura %s",
+	"QR written to %s":                                                       "QR escrito en %s",
+	"watching %s (Ctrl+C to stop)":                                           "vigilando %s (Ctrl+C para terminar)",
+	"%s changed, regenerating":                                               "%s cambió, regenerando",
+	"Only print errors":                                                      "Mostrar solo errores",
+	"Print QR version, mask, timings and file paths":                         "Mostrar versión del QR, máscara, tiempos y rutas de archivos",
+	"Message language: en or es (default from LC_ALL, LC_MESSAGES or LANG)":  "Idioma de los mensajes: en o es (por defecto según LC_ALL, LC_MESSAGES o LANG)",
+	"Usage of %s:\n":                                                         "Uso de %s:\n",
+	"Write the generated files to this directory instead of a temporary one": "Escribir los archivos generados en este directorio en lugar de uno temporal",
+	"payload type %s has no selftest case":                                   "el tipo de payload %s no tiene caso en selftest",
+	"selftest failed: %d of %d checks":                                       "selftest falló: %d de %d verificaciones",
+	"selftest passed: %d checks":                                             "selftest aprobado: %d verificaciones",
+	"skipping %s: %v":                                                        "se omite %s: %v",
+	"Time between checks":                                                    "Tiempo entre revisiones",
+	"HTTP timeout for images, destinations and webhook":                      "Timeout HTTP para imágenes, destinos y webhook",
+	"URL that receives a JSON POST when a code starts failing or recovers":   "URL que recibe un POST JSON cuando un código empieza a fallar o se recupera",
+	"File with one image path or URL per line":                               "Archivo con una ruta o URL de imagen por línea",
+	"Check every target once and exit":                                       "Revisar cada objetivo una vez y salir",
+	"Usage: qrgenerator_cli monitor [flags] image-or-url...\n":               "Uso: qrgenerator_cli monitor [flags] imagen-o-url...\n",
+	"no targets to monitor":                                                  "no hay objetivos para monitorear",
+	"--interval must be positive":                                            "--interval debe ser positivo",
+	"monitoring %d targets every %s":                                         "monitoreando %d objetivos cada %s",
+	"warning: ":                                                              "advertencia: ",
+}
@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Lang es un idioma de mensajes
+type Lang string
+
+// Idiomas soportados. Los mensajes del código fuente están en inglés; el
+// resto de los idiomas se traduce con su catálogo.
+const (
+	English Lang = "en"
+	Spanish Lang = "es"
+)
+
+// catalogs asocia cada idioma con sus traducciones, indexadas por el mensaje en inglés
+var catalogs = map[Lang]map[string]string{
+	English: {},
+	Spanish: spanish,
+}
+
+var current atomic.Value
+
+func init() {
+	current.Store(English)
+}
+
+// Langs devuelve los idiomas con catálogo
+func Langs() []Lang {
+	return []Lang{English, Spanish}
+}
+
+// Parse interpreta un código de idioma o un locale POSIX (es_AR.UTF-8, es-419)
+func Parse(value string) (Lang, bool) {
+	code := strings.ToLower(value)
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	lang := Lang(code)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Detect elige el idioma: primero el valor explícito (--lang) y después las
+// variables LC_ALL, LC_MESSAGES y LANG, en el orden de prioridad de POSIX.
+// Un locale desconocido o C/POSIX cae en inglés.
+func Detect(explicit string) Lang {
+	if explicit != "" {
+		if lang, ok := Parse(explicit); ok {
+			return lang
+		}
+		return English
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if lang, ok := Parse(value); ok {
+			return lang
+		}
+		return English
+	}
+	return English
+}
+
+// Set cambia el idioma de los mensajes
+func Set(lang Lang) {
+	if _, ok := catalogs[lang]; !ok {
+		lang = English
+	}
+	current.Store(lang)
+}
+
+// Current devuelve el idioma activo
+func Current() Lang {
+	return current.Load().(Lang)
+}
+
+// FromArgs busca --lang entre los argumentos sin parsear el resto, para poder
+// traducir la ayuda de los flags antes de registrarlos
+func FromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "lang" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// T traduce un mensaje al idioma activo; sin traducción devuelve el original
+func T(msg string) string {
+	if translated, ok := catalogs[Current()][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf formatea un mensaje traducido
+func Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf crea un error con el mensaje traducido; %w se conserva
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// message es un error cuyo texto se traduce al mostrarlo, así los errores
+// centinela creados al iniciar respetan el idioma elegido después
+type message string
+
+func (m message) Error() string {
+	return T(string(m))
+}
+
+// NewError crea un error comparable con errors.Is cuyo texto se traduce al mostrarlo
+func NewError(msg string) error {
+	return message(msg)
+}
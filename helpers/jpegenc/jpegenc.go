@@ -0,0 +1,454 @@
+// Package jpegenc es un encoder JPEG que, a diferencia de image/jpeg, permite
+// elegir el submuestreo de croma y la codificación progresiva.
+package jpegenc
+
+import (
+	"bufio"
+	"image"
+	"image/color"
+	"io"
+	"math"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Subsampling es el submuestreo de croma
+type Subsampling string
+
+// Submuestreos soportados
+const (
+	Subsampling444 Subsampling = "444" // Sin submuestreo: bordes nítidos
+	Subsampling422 Subsampling = "422" // Croma a la mitad en horizontal
+	Subsampling420 Subsampling = "420" // Croma a la mitad en ambos ejes (el de image/jpeg)
+)
+
+// factors devuelve los factores de muestreo de luminancia (horizontal, vertical)
+func (s Subsampling) factors() (int, int, error) {
+	switch s {
+	case Subsampling444, "":
+		return 1, 1, nil
+	case Subsampling422:
+		return 2, 1, nil
+	case Subsampling420:
+		return 2, 2, nil
+	default:
+		return 0, 0, i18n.Errorf("unsupported subsampling: %s (444, 422 or 420)", s)
+	}
+}
+
+// Options son los parámetros de codificación
+type Options struct {
+	Quality     int         // 1-100
+	Subsampling Subsampling // Por defecto 4:4:4
+	Progressive bool        // Codificación progresiva por selección espectral
+	DPI         int         // Resolución declarada en el encabezado JFIF; 0 no lo escribe
+}
+
+// DefaultQuality es la calidad usada si Options.Quality es 0
+const DefaultQuality = 90
+
+// component describe una componente de color dentro del MCU
+type component struct {
+	h, v    int // Factores de muestreo
+	quant   int // Tabla de cuantización
+	huffDC  int
+	huffAC  int
+	blocksX int // Bloques por fila en la grilla completa de MCUs
+	blocksY int
+}
+
+// encoder mantiene el estado de escritura
+type encoder struct {
+	w     *bufio.Writer
+	err   error
+	bits  uint32
+	nBits uint
+	quant [2][64]float64 // Divisores en orden zigzag
+	qraw  [2][64]byte
+	comps [3]component
+	hMax  int
+	vMax  int
+	width int
+	hgt   int
+	pixel func(x, y int) (uint8, uint8, uint8)
+}
+
+// Encode escribe m como JPEG
+func Encode(w io.Writer, m image.Image, o *Options) error {
+	opts := Options{Quality: DefaultQuality, Subsampling: Subsampling444}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Quality == 0 {
+		opts.Quality = DefaultQuality
+	}
+	opts.Quality = min(max(opts.Quality, 1), 100)
+
+	b := m.Bounds()
+	if b.Dx() < 1 || b.Dy() < 1 || b.Dx() > 65535 || b.Dy() > 65535 {
+		return i18n.NewError("invalid image dimensions for JPEG")
+	}
+	hMax, vMax, err := opts.Subsampling.factors()
+	if err != nil {
+		return err
+	}
+
+	e := &encoder{w: bufio.NewWriter(w), hMax: hMax, vMax: vMax, width: b.Dx(), hgt: b.Dy(), pixel: pixelSource(m)}
+	e.setQuality(opts.Quality)
+
+	mcusX := (e.width + 8*hMax - 1) / (8 * hMax)
+	mcusY := (e.hgt + 8*vMax - 1) / (8 * vMax)
+	e.comps = [3]component{
+		{h: hMax, v: vMax, quant: 0, huffDC: huffLumaDC, huffAC: huffLumaAC},
+		{h: 1, v: 1, quant: 1, huffDC: huffChromaDC, huffAC: huffChromaAC},
+		{h: 1, v: 1, quant: 1, huffDC: huffChromaDC, huffAC: huffChromaAC},
+	}
+	for i := range e.comps {
+		e.comps[i].blocksX = mcusX * e.comps[i].h
+		e.comps[i].blocksY = mcusY * e.comps[i].v
+	}
+
+	e.write([]byte{0xff, 0xd8}) // SOI
+	if opts.DPI > 0 {
+		e.writeJFIF(opts.DPI)
+	}
+	e.writeDQT()
+	e.writeSOF(opts.Progressive)
+	e.writeDHT()
+	if opts.Progressive {
+		e.writeProgressive(mcusX, mcusY)
+	} else {
+		e.writeBaseline(mcusX, mcusY)
+	}
+	e.write([]byte{0xff, 0xd9}) // EOI
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// pixelSource devuelve una función que lee el píxel en YCbCr; para imágenes con
+// paleta convierte cada color una sola vez
+func pixelSource(m image.Image) func(x, y int) (uint8, uint8, uint8) {
+	b := m.Bounds()
+	convert := func(c color.Color) [3]uint8 {
+		r, g, bl, _ := c.RGBA()
+		y, cb, cr := color.RGBToYCbCr(uint8(r>>8), uint8(g>>8), uint8(bl>>8))
+		return [3]uint8{y, cb, cr}
+	}
+	if p, ok := m.(image.PalettedImage); ok {
+		if palette, ok := m.ColorModel().(color.Palette); ok {
+			colors := make([][3]uint8, len(palette))
+			for i, c := range palette {
+				colors[i] = convert(c)
+			}
+			return func(x, y int) (uint8, uint8, uint8) {
+				c := colors[p.ColorIndexAt(b.Min.X+x, b.Min.Y+y)]
+				return c[0], c[1], c[2]
+			}
+		}
+	}
+	return func(x, y int) (uint8, uint8, uint8) {
+		c := convert(m.At(b.Min.X+x, b.Min.Y+y))
+		return c[0], c[1], c[2]
+	}
+}
+
+// setQuality escala las tablas base como hace libjpeg
+func (e *encoder) setQuality(quality int) {
+	scale := 200 - 2*quality
+	if quality < 50 {
+		scale = 5000 / quality
+	}
+	for t := range baseQuant {
+		for i, q := range baseQuant[t] {
+			v := min(max((int(q)*scale+50)/100, 1), 255)
+			e.qraw[t][i] = byte(v)
+			e.quant[t][i] = float64(v)
+		}
+	}
+}
+
+// write escribe bytes crudos guardando el primer error
+func (e *encoder) write(p []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(p)
+	}
+}
+
+// writeMarker escribe un marcador con su longitud
+func (e *encoder) writeMarker(marker byte, length int) {
+	e.write([]byte{0xff, marker, byte((length + 2) >> 8), byte(length + 2)})
+}
+
+// writeJFIF escribe el encabezado JFIF (APP0) con la resolución en puntos
+// por pulgada y sin miniatura
+func (e *encoder) writeJFIF(dpi int) {
+	e.writeMarker(0xe0, 14)
+	e.write([]byte{'J', 'F', 'I', 'F', 0, 1, 1, 1, byte(dpi >> 8), byte(dpi), byte(dpi >> 8), byte(dpi), 0, 0})
+}
+
+// writeDQT escribe las dos tablas de cuantización
+func (e *encoder) writeDQT() {
+	e.writeMarker(0xdb, 2*65)
+	for t := range e.qraw {
+		e.write([]byte{byte(t)})
+		e.write(e.qraw[t][:])
+	}
+}
+
+// writeSOF escribe el encabezado de cuadro baseline (SOF0) o progresivo (SOF2)
+func (e *encoder) writeSOF(progressive bool) {
+	marker := byte(0xc0)
+	if progressive {
+		marker = 0xc2
+	}
+	e.writeMarker(marker, 6+3*len(e.comps))
+	e.write([]byte{8, byte(e.hgt >> 8), byte(e.hgt), byte(e.width >> 8), byte(e.width), byte(len(e.comps))})
+	for i, c := range e.comps {
+		e.write([]byte{byte(i + 1), byte(c.h<<4 | c.v), byte(c.quant)})
+	}
+}
+
+// writeDHT escribe las cuatro tablas de Huffman estándar
+func (e *encoder) writeDHT() {
+	length := 0
+	for _, s := range standardHuffman {
+		length += 17 + len(s.values)
+	}
+	e.writeMarker(0xc4, length)
+	classes := [4]byte{0x00, 0x10, 0x01, 0x11}
+	for t, s := range standardHuffman {
+		e.write([]byte{classes[t]})
+		e.write(s.count[:])
+		e.write(s.values)
+	}
+}
+
+// writeSOS escribe el encabezado de un scan
+func (e *encoder) writeSOS(comps []int, ss, se int) {
+	e.writeMarker(0xda, 4+2*len(comps))
+	e.write([]byte{byte(len(comps))})
+	for _, i := range comps {
+		c := e.comps[i]
+		e.write([]byte{byte(i + 1), byte(c.huffDC/2<<4 | c.huffAC/2)})
+	}
+	e.write([]byte{byte(ss), byte(se), 0})
+}
+
+// emit agrega nBits bits al flujo, con relleno de bytes 0xff
+func (e *encoder) emit(bits uint32, n uint) {
+	e.bits = e.bits<<n | bits&(1<<n-1)
+	e.nBits += n
+	for e.nBits >= 8 {
+		b := byte(e.bits >> (e.nBits - 8))
+		e.write([]byte{b})
+		if b == 0xff {
+			e.write([]byte{0})
+		}
+		e.nBits -= 8
+	}
+}
+
+// flushBits completa el último byte del scan con unos
+func (e *encoder) flushBits() {
+	if e.nBits > 0 {
+		e.emit(0x7f, 8-e.nBits)
+	}
+	e.bits, e.nBits = 0, 0
+}
+
+// emitHuff escribe un símbolo con la tabla indicada
+func (e *encoder) emitHuff(table int, symbol byte) {
+	c := huffmanCodes[table][symbol]
+	e.emit(c.bits, uint(c.size))
+}
+
+// emitValue escribe la categoría (si table >= 0, con run para AC) y los bits del valor
+func (e *encoder) emitValue(table int, run int, value int32) {
+	a, bits := value, value
+	if a < 0 {
+		a, bits = -value, value-1
+	}
+	size := 0
+	for v := a; v > 0; v >>= 1 {
+		size++
+	}
+	e.emitHuff(table, byte(run<<4|size))
+	if size > 0 {
+		e.emit(uint32(bits), uint(size))
+	}
+}
+
+// encodeDC escribe la diferencia de DC y devuelve el nuevo valor previo
+func (e *encoder) encodeDC(table int, coefs *[64]int32, prev int32) int32 {
+	e.emitValue(table, 0, coefs[0]-prev)
+	return coefs[0]
+}
+
+// encodeAC escribe los coeficientes ss..se con codificación por corridas de ceros
+func (e *encoder) encodeAC(table int, coefs *[64]int32, ss, se int) {
+	run := 0
+	for k := ss; k <= se; k++ {
+		if coefs[k] == 0 {
+			run++
+			continue
+		}
+		for run > 15 {
+			e.emitHuff(table, 0xf0) // ZRL
+			run -= 16
+		}
+		e.emitValue(table, run, coefs[k])
+		run = 0
+	}
+	if run > 0 {
+		e.emitHuff(table, 0x00) // EOB
+	}
+}
+
+// block calcula los coeficientes cuantizados (en zigzag) del bloque 8x8 de una
+// componente cuya esquina en muestras de la componente es (bx, by)
+func (e *encoder) block(comp int, bx, by int, coefs *[64]int32) {
+	c := e.comps[comp]
+	sx, sy := e.hMax/c.h, e.vMax/c.v // Píxeles por muestra
+	var samples [64]float64
+	uniform := true
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			sum := 0
+			for dy := 0; dy < sy; dy++ {
+				for dx := 0; dx < sx; dx++ {
+					px := min((bx+x)*sx+dx, e.width-1)
+					py := min((by+y)*sy+dy, e.hgt-1)
+					yy, cb, cr := e.pixel(px, py)
+					sum += int([3]uint8{yy, cb, cr}[comp])
+				}
+			}
+			samples[y*8+x] = float64(sum)/float64(sx*sy) - 128
+			if samples[y*8+x] != samples[0] {
+				uniform = false
+			}
+		}
+	}
+
+	var dct [64]float64
+	if uniform {
+		// Un bloque liso solo tiene componente continua
+		dct[0] = 8 * samples[0]
+	} else {
+		fdct(&samples, &dct)
+	}
+	for k := 0; k < 64; k++ {
+		coefs[k] = int32(math.Round(dct[unzig[k]] / e.quant[c.quant][k]))
+	}
+}
+
+// writeBaseline escribe un único scan entrelazado, MCU por MCU
+func (e *encoder) writeBaseline(mcusX, mcusY int) {
+	e.writeSOS([]int{0, 1, 2}, 0, 63)
+	var prev [3]int32
+	var coefs [64]int32
+	for my := 0; my < mcusY; my++ {
+		for mx := 0; mx < mcusX; mx++ {
+			for i, c := range e.comps {
+				for v := 0; v < c.v; v++ {
+					for h := 0; h < c.h; h++ {
+						e.block(i, (mx*c.h+h)*8, (my*c.v+v)*8, &coefs)
+						prev[i] = e.encodeDC(c.huffDC, &coefs, prev[i])
+						e.encodeAC(c.huffAC, &coefs, 1, 63)
+					}
+				}
+			}
+		}
+	}
+	e.flushBits()
+}
+
+// writeProgressive calcula todos los coeficientes y los escribe en scans por
+// selección espectral: primero los DC de todas las componentes y luego las
+// bandas AC de cada una, de baja a alta frecuencia
+func (e *encoder) writeProgressive(mcusX, mcusY int) {
+	coefs := make([][][64]int32, len(e.comps))
+	for i, c := range e.comps {
+		coefs[i] = make([][64]int32, c.blocksX*c.blocksY)
+		for by := 0; by < c.blocksY; by++ {
+			for bx := 0; bx < c.blocksX; bx++ {
+				e.block(i, bx*8, by*8, &coefs[i][by*c.blocksX+bx])
+			}
+		}
+	}
+
+	// Scan DC entrelazado
+	e.writeSOS([]int{0, 1, 2}, 0, 0)
+	var prev [3]int32
+	for my := 0; my < mcusY; my++ {
+		for mx := 0; mx < mcusX; mx++ {
+			for i, c := range e.comps {
+				for v := 0; v < c.v; v++ {
+					for h := 0; h < c.h; h++ {
+						block := &coefs[i][(my*c.v+v)*c.blocksX+mx*c.h+h]
+						prev[i] = e.encodeDC(c.huffDC, block, prev[i])
+					}
+				}
+			}
+		}
+	}
+	e.flushBits()
+
+	// Scans AC no entrelazados: solo cubren los bloques con píxeles reales
+	bands := []struct{ comp, ss, se int }{{0, 1, 5}, {1, 1, 63}, {2, 1, 63}, {0, 6, 63}}
+	for _, band := range bands {
+		c := e.comps[band.comp]
+		w := ((e.width*c.h+e.hMax-1)/e.hMax + 7) / 8
+		h := ((e.hgt*c.v+e.vMax-1)/e.vMax + 7) / 8
+		e.writeSOS([]int{band.comp}, band.ss, band.se)
+		for by := 0; by < h; by++ {
+			for bx := 0; bx < w; bx++ {
+				e.encodeAC(c.huffAC, &coefs[band.comp][by*c.blocksX+bx], band.ss, band.se)
+			}
+		}
+		e.flushBits()
+	}
+}
+
+// cosTable[x][u] = cos((2x+1)uπ/16)
+var cosTable [8][8]float64
+
+func init() {
+	for x := 0; x < 8; x++ {
+		for u := 0; u < 8; u++ {
+			cosTable[x][u] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / 16)
+		}
+	}
+}
+
+// fdct calcula la DCT 2D separable de un bloque (orden natural)
+func fdct(in, out *[64]float64) {
+	var tmp [64]float64
+	for y := 0; y < 8; y++ {
+		for u := 0; u < 8; u++ {
+			s := 0.0
+			for x := 0; x < 8; x++ {
+				s += in[y*8+x] * cosTable[x][u]
+			}
+			tmp[y*8+u] = s
+		}
+	}
+	for u := 0; u < 8; u++ {
+		for v := 0; v < 8; v++ {
+			s := 0.0
+			for y := 0; y < 8; y++ {
+				s += tmp[y*8+u] * cosTable[y][v]
+			}
+			cu, cv := 1.0, 1.0
+			if u == 0 {
+				cu = math.Sqrt2 / 2
+			}
+			if v == 0 {
+				cv = math.Sqrt2 / 2
+			}
+			out[v*8+u] = s * cu * cv / 4
+		}
+	}
+}
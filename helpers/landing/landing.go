@@ -0,0 +1,136 @@
+package landing
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Link es el destino para un idioma
+type Link struct {
+	Lang  string // Etiqueta BCP 47: es, pt-BR...
+	URL   string
+	Label string // Nombre del idioma en ese idioma ("Español"), para el botón
+}
+
+// Page es la página de aterrizaje: redirige al idioma del navegador y, si no
+// hay uno que coincida (o sin JavaScript), muestra un botón por idioma
+type Page struct {
+	Title string
+	Links []Link // El primero es el idioma por defecto
+}
+
+// ParseLinks interpreta una lista "es=https://...,en=https://..."; el orden se
+// conserva y el primero es el idioma por defecto
+func ParseLinks(list string) ([]Link, error) {
+	var links []Link
+	seen := map[string]bool{}
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		lang, target, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, i18n.Errorf("invalid link %q; use lang=URL, e.g. es=https://example.com/es", item)
+		}
+		tag, err := language.Parse(strings.TrimSpace(lang))
+		if err != nil {
+			return nil, i18n.Errorf("unknown language %q: %w", lang, err)
+		}
+		target = strings.TrimSpace(target)
+		if u, err := url.Parse(target); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, i18n.Errorf("invalid URL for %s: %q", tag, target)
+		}
+		if seen[tag.String()] {
+			return nil, i18n.Errorf("language %s is listed more than once", tag)
+		}
+		seen[tag.String()] = true
+		label := display.Self.Name(tag)
+		if label == "" {
+			label = tag.String()
+		}
+		// Los nombres propios de algunos idiomas empiezan en minúscula ("español")
+		first, size := utf8.DecodeRuneInString(label)
+		label = string(unicode.ToUpper(first)) + label[size:]
+		links = append(links, Link{Lang: tag.String(), URL: target, Label: label})
+	}
+	if len(links) == 0 {
+		return nil, i18n.Errorf("no links; use lang=URL pairs, e.g. es=https://example.com/es,en=https://example.com/en")
+	}
+	return links, nil
+}
+
+// pageTemplate es la página: html/template escapa los textos y arma el JSON
+// de los enlaces para el script
+var pageTemplate = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html lang="{{(index .Links 0).Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<script>
+(function () {
+  // #choose muestra los botones sin redirigir
+  if (location.hash === "#choose") return;
+  var links = {{.Map}};
+  var prefs = navigator.languages || [navigator.language || ""];
+  for (var i = 0; i < prefs.length; i++) {
+    var lang = prefs[i].toLowerCase();
+    var target = links[lang] || links[lang.split("-")[0]];
+    if (target) { location.replace(target); return; }
+  }
+})();
+</script>
+<style>
+body{font-family:system-ui,sans-serif;margin:0;min-height:100vh;display:flex;flex-direction:column;align-items:center;justify-content:center;gap:12px;background:#fafafa}
+h1{font-size:1.3em;margin:0 0 8px}
+a{display:block;min-width:220px;padding:14px 20px;border-radius:8px;background:#111;color:#fff;text-align:center;text-decoration:none;font-size:1.1em}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Links}}<a href="{{.URL}}" hreflang="{{.Lang}}" lang="{{.Lang}}">{{.Label}}</a>
+{{end}}</body>
+</html>
+`))
+
+// Write escribe index.html en dir y devuelve su ruta
+func Write(dir string, page Page) (string, error) {
+	data := struct {
+		Page
+		Map map[string]string
+	}{Page: page, Map: map[string]string{}}
+	// Los navegadores informan "es-AR": se busca la etiqueta completa y después el idioma
+	for i := len(page.Links) - 1; i >= 0; i-- {
+		link := page.Links[i]
+		data.Map[strings.ToLower(link.Lang)] = link.URL
+		if base, _, ok := strings.Cut(strings.ToLower(link.Lang), "-"); ok {
+			data.Map[base] = link.URL
+		}
+	}
+	for _, link := range page.Links {
+		// Una etiqueta exacta gana siempre sobre el idioma base de otra
+		data.Map[strings.ToLower(link.Lang)] = link.URL
+	}
+
+	var buf bytes.Buffer
+	if err := pageTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "index.html")
+	return path, os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Level define el nivel de detalle de los mensajes
+type Level int
+
+// Niveles soportados, de menor a mayor detalle
+const (
+	LevelError Level = iota // Solo errores (--quiet)
+	LevelInfo               // Mensajes normales
+	LevelDebug              // Diagnóstico detallado (--verbose)
+)
+
+// Logger escribe mensajes filtrando por nivel. Los mensajes informativos van
+// a out; los errores y el diagnóstico van a errOut para no mezclarse con la salida.
+// Los formatos se traducen al idioma activo de i18n.
+type Logger struct {
+	out    io.Writer
+	errOut io.Writer
+	level  Level
+}
+
+// New crea un logger con las salidas y el nivel indicados
+func New(out, errOut io.Writer, level Level) *Logger {
+	return &Logger{out: out, errOut: errOut, level: level}
+}
+
+// Default crea un logger sobre stdout/stderr
+func Default(level Level) *Logger {
+	return New(os.Stdout, os.Stderr, level)
+}
+
+// Level devuelve el nivel configurado
+func (l *Logger) Level() Level {
+	return l.level
+}
+
+// Errorf escribe un error; se muestra siempre
+func (l *Logger) Errorf(format string, args ...any) {
+	fmt.Fprintf(l.errOut, "error: "+i18n.T(format)+"\n", args...)
+}
+
+// Warnf escribe una advertencia salvo en modo silencioso
+func (l *Logger) Warnf(format string, args ...any) {
+	if l.level >= LevelInfo {
+		fmt.Fprintf(l.errOut, i18n.T("warning: ")+i18n.T(format)+"\n", args...)
+	}
+}
+
+// Infof escribe un mensaje informativo salvo en modo silencioso
+func (l *Logger) Infof(format string, args ...any) {
+	if l.level >= LevelInfo {
+		fmt.Fprintf(l.out, i18n.T(format)+"\n", args...)
+	}
+}
+
+// Debugf escribe un mensaje de diagnóstico solo en modo detallado
+func (l *Logger) Debugf(format string, args ...any) {
+	if l.level >= LevelDebug {
+		fmt.Fprintf(l.errOut, "debug: "+i18n.T(format)+"\n", args...)
+	}
+}
@@ -0,0 +1,219 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// State es el resultado de revisar un código publicado
+type State string
+
+// Estados posibles de una revisión
+const (
+	StateHealthy    State = "healthy"    // El código se lee y su destino responde
+	StateUnreadable State = "unreadable" // No se pudo obtener o decodificar la imagen
+	StateBroken     State = "broken"     // El código se lee pero su destino falla
+)
+
+// Status describe una revisión de un código publicado
+type Status struct {
+	Source     string    `json:"source"`
+	State      State     `json:"state"`
+	Payload    string    `json:"payload,omitempty"`
+	HTTPStatus int       `json:"http_status,omitempty"`
+	Problem    string    `json:"problem,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// Alert es el cuerpo JSON que se envía al webhook cuando cambia el estado de un código
+type Alert struct {
+	Event    string `json:"event"` // "failing" o "recovered"
+	Previous State  `json:"previous,omitempty"`
+	Status
+}
+
+// Monitor revisa periódicamente imágenes QR publicadas (archivos locales o URLs)
+type Monitor struct {
+	Client  *http.Client
+	Webhook string // URL a la que se envían las alertas (opcional)
+	Log     *logger.Logger
+
+	last map[string]State
+}
+
+// New crea un monitor con un cliente HTTP con el timeout indicado
+func New(webhook string, timeout time.Duration, log *logger.Logger) *Monitor {
+	return &Monitor{
+		Client:  &http.Client{Timeout: timeout},
+		Webhook: webhook,
+		Log:     log,
+		last:    make(map[string]State),
+	}
+}
+
+// Check obtiene la imagen, la decodifica y, si el contenido es una URL http(s),
+// comprueba que el destino responda sin error
+func (m *Monitor) Check(ctx context.Context, source string) Status {
+	status := Status{Source: source, State: StateHealthy, CheckedAt: time.Now()}
+
+	result, err := m.decode(ctx, source)
+	if err != nil {
+		status.State, status.Problem = StateUnreadable, err.Error()
+		return status
+	}
+	status.Payload = result.Text
+
+	target, err := url.Parse(result.Text)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		// Payloads que no son URLs web (Wi-Fi, texto, etc.) solo se comprueban al leerlos
+		return status
+	}
+
+	code, err := m.probe(ctx, target.String())
+	status.HTTPStatus = code
+	switch {
+	case err != nil:
+		status.State, status.Problem = StateBroken, err.Error()
+	case code >= 400:
+		status.State, status.Problem = StateBroken, i18n.Sprintf("destination answered %d", code)
+	}
+	return status
+}
+
+// decode lee la imagen desde un archivo o una URL y la decodifica
+func (m *Monitor) decode(ctx context.Context, source string) (*qrcodec.Result, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return qrcodec.DecodeFile(source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, i18n.Errorf("image answered %d", resp.StatusCode)
+	}
+	return qrcodec.DecodeReader(resp.Body, req.URL.Path)
+}
+
+// probe consulta el destino con HEAD y, si el servidor no lo admite, con GET
+func (m *Monitor) probe(ctx context.Context, target string) (int, error) {
+	code := 0
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, target, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := m.Client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		code = resp.StatusCode
+		if code != http.StatusMethodNotAllowed && code != http.StatusNotImplemented {
+			break
+		}
+	}
+	return code, nil
+}
+
+// CheckAll revisa todas las fuentes, registra el resultado y alerta los cambios
+// de estado. Devuelve la cantidad de fuentes con problemas.
+func (m *Monitor) CheckAll(ctx context.Context, sources []string) int {
+	failing := 0
+	for _, source := range sources {
+		status := m.Check(ctx, source)
+		if status.State != StateHealthy {
+			failing++
+			m.Log.Infof("%s: %s (%s)", source, status.State, status.Problem)
+		} else {
+			m.Log.Debugf("%s: %s -> %q", source, status.State, status.Payload)
+		}
+
+		previous, seen := m.last[source]
+		m.last[source] = status.State
+		switch {
+		case status.State != StateHealthy && previous != status.State:
+			m.alert(ctx, Alert{Event: "failing", Previous: previous, Status: status})
+		case status.State == StateHealthy && seen && previous != StateHealthy:
+			m.alert(ctx, Alert{Event: "recovered", Previous: previous, Status: status})
+		}
+	}
+	return failing
+}
+
+// Run revisa las fuentes cada interval hasta que se cancele el contexto
+func (m *Monitor) Run(ctx context.Context, sources []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.CheckAll(ctx, sources)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// alert envía la alerta al webhook, si está configurado
+func (m *Monitor) alert(ctx context.Context, alert Alert) {
+	if m.Webhook == "" {
+		return
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		m.Log.Errorf("error serializing alert: %v", err)
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.Webhook, bytes.NewReader(body))
+	if err != nil {
+		m.Log.Errorf("invalid webhook: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		m.Log.Errorf("error sending alert: %v", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		m.Log.Errorf("webhook answered %d", resp.StatusCode)
+		return
+	}
+	m.Log.Debugf("%s alert sent for %s", alert.Event, alert.Source)
+}
+
+// ReadSources lee una lista de fuentes, una por línea, ignorando vacías y comentarios
+func ReadSources(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			sources = append(sources, line)
+		}
+	}
+	return sources, nil
+}
@@ -0,0 +1,249 @@
+package obs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// DefaultTimeout es el tiempo máximo para conectar y actualizar la fuente
+const DefaultTimeout = 10 * time.Second
+
+// defaultPort es el puerto de obs-websocket 5.x
+const defaultPort = "4455"
+
+// Códigos de operación del protocolo de obs-websocket 5.x
+const (
+	opHello           = 0
+	opIdentify        = 1
+	opIdentified      = 2
+	opRequest         = 6
+	opRequestResponse = 7
+)
+
+// rpcVersion es la versión del protocolo que habla el cliente
+const rpcVersion = 1
+
+// closeReasons son los códigos de cierre de obs-websocket que se explican
+var closeReasons = map[int]string{
+	4009: "authentication failed; check --obs-password",
+	4010: "unsupported obs-websocket version; OBS 28 or newer is needed",
+}
+
+// Target es la instancia de OBS y la fuente a actualizar
+type Target struct {
+	URL      *url.URL
+	Password string
+	Source   string // Nombre de la fuente de imagen o navegador
+}
+
+// ParseAddress interpreta la dirección de obs-websocket: host, host:puerto o
+// ws://host:puerto; el puerto por defecto es 4455
+func ParseAddress(addr string) (*url.URL, error) {
+	if !strings.Contains(addr, "://") {
+		addr = "ws://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, i18n.Errorf("invalid OBS address: %w", err)
+	}
+	if u.Scheme != "ws" {
+		return nil, i18n.Errorf("unsupported OBS address %q; use host:port or ws://host:port", addr)
+	}
+	if u.Hostname() == "" {
+		return nil, i18n.Errorf("OBS address %q has no host", addr)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, nil
+}
+
+// message es un mensaje del protocolo: el código de operación y sus datos
+type message struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d"`
+}
+
+// session es una conexión identificada con OBS
+type session struct {
+	conn *conn
+	next int
+}
+
+// UpdateSource apunta la fuente al archivo generado. Una fuente de imagen
+// recibe el archivo y se recarga; una de navegador lo recibe como archivo
+// local y se refresca sin caché, porque con la misma ruta OBS no la recargaría.
+func UpdateSource(ctx context.Context, target *Target, path string) error {
+	s, err := connect(ctx, target)
+	if err != nil {
+		return err
+	}
+	defer s.conn.close()
+
+	var current struct {
+		InputKind string `json:"inputKind"`
+	}
+	if err := s.request("GetInputSettings", map[string]any{"inputName": target.Source}, &current); err != nil {
+		return err
+	}
+	switch current.InputKind {
+	case "image_source":
+		return s.request("SetInputSettings", map[string]any{
+			"inputName":     target.Source,
+			"inputSettings": map[string]any{"file": path},
+		}, nil)
+	case "browser_source":
+		err := s.request("SetInputSettings", map[string]any{
+			"inputName":     target.Source,
+			"inputSettings": map[string]any{"is_local_file": true, "local_file": path},
+		}, nil)
+		if err != nil {
+			return err
+		}
+		return s.request("PressInputPropertiesButton", map[string]any{
+			"inputName":    target.Source,
+			"propertyName": "refreshnocache",
+		}, nil)
+	}
+	return i18n.Errorf("OBS source %q is a %s; use an image or browser source", target.Source, current.InputKind)
+}
+
+// connect abre la conexión y se identifica, autenticándose si OBS lo pide
+func connect(ctx context.Context, target *Target) (*session, error) {
+	c, err := dial(ctx, target.URL, "obswebsocket.json")
+	if err != nil {
+		return nil, err
+	}
+	s := &session{conn: c}
+
+	var hello struct {
+		Authentication *struct {
+			Challenge string `json:"challenge"`
+			Salt      string `json:"salt"`
+		} `json:"authentication"`
+	}
+	if err := s.receive(opHello, &hello); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	identify := map[string]any{"rpcVersion": rpcVersion, "eventSubscriptions": 0}
+	if auth := hello.Authentication; auth != nil {
+		if target.Password == "" {
+			c.Close()
+			return nil, i18n.Errorf("OBS requires a password; pass --obs-password")
+		}
+		identify["authentication"] = authResponse(target.Password, auth.Salt, auth.Challenge)
+	}
+	if err := s.send(opIdentify, identify); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if err := s.receive(opIdentified, nil); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// authResponse calcula la respuesta al desafío:
+// base64(sha256(base64(sha256(password + salt)) + challenge))
+func authResponse(password, salt, challenge string) string {
+	secret := sha256.Sum256([]byte(password + salt))
+	response := sha256.Sum256([]byte(base64.StdEncoding.EncodeToString(secret[:]) + challenge))
+	return base64.StdEncoding.EncodeToString(response[:])
+}
+
+// request envía un pedido y espera su respuesta; si result no es nil se
+// completa con los datos de la respuesta
+func (s *session) request(kind string, data map[string]any, result any) error {
+	s.next++
+	id := kind + "-" + strconv.Itoa(s.next)
+	if err := s.send(opRequest, map[string]any{"requestType": kind, "requestId": id, "requestData": data}); err != nil {
+		return err
+	}
+
+	var response struct {
+		RequestID     string `json:"requestId"`
+		RequestStatus struct {
+			Result  bool   `json:"result"`
+			Code    int    `json:"code"`
+			Comment string `json:"comment"`
+		} `json:"requestStatus"`
+		ResponseData json.RawMessage `json:"responseData"`
+	}
+	for response.RequestID != id {
+		if err := s.receive(opRequestResponse, &response); err != nil {
+			return err
+		}
+	}
+	if status := response.RequestStatus; !status.Result {
+		if status.Code == 600 { // ResourceNotFound
+			return i18n.Errorf("OBS has no source named %q", data["inputName"])
+		}
+		return i18n.Errorf("OBS rejected %s (%d): %s", kind, status.Code, status.Comment)
+	}
+	if result != nil && len(response.ResponseData) > 0 {
+		if err := json.Unmarshal(response.ResponseData, result); err != nil {
+			return i18n.Errorf("unexpected answer from OBS: %w", err)
+		}
+	}
+	return nil
+}
+
+// send envía un mensaje del protocolo
+func (s *session) send(op int, data any) error {
+	d, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(message{Op: op, D: d})
+	if err != nil {
+		return err
+	}
+	return s.conn.writeText(msg)
+}
+
+// receive espera el próximo mensaje con el código de operación indicado,
+// ignorando los demás; si data no es nil se completa con sus datos
+func (s *session) receive(op int, data any) error {
+	for {
+		raw, err := s.conn.readMessage()
+		if err != nil {
+			var closeErr *CloseError
+			if errors.As(err, &closeErr) {
+				if reason, ok := closeReasons[closeErr.Code]; ok {
+					return i18n.Errorf("OBS closed the connection: %s", i18n.T(reason))
+				}
+				return err
+			}
+			return i18n.Errorf("no answer from OBS: %w", err)
+		}
+		var msg message
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return i18n.Errorf("unexpected answer from OBS: %w", err)
+		}
+		if msg.Op != op {
+			continue
+		}
+		if data != nil {
+			if err := json.Unmarshal(msg.D, data); err != nil {
+				return i18n.Errorf("unexpected answer from OBS: %w", err)
+			}
+		}
+		return nil
+	}
+}
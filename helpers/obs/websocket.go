@@ -0,0 +1,185 @@
+package obs
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Códigos de operación de los frames de WebSocket (RFC 6455)
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// websocketGUID se concatena a la clave para calcular Sec-WebSocket-Accept
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessage es el tamaño máximo de mensaje aceptado; las respuestas de OBS son chicas
+const maxMessage = 16 << 20
+
+// conn es una conexión WebSocket de cliente, lo justo para hablar con obs-websocket
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// CloseError es el cierre de la conexión por parte del servidor, con su código
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return i18n.Sprintf("connection closed by OBS (%d %s)", e.Code, e.Reason)
+}
+
+// dial abre la conexión y hace el handshake de WebSocket con el subprotocolo indicado
+func dial(ctx context.Context, u *url.URL, protocol string) (*conn, error) {
+	var dialer net.Dialer
+	c, err := dialer.DialContext(ctx, "tcp", u.Host)
+	if err != nil {
+		return nil, i18n.Errorf("cannot connect to OBS at %s: %w", u.Host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		c.SetDeadline(deadline)
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    u,
+		Host:   u.Host,
+		Header: http.Header{
+			"Upgrade":                {"websocket"},
+			"Connection":             {"Upgrade"},
+			"Sec-WebSocket-Key":      {key},
+			"Sec-WebSocket-Version":  {"13"},
+			"Sec-WebSocket-Protocol": {protocol},
+		},
+	}
+	if err := req.Write(c); err != nil {
+		c.Close()
+		return nil, i18n.Errorf("error sending to OBS: %w", err)
+	}
+
+	r := bufio.NewReader(c)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		c.Close()
+		return nil, i18n.Errorf("no answer from OBS: %w", err)
+	}
+	resp.Body.Close()
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		c.Close()
+		return nil, i18n.Errorf("%s is not an obs-websocket server (HTTP %d)", u.Host, resp.StatusCode)
+	}
+	return &conn{Conn: c, r: r}, nil
+}
+
+// writeFrame escribe un frame completo; los del cliente van enmascarados
+func (c *conn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, 0x80|byte(n))
+	case n <= 0xffff:
+		header = append(header, 0x80|126, byte(n>>8), byte(n))
+	default:
+		header = binary.BigEndian.AppendUint64(append(header, 0x80|127), uint64(n))
+	}
+	mask := make([]byte, 4)
+	rand.Read(mask)
+	frame := append(header, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.Write(frame)
+	return err
+}
+
+// writeText envía un mensaje de texto
+func (c *conn) writeText(data []byte) error {
+	if err := c.writeFrame(opText, data); err != nil {
+		return i18n.Errorf("error sending to OBS: %w", err)
+	}
+	return nil
+}
+
+// readMessage lee el próximo mensaje de datos, uniendo sus fragmentos y
+// contestando los ping que lleguen en el medio
+func (c *conn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			return nil, err
+		}
+		fin, opcode := header[0]&0x80 != 0, header[0]&0x0f
+		n := uint64(header[1] & 0x7f)
+		switch n {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return nil, err
+			}
+			n = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return nil, err
+			}
+			n = binary.BigEndian.Uint64(ext[:])
+		}
+		if n > maxMessage || uint64(len(message))+n > maxMessage {
+			return nil, i18n.Errorf("OBS message too large")
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			closeErr := &CloseError{Code: 1005}
+			if len(payload) >= 2 {
+				closeErr.Code, closeErr.Reason = int(binary.BigEndian.Uint16(payload)), string(payload[2:])
+			}
+			return nil, closeErr
+		case opText, opBinary, opContinuation:
+			message = append(message, payload...)
+			if fin {
+				return message, nil
+			}
+		default:
+			return nil, i18n.Errorf("unexpected WebSocket frame %d from OBS", opcode)
+		}
+	}
+}
+
+// close envía el frame de cierre normal y cierra la conexión
+func (c *conn) close() {
+	c.writeFrame(opClose, []byte{0x03, 0xe8}) // 1000: cierre normal
+	c.Conn.Close()
+}
@@ -0,0 +1,82 @@
+package paperkey
+
+import (
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/pdf"
+)
+
+// Medidas de la hoja en puntos: dos QR por hoja y las instrucciones abajo
+const (
+	margin     = 40
+	qrSide     = 250
+	qrsPerPage = 2
+)
+
+// Backup describe lo que se imprime en cada hoja
+type Backup struct {
+	Name    string     // Nombre del archivo respaldado
+	Sum     string     // SHA-256 del archivo, en hexadecimal
+	Created time.Time  // Fecha del respaldo
+	Codes   [][][]bool // Módulos de cada QR, sin la zona de silencio
+
+	// Con Shamir: número de esta parte (desde 1), cantidad de partes y
+	// cuántas hacen falta para restaurar; Shares en 0 si no se repartió
+	Share, Shares, Threshold int
+}
+
+// Layout arma el PDF para imprimir: cada hoja lleva el encabezado, hasta dos
+// QR numerados y las instrucciones para restaurar, con o sin esta herramienta
+func Layout(backup Backup) *pdf.Document {
+	doc := &pdf.Document{}
+	pages := (len(backup.Codes) + qrsPerPage - 1) / qrsPerPage
+	width := pdf.A4Width - 2*margin
+
+	for p := 0; p < pages; p++ {
+		page := doc.AddPage()
+		y := pdf.A4Height - margin - 18
+		page.Text(margin, y, 18, true, i18n.T("Paper key backup"))
+		y -= 18
+		page.Text(margin, y, 9, false, i18n.Sprintf("File: %s - created %s", backup.Name, backup.Created.Format("2006-01-02")))
+		y -= 12
+		page.Text(margin, y, 9, false, i18n.Sprintf("SHA-256: %s", backup.Sum))
+		if backup.Shares > 0 {
+			page.Text(pdf.A4Width-margin-150, pdf.A4Height-margin-18, 12, true, i18n.Sprintf("Share %d of %d", backup.Share, backup.Shares))
+		}
+
+		for slot := 0; slot < qrsPerPage; slot++ {
+			i := p*qrsPerPage + slot
+			if i >= len(backup.Codes) {
+				break
+			}
+			bottom := y - 10 - float64(slot+1)*(qrSide+28)
+			page.Bitmap((pdf.A4Width-qrSide)/2, bottom+14, qrSide, backup.Codes[i])
+			page.Text((pdf.A4Width-qrSide)/2, bottom, 10, true, i18n.Sprintf("QR %d of %d", i+1, len(backup.Codes)))
+		}
+
+		y = 165
+		for _, paragraph := range instructions(backup) {
+			for _, line := range pdf.Wrap(paragraph, 8, width) {
+				page.Text(margin, y, 8, false, line)
+				y -= 10
+			}
+			y -= 4
+		}
+		page.Text(margin, margin/2, 8, false, i18n.Sprintf("Page %d of %d", p+1, pages))
+	}
+	return doc
+}
+
+// instructions son los párrafos para restaurar; el último describe el
+// formato para poder recuperar el archivo aunque esta herramienta ya no exista
+func instructions(backup Backup) []string {
+	var shares []string
+	if backup.Shares > 0 {
+		shares = append(shares, i18n.Sprintf("This page holds one of %d shares; any %d of them, scanned together and with the passphrase, restore the key, and fewer reveal nothing. Shares are QRSS1, a 4-byte backup ID, the threshold, the share number and the Shamir values over GF(256) (AES polynomial), one per byte of the envelope below.", backup.Shares, backup.Threshold))
+	}
+	return append(shares,
+		i18n.T("To restore, scan every QR of this backup flat and in good light (any order; repeats are fine) and run: qrgenerator_cli paperkey-restore -o FILE IMAGES... It asks for the passphrase chosen when the backup was made."),
+		i18n.T("Format, to restore without this tool: each QR holds QRF1:<n>/<total>:<SHA-256>:<CRC32>:<data in base45, RFC 9285>. Joining the data of QRs 1 to total gives QRPK1, a 16-byte salt, a 4-byte big-endian iteration count, a 12-byte nonce and the AES-256-GCM ciphertext, with \"QRPK1\" as additional data. The key is PBKDF2-HMAC-SHA256 of the passphrase and the salt. The plaintext is the file name length (2 bytes), the name and the file."),
+	)
+}
@@ -0,0 +1,94 @@
+package paperkey
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"path/filepath"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Magic encabeza el sobre cifrado y es su dato adicional autenticado. El
+// sobre es Magic + sal (16) + iteraciones (4, big endian) + nonce (12) +
+// AES-256-GCM del nombre (largo en 2 bytes y el nombre) seguido del archivo.
+const Magic = "QRPK1"
+
+// Parámetros del cifrado
+const (
+	Iterations    = 600_000 // PBKDF2-HMAC-SHA256, lo que recomienda OWASP
+	MinPassphrase = 12      // Caracteres mínimos de la frase de contraseña
+	MaxSecret     = 64 << 10
+	saltSize      = 16
+	nonceSize     = 12
+	headerSize    = len(Magic) + saltSize + 4 + nonceSize
+)
+
+// ErrPassphrase indica que la frase no abre el sobre (o que está dañado, ya
+// que GCM no distingue entre ambos casos)
+var ErrPassphrase = i18n.NewError("wrong passphrase or damaged backup")
+
+// Seal cifra el archivo con una clave derivada de la frase
+func Seal(name string, secret []byte, passphrase string) ([]byte, error) {
+	if len([]rune(passphrase)) < MinPassphrase {
+		return nil, i18n.Errorf("the passphrase needs at least %d characters", MinPassphrase)
+	}
+	if len(secret) == 0 {
+		return nil, i18n.Errorf("the file is empty")
+	}
+	if len(secret) > MaxSecret {
+		return nil, i18n.Errorf("paper backups hold up to %d KiB; this file has %d bytes", MaxSecret>>10, len(secret))
+	}
+	name = filepath.Base(name)
+	if len(name) > 0xffff {
+		name = name[:0xffff]
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, Magic)
+	salt := header[len(Magic) : len(Magic)+saltSize]
+	nonce := header[headerSize-nonceSize:]
+	rand.Read(salt)
+	rand.Read(nonce)
+	binary.BigEndian.PutUint32(header[len(Magic)+saltSize:], Iterations)
+
+	aead := newAEAD(passphrase, salt, Iterations)
+	plain := binary.BigEndian.AppendUint16(nil, uint16(len(name)))
+	plain = append(append(plain, name...), secret...)
+	return aead.Seal(header, nonce, plain, []byte(Magic)), nil
+}
+
+// Open descifra un sobre de Seal y devuelve el nombre y el contenido del archivo
+func Open(envelope []byte, passphrase string) (name string, secret []byte, err error) {
+	if len(envelope) < headerSize || !bytes.HasPrefix(envelope, []byte(Magic)) {
+		return "", nil, i18n.Errorf("not a paperkey backup")
+	}
+	salt := envelope[len(Magic) : len(Magic)+saltSize]
+	iterations := binary.BigEndian.Uint32(envelope[len(Magic)+saltSize:])
+	nonce := envelope[headerSize-nonceSize : headerSize]
+	if iterations == 0 || iterations > 100*Iterations {
+		return "", nil, i18n.Errorf("not a paperkey backup")
+	}
+
+	plain, err := newAEAD(passphrase, salt, int(iterations)).Open(nil, nonce, envelope[headerSize:], []byte(Magic))
+	if err != nil {
+		return "", nil, ErrPassphrase
+	}
+	if len(plain) < 2 || len(plain) < 2+int(binary.BigEndian.Uint16(plain)) {
+		return "", nil, ErrPassphrase
+	}
+	n := 2 + int(binary.BigEndian.Uint16(plain))
+	return string(plain[2:n]), plain[n:], nil
+}
+
+// newAEAD deriva la clave AES-256 de la frase
+func newAEAD(passphrase string, salt []byte, iterations int) cipher.AEAD {
+	block, _ := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New))
+	aead, _ := cipher.NewGCM(block)
+	return aead
+}
@@ -0,0 +1,61 @@
+package paperkey
+
+import (
+	"bytes"
+	"crypto/rand"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/shamir"
+)
+
+// ShareMagic encabeza cada parte de un sobre repartido con Shamir:
+// ShareMagic + id del reparto (4) + umbral (1) + x (1) + valores
+const ShareMagic = "QRSS1"
+
+const shareHeader = len(ShareMagic) + 4 + 1 + 1
+
+// SplitEnvelope reparte el sobre cifrado en n partes, de las que hacen falta
+// k (además de la frase) para restaurarlo
+func SplitEnvelope(envelope []byte, n, k int) ([][]byte, error) {
+	shares, err := shamir.Split(envelope, n, k)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 4)
+	rand.Read(id)
+	parts := make([][]byte, n)
+	for i, share := range shares {
+		header := append([]byte(ShareMagic), id...)
+		parts[i] = append(append(header, byte(k), share.X), share.Y...)
+	}
+	return parts, nil
+}
+
+// IsShare indica si los datos son una parte de SplitEnvelope
+func IsShare(data []byte) bool {
+	return len(data) > shareHeader && bytes.HasPrefix(data, []byte(ShareMagic))
+}
+
+// CombineShares reconstruye el sobre a partir de al menos umbral partes del
+// mismo reparto
+func CombineShares(parts [][]byte) ([]byte, error) {
+	var shares []shamir.Share
+	var id []byte
+	threshold := 0
+	for _, part := range parts {
+		if !IsShare(part) {
+			return nil, i18n.Errorf("a paperkey share is mixed with other data")
+		}
+		partID, k := part[len(ShareMagic):len(ShareMagic)+4], int(part[shareHeader-2])
+		if id == nil {
+			id, threshold = partID, k
+		} else if !bytes.Equal(id, partID) || k != threshold {
+			return nil, i18n.Errorf("the shares belong to different backups")
+		}
+		shares = append(shares, shamir.Share{X: part[shareHeader-1], Y: part[shareHeader:]})
+	}
+	if len(shares) < threshold {
+		return nil, i18n.Errorf("restoring needs %d shares; found %d", threshold, len(shares))
+	}
+	return shamir.Combine(shares[:threshold])
+}
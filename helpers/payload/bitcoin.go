@@ -0,0 +1,228 @@
+package payload
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// bitcoinType arma una URI de pago BIP-21 (bitcoin:<dirección>?amount=...);
+// la dirección se valida con su checksum para no imprimir un código que
+// mande el pago a ninguna parte
+var bitcoinType = &Type{
+	Name: "bitcoin",
+	Fields: []Field{
+		addressField,
+		amountField,
+		{Name: "label", Usage: "Payee name shown by the wallet (--type bitcoin)"},
+		{Name: "message", Usage: "Payment description shown by the wallet (--type bitcoin)"},
+	},
+	Build: buildBitcoin,
+}
+
+// addressField lo comparten los pagos con criptomonedas y amountField todos los pagos
+var (
+	addressField = Field{Name: "address", Usage: "Receiving address: Bitcoin legacy (1..., 3...) or bech32 (bc1...) (--type bitcoin), or 0x... (--type ethereum)"}
+	amountField  = Field{Name: "amount", Usage: "Amount to request: BTC up to 8 decimals (--type bitcoin), ether or --token units (--type ethereum), euros (--type epc), reais (--type pix), or rupees (--type upi)", Kind: KindAmount}
+)
+
+// maxBitcoin es la cantidad máxima de bitcoins que existirán
+const maxBitcoin = 21_000_000
+
+func buildBitcoin(values Values) (string, error) {
+	address := strings.TrimSpace(values["address"])
+	if address == "" {
+		return "", i18n.Errorf("--type bitcoin needs --address")
+	}
+	if err := checkBitcoinAddress(address); err != nil {
+		return "", err
+	}
+
+	var query []string
+	if value := strings.TrimSpace(values["amount"]); value != "" {
+		amount, err := decimalAmount(value, 8, maxBitcoin)
+		if err != nil {
+			return "", err
+		}
+		query = append(query, "amount="+amount)
+	}
+	if label := values["label"]; label != "" {
+		query = append(query, "label="+percentEscape(label, ""))
+	}
+	if message := values["message"]; message != "" {
+		query = append(query, "message="+percentEscape(message, ""))
+	}
+
+	uri := "bitcoin:" + address
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+	return uri, nil
+}
+
+// decimalAmount valida un importe positivo con hasta decimals decimales y
+// menor o igual a limit, y lo devuelve sin ceros de más. Se trabaja sobre el
+// texto para no perder precisión con float64.
+func decimalAmount(value string, decimals int, limit int64) (string, error) {
+	whole, fraction, _ := strings.Cut(value, ".")
+	valid := whole != "" || fraction != ""
+	for _, r := range whole + fraction {
+		valid = valid && r >= '0' && r <= '9'
+	}
+	if !valid {
+		return "", i18n.Errorf("invalid --amount %q; use a decimal number such as 12.50", value)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return "", i18n.Errorf("--amount %q has more than %d decimals", value, decimals)
+	}
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+
+	n, _ := new(big.Int).SetString(whole, 10)
+	switch {
+	case whole == "0" && fraction == "":
+		return "", i18n.Errorf("--amount must be greater than zero")
+	case n.Cmp(big.NewInt(limit)) > 0 || n.Cmp(big.NewInt(limit)) == 0 && fraction != "":
+		return "", i18n.Errorf("--amount %q is above the %d limit", value, limit)
+	}
+	if fraction != "" {
+		return whole + "." + fraction, nil
+	}
+	return whole, nil
+}
+
+// checkBitcoinAddress valida una dirección base58check (P2PKH o P2SH) o
+// segwit (bech32 para la versión 0, bech32m para las siguientes)
+func checkBitcoinAddress(address string) error {
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") || strings.HasPrefix(lower, "bcrt1") {
+		return checkSegwitAddress(address)
+	}
+	return checkBase58Address(address)
+}
+
+// base58Alphabet es el alfabeto de Bitcoin, sin 0, O, I ni l
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Versions son los prefijos de versión de las direcciones base58:
+// P2PKH y P2SH de mainnet y de testnet
+var base58Versions = map[byte]bool{0x00: true, 0x05: true, 0x6f: true, 0xc4: true}
+
+func checkBase58Address(address string) error {
+	n := new(big.Int)
+	for _, r := range address {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return i18n.Errorf("invalid Bitcoin address %q: %q is not a base58 character", address, r)
+		}
+		n.Mul(n, big.NewInt(58)).Add(n, big.NewInt(int64(digit)))
+	}
+	// Cada '1' inicial es un byte cero
+	zeros := len(address) - len(strings.TrimLeft(address, "1"))
+	data := append(make([]byte, zeros), n.Bytes()...)
+
+	if len(data) != 25 || !base58Versions[data[0]] {
+		return i18n.Errorf("invalid Bitcoin address %q: not a P2PKH or P2SH address", address)
+	}
+	first := sha256.Sum256(data[:21])
+	second := sha256.Sum256(first[:])
+	if string(second[:4]) != string(data[21:]) {
+		return i18n.Errorf("invalid Bitcoin address %q: checksum mismatch (typo?)", address)
+	}
+	return nil
+}
+
+// bech32Charset es el alfabeto de bech32 (BIP-173)
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Constantes del checksum: bech32 para segwit v0 y bech32m (BIP-350) para el resto
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func checkSegwitAddress(address string) error {
+	if address != strings.ToLower(address) && address != strings.ToUpper(address) {
+		return i18n.Errorf("invalid Bitcoin address %q: mixes upper and lower case", address)
+	}
+	lower := strings.ToLower(address)
+	sep := strings.LastIndexByte(lower, '1')
+	if len(lower) > 90 || len(lower)-sep-1 < 7 {
+		return i18n.Errorf("invalid Bitcoin address %q: wrong length", address)
+	}
+	hrp, rest := lower[:sep], lower[sep+1:]
+	data := make([]int, len(rest))
+	for i := range rest {
+		if data[i] = strings.IndexByte(bech32Charset, rest[i]); data[i] < 0 {
+			return i18n.Errorf("invalid Bitcoin address %q: %q is not a bech32 character", address, rest[i])
+		}
+	}
+
+	version := data[0]
+	want := bech32Const
+	if version > 0 {
+		want = bech32mConst
+	}
+	if bech32Polymod(hrp, data) != want {
+		return i18n.Errorf("invalid Bitcoin address %q: checksum mismatch (typo?)", address)
+	}
+
+	program, ok := convertBits(data[1:len(data)-6], 5, 8)
+	switch {
+	case version > 16 || !ok || len(program) < 2 || len(program) > 40:
+		return i18n.Errorf("invalid Bitcoin address %q: malformed witness program", address)
+	case version == 0 && len(program) != 20 && len(program) != 32:
+		return i18n.Errorf("invalid Bitcoin address %q: malformed witness program", address)
+	}
+	return nil
+}
+
+// bech32Polymod calcula el checksum de bech32 sobre la parte legible y los datos
+func bech32Polymod(hrp string, data []int) int {
+	generator := [5]int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	values := make([]int, 0, 2*len(hrp)+1+len(data))
+	for i := range hrp {
+		values = append(values, int(hrp[i])>>5)
+	}
+	values = append(values, 0)
+	for i := range hrp {
+		values = append(values, int(hrp[i])&31)
+	}
+	values = append(values, data...)
+
+	chk := 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i, g := range generator {
+			if top>>i&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+// convertBits reagrupa valores de from bits en valores de to bits; el relleno
+// sobrante debe ser cero y menor a un grupo de from bits
+func convertBits(data []int, from, to uint) ([]byte, bool) {
+	acc, bits := 0, uint(0)
+	var out []byte
+	for _, v := range data {
+		acc = acc<<from | v
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&(1<<to-1)))
+		}
+	}
+	if bits >= from || acc<<(to-bits)&(1<<to-1) != 0 {
+		return nil, false
+	}
+	return out, true
+}
@@ -0,0 +1,52 @@
+package payload
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// emailType arma una URI mailto: (RFC 6068) que abre un email nuevo con los
+// destinatarios, el asunto y el texto completos
+var emailType = &Type{
+	Name: "email",
+	Fields: []Field{
+		toField,
+		{Name: "subject", Usage: "Email subject (--type email)"},
+		bodyField,
+	},
+	Build: buildEmail,
+}
+
+func buildEmail(values Values) (string, error) {
+	var recipients []string
+	for _, address := range strings.Split(values["to"], ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if at := strings.LastIndex(address, "@"); at <= 0 || at == len(address)-1 || strings.ContainsAny(address, " <>") {
+			return "", i18n.Errorf("invalid email address %q", address)
+		}
+		recipients = append(recipients, percentEscape(address, "@+"))
+	}
+	if len(recipients) == 0 {
+		return "", i18n.Errorf("--type email needs --to")
+	}
+
+	var query []string
+	if subject := values["subject"]; subject != "" {
+		query = append(query, "subject="+percentEscape(subject, ""))
+	}
+	if body := values["body"]; body != "" {
+		// Los saltos de línea de un email son CRLF
+		body = strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+		query = append(query, "body="+percentEscape(body, ""))
+	}
+
+	uri := "mailto:" + strings.Join(recipients, ",")
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+	return uri, nil
+}
@@ -0,0 +1,178 @@
+package payload
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// epcType arma el QR de transferencia SEPA del European Payments Council
+// (EPC069-12, "GiroCode"), que las apps de los bancos leen para completar
+// una transferencia. Cada dato va en su línea, en el orden del estándar.
+var epcType = &Type{
+	Name: "epc",
+	Fields: []Field{
+		nameField,
+		{Name: "iban", Usage: "Payee IBAN (--type epc)"},
+		{Name: "bic", Usage: "Payee bank BIC; optional inside the EEA (--type epc)"},
+		amountField,
+		{Name: "purpose", Usage: "ISO 20022 purpose code, four letters such as GDDS (--type epc)"},
+		{Name: "reference", Usage: "Structured creditor reference, RF followed by check digits (--type epc)"},
+		{Name: "remittance", Usage: "Unstructured remittance text, up to 140 characters; not combinable with --reference (--type epc)"},
+		infoField,
+	},
+	Build: buildEPC,
+}
+
+// infoField es la nota que ve quien paga; la comparten epc y pix
+var infoField = Field{Name: "info", Usage: "Note shown to the payer: up to 70 characters (--type epc), or what fits next to --key (--type pix)"}
+
+// Límites de EPC069-12
+const (
+	epcMaxBytes  = 331
+	epcMaxAmount = 999_999_999
+)
+
+func buildEPC(values Values) (string, error) {
+	name, err := epcText(values, "name", 70)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", i18n.Errorf("--type epc needs --name")
+	}
+	iban := compactUpper(values["iban"])
+	if iban == "" {
+		return "", i18n.Errorf("--type epc needs --iban")
+	}
+	if !validIBAN(iban) {
+		return "", i18n.Errorf("invalid --iban %q: wrong format or check digits (typo?)", values["iban"])
+	}
+	bic := compactUpper(values["bic"])
+	if bic != "" && !validBIC(bic) {
+		return "", i18n.Errorf("invalid --bic %q; use 8 or 11 characters such as DEUTDEFF500", values["bic"])
+	}
+
+	var amount string
+	if value := strings.TrimSpace(values["amount"]); value != "" {
+		if amount, err = decimalAmount(value, 2, epcMaxAmount); err != nil {
+			return "", err
+		}
+		// Siempre con dos decimales, como lo escriben las facturas
+		whole, cents, _ := strings.Cut(amount, ".")
+		amount = "EUR" + whole + "." + cents + strings.Repeat("0", 2-len(cents))
+	}
+	purpose := compactUpper(values["purpose"])
+	if purpose != "" && (len(purpose) != 4 || !isUpperAlpha(purpose)) {
+		return "", i18n.Errorf("invalid --purpose %q; use a four-letter ISO 20022 code such as GDDS", values["purpose"])
+	}
+
+	reference := compactUpper(values["reference"])
+	if reference != "" && !validCreditorReference(reference) {
+		return "", i18n.Errorf("invalid --reference %q: not an RF creditor reference or wrong check digits", values["reference"])
+	}
+	remittance, err := epcText(values, "remittance", 140)
+	if err != nil {
+		return "", err
+	}
+	if reference != "" && remittance != "" {
+		return "", i18n.Errorf("--reference and --remittance cannot be combined; the EPC format takes one or the other")
+	}
+	info, err := epcText(values, "info", 70)
+	if err != nil {
+		return "", err
+	}
+
+	// Versión 002: el BIC es opcional. Codificación 1: UTF-8.
+	lines := []string{"BCD", "002", "1", "SCT", bic, name, iban, amount, purpose, reference, remittance, info}
+	for lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	payload := strings.Join(lines, "\n")
+	if len(payload) > epcMaxBytes {
+		return "", i18n.Errorf("the EPC payload is %d bytes; the standard allows %d, shorten --remittance or --info", len(payload), epcMaxBytes)
+	}
+	return payload, nil
+}
+
+// epcText valida un campo de texto: una sola línea, sin caracteres de
+// control y con el largo máximo del estándar, contado en caracteres
+func epcText(values Values, field string, limit int) (string, error) {
+	text := strings.TrimSpace(values[field])
+	if !utf8.ValidString(text) || strings.IndexFunc(text, unicode.IsControl) >= 0 {
+		return "", i18n.Errorf("--%s must be a single line of text", field)
+	}
+	if n := utf8.RuneCountInString(text); n > limit {
+		return "", i18n.Errorf("--%s has %d characters; the EPC format allows %d", field, n, limit)
+	}
+	return text, nil
+}
+
+// compactUpper quita los espacios y pasa a mayúsculas, como se suelen
+// escribir IBAN y referencias en grupos de cuatro
+func compactUpper(value string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+}
+
+// validIBAN revisa la forma (país, dígitos de control y hasta 30
+// alfanuméricos) y el módulo 97 de ISO 13616
+func validIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 || !isUpperAlpha(iban[:2]) || !isDigits(iban[2:4]) {
+		return false
+	}
+	return mod97(iban[4:] + iban[:4])
+}
+
+// validCreditorReference revisa una referencia RF de ISO 11649: RF, dos
+// dígitos de control y hasta 21 alfanuméricos, con el mismo módulo 97
+func validCreditorReference(reference string) bool {
+	if len(reference) < 5 || len(reference) > 25 || !strings.HasPrefix(reference, "RF") || !isDigits(reference[2:4]) {
+		return false
+	}
+	return mod97(reference[4:] + reference[:4])
+}
+
+// validBIC revisa un BIC de ISO 9362: banco (4 letras), país (2 letras),
+// localidad (2) y sucursal opcional (3)
+func validBIC(bic string) bool {
+	if len(bic) != 8 && len(bic) != 11 {
+		return false
+	}
+	return isUpperAlpha(bic[:6]) && isAlnum(bic[6:])
+}
+
+// mod97 convierte las letras en números (A = 10) y verifica que el resto
+// de dividir por 97 sea 1
+func mod97(value string) bool {
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// isUpperAlpha, isDigits e isAlnum revisan que el valor tenga solo letras
+// mayúsculas, dígitos, o ambos
+func isUpperAlpha(value string) bool {
+	return value != "" && strings.TrimFunc(value, func(r rune) bool { return r >= 'A' && r <= 'Z' }) == ""
+}
+
+func isDigits(value string) bool {
+	return value != "" && strings.TrimFunc(value, func(r rune) bool { return r >= '0' && r <= '9' }) == ""
+}
+
+func isAlnum(value string) bool {
+	return value != "" && strings.TrimFunc(value, func(r rune) bool { return r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' }) == ""
+}
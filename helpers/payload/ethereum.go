@@ -0,0 +1,137 @@
+package payload
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ethereumType arma una URI de pago EIP-681: ethereum:<dirección>@<cadena>?value=<wei>
+// para ether, o ethereum:<token>@<cadena>/transfer?address=<destino>&uint256=<unidades>
+// para un token ERC-20. Las direcciones se validan con su checksum EIP-55.
+var ethereumType = &Type{
+	Name: "ethereum",
+	Fields: []Field{
+		addressField,
+		amountField,
+		{Name: "chain-id", Usage: "EVM chain ID: 1 Ethereum mainnet, 137 Polygon, 8453 Base... (--type ethereum)"},
+		{Name: "token", Usage: "ERC-20 contract address; requests a token transfer instead of ether (--type ethereum)"},
+		{Name: "decimals", Usage: "Decimals of the --token, e.g. 6 for USDC; required with --token and --amount (--type ethereum)"},
+	},
+	Build: buildEthereum,
+}
+
+// etherDecimals son los decimales del ether: 1 ETH son 10^18 wei
+const etherDecimals = 18
+
+func buildEthereum(values Values) (string, error) {
+	address, err := ethereumAddress(values["address"], "address")
+	if err != nil {
+		return "", err
+	}
+
+	target, decimals := address, etherDecimals
+	token := strings.TrimSpace(values["token"])
+	if token != "" {
+		if target, err = ethereumAddress(token, "token"); err != nil {
+			return "", err
+		}
+	}
+	if value := strings.TrimSpace(values["decimals"]); value != "" {
+		if token == "" {
+			return "", i18n.Errorf("--decimals is only used with --token")
+		}
+		if decimals, err = strconv.Atoi(value); err != nil || decimals < 0 || decimals > 36 {
+			return "", i18n.Errorf("--decimals must be between 0 and 36")
+		}
+	}
+
+	uri := "ethereum:" + target
+	if chain := strings.TrimSpace(values["chain-id"]); chain != "" {
+		if id, err := strconv.ParseUint(chain, 10, 64); err != nil || id == 0 {
+			return "", i18n.Errorf("invalid --chain-id %q; use a positive integer such as 1", chain)
+		}
+		uri += "@" + chain
+	}
+
+	var units string
+	if value := strings.TrimSpace(values["amount"]); value != "" {
+		if token != "" && values["decimals"] == "" {
+			return "", i18n.Errorf("--token with --amount needs --decimals; tokens do not share a fixed number of decimals")
+		}
+		if units, err = baseUnits(value, decimals); err != nil {
+			return "", err
+		}
+	}
+
+	if token != "" {
+		uri += "/transfer?address=" + address
+		if units != "" {
+			uri += "&uint256=" + units
+		}
+		return uri, nil
+	}
+	if units != "" {
+		uri += "?value=" + units
+	}
+	return uri, nil
+}
+
+// baseUnits convierte un importe decimal a la unidad mínima (wei para ether),
+// que es como EIP-681 expresa las cantidades
+func baseUnits(value string, decimals int) (string, error) {
+	amount, err := decimalAmount(value, decimals, math.MaxInt64)
+	if err != nil {
+		return "", err
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	n, _ := new(big.Int).SetString(digits, 10)
+	return n.String(), nil
+}
+
+// ethereumAddress valida una dirección de 20 bytes en hexadecimal y la
+// devuelve con el checksum EIP-55. Si mezcla mayúsculas y minúsculas ya trae
+// un checksum, que tiene que coincidir.
+func ethereumAddress(address, field string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", i18n.Errorf("--type ethereum needs --%s", field)
+	}
+	digits, ok := strings.CutPrefix(address, "0x")
+	if !ok || len(digits) != 40 {
+		return "", i18n.Errorf("invalid --%s %q; use 0x and 40 hex digits", field, address)
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "", i18n.Errorf("invalid --%s %q; use 0x and 40 hex digits", field, address)
+	}
+
+	checksummed := eip55(digits)
+	lower, upper := strings.ToLower(digits), strings.ToUpper(digits)
+	if digits != lower && digits != upper && "0x"+digits != checksummed {
+		return "", i18n.Errorf("invalid --%s %q: checksum mismatch (typo?)", field, address)
+	}
+	return checksummed, nil
+}
+
+// eip55 escribe en mayúscula cada letra cuyo nibble en el Keccak-256 de la
+// dirección en minúsculas es 8 o más
+func eip55(digits string) string {
+	lower := strings.ToLower(digits)
+	hash := keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,419 @@
+package payload
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// eventType arma un bloque VEVENT (iCalendar, RFC 5545), con el que las
+// cámaras ofrecen agregar el evento al calendario. Los datos pueden salir de
+// un .ics existente; los flags indicados reemplazan los del archivo. Con --tz
+// el evento va dentro de un VCALENDAR, junto al VTIMEZONE de su zona.
+var eventType = &Type{
+	Name: "event",
+	Fields: []Field{
+		titleField,
+		{Name: "start", Usage: `Event start: "2026-03-14 18:00", with an optional offset ("2026-03-14T18:00-03:00") or a date for all-day events (--type event)`, Kind: KindDate},
+		{Name: "end", Usage: "Event end, like --start (default one hour later, or the next day for all-day events) (--type event)", Kind: KindDate},
+		{Name: "location", Usage: "Event location (--type event)"},
+		{Name: "description", Usage: "Event description (--type event)"},
+		{Name: "tz", Usage: `IANA time zone of --start and --end, like "America/Argentina/Buenos_Aires"; the event keeps it and carries its rules (--type event)`},
+		{Name: "ics", Usage: "Read the first event of an .ics file; the other event flags override its values (--type event)"},
+	},
+	Build: buildEvent,
+}
+
+// titleField lo comparten el cargo de un contacto y el título de un evento
+var titleField = Field{Name: "title", Usage: "Contact job title (--type vcard) or event title (--type event)"}
+
+// eventProperties son las propiedades de VEVENT que se conservan de un .ics,
+// en el orden en que se escriben; el resto (UID, DTSTAMP, alarmas...) solo
+// agranda el código
+var eventProperties = []string{"SUMMARY", "DTSTART", "DTEND", "DURATION", "LOCATION", "DESCRIPTION", "GEO", "URL"}
+
+// Formatos de fecha y hora de iCalendar
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405"
+)
+
+// eventTimeLayouts son los formatos aceptados en --start y --end, con la "T"
+// reemplazada por un espacio
+var eventTimeLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// eventTime es un instante de --start o --end
+type eventTime struct {
+	t        time.Time
+	allDay   bool           // Solo la fecha
+	floating bool           // Sin zona horaria: la hora local de quien lo agenda
+	zone     *time.Location // Zona de --tz, en la que se escribe la hora
+}
+
+// property devuelve los parámetros y el valor de DTSTART o DTEND: ";VALUE=DATE:20260314"
+func (e eventTime) property() string {
+	switch {
+	case e.allDay:
+		return ";VALUE=DATE:" + e.t.Format(icalDate)
+	case e.floating:
+		return ":" + e.t.Format(icalDateTime)
+	case e.zone != nil:
+		return ";TZID=" + e.zone.String() + ":" + e.t.In(e.zone).Format(icalDateTime)
+	}
+	return ":" + e.t.UTC().Format(icalDateTime) + "Z"
+}
+
+func buildEvent(values Values) (string, error) {
+	// Cada propiedad se guarda con sus parámetros: "DTSTART" -> ";TZID=...:2026..."
+	props := map[string]string{}
+	if path := values["ics"]; path != "" {
+		var err error
+		if props, err = readICS(path); err != nil {
+			return "", err
+		}
+	}
+
+	var zone *time.Location
+	if name := strings.TrimSpace(values["tz"]); name != "" {
+		var err error
+		if zone, err = loadZone(name); err != nil {
+			return "", err
+		}
+		// Las horas del .ics pasan a la zona de --tz
+		for _, name := range []string{"DTSTART", "DTEND"} {
+			if value, ok := props[name]; ok {
+				if props[name], err = zonedProperty(value, zone); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	if title := values["title"]; title != "" {
+		props["SUMMARY"] = ":" + icalEscaper.Replace(title)
+	}
+	for field, name := range map[string]string{"location": "LOCATION", "description": "DESCRIPTION"} {
+		if value := values[field]; value != "" {
+			props[name] = ":" + icalEscaper.Replace(value)
+		}
+	}
+	if values["start"] != "" || values["end"] != "" {
+		if values["start"] == "" {
+			return "", i18n.Errorf("--end needs --start")
+		}
+		start, err := parseEventTime(values["start"], zone)
+		if err != nil {
+			return "", err
+		}
+		end := start
+		if start.allDay {
+			end.t = start.t.AddDate(0, 0, 1)
+		} else {
+			end.t = start.t.Add(time.Hour)
+		}
+		if values["end"] != "" {
+			if end, err = parseEventTime(values["end"], zone); err != nil {
+				return "", err
+			}
+			if end.allDay != start.allDay {
+				return "", i18n.Errorf("--start and --end must both be dates or both have a time")
+			}
+			if !end.t.After(start.t) {
+				return "", i18n.Errorf("the event must end after it starts")
+			}
+		}
+		props["DTSTART"], props["DTEND"] = start.property(), end.property()
+		delete(props, "DURATION")
+	}
+
+	if props["DTSTART"] == "" {
+		return "", i18n.Errorf("--type event needs --start (or an --ics file)")
+	}
+	if props["SUMMARY"] == "" {
+		return "", i18n.Errorf("--type event needs --title")
+	}
+	lines := []string{"BEGIN:VEVENT"}
+	for _, name := range eventProperties {
+		if value, ok := props[name]; ok {
+			lines = append(lines, name+value)
+		}
+	}
+	lines = append(lines, "END:VEVENT")
+
+	// Un TZID solo se resuelve con su VTIMEZONE, que tiene que ir en el mismo VCALENDAR
+	if zone != nil && (strings.HasPrefix(props["DTSTART"], ";TZID=") || strings.HasPrefix(props["DTEND"], ";TZID=")) {
+		calendar := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//qrgenerator_cli//EN"}
+		calendar = append(calendar, vtimezone(zone, props["DTSTART"], props["DTEND"])...)
+		lines = append(append(calendar, lines...), "END:VCALENDAR")
+	}
+	return strings.Join(lines, "\r\n"), nil
+}
+
+// parseEventTime interpreta --start o --end. Las horas con zona se escriben
+// en UTC; las que no tienen quedan flotantes, en la hora local de cada teléfono.
+// Con --tz, las horas sin desplazamiento son de esa zona y las que lo tienen
+// se pasan a ella.
+func parseEventTime(value string, zone *time.Location) (eventTime, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return eventTime{t: t, allDay: true}, nil
+	}
+	normalized := strings.Replace(value, "T", " ", 1)
+	for _, layout := range eventTimeLayouts {
+		t, err := time.Parse(layout, normalized)
+		if err != nil {
+			continue
+		}
+		floating := !strings.Contains(layout, "Z")
+		if zone == nil {
+			return eventTime{t: t, floating: floating}, nil
+		}
+		if floating {
+			if t, err = zonedTime(t, zone); err != nil {
+				return eventTime{}, err
+			}
+		}
+		return eventTime{t: t, zone: zone}, nil
+	}
+	return eventTime{}, i18n.Errorf(`invalid event time %q; use "2026-03-14 18:00", "2026-03-14T18:00-03:00" or "2026-03-14"`, value)
+}
+
+// icalEscaper escapa los caracteres especiales de los valores de texto de iCalendar
+var icalEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// readICS lee las propiedades del primer VEVENT de un archivo .ics. Las horas
+// con TZID se pasan a UTC, porque los lectores de QR no conocen las zonas.
+func readICS(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read --ics: %w", err)
+	}
+	defer f.Close()
+
+	// Desplegar las líneas: las que empiezan con espacio o tab continúan la anterior
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("cannot read --ics: %w", err)
+	}
+
+	props := map[string]string{}
+	depth, found := 0, false
+	for _, line := range lines {
+		switch strings.ToUpper(line) {
+		case "BEGIN:VEVENT":
+			found = true
+			depth = 1
+			continue
+		case "END:VEVENT":
+			if depth == 1 {
+				return props, nil
+			}
+		}
+		if depth == 0 {
+			continue
+		}
+		// Los bloques anidados (VALARM) no se copian
+		if strings.HasPrefix(strings.ToUpper(line), "BEGIN:") {
+			depth++
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(line), "END:") {
+			depth--
+			continue
+		}
+		if depth > 1 {
+			continue
+		}
+		i := strings.IndexAny(line, ";:")
+		if i <= 0 {
+			continue
+		}
+		name := strings.ToUpper(line[:i])
+		for _, keep := range eventProperties {
+			if name == keep {
+				props[name] = icsDateToUTC(name, line[i:])
+			}
+		}
+	}
+	if !found {
+		return nil, i18n.Errorf("%s has no VEVENT", path)
+	}
+	return nil, i18n.Errorf("%s: unterminated VEVENT", path)
+}
+
+// icsDateToUTC pasa a UTC un DTSTART o DTEND con TZID (";TZID=Europe/Madrid:20260314T180000");
+// si la zona no se conoce, lo deja como está
+func icsDateToUTC(name, rest string) string {
+	if name != "DTSTART" && name != "DTEND" {
+		return rest
+	}
+	params, value, ok := strings.Cut(rest[1:], ":")
+	if !ok || rest[0] != ';' {
+		return rest
+	}
+	for _, param := range strings.Split(params, ";") {
+		if zone, ok := strings.CutPrefix(param, "TZID="); ok {
+			loc, err := time.LoadLocation(strings.Trim(zone, `"`))
+			if err != nil {
+				return rest
+			}
+			t, err := time.ParseInLocation(icalDateTime, value, loc)
+			if err != nil {
+				return rest
+			}
+			return ":" + t.UTC().Format(icalDateTime) + "Z"
+		}
+	}
+	return rest
+}
+
+// loadZone carga una zona IANA de --tz
+func loadZone(name string) (*time.Location, error) {
+	// "Local" es válido para time, pero no dice nada a quien escanea
+	zone, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, i18n.Errorf(`unknown --tz %q; use an IANA time zone name such as "Europe/Madrid" or "America/New_York"`, name)
+	}
+	return zone, nil
+}
+
+// zonedTime ubica una hora de reloj en la zona. Las que no existen, porque
+// caen en el salto del cambio de hora, son un error; de las que se repiten
+// al atrasar el reloj se toma la primera.
+func zonedTime(wall time.Time, zone *time.Location) (time.Time, error) {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, zone)
+	if t.Hour() != wall.Hour() || t.Minute() != wall.Minute() {
+		return time.Time{}, i18n.Errorf("%s does not exist in %s: the clocks skip it when daylight saving time starts", wall.Format("2006-01-02 15:04"), zone)
+	}
+	return t, nil
+}
+
+// zonedProperty escribe un DTSTART o DTEND del .ics en la zona de --tz: las
+// horas en UTC se convierten y las flotantes se toman como de esa zona
+func zonedProperty(rest string, zone *time.Location) (string, error) {
+	value, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return rest, nil
+	}
+	if t, err := time.Parse(icalDateTime+"Z", value); err == nil {
+		return eventTime{t: t, zone: zone}.property(), nil
+	}
+	wall, err := time.Parse(icalDateTime, value)
+	if err != nil {
+		return rest, nil
+	}
+	t, err := zonedTime(wall, zone)
+	if err != nil {
+		return "", err
+	}
+	return eventTime{t: t, zone: zone}.property(), nil
+}
+
+// vtimezone arma el VTIMEZONE de la zona con los cambios de hora de los años
+// del evento, cada uno como un STANDARD o DAYLIGHT sin regla de repetición:
+// alcanza para que el calendario ubique las horas sin conocer la zona
+func vtimezone(zone *time.Location, start, end string) []string {
+	var years []int
+	for _, prop := range []string{start, end} {
+		if _, value, ok := strings.Cut(prop, ":"); ok && len(value) >= 8 {
+			if t, err := time.Parse(icalDate, value[:8]); err == nil {
+				years = append(years, t.Year())
+			}
+		}
+	}
+	if len(years) == 0 {
+		years = append(years, time.Now().Year())
+	}
+	first, last := slices.Min(years), slices.Max(years)
+	from := time.Date(first, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(last+1, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + zone.String()}
+	// La primera componente es la hora vigente al empezar el período
+	name, offset := from.In(zone).Zone()
+	lines = append(lines, zoneComponent(from.In(zone).IsDST(), time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), offset, offset, name)...)
+	for t := from; t.Before(to); {
+		next := nextTransition(t, to, zone)
+		if next.IsZero() {
+			break
+		}
+		_, before := next.Add(-time.Second).In(zone).Zone()
+		name, after := next.In(zone).Zone()
+		lines = append(lines, zoneComponent(next.In(zone).IsDST(), next.Add(time.Duration(before)*time.Second).UTC(), before, after, name)...)
+		t = next
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+// zoneComponent arma un STANDARD o DAYLIGHT; onset es la hora de reloj en que
+// empieza, con el desplazamiento anterior
+func zoneComponent(daylight bool, onset time.Time, from, to int, name string) []string {
+	kind := "STANDARD"
+	if daylight {
+		kind = "DAYLIGHT"
+	}
+	return []string{
+		"BEGIN:" + kind,
+		"DTSTART:" + onset.Format(icalDateTime),
+		"TZOFFSETFROM:" + icalOffset(from),
+		"TZOFFSETTO:" + icalOffset(to),
+		"TZNAME:" + name,
+		"END:" + kind,
+	}
+}
+
+// nextTransition busca el próximo cambio de desplazamiento de la zona entre
+// t y limit: avanza de a un día y después bisecciona hasta el segundo
+func nextTransition(t, limit time.Time, zone *time.Location) time.Time {
+	_, offset := t.In(zone).Zone()
+	for day := t; day.Before(limit); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		if _, o := next.In(zone).Zone(); o == offset {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if _, o := mid.In(zone).Zone(); o == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return hi
+	}
+	return time.Time{}
+}
+
+// icalOffset escribe un desplazamiento en segundos como "-0300" (o "+053030")
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
@@ -0,0 +1,83 @@
+package payload
+
+import (
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// geoType arma una URI geo: (RFC 5870) con la ubicación, o un enlace de
+// Google Maps o Apple Maps para los lectores que no abren geo:
+var geoType = &Type{
+	Name: "geo",
+	Fields: []Field{
+		{Name: "lat", Usage: "Latitude in decimal degrees, -90 to 90 (--type geo)"},
+		{Name: "lon", Usage: "Longitude in decimal degrees, -180 to 180 (--type geo)"},
+		{Name: "alt", Usage: "Altitude in meters (--type geo)"},
+		{Name: "query", Usage: "Place name shown on the pin (--type geo)"},
+		{Name: "maps", Usage: "Write a maps link instead of geo: google or apple (--type geo)"},
+	},
+	Build: buildGeo,
+}
+
+func buildGeo(values Values) (string, error) {
+	lat, err := coordinate(values, "lat", 90)
+	if err != nil {
+		return "", err
+	}
+	lon, err := coordinate(values, "lon", 180)
+	if err != nil {
+		return "", err
+	}
+	point := lat + "," + lon
+	name := strings.TrimSpace(values["query"])
+
+	switch strings.ToLower(values["maps"]) {
+	case "":
+	case "google":
+		if values["alt"] != "" {
+			return "", i18n.Errorf("--alt is only used by geo: URIs, not by --maps links")
+		}
+		// Google Maps busca el texto; con coordenadas pone el pin exacto
+		return "https://www.google.com/maps/search/?api=1&query=" + percentEscape(point, ","), nil
+	case "apple":
+		if values["alt"] != "" {
+			return "", i18n.Errorf("--alt is only used by geo: URIs, not by --maps links")
+		}
+		link := "https://maps.apple.com/?ll=" + percentEscape(point, ",")
+		if name != "" {
+			link += "&q=" + percentEscape(name, "")
+		}
+		return link, nil
+	default:
+		return "", i18n.Errorf("unsupported maps service %q (google or apple)", values["maps"])
+	}
+
+	uri := "geo:" + point
+	if alt := strings.TrimSpace(values["alt"]); alt != "" {
+		v, err := strconv.ParseFloat(alt, 64)
+		if err != nil {
+			return "", i18n.Errorf("invalid --alt %q; use meters, e.g. 25.5", alt)
+		}
+		uri += "," + strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if name != "" {
+		// Android muestra el nombre con q=lat,lon(nombre); el resto lo ignora
+		uri += "?q=" + point + "(" + percentEscape(name, "") + ")"
+	}
+	return uri, nil
+}
+
+// coordinate lee una coordenada en grados decimales dentro de ±limit
+func coordinate(values Values, field string, limit float64) (string, error) {
+	value := strings.TrimSpace(values[field])
+	if value == "" {
+		return "", i18n.Errorf("--type geo needs --%s", field)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || v < -limit || v > limit {
+		return "", i18n.Errorf("invalid --%s %q; use decimal degrees between -%g and %g", field, value, limit, limit)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64), nil
+}
@@ -0,0 +1,313 @@
+package payload
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// gs1Type arma una cadena de elementos GS1 a partir de su forma legible
+// ("(01)09506000134352(17)260101"). Se codifica con FNC1 en primera posición
+// y separadores GS, como lo leen las cajas y los sistemas de logística.
+var gs1Type = &Type{
+	Name: "gs1",
+	Fields: []Field{
+		{Name: "elements", Usage: `GS1 element string such as "(01)09506000134352(17)260101(10)AB-123"; also the argument after the flags (--type gs1)`},
+	},
+	Build: buildGS1,
+	Arg:   "elements",
+	FNC1:  true,
+}
+
+// gs1Format es el formato de los datos de un AI
+type gs1Format struct {
+	numeric  bool
+	min, max int
+	check    bool // El último dígito es un dígito de control mod 10
+	date     bool // YYMMDD, con el día 00 para el fin de mes
+}
+
+// gs1AIs son los AI admitidos, con el formato de sus datos. Cubre los de
+// identificación, trazabilidad, fechas, medidas y logística más usados.
+var gs1AIs = map[string]gs1Format{
+	"00":   {numeric: true, min: 18, max: 18, check: true},
+	"01":   {numeric: true, min: 14, max: 14, check: true},
+	"02":   {numeric: true, min: 14, max: 14, check: true},
+	"10":   {min: 1, max: 20},
+	"11":   {numeric: true, min: 6, max: 6, date: true},
+	"12":   {numeric: true, min: 6, max: 6, date: true},
+	"13":   {numeric: true, min: 6, max: 6, date: true},
+	"15":   {numeric: true, min: 6, max: 6, date: true},
+	"16":   {numeric: true, min: 6, max: 6, date: true},
+	"17":   {numeric: true, min: 6, max: 6, date: true},
+	"20":   {numeric: true, min: 2, max: 2},
+	"21":   {min: 1, max: 20},
+	"22":   {min: 1, max: 20},
+	"240":  {min: 1, max: 30},
+	"241":  {min: 1, max: 30},
+	"250":  {min: 1, max: 30},
+	"251":  {min: 1, max: 30},
+	"254":  {min: 1, max: 20},
+	"30":   {numeric: true, min: 1, max: 8},
+	"37":   {numeric: true, min: 1, max: 8},
+	"400":  {min: 1, max: 30},
+	"401":  {min: 1, max: 30},
+	"402":  {numeric: true, min: 17, max: 17, check: true},
+	"403":  {min: 1, max: 30},
+	"420":  {min: 1, max: 20},
+	"421":  {min: 4, max: 12},
+	"422":  {numeric: true, min: 3, max: 3},
+	"7003": {numeric: true, min: 10, max: 10},
+	"8004": {min: 1, max: 30},
+	"8008": {numeric: true, min: 8, max: 12},
+	"8017": {numeric: true, min: 18, max: 18, check: true},
+	"8018": {numeric: true, min: 18, max: 18, check: true},
+	"8020": {min: 1, max: 25},
+	"8200": {min: 1, max: 70},
+	"90":   {min: 1, max: 30},
+}
+
+func init() {
+	// Medidas con la posición del punto decimal en el cuarto dígito (3103: kg
+	// con tres decimales), montos (390n-393n) y GLN de destino (410-417)
+	for decimals := 0; decimals <= 5; decimals++ {
+		for _, prefix := range []string{"310", "311", "312", "313", "314", "315", "316", "320", "321", "322", "323", "324", "325", "326", "327", "328", "329", "330", "331", "332", "333", "334", "335", "336", "340", "341", "342", "343", "344", "345", "346", "347", "348", "349", "350", "351", "352", "353", "354", "355", "356", "357", "360", "361", "362", "363", "364", "365", "366", "367", "368", "369"} {
+			gs1AIs[fmt.Sprintf("%s%d", prefix, decimals)] = gs1Format{numeric: true, min: 6, max: 6}
+		}
+	}
+	for decimals := 0; decimals <= 9; decimals++ {
+		gs1AIs[fmt.Sprintf("390%d", decimals)] = gs1Format{numeric: true, min: 1, max: 15}
+		gs1AIs[fmt.Sprintf("391%d", decimals)] = gs1Format{numeric: true, min: 4, max: 18}
+		gs1AIs[fmt.Sprintf("392%d", decimals)] = gs1Format{numeric: true, min: 1, max: 15}
+		gs1AIs[fmt.Sprintf("393%d", decimals)] = gs1Format{numeric: true, min: 4, max: 18}
+	}
+	for ai := 410; ai <= 417; ai++ {
+		gs1AIs[fmt.Sprint(ai)] = gs1Format{numeric: true, min: 13, max: 13, check: true}
+	}
+	// Uso interno de cada empresa
+	for ai := 91; ai <= 99; ai++ {
+		gs1AIs[fmt.Sprint(ai)] = gs1Format{min: 1, max: 90}
+	}
+}
+
+// gs1FixedPrefixes son los dos primeros dígitos de los AI de largo
+// predefinido, que no necesitan separador aunque no vayan al final
+var gs1FixedPrefixes = []string{"00", "01", "02", "03", "04", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "31", "32", "33", "34", "35", "36", "41"}
+
+// gs1Element es un AI con sus datos
+type gs1Element struct {
+	ai, value string
+}
+
+func buildGS1(values Values) (string, error) {
+	text := strings.TrimSpace(values["elements"])
+	if text == "" {
+		return "", i18n.Errorf(`--type gs1 needs an element string such as "(01)09506000134352(17)260101"`)
+	}
+	elements, err := parseGS1(text)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i, e := range elements {
+		sb.WriteString(e.ai + e.value)
+		// Los elementos de largo variable terminan con GS, salvo el último
+		if i < len(elements)-1 && !slices.Contains(gs1FixedPrefixes, e.ai[:2]) {
+			sb.WriteByte(gs1Separator)
+		}
+	}
+	return sb.String(), nil
+}
+
+// gs1Separator es el GS (FNC1) que termina un elemento de largo variable
+const gs1Separator = '\x1d'
+
+// parseGS1 interpreta la forma legible "(AI)datos(AI)datos..." y valida cada elemento
+func parseGS1(text string) ([]gs1Element, error) {
+	var elements []gs1Element
+	seen := map[string]bool{}
+	rest := text
+	for rest != "" {
+		if rest[0] != '(' {
+			return nil, i18n.Errorf(`invalid GS1 element string %q; write each AI in parentheses, like "(01)09506000134352(10)AB-123"`, text)
+		}
+		ai, after, ok := strings.Cut(rest[1:], ")")
+		if !ok {
+			return nil, i18n.Errorf(`invalid GS1 element string %q; write each AI in parentheses, like "(01)09506000134352(10)AB-123"`, text)
+		}
+		value := after
+		if i := strings.IndexByte(after, '('); i >= 0 {
+			value = after[:i]
+		}
+		rest = after[len(value):]
+
+		if err := checkGS1Element(ai, value); err != nil {
+			return nil, err
+		}
+		if seen[ai] {
+			return nil, i18n.Errorf("AI (%s) appears twice", ai)
+		}
+		seen[ai] = true
+		elements = append(elements, gs1Element{ai: ai, value: value})
+	}
+	return elements, nil
+}
+
+// checkGS1Element valida los datos de un AI según su formato
+func checkGS1Element(ai, value string) error {
+	format, ok := gs1AIs[ai]
+	if !ok {
+		return i18n.Errorf("unknown or unsupported GS1 AI (%s)", ai)
+	}
+	if len(value) < format.min || len(value) > format.max {
+		if format.min == format.max {
+			return i18n.Errorf("AI (%s) takes %d characters, not %d", ai, format.min, len(value))
+		}
+		return i18n.Errorf("AI (%s) takes %d to %d characters, not %d", ai, format.min, format.max, len(value))
+	}
+	if format.numeric && !isDigits(value) {
+		return i18n.Errorf("AI (%s) takes only digits: %q", ai, value)
+	}
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(gs1CSet82, r)) {
+			return i18n.Errorf("AI (%s) cannot contain %q; GS1 allows letters, digits and %s", ai, r, gs1CSet82)
+		}
+	}
+	if format.check {
+		if want := gs1CheckDigit(value[:len(value)-1]); value[len(value)-1] != want {
+			return i18n.Errorf("AI (%s) %s has a wrong check digit; it should end in %c", ai, value, want)
+		}
+	}
+	if format.date {
+		if _, err := gs1Date(fmt.Sprintf("(%s)", ai), value); err != nil {
+			return i18n.Errorf("AI (%s) %s is not a valid YYMMDD date", ai, value)
+		}
+	}
+	return nil
+}
+
+// GS1Readable devuelve la forma legible ("(01)...(17)...") de una cadena de
+// elementos GS1 leída de un símbolo con FNC1. Si no la puede interpretar con
+// los AI conocidos, devuelve la cadena tal cual y false.
+func GS1Readable(data string) (string, bool) {
+	var sb strings.Builder
+	rest := data
+	for rest != "" {
+		ai := ""
+		for n := 2; n <= 4 && n <= len(rest); n++ {
+			if _, ok := gs1AIs[rest[:n]]; ok {
+				ai = rest[:n]
+				break
+			}
+		}
+		if ai == "" {
+			return data, false
+		}
+		format := gs1AIs[ai]
+		rest = rest[len(ai):]
+		end := strings.IndexByte(rest, gs1Separator)
+		if end < 0 {
+			end = len(rest)
+		}
+		if slices.Contains(gs1FixedPrefixes, ai[:2]) {
+			end = min(format.max, len(rest))
+		}
+		sb.WriteString("(" + ai + ")" + rest[:end])
+		rest = strings.TrimPrefix(rest[end:], string(gs1Separator))
+	}
+	return sb.String(), true
+}
+
+// Reglas de los AI de GS1 que usan los enlaces de producto: GTIN (01), lote
+// (10), vencimiento (17) y serie (21)
+
+// gs1MaxText es el largo máximo del lote y de la serie (AI 10 y 21)
+const gs1MaxText = 20
+
+// gs1CSet82 son los caracteres que admiten los AI alfanuméricos (GS1 AI
+// encodable character set 82): letras, dígitos y algunos signos ASCII
+const gs1CSet82 = `!"%&'()*+,-./:;<=>?_`
+
+// gtin14 valida un GTIN-8, 12, 13 o 14 con su dígito de control y lo
+// completa con ceros a la izquierda hasta 14 dígitos, como lo pide el AI 01
+func gtin14(value string) (string, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	switch len(value) {
+	case 8, 12, 13, 14:
+	default:
+		return "", i18n.Errorf("invalid --gtin %q; use a GTIN-8, 12 (UPC), 13 (EAN) or 14 with its check digit", value)
+	}
+	if !isDigits(value) {
+		return "", i18n.Errorf("invalid --gtin %q; use a GTIN-8, 12 (UPC), 13 (EAN) or 14 with its check digit", value)
+	}
+	if want := gs1CheckDigit(value[:len(value)-1]); value[len(value)-1] != want {
+		return "", i18n.Errorf("--gtin %s has a wrong check digit; it should end in %c", value, want)
+	}
+	return strings.Repeat("0", 14-len(value)) + value, nil
+}
+
+// gs1CheckDigit calcula el dígito de control mod 10 de GS1: desde la
+// derecha, los dígitos se multiplican alternadamente por 3 y por 1
+func gs1CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// gs1Text valida el lote o la serie: hasta 20 caracteres del conjunto 82
+func gs1Text(values Values, field string) (string, error) {
+	value := strings.TrimSpace(values[field])
+	if len(value) > gs1MaxText {
+		return "", i18n.Errorf("--%s has %d characters; GS1 allows %d", field, len(value), gs1MaxText)
+	}
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(gs1CSet82, r)) {
+			return "", i18n.Errorf("--%s cannot contain %q; GS1 allows letters, digits and %s", field, r, gs1CSet82)
+		}
+	}
+	return value, nil
+}
+
+// gs1Date convierte una fecha a YYMMDD (AI 17). "2027-03" es el fin de ese
+// mes, que GS1 escribe con el día 00; YYMMDD se acepta tal cual.
+func gs1Date(field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	fail := func() (string, error) {
+		return "", i18n.Errorf(`invalid --%s %q; use "2027-03-31", "2027-03" for the end of the month, or YYMMDD`, field, value)
+	}
+	switch {
+	case len(value) == 6 && isDigits(value):
+		if _, err := time.Parse("0601", value[:4]); err != nil {
+			return fail()
+		}
+		if value[4:] != "00" {
+			if _, err := time.Parse("060102", value); err != nil {
+				return fail()
+			}
+		}
+		return value, nil
+	case len(value) == 7:
+		t, err := time.Parse("2006-01", value)
+		if err != nil {
+			return fail()
+		}
+		return t.Format("0601") + "00", nil
+	}
+	// Las fechas de --locale pueden traer hora, que GS1 no usa
+	date, _, _ := strings.Cut(value, " ")
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return fail()
+	}
+	return t.Format("060102"), nil
+}
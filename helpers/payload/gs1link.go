@@ -0,0 +1,66 @@
+package payload
+
+import (
+	"net/url"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// gs1LinkType arma una URI de GS1 Digital Link
+// (https://id.gs1.org/01/09506000134352/10/AB-123?17=270331): la abre
+// cualquier cámara como enlace y los resolvedores de GS1 la llevan a la
+// información del producto, mientras que las cajas leen de ella los AI
+var gs1LinkType = &Type{
+	Name: "gs1-link",
+	Fields: []Field{
+		{Name: "gtin", Usage: "Product GTIN-8, 12 (UPC), 13 (EAN) or 14, with its check digit (--type gs1-link)"},
+		{Name: "lot", Usage: "Batch or lot number, up to 20 characters (--type gs1-link)"},
+		{Name: "serial", Usage: "Serial number of the item, up to 20 characters (--type gs1-link)"},
+		{Name: "expiry", Usage: `Expiry date: "2027-03-31", or "2027-03" for the end of the month (--type gs1-link)`, Kind: KindDate},
+		{Name: "resolver", Usage: "Resolver the link points to (default https://id.gs1.org) (--type gs1-link)"},
+	},
+	Build: buildGS1Link,
+}
+
+// gs1Resolver es el resolvedor global de GS1
+const gs1Resolver = "https://id.gs1.org"
+
+func buildGS1Link(values Values) (string, error) {
+	if strings.TrimSpace(values["gtin"]) == "" {
+		return "", i18n.Errorf("--type gs1-link needs --gtin")
+	}
+	gtin, err := gtin14(values["gtin"])
+	if err != nil {
+		return "", err
+	}
+
+	resolver := strings.TrimRight(strings.TrimSpace(values["resolver"]), "/")
+	if resolver == "" {
+		resolver = gs1Resolver
+	}
+	if u, err := url.Parse(resolver); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", i18n.Errorf("invalid --resolver %q; use an http or https URL such as %s", resolver, gs1Resolver)
+	}
+
+	// Los calificadores del GTIN van en la ruta y en este orden: lote y serie
+	link := resolver + "/01/" + gtin
+	for _, q := range []struct{ field, ai string }{{"lot", "10"}, {"serial", "21"}} {
+		value, err := gs1Text(values, q.field)
+		if err != nil {
+			return "", err
+		}
+		if value != "" {
+			link += "/" + q.ai + "/" + percentEscape(value, "")
+		}
+	}
+	// Los atributos, como el vencimiento, van en la consulta
+	if values["expiry"] != "" {
+		expiry, err := gs1Date("expiry", values["expiry"])
+		if err != nil {
+			return "", err
+		}
+		link += "?17=" + expiry
+	}
+	return link, nil
+}
@@ -0,0 +1,93 @@
+package payload
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// countField es la cantidad de códigos de los tipos que generan identificadores
+var countField = Field{Name: "count", Usage: "Number of codes to generate, each with its own identifier, numbered like --batch; a CSV next to the output maps each identifier to its file (--type uuid, token)"}
+
+// uuidType genera un UUID versión 4 aleatorio por código, para etiquetar
+// activos o entradas que se registran después en una base de datos
+var uuidType = &Type{
+	Name:      "uuid",
+	Fields:    []Field{countField},
+	Build:     buildUUID,
+	Generated: true,
+}
+
+// tokenType genera un token aleatorio por código en base32 de Crockford, sin
+// I, L, O ni U para que se pueda dictar o tipear desde la etiqueta impresa
+var tokenType = &Type{
+	Name: "token",
+	Fields: []Field{
+		{Name: "length", Usage: "Token length in characters, 8-64 (default 16, 80 random bits) (--type token)"},
+		countField,
+	},
+	Build:     buildToken,
+	Generated: true,
+}
+
+// crockford es el alfabeto de los tokens. Como los UUID en mayúsculas, entra
+// en el modo alfanumérico del QR, que ocupa menos módulos que el de bytes.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Límites de los identificadores
+const (
+	defaultTokenLength = 16
+	minTokenLength     = 8
+	maxTokenLength     = 64
+	maxCount           = 100000
+)
+
+// buildUUID arma un UUID v4 (RFC 9562) en mayúsculas: los lectores los
+// comparan sin distinguir mayúsculas y así el QR usa el modo alfanumérico
+func buildUUID(values Values) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", i18n.Errorf("cannot generate a random identifier: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // Versión 4
+	b[8] = b[8]&0x3f | 0x80 // Variante RFC 9562
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func buildToken(values Values) (string, error) {
+	length := defaultTokenLength
+	if value := values["length"]; value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < minTokenLength || n > maxTokenLength {
+			return "", i18n.Errorf("--length must be a number from %d to %d", minTokenLength, maxTokenLength)
+		}
+		length = n
+	}
+
+	// 256 es múltiplo de 32: tomar cada byte módulo 32 no sesga el alfabeto
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", i18n.Errorf("cannot generate a random identifier: %w", err)
+	}
+	var sb strings.Builder
+	for _, c := range b {
+		sb.WriteByte(crockford[c%32])
+	}
+	return sb.String(), nil
+}
+
+// buildCount lee --count de un tipo que genera identificadores
+func buildCount(values Values) (int, error) {
+	value := values["count"]
+	if value == "" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > maxCount {
+		return 0, i18n.Errorf("--count must be a number from 1 to %d", maxCount)
+	}
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// keccak256 es el Keccak-256 original que usa Ethereum; difiere del SHA3-256
+// estandarizado solo en el relleno (0x01 en lugar de 0x06)
+func keccak256(data []byte) [32]byte {
+	const rate = 136
+	var state [25]uint64
+
+	padded := append([]byte(nil), data...)
+	padded = append(padded, 0x01)
+	for len(padded)%rate != 0 {
+		padded = append(padded, 0)
+	}
+	padded[len(padded)-1] |= 0x80
+
+	for block := padded; len(block) > 0; block = block[rate:] {
+		for i := 0; i < rate/8; i++ {
+			state[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+		}
+		keccakF(&state)
+	}
+
+	var sum [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(sum[i*8:], state[i])
+	}
+	return sum
+}
+
+// keccakRoundConstants son las constantes de iota de las 24 rondas
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations y keccakLanes recorren los carriles en el orden de rho y pi
+var (
+	keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakLanes     = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+// keccakF aplica la permutación Keccak-f[1600]
+func keccakF(a *[25]uint64) {
+	for round := 0; round < 24; round++ {
+		// theta
+		var c [5]uint64
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+		// rho y pi
+		current := a[1]
+		for i := 0; i < 24; i++ {
+			lane := keccakLanes[i]
+			current, a[lane] = a[lane], bits.RotateLeft64(current, keccakRotations[i])
+		}
+		// chi
+		for y := 0; y < 25; y += 5 {
+			var row [5]uint64
+			copy(row[:], a[y:y+5])
+			for x := 0; x < 5; x++ {
+				a[y+x] = row[x] ^ ^row[(x+1)%5]&row[(x+2)%5]
+			}
+		}
+		// iota
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
@@ -0,0 +1,211 @@
+package payload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Locale son las convenciones con las que se escriben importes y fechas en
+// un idioma y región. Los estándares de los payloads piden siempre la forma
+// canónica (1234.56, 2025-12-31); con --locale se aceptan también las locales
+// y se convierten antes de armar el payload.
+type Locale struct {
+	Tag     language.Tag
+	decimal byte   // Separador decimal: ',' o '.'
+	groups  string // Separadores de miles aceptados
+	indian  bool   // Agrupa de a dos después de los primeros tres (1,00,000)
+	order   string // Orden de día, mes y año: "dmy", "mdy" o "ymd"
+}
+
+// Idiomas y regiones cuyas convenciones difieren de la coma decimal y el
+// orden día/mes/año, que son los más comunes
+var (
+	decimalPointLanguages = map[string]bool{"en": true, "ja": true, "zh": true, "ko": true, "he": true, "th": true, "hi": true, "ms": true, "fil": true, "ga": true, "mt": true}
+	decimalPointRegions   = map[string]bool{"MX": true, "US": true, "PR": true, "GT": true, "DO": true, "HN": true, "NI": true, "PA": true, "SV": true, "PE": true, "CH": true, "LI": true}
+	ymdLanguages          = map[string]bool{"ja": true, "zh": true, "ko": true, "hu": true, "lt": true, "mn": true}
+	mdyRegions            = map[string]bool{"US": true, "PH": true, "PR": true, "FM": true, "MH": true}
+)
+
+// ParseLocale interpreta una etiqueta como es-AR, de, en_US.UTF-8 o pt-BR
+func ParseLocale(value string) (*Locale, error) {
+	name, _, _ := strings.Cut(strings.TrimSpace(value), ".")
+	tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+	if err != nil || tag == language.Und {
+		return nil, i18n.Errorf("unknown --locale %q; use a language tag such as es-AR, de or en-US", value)
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	lang, country := base.String(), region.String()
+
+	l := &Locale{Tag: tag, decimal: ',', groups: ". \u00a0\u202f", order: "dmy"}
+	if decimalPointLanguages[lang] || decimalPointRegions[country] {
+		l.decimal, l.groups = '.', ", \u00a0\u202f"
+	}
+	if country == "CH" || country == "LI" {
+		l.groups = "'’ \u00a0\u202f"
+	}
+	l.indian = country == "IN" || lang == "hi"
+	switch {
+	case ymdLanguages[lang]:
+		l.order = "ymd"
+	case mdyRegions[country]:
+		// Sin región se toma la más probable: "en" es inglés de Estados Unidos
+		l.order = "mdy"
+	}
+	return l, nil
+}
+
+// Amount convierte un importe escrito en la convención local ("1.234,56" en
+// es-AR) a la forma canónica ("1234.56"). Los separadores de miles tienen
+// que agrupar de a tres (o al modo indio), para no confundir un separador
+// decimal de otra convención con uno de miles.
+func (l *Locale) Amount(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	whole, fraction, hasFraction := strings.Cut(value, string(l.decimal))
+	var groups []string
+	start := 0
+	for i, r := range whole {
+		if strings.ContainsRune(l.groups, r) {
+			groups = append(groups, whole[start:i])
+			start = i + len(string(r))
+		}
+	}
+	groups = append(groups, whole[start:])
+	if len(groups) > 1 && !l.validGroups(groups) || strings.ContainsAny(fraction, l.groups+".,") {
+		return "", i18n.Errorf("invalid --amount %q for --locale %s; write it like %s", value, l.Tag, l.example())
+	}
+
+	canonical := strings.Join(groups, "")
+	if hasFraction {
+		canonical += "." + fraction
+	}
+	return canonical, nil
+}
+
+// validGroups revisa la posición de los separadores de miles
+func (l *Locale) validGroups(groups []string) bool {
+	if n := len(groups[0]); n < 1 || n > 3 || !isDigits(groups[0]) {
+		return false
+	}
+	last := len(groups) - 1
+	for i, group := range groups[1:] {
+		size := 3
+		if l.indian && i+1 < last {
+			size = 2
+		}
+		if len(group) != size || !isDigits(group) {
+			// En India también se usan grupos de a tres
+			if !(l.indian && len(group) == 3 && isDigits(group)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// example es un importe de muestra en la convención local
+func (l *Locale) example() string {
+	group := string([]rune(l.groups)[0])
+	return "1" + group + "234" + string(l.decimal) + "56"
+}
+
+// DateTime convierte una fecha con hora opcional escrita en el orden local
+// ("31/12/2025 18:30", "12/31/2025 6:30 PM") a la forma canónica que aceptan
+// los campos de fecha ("2025-12-31 18:30"). Las fechas que ya empiezan con
+// el año y un guion se dejan como están, con su zona horaria si la tienen.
+func (l *Locale) DateTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 5 && isDigits(value[:4]) && value[4] == '-' {
+		return value, nil
+	}
+	fail := func() (string, error) {
+		return "", i18n.Errorf("invalid date %q for --locale %s; write it like %s", value, l.Tag, l.dateExample())
+	}
+
+	date, clock, _ := strings.Cut(value, " ")
+	parts := strings.FieldsFunc(date, func(r rune) bool { return r == '/' || r == '.' || r == '-' })
+	if len(parts) != 3 {
+		return fail()
+	}
+	var day, month, year string
+	switch l.order {
+	case "mdy":
+		month, day, year = parts[0], parts[1], parts[2]
+	case "ymd":
+		year, month, day = parts[0], parts[1], parts[2]
+	default:
+		day, month, year = parts[0], parts[1], parts[2]
+	}
+	d, errD := strconv.Atoi(day)
+	m, errM := strconv.Atoi(month)
+	if errD != nil || errM != nil || len(year) != 4 || !isDigits(year) || m < 1 || m > 12 || d < 1 || d > 31 {
+		return fail()
+	}
+	canonical := fmt.Sprintf("%s-%02d-%02d", year, m, d)
+
+	clock = strings.TrimSpace(clock)
+	if clock == "" {
+		return canonical, nil
+	}
+	hour, ok := l.clock(clock)
+	if !ok {
+		return fail()
+	}
+	return canonical + " " + hour, nil
+}
+
+// clock convierte una hora de 24 horas o con AM/PM ("6:30 PM") a "18:30"
+func (l *Locale) clock(value string) (string, bool) {
+	upper := strings.ToUpper(strings.ReplaceAll(value, ".", ""))
+	suffix := ""
+	for _, s := range []string{"AM", "PM"} {
+		if rest, ok := strings.CutSuffix(upper, s); ok {
+			upper, suffix = strings.TrimSpace(rest), s
+		}
+	}
+	parts := strings.Split(upper, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", false
+	}
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || i > 0 && len(part) != 2 {
+			return "", false
+		}
+		numbers[i] = n
+	}
+	hour := numbers[0]
+	switch {
+	case suffix != "" && (hour < 1 || hour > 12):
+		return "", false
+	case suffix == "AM" && hour == 12:
+		hour = 0
+	case suffix == "PM" && hour < 12:
+		hour += 12
+	}
+	if hour > 23 || numbers[1] > 59 || len(numbers) == 3 && numbers[2] > 59 {
+		return "", false
+	}
+	clock := fmt.Sprintf("%02d:%02d", hour, numbers[1])
+	if len(numbers) == 3 {
+		clock += fmt.Sprintf(":%02d", numbers[2])
+	}
+	return clock, true
+}
+
+// dateExample es una fecha de muestra en el orden local
+func (l *Locale) dateExample() string {
+	switch l.order {
+	case "mdy":
+		return "12/31/2025 6:30 PM"
+	case "ymd":
+		return "2025/12/31 18:30"
+	}
+	return "31/12/2025 18:30"
+}
@@ -0,0 +1,65 @@
+package payload
+
+import "strings"
+
+// mecardType arma el formato MECARD:, más compacto que vCard, para los lectores
+// que lo prefieren. Comparte los campos de contacto de vCard; MeCard no tiene
+// cargo ni tipos de teléfono o email, así que los prefijos de tipo se ignoran.
+var mecardType = &Type{
+	Name:   "mecard",
+	Fields: fieldsWithout(contactFields, "title"),
+	Build:  buildMeCard,
+}
+
+// mecardEscaper escapa los caracteres reservados de MeCard
+var mecardEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `:`, `\:`, `,`, `\,`, `"`, `\"`)
+
+func buildMeCard(values Values) (string, error) {
+	c, err := parseContact(values, "mecard")
+	if err != nil {
+		return "", err
+	}
+
+	esc := mecardEscaper.Replace
+	var sb strings.Builder
+	sb.WriteString("MECARD:N:" + esc(c.family))
+	if c.given != "" {
+		sb.WriteString("," + esc(c.given))
+	}
+	sb.WriteString(";")
+	if org := values["org"]; org != "" {
+		sb.WriteString("ORG:" + esc(org) + ";")
+	}
+	for _, phone := range c.phones {
+		sb.WriteString("TEL:" + esc(phone.value) + ";")
+	}
+	for _, email := range c.emails {
+		sb.WriteString("EMAIL:" + esc(email.value) + ";")
+	}
+	if !c.birthday.IsZero() {
+		sb.WriteString("BDAY:" + c.birthday.Format("20060102") + ";")
+	}
+	if website := values["website"]; website != "" {
+		sb.WriteString("URL:" + esc(website) + ";")
+	}
+	if c.hasAddress() {
+		parts := []string{"", ""} // Apartado postal y departamento
+		for _, part := range []string{"street", "city", "region", "postcode", "country"} {
+			parts = append(parts, esc(values["address-"+part]))
+		}
+		sb.WriteString("ADR:" + strings.Join(parts, ",") + ";")
+	}
+	sb.WriteString(";")
+	return sb.String(), nil
+}
+
+// fieldsWithout copia los campos salvo el indicado
+func fieldsWithout(fields []Field, name string) []Field {
+	var kept []Field
+	for _, field := range fields {
+		if field.Name != name {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,143 @@
+package payload
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// defaultPorts son los puertos que se sobreentienden en cada esquema
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// NormalizeURL deja un payload URL en la forma que mejor leen los escáneres:
+// agrega https:// si falta (www.example.com), pasa el host a minúsculas, quita
+// el puerto por defecto y convierte los dominios internacionales a punycode.
+// Los payloads que no son URL http(s) ni parecen un dominio (texto, mailto:,
+// WIFI:...) se devuelven sin cambios; el camino, la query y el fragmento se
+// conservan tal cual.
+func NormalizeURL(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if !hasScheme(value) {
+		if !looksLikeHost(value) {
+			return raw, nil
+		}
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	lower := strings.ToLower(value)
+	if err != nil && (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) {
+		return "", i18n.Errorf("the URL %s is malformed: %w", abbreviate(raw), errors.Unwrap(err))
+	}
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return raw, nil
+	}
+	if u.Host == "" {
+		return "", i18n.Errorf("the URL %s has no host", abbreviate(raw))
+	}
+
+	// Solo los dominios internacionales pasan por idna, que es más estricto que
+	// los resolvers con los nombres ASCII (acepta my_host.intranet, por ejemplo)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if _, err := netip.ParseAddr(host); err != nil && !isASCII(host) {
+		if host, err = idna.Lookup.ToASCII(host); err != nil {
+			return "", i18n.Errorf("the URL %s has an invalid host: %w", abbreviate(raw), err)
+		}
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	}
+	if port != "" && port != defaultPorts[u.Scheme] {
+		u.Host += ":" + port
+	}
+
+	// El resto se copia del original: u.String() volvería a escapar el camino
+	// (!, ', paréntesis y los caracteres no ASCII)
+	rest := value[strings.Index(value, "://")+3:]
+	end := strings.IndexAny(rest, "/?#")
+	if end < 0 {
+		end = len(rest)
+	}
+	userinfo := ""
+	if at := strings.LastIndex(rest[:end], "@"); at >= 0 {
+		userinfo = rest[:at+1]
+	}
+	return u.Scheme + "://" + userinfo + u.Host + rest[end:], nil
+}
+
+// hasScheme informa si el payload empieza con un esquema (https://, mailto:,
+// WIFI:...). Un nombre con puntos antes de los dos puntos es un host con
+// puerto, no un esquema: www.example.com:8080
+func hasScheme(value string) bool {
+	if strings.Contains(value, "://") {
+		return true
+	}
+	scheme, _, found := strings.Cut(value, ":")
+	if !found || scheme == "" || strings.Contains(scheme, ".") {
+		return false
+	}
+	for i, r := range scheme {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-')) {
+			return false
+		}
+	}
+	return true
+}
+
+// looksLikeHost informa si un payload sin esquema empieza con un dominio:
+// etiquetas de letras, dígitos y guiones separadas por puntos, con un dominio
+// de primer nivel de letras. Así "www.example.com/menu" se completa y un
+// texto como "hola mundo" o "v1.2" no.
+func looksLikeHost(value string) bool {
+	if value == "" || strings.ContainsAny(value, " \t\r\n") {
+		return false
+	}
+	end := strings.IndexAny(value, "/?#")
+	if end < 0 {
+		end = len(value)
+	}
+	host := value[:end]
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-') {
+				return false
+			}
+		}
+	}
+	tld := labels[len(labels)-1]
+	for _, r := range tld {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return len([]rune(tld)) >= 2
+}
+
+// isASCII informa si s no tiene caracteres fuera de ASCII
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package payload
+
+import (
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Field es un dato de un tipo de payload; en la CLI cada campo es un flag
+type Field struct {
+	Name  string
+	Usage string    // Ayuda en inglés; se traduce al registrar el flag
+	Bool  bool      // Flag sin valor, como --hidden
+	Kind  FieldKind // Cómo se convierte el valor con --locale
+}
+
+// FieldKind indica si el valor de un campo se escribe distinto según la
+// convención local y cómo se lleva a la forma canónica
+type FieldKind int
+
+const (
+	KindText   FieldKind = iota // Sin conversión
+	KindAmount                  // Importe: "1.234,56" en es-AR
+	KindDate                    // Fecha con hora opcional: "31/12/2025 18:30" en es-AR
+)
+
+// Values son los campos indicados, por nombre
+type Values map[string]string
+
+// Bool interpreta un campo booleano
+func (v Values) Bool(name string) bool {
+	return v[name] == "true"
+}
+
+// Type es un tipo de payload: los campos que usa y cómo se arma el contenido
+type Type struct {
+	Name   string
+	Fields []Field
+	Build  func(values Values) (string, error)
+	Arg    string // Campo que se puede pasar como argumento después de los flags
+	FNC1   bool   // El payload es una cadena de elementos GS1: se codifica con FNC1
+	// Generated indica que cada llamada a Build da un identificador nuevo:
+	// --count arma varios payloads distintos
+	Generated bool
+}
+
+// types asocia cada nombre de tipo con su definición
+var types = map[string]*Type{
+	"bitcoin":         bitcoinType,
+	"epc":             epcType,
+	"email":           emailType,
+	"ethereum":        ethereumType,
+	"event":           eventType,
+	"geo":             geoType,
+	"gs1":             gs1Type,
+	"gs1-link":        gs1LinkType,
+	"mecard":          mecardType,
+	"pgp":             pgpType,
+	"pix":             pixType,
+	"sms":             smsType,
+	"social":          socialType,
+	"ssh-fingerprint": sshFingerprintType,
+	"ssh-key":         sshKeyType,
+	"tel":             telType,
+	"token":           tokenType,
+	"totp":            totpType,
+	"upi":             upiType,
+	"uuid":            uuidType,
+	"vcard":           vcardType,
+	"wifi":            wifiType,
+	"wireguard":       wireguardType,
+}
+
+// Lookup busca un tipo por nombre
+func Lookup(name string) (*Type, bool) {
+	t, ok := types[strings.ToLower(name)]
+	return t, ok
+}
+
+// Types devuelve los nombres de los tipos, ordenados
+func Types() []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Fields devuelve los campos de todos los tipos, sin repetir y ordenados por
+// nombre; los tipos comparten un campo cuando significa lo mismo (phone, email)
+func Fields() []Field {
+	seen := map[string]bool{}
+	var fields []Field
+	for _, name := range Types() {
+		for _, field := range types[name].Fields {
+			if !seen[field.Name] {
+				seen[field.Name] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	slices.SortFunc(fields, func(a, b Field) int { return strings.Compare(a.Name, b.Name) })
+	return fields
+}
+
+// Has indica si el tipo usa el campo
+func (t *Type) Has(name string) bool {
+	return slices.ContainsFunc(t.Fields, func(f Field) bool { return f.Name == name })
+}
+
+// TypesWith devuelve los tipos que usan el campo, para sugerirlos en los errores
+func TypesWith(field string) []string {
+	var names []string
+	for _, name := range Types() {
+		if types[name].Has(field) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Build arma los payloads del tipo indicado: uno, o --count distintos en
+// los tipos que generan identificadores. Con locale, los importes y las
+// fechas pueden venir en la convención local y se convierten antes.
+func Build(name string, values Values, locale *Locale) ([]string, error) {
+	t, ok := Lookup(name)
+	if !ok {
+		return nil, i18n.Errorf("unknown payload type %q (%s)", name, strings.Join(Types(), ", "))
+	}
+	for field := range values {
+		if !t.Has(field) {
+			return nil, i18n.Errorf("--%s is not used by --type %s", field, t.Name)
+		}
+	}
+	if locale != nil {
+		var err error
+		if values, err = t.localize(values, locale); err != nil {
+			return nil, err
+		}
+	}
+	if !t.Generated {
+		content, err := t.Build(values)
+		if err != nil {
+			return nil, err
+		}
+		return []string{content}, nil
+	}
+
+	count, err := buildCount(values)
+	if err != nil {
+		return nil, err
+	}
+	// Un token corto se puede repetir en un lote grande: se vuelve a sortear
+	seen := make(map[string]bool, count)
+	contents := make([]string, 0, count)
+	for attempts := 0; len(contents) < count; attempts++ {
+		if attempts == 10*count {
+			return nil, i18n.Errorf("cannot generate %d distinct identifiers; raise --length", count)
+		}
+		content, err := t.Build(values)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[content] {
+			seen[content] = true
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
+
+// localize devuelve una copia de los valores con los importes y las fechas
+// en la forma canónica
+func (t *Type) localize(values Values, locale *Locale) (Values, error) {
+	canonical := make(Values, len(values))
+	for _, field := range t.Fields {
+		value, ok := values[field.Name]
+		if !ok {
+			continue
+		}
+		var err error
+		switch field.Kind {
+		case KindAmount:
+			value, err = locale.Amount(value)
+		case KindDate:
+			value, err = locale.DateTime(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		canonical[field.Name] = value
+	}
+	return canonical, nil
+}
+
+// percentEscape codifica en porcentaje todo lo que no sea un carácter no
+// reservado ni esté en keep. No usa url.QueryEscape porque escribe los
+// espacios como "+", que los clientes de correo y de mapas muestran literalmente.
+func percentEscape(value, keep string) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~"+keep, c) >= 0 {
+			sb.WriteByte(c)
+		} else {
+			sb.WriteByte('%')
+			sb.WriteByte(hex[c>>4])
+			sb.WriteByte(hex[c&0x0f])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,146 @@
+package payload
+
+import (
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// regionField lo comparten los tipos que marcan o escriben a un teléfono
+var regionField = Field{Name: "region", Usage: "Country of phone numbers written without +, as a two-letter code such as US, ES or AR; the number is checked and written in international (E.164) form (--type tel or sms)"}
+
+// phoneRegion son las reglas de numeración de un país: el código de país, el
+// prefijo que se marca dentro del país antes del número nacional y el largo
+// del número nacional sin ese prefijo
+type phoneRegion struct {
+	code     string
+	trunk    string
+	min, max int
+}
+
+// phoneRegions son los países cuyos números se pueden escribir sin +; los
+// números internacionales de otros países solo se revisan contra el largo
+// máximo de E.164
+var phoneRegions = map[string]phoneRegion{
+	// Plan de numeración de América del Norte: comparten el código 1
+	"US": {"1", "1", 10, 10}, "CA": {"1", "1", 10, 10}, "PR": {"1", "1", 10, 10}, "DO": {"1", "1", 10, 10},
+
+	"AR": {"54", "0", 10, 11}, "BO": {"591", "0", 8, 8}, "BR": {"55", "0", 10, 11}, "CL": {"56", "", 9, 9},
+	"CO": {"57", "", 10, 10}, "EC": {"593", "0", 8, 9}, "MX": {"52", "", 10, 10}, "PE": {"51", "0", 8, 9},
+	"PY": {"595", "0", 7, 9}, "UY": {"598", "0", 8, 8}, "VE": {"58", "0", 10, 10},
+
+	"AT": {"43", "0", 4, 13}, "BE": {"32", "0", 8, 9}, "CH": {"41", "0", 9, 9}, "DE": {"49", "0", 6, 13},
+	"DK": {"45", "", 8, 8}, "ES": {"34", "", 9, 9}, "FI": {"358", "0", 5, 12}, "FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10}, "IE": {"353", "0", 7, 9}, "IT": {"39", "", 6, 11}, "NL": {"31", "0", 9, 9},
+	"NO": {"47", "", 8, 8}, "PL": {"48", "", 9, 9}, "PT": {"351", "", 9, 9}, "SE": {"46", "0", 7, 10},
+	"RU": {"7", "8", 10, 10}, "TR": {"90", "0", 10, 10},
+
+	"AU": {"61", "0", 9, 9}, "CN": {"86", "0", 10, 11}, "IL": {"972", "0", 8, 9}, "IN": {"91", "0", 10, 10},
+	"JP": {"81", "0", 9, 10}, "KR": {"82", "0", 8, 10}, "NZ": {"64", "0", 8, 10}, "ZA": {"27", "0", 9, 9},
+}
+
+// e164Max es el largo máximo de un número E.164, código de país incluido
+const e164Max = 15
+
+// shortCodeMax es el largo máximo de los números cortos (emergencias,
+// servicios de SMS), que no llevan código de país y se marcan tal cual
+const shortCodeMax = 6
+
+// NormalizePhone revisa un teléfono y lo devuelve listo para marcar. Los
+// números con + (o 00) y los números locales con region se devuelven en forma
+// E.164 (+5491123456789) y se rechazan si su largo no es posible en el país.
+// Sin region, los números locales y los números cortos quedan como se marcan.
+func NormalizePhone(number, region string) (string, error) {
+	number = strings.TrimSpace(number)
+	if !validPhone(number) || strings.LastIndex(number, "+") > 0 {
+		return "", i18n.Errorf("invalid phone number %q", number)
+	}
+	rules, hasRegion := phoneRegions[strings.ToUpper(region)]
+	if region != "" && !hasRegion {
+		return "", i18n.Errorf("unknown phone region %q; use a two-letter country code such as US, ES or AR", region)
+	}
+
+	digits := strings.Map(dialRune, number)
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		return internationalPhone(number, digits[1:])
+	case strings.HasPrefix(digits, "00"):
+		return internationalPhone(number, digits[2:])
+	case len(digits) <= shortCodeMax || !hasRegion:
+		return digits, nil
+	}
+
+	national := strings.TrimPrefix(digits, rules.trunk)
+	if rules.code == "54" {
+		national = argentineMobile(national)
+	}
+	if err := checkNational(number, strings.ToUpper(region), rules, national); err != nil {
+		return "", err
+	}
+	return "+" + rules.code + national, nil
+}
+
+// internationalPhone revisa un número escrito con su código de país
+func internationalPhone(number, digits string) (string, error) {
+	if digits == "" || digits[0] == '0' {
+		return "", i18n.Errorf("invalid phone number %q: it has no country code after +", number)
+	}
+	if len(digits) > e164Max {
+		return "", i18n.Errorf("invalid phone number %q: international numbers have at most %d digits", number, e164Max)
+	}
+	// Los códigos de país no son prefijo uno de otro: a lo sumo coincide uno
+	for region, rules := range phoneRegions {
+		if national, ok := strings.CutPrefix(digits, rules.code); ok {
+			if err := checkNational(number, region, rules, national); err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+	return "+" + digits, nil
+}
+
+// checkNational revisa el largo del número nacional y, en América del Norte,
+// que el código de área y la central no empiecen con 0 ni 1
+func checkNational(number, region string, rules phoneRegion, national string) error {
+	if len(national) < rules.min || len(national) > rules.max {
+		length := strconv.Itoa(rules.min)
+		if rules.max != rules.min {
+			length += "-" + strconv.Itoa(rules.max)
+		}
+		if rules.code == "1" {
+			region = "North American"
+		}
+		return i18n.Errorf("invalid phone number %q: %s numbers have %s digits after +%s, not %d", number, region, length, rules.code, len(national))
+	}
+	if rules.code == "1" {
+		area, exchange := national[:3], national[3:6]
+		if area[0] < '2' {
+			return i18n.Errorf("invalid phone number %q: area code %s starts with %c; North American area codes cannot start with 0 or 1", number, area, area[0])
+		}
+		if exchange[0] < '2' {
+			return i18n.Errorf("invalid phone number %q: exchange %s starts with %c; North American exchanges cannot start with 0 or 1", number, exchange, exchange[0])
+		}
+	}
+	return nil
+}
+
+// argentineMobile pasa un celular argentino de la forma local, con 15 después
+// del código de área (11 15 2345 6789), a la internacional, con 9 antes del
+// código de área (9 11 2345 6789). El área tiene 2 dígitos en Buenos Aires
+// (11) y 3 o 4 en el resto del país, siempre con 10 dígitos en total.
+func argentineMobile(national string) string {
+	if len(national) != 12 {
+		return national
+	}
+	for _, area := range []int{2, 3, 4} {
+		if area == 2 && !strings.HasPrefix(national, "11") {
+			continue
+		}
+		if national[area:area+2] == "15" {
+			return "9" + national[:area] + national[area+2:]
+		}
+	}
+	return national
+}
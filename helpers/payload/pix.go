@@ -0,0 +1,221 @@
+package payload
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// pixType arma el BR Code estático de PIX del Banco Central do Brasil: un
+// payload EMV-QRCPS de campos ID + largo + valor que termina con un CRC16
+var pixType = &Type{
+	Name: "pix",
+	Fields: []Field{
+		{Name: "key", Usage: "PIX key: CPF, CNPJ, +55 phone number, email or random key (--type pix)"},
+		nameField,
+		{Name: "city", Usage: "Merchant city, up to 15 characters (--type pix)"},
+		amountField,
+		{Name: "txid", Usage: "Transaction ID, up to 25 letters and digits (--type pix)"},
+		infoField,
+	},
+	Build: buildPIX,
+}
+
+// Límites del manual del BR Code
+const (
+	pixGUI          = "br.gov.bcb.pix"
+	pixMaxAccount   = 99 // Largo máximo del campo 26 (GUI, clave e información)
+	pixMaxName      = 25
+	pixMaxCity      = 15
+	pixMaxTxID      = 25
+	pixMaxAmount    = 9_999_999_999
+	pixDefaultTxID  = "***" // Sin identificador de la transacción
+	pixCurrencyReal = "986" // ISO 4217
+)
+
+func buildPIX(values Values) (string, error) {
+	key, err := pixKey(values["key"])
+	if err != nil {
+		return "", err
+	}
+	name, err := pixText(values, "name", pixMaxName)
+	if err != nil {
+		return "", err
+	}
+	city, err := pixText(values, "city", pixMaxCity)
+	if err != nil {
+		return "", err
+	}
+	if name == "" || city == "" {
+		return "", i18n.Errorf("--type pix needs --name and --city")
+	}
+
+	info, err := pixText(values, "info", pixMaxAccount)
+	if err != nil {
+		return "", err
+	}
+	account := emvField("00", pixGUI) + emvField("01", key)
+	if info != "" {
+		// La información comparte el campo 26 con la clave
+		if room := pixMaxAccount - len(account) - 4; len(info) > room {
+			return "", i18n.Errorf("--info has %d characters; with this key only %d fit", len(info), max(room, 0))
+		}
+		account += emvField("02", info)
+	}
+
+	var amount string
+	if value := strings.TrimSpace(values["amount"]); value != "" {
+		if amount, err = decimalAmount(value, 2, pixMaxAmount); err != nil {
+			return "", err
+		}
+		whole, cents, _ := strings.Cut(amount, ".")
+		amount = whole + "." + cents + strings.Repeat("0", 2-len(cents))
+	}
+
+	txid := pixDefaultTxID
+	if value := strings.TrimSpace(values["txid"]); value != "" {
+		if len(value) > pixMaxTxID || !isAlnum(strings.ToUpper(value)) {
+			return "", i18n.Errorf("invalid --txid %q; use up to %d letters and digits", value, pixMaxTxID)
+		}
+		txid = value
+	}
+
+	var sb strings.Builder
+	sb.WriteString(emvField("00", "01"))
+	sb.WriteString(emvField("26", account))
+	sb.WriteString(emvField("52", "0000"))
+	sb.WriteString(emvField("53", pixCurrencyReal))
+	if amount != "" {
+		sb.WriteString(emvField("54", amount))
+	}
+	sb.WriteString(emvField("58", "BR"))
+	sb.WriteString(emvField("59", name))
+	sb.WriteString(emvField("60", city))
+	sb.WriteString(emvField("62", emvField("05", txid)))
+	// El CRC cubre todo el payload, incluidos el ID y el largo del propio CRC
+	sb.WriteString("6304")
+	sb.WriteString(fmt.Sprintf("%04X", crc16CCITT(sb.String())))
+	return sb.String(), nil
+}
+
+// emvField codifica un campo EMV: ID de dos dígitos, largo de dos dígitos y valor
+func emvField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// crc16CCITT es el CRC16 del BR Code: polinomio 0x1021 y valor inicial 0xFFFF
+func crc16CCITT(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// pixKey valida la clave PIX y la devuelve en la forma que registra el
+// banco: teléfono +55 con DDD, correo en minúsculas, clave aleatoria (UUID)
+// en minúsculas, o CPF y CNPJ solo con dígitos y sus dígitos verificadores
+func pixKey(value string) (string, error) {
+	key := strings.TrimSpace(value)
+	switch {
+	case key == "":
+		return "", i18n.Errorf("--type pix needs --key")
+	case strings.HasPrefix(key, "+"):
+		phone := "+" + strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, key)
+		if !strings.HasPrefix(phone, "+55") || len(phone) < 13 || len(phone) > 14 {
+			return "", i18n.Errorf("invalid --key %q; a phone key is +55, the area code and the number", value)
+		}
+		return phone, nil
+	case strings.Contains(key, "@"):
+		if len(key) > 77 || strings.ContainsAny(key, " ,;") || strings.Count(key, "@") != 1 {
+			return "", i18n.Errorf("invalid --key %q; not an email address", value)
+		}
+		return strings.ToLower(key), nil
+	case len(key) == 36 && strings.Count(key, "-") == 4:
+		if !isAlnum(strings.ToUpper(strings.ReplaceAll(key, "-", ""))) {
+			return "", i18n.Errorf("invalid --key %q; a random key is a UUID", value)
+		}
+		return strings.ToLower(key), nil
+	}
+
+	digits := strings.NewReplacer(".", "", "-", "", "/", "", " ", "").Replace(key)
+	switch {
+	case len(digits) == 11 && isDigits(digits):
+		if !cpfValid(digits) {
+			return "", i18n.Errorf("invalid --key %q: wrong CPF check digits (typo?)", value)
+		}
+	case len(digits) == 14 && isDigits(digits):
+		if !cnpjValid(digits) {
+			return "", i18n.Errorf("invalid --key %q: wrong CNPJ check digits (typo?)", value)
+		}
+	default:
+		return "", i18n.Errorf("invalid --key %q; use a CPF, CNPJ, +55 phone number, email or random key", value)
+	}
+	return digits, nil
+}
+
+// cpfValid revisa los dos dígitos verificadores (módulo 11) de un CPF
+func cpfValid(cpf string) bool {
+	if strings.Count(cpf, cpf[:1]) == len(cpf) {
+		return false // 111.111.111-11 y similares pasan el cálculo pero no existen
+	}
+	return checkDigit(cpf[:9], []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[9] &&
+		checkDigit(cpf[:10], []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == cpf[10]
+}
+
+// cnpjValid revisa los dos dígitos verificadores (módulo 11) de un CNPJ
+func cnpjValid(cnpj string) bool {
+	if strings.Count(cnpj, cnpj[:1]) == len(cnpj) {
+		return false
+	}
+	return checkDigit(cnpj[:12], []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[12] &&
+		checkDigit(cnpj[:13], []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == cnpj[13]
+}
+
+// checkDigit calcula el dígito verificador módulo 11 con los pesos indicados
+func checkDigit(digits string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	if rest := sum % 11; rest >= 2 {
+		return byte('0' + 11 - rest)
+	}
+	return '0'
+}
+
+// pixText valida un campo de texto del BR Code. Los lectores solo aceptan
+// ASCII, así que se quitan los acentos (São Paulo pasa a Sao Paulo).
+func pixText(values Values, field string, limit int) (string, error) {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.TrimSpace(values[field])) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case r < ' ' || r > '~':
+			return "", i18n.Errorf("--%s must be a single line of plain text (--type pix)", field)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	text := sb.String()
+	if len(text) > limit {
+		return "", i18n.Errorf("--%s has %d characters; the PIX format allows %d", field, len(text), limit)
+	}
+	return text, nil
+}
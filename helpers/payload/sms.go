@@ -0,0 +1,48 @@
+package payload
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// smsType arma el formato SMSTO:<número>:<texto>, que abre la app de mensajes
+// con el destinatario y el texto completos
+var smsType = &Type{
+	Name:   "sms",
+	Fields: []Field{toField, bodyField, regionField},
+	Build:  buildSMS,
+}
+
+// toField y bodyField los comparten los tipos de mensaje (sms y email)
+var (
+	toField   = Field{Name: "to", Usage: "Recipient: a phone number such as +14155550132 (--type sms) or comma-separated email addresses (--type email)"}
+	bodyField = Field{Name: "body", Usage: "Message text (--type sms or email)"}
+)
+
+func buildSMS(values Values) (string, error) {
+	to := strings.TrimSpace(values["to"])
+	if to == "" {
+		return "", i18n.Errorf("--type sms needs --to")
+	}
+	to, err := NormalizePhone(to, values["region"])
+	if err != nil {
+		return "", err
+	}
+	// El texto va sin escapar: los lectores toman como número solo lo que está
+	// antes del primer ":"
+	return "SMSTO:" + to + ":" + values["body"], nil
+}
+
+// validPhone acepta dígitos, "+" y los separadores habituales (espacios, guiones, paréntesis y puntos)
+func validPhone(phone string) bool {
+	return strings.Trim(phone, "+0123456789 -().") == "" && strings.ContainsAny(phone, "0123456789")
+}
+
+// dialRune conserva de un teléfono solo los dígitos y el "+"
+func dialRune(r rune) rune {
+	if r >= '0' && r <= '9' || r == '+' {
+		return r
+	}
+	return -1
+}
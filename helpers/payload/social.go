@@ -0,0 +1,89 @@
+package payload
+
+import (
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// socialType arma el enlace canónico de un perfil o canal; las apps de cada
+// red lo abren directamente y en el navegador lleva a la misma página
+var socialType = &Type{
+	Name: "social",
+	Fields: []Field{
+		{Name: "platform", Usage: "Social network: telegram, instagram, x (or twitter), linkedin or youtube (--type social)"},
+		{Name: "handle", Usage: "Profile handle, with or without @; linkedin also takes company/NAME, youtube a UC... channel ID (--type social)"},
+	},
+	Build: buildSocial,
+}
+
+// socialPlatform describe los nombres de usuario de una red y su enlace
+type socialPlatform struct {
+	min, max int    // Largo del nombre
+	extra    string // Caracteres admitidos además de letras y dígitos
+	url      string // Enlace, con %s en lugar del nombre
+	lower    bool   // Los nombres no distinguen mayúsculas y se escriben en minúsculas
+}
+
+// socialPlatforms son las redes admitidas, con las reglas de nombres de cada una
+var socialPlatforms = map[string]socialPlatform{
+	"telegram":  {min: 5, max: 32, extra: "_", url: "https://t.me/%s"},
+	"instagram": {min: 1, max: 30, extra: "._", url: "https://www.instagram.com/%s/", lower: true},
+	"x":         {min: 1, max: 15, extra: "_", url: "https://x.com/%s"},
+	"linkedin":  {min: 3, max: 100, extra: "-", url: "https://www.linkedin.com/in/%s"},
+	"youtube":   {min: 3, max: 30, extra: "._-", url: "https://www.youtube.com/@%s"},
+}
+
+func buildSocial(values Values) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(values["platform"]))
+	if name == "twitter" {
+		name = "x"
+	}
+	platform, ok := socialPlatforms[name]
+	if !ok {
+		names := make([]string, 0, len(socialPlatforms))
+		for name := range socialPlatforms {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return "", i18n.Errorf("--type social needs --platform: %s", strings.Join(names, ", "))
+	}
+	handle := strings.TrimPrefix(strings.TrimSpace(values["handle"]), "@")
+	if handle == "" {
+		return "", i18n.Errorf("--type social needs --handle")
+	}
+
+	url := platform.url
+	switch {
+	case name == "linkedin" && strings.HasPrefix(handle, "company/"):
+		url, handle = "https://www.linkedin.com/company/%s", strings.TrimPrefix(handle, "company/")
+	case name == "youtube" && len(handle) == 24 && strings.HasPrefix(handle, "UC"):
+		// Los ID de canal no llevan @ y distinguen mayúsculas
+		if !validHandle(handle, "-_") {
+			return "", i18n.Errorf("invalid YouTube channel ID %q", handle)
+		}
+		return "https://www.youtube.com/channel/" + handle, nil
+	}
+
+	if len(handle) < platform.min || len(handle) > platform.max || !validHandle(handle, platform.extra) {
+		return "", i18n.Errorf("invalid %s handle %q: %d to %d letters, digits or %s", name, handle, platform.min, platform.max, platform.extra)
+	}
+	if name == "telegram" && !isUpperAlpha(strings.ToUpper(handle[:1])) {
+		return "", i18n.Errorf("invalid telegram handle %q: it must start with a letter", handle)
+	}
+	if platform.lower {
+		handle = strings.ToLower(handle)
+	}
+	return strings.Replace(url, "%s", handle, 1), nil
+}
+
+// validHandle indica si el nombre tiene solo letras ASCII, dígitos y extra
+func validHandle(handle, extra string) bool {
+	for _, r := range handle {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(extra, r)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,251 @@
+package payload
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"math/big"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// sshFields son los campos de ssh-key y ssh-fingerprint: una clave pública
+// (.pub o authorized_keys) o una entrada de known_hosts
+var sshFields = []Field{
+	{Name: "public-key", Usage: "SSH public key (.pub) or known_hosts file; also the argument after the flags (--type ssh-key, ssh-fingerprint)"},
+	{Name: "host", Usage: "Host whose known_hosts entry to use, also for hashed entries; [host]:port for other ports (--type ssh-key, ssh-fingerprint)"},
+}
+
+// sshKeyType codifica la clave pública tal como se pega en authorized_keys, o
+// la entrada de known_hosts tal como se agrega al archivo del otro equipo
+var sshKeyType = &Type{
+	Name:   "ssh-key",
+	Fields: sshFields,
+	Build:  buildSSHKey,
+	Arg:    "public-key",
+}
+
+// sshFingerprintType codifica la huella SHA256 de la clave en el formato de
+// ssh-keygen -l, para compararla con la que muestra ssh al conectarse
+var sshFingerprintType = &Type{
+	Name:   "ssh-fingerprint",
+	Fields: sshFields,
+	Build:  buildSSHFingerprint,
+	Arg:    "public-key",
+}
+
+// sshKeyTypes son los tipos de clave admitidos, con su nombre en ssh-keygen -l
+var sshKeyTypes = map[string]string{
+	"ssh-ed25519":                        "ED25519",
+	"ssh-rsa":                            "RSA",
+	"ecdsa-sha2-nistp256":                "ECDSA",
+	"ecdsa-sha2-nistp384":                "ECDSA",
+	"ecdsa-sha2-nistp521":                "ECDSA",
+	"sk-ssh-ed25519@openssh.com":         "ED25519-SK",
+	"sk-ecdsa-sha2-nistp256@openssh.com": "ECDSA-SK",
+}
+
+// sshEntry es una línea de un archivo de claves
+type sshEntry struct {
+	line    int
+	marker  string // @cert-authority o @revoked, solo en known_hosts
+	hosts   string // "" en una clave pública
+	keyType string
+	blob    []byte
+	comment string
+	bits    int
+}
+
+func buildSSHKey(values Values) (string, error) {
+	entry, err := sshSelect(values)
+	if err != nil {
+		return "", err
+	}
+	fields := []string{entry.marker, entry.hosts, entry.keyType, base64.StdEncoding.EncodeToString(entry.blob), entry.comment}
+	return strings.Join(strings.Fields(strings.Join(fields, " ")), " "), nil
+}
+
+func buildSSHFingerprint(values Values) (string, error) {
+	entry, err := sshSelect(values)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(entry.blob)
+	// Como ssh-keygen -F, la huella lleva el host buscado: los hosts cifrados
+	// no se pueden mostrar y los patrones no dicen a qué equipo se conecta
+	label := entry.comment
+	switch {
+	case values["host"] != "":
+		label = strings.TrimSpace(values["host"])
+	case entry.hosts != "" && !strings.HasPrefix(entry.hosts, "|"):
+		label = entry.hosts
+	case label == "":
+		label = "no comment"
+	}
+	return fmt.Sprintf("%d SHA256:%s %s (%s)", entry.bits, base64.RawStdEncoding.EncodeToString(sum[:]), label, sshKeyTypes[entry.keyType]), nil
+}
+
+// sshSelect lee el archivo y elige la clave: la única que tiene o, con
+// --host, la de la entrada de known_hosts de ese host
+func sshSelect(values Values) (*sshEntry, error) {
+	path := values["public-key"]
+	if path == "" {
+		return nil, i18n.Errorf("--type ssh-key and ssh-fingerprint need a key file: --public-key ~/.ssh/id_ed25519.pub")
+	}
+	entries, err := readSSHKeys(path)
+	if err != nil {
+		return nil, err
+	}
+
+	host := strings.TrimSpace(values["host"])
+	if host != "" {
+		var matches []*sshEntry
+		for _, entry := range entries {
+			if entry.hosts != "" && sshHostMatch(entry.hosts, host) {
+				matches = append(matches, entry)
+			}
+		}
+		entries = matches
+	}
+	switch {
+	case len(entries) == 0 && host != "":
+		return nil, i18n.Errorf("%s has no known_hosts entry for %s", path, host)
+	case len(entries) == 0:
+		return nil, i18n.Errorf("%s has no SSH keys", path)
+	case len(entries) > 1:
+		return nil, i18n.Errorf("%s has %d keys (lines %d and %d...); pick one with --host", path, len(entries), entries[0].line, entries[1].line)
+	}
+	if entries[0].marker == "@revoked" {
+		return nil, i18n.Errorf("%s:%d: the key is marked @revoked", path, entries[0].line)
+	}
+	return entries[0], nil
+}
+
+// readSSHKeys lee las claves de un .pub, authorized_keys o known_hosts
+func readSSHKeys(path string) ([]*sshEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read the SSH key: %w", err)
+	}
+	defer f.Close()
+
+	var entries []*sshEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		entry := &sshEntry{line: n}
+		if strings.HasPrefix(fields[0], "@") {
+			entry.marker, fields = fields[0], fields[1:]
+			if entry.marker != "@cert-authority" && entry.marker != "@revoked" {
+				return nil, i18n.Errorf("%s:%d: unknown marker %s", path, n, entry.marker)
+			}
+		}
+		// En known_hosts el tipo va después de los hosts
+		if len(fields) > 0 && (entry.marker != "" || sshKeyTypes[fields[0]] == "") {
+			entry.hosts, fields = fields[0], fields[1:]
+		}
+		if len(fields) < 2 || sshKeyTypes[fields[0]] == "" {
+			return nil, i18n.Errorf("%s:%d: not an SSH public key; supported types: %s", path, n, strings.Join(slices.Sorted(maps.Keys(sshKeyTypes)), ", "))
+		}
+		entry.keyType = fields[0]
+		entry.comment = strings.Join(fields[2:], " ")
+		if entry.blob, err = base64.StdEncoding.DecodeString(fields[1]); err != nil {
+			return nil, i18n.Errorf("%s:%d: the key is not valid base64", path, n)
+		}
+		if entry.bits, err = sshKeyBits(entry.keyType, entry.blob); err != nil {
+			return nil, i18n.Errorf("%s:%d: %w", path, n, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("cannot read the SSH key: %w", err)
+	}
+	return entries, nil
+}
+
+// sshKeyBits valida la estructura de la clave (formato de RFC 4253) y
+// devuelve su tamaño en bits
+func sshKeyBits(keyType string, blob []byte) (int, error) {
+	var parts [][]byte
+	for rest := blob; len(rest) > 0; {
+		if len(rest) < 4 || uint32(len(rest)-4) < binary.BigEndian.Uint32(rest) {
+			return 0, i18n.Errorf("the %s key is truncated", keyType)
+		}
+		size := binary.BigEndian.Uint32(rest)
+		parts = append(parts, rest[4:4+size])
+		rest = rest[4+size:]
+	}
+	if len(parts) == 0 || string(parts[0]) != keyType {
+		return 0, i18n.Errorf("the key data does not match its type %s", keyType)
+	}
+
+	switch keyType {
+	case "ssh-ed25519", "sk-ssh-ed25519@openssh.com":
+		if len(parts) < 2 || len(parts[1]) != 32 {
+			return 0, i18n.Errorf("the %s key is truncated", keyType)
+		}
+		return 256, nil
+	case "ssh-rsa":
+		if len(parts) != 3 {
+			return 0, i18n.Errorf("the %s key is truncated", keyType)
+		}
+		// Como OpenSSH, las claves RSA de menos de 1024 bits se rechazan
+		bits := new(big.Int).SetBytes(parts[2]).BitLen()
+		if bits < 1024 {
+			return 0, i18n.Errorf("the RSA key has %d bits; OpenSSH needs at least 1024", bits)
+		}
+		return bits, nil
+	}
+	// ECDSA: tipo, curva y punto sin comprimir (04 || X || Y)
+	curve := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(keyType, "sk-"), "ecdsa-sha2-"), "@openssh.com")
+	sizes := map[string]int{"nistp256": 256, "nistp384": 384, "nistp521": 521}
+	bits := sizes[curve]
+	if len(parts) < 3 || string(parts[1]) != curve || len(parts[2]) != 1+2*((bits+7)/8) || parts[2][0] != 4 {
+		return 0, i18n.Errorf("the %s key is truncated", keyType)
+	}
+	return bits, nil
+}
+
+// sshHostMatch informa si host coincide con los patrones de una entrada de
+// known_hosts: nombres con comodines * y ?, negaciones con ! y hosts
+// cifrados (|1|sal|hmac)
+func sshHostMatch(patterns, host string) bool {
+	if strings.HasPrefix(patterns, "|1|") {
+		parts := strings.Split(patterns, "|")
+		if len(parts) != 4 {
+			return false
+		}
+		salt, err1 := base64.StdEncoding.DecodeString(parts[2])
+		want, err2 := base64.StdEncoding.DecodeString(parts[3])
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		mac := hmac.New(sha1.New, salt)
+		mac.Write([]byte(host))
+		return hmac.Equal(mac.Sum(nil), want)
+	}
+
+	matched := false
+	for _, pattern := range strings.Split(patterns, ",") {
+		negated := strings.HasPrefix(pattern, "!")
+		ok, _ := path.Match(strings.ToLower(strings.TrimPrefix(pattern, "!")), strings.ToLower(host))
+		if ok && negated {
+			return false
+		}
+		matched = matched || ok
+	}
+	return matched
+}
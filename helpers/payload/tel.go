@@ -0,0 +1,30 @@
+package payload
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// telType arma una URI tel: (RFC 3966) que abre el marcador con el número
+var telType = &Type{
+	Name: "tel",
+	Fields: []Field{
+		{Name: "number", Usage: "Phone number to call, e.g. +1 415 555 0132 (--type tel)"},
+		regionField,
+	},
+	Build: buildTel,
+}
+
+func buildTel(values Values) (string, error) {
+	number := strings.TrimSpace(values["number"])
+	if number == "" {
+		return "", i18n.Errorf("--type tel needs --number")
+	}
+	// Los espacios, guiones, paréntesis y puntos solo son para leerlo
+	number, err := NormalizePhone(number, values["region"])
+	if err != nil {
+		return "", err
+	}
+	return "tel:" + number, nil
+}
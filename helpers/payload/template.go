@@ -0,0 +1,111 @@
+package payload
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ParseTemplate prepara una plantilla de payload de text/template. Un campo
+// que falta en un registro es un error, no un "<no value>" codificado.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("payload").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, i18n.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// RenderRecords lee los registros del archivo y arma un payload por registro
+// con la plantilla. Los registros pueden venir en CSV o TSV con una fila de
+// encabezados, en un arreglo JSON de objetos o en JSON Lines (.jsonl, .ndjson).
+func RenderRecords(tmpl *template.Template, path string) ([]string, error) {
+	records, err := readRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, i18n.Errorf("%s has no records", path)
+	}
+	payloads := make([]string, len(records))
+	for i, record := range records {
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, record); err != nil {
+			return nil, i18n.Errorf("record %d: %w", i+1, err)
+		}
+		if out.Len() == 0 {
+			return nil, i18n.Errorf("record %d: the template renders an empty payload", i+1)
+		}
+		payloads[i] = out.String()
+	}
+	return payloads, nil
+}
+
+// readRecords lee los registros según la extensión del archivo
+func readRecords(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read records: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv", ".tsv":
+		reader := csv.NewReader(bytes.NewReader(data))
+		if ext == ".tsv" {
+			reader.Comma = '\t'
+		}
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, i18n.Errorf("%s: %w", path, err)
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		records := make([]map[string]any, len(rows)-1)
+		for i, row := range rows[1:] {
+			records[i] = make(map[string]any, len(row))
+			for j, name := range rows[0] {
+				records[i][strings.TrimSpace(name)] = row[j]
+			}
+		}
+		return records, nil
+	case ".json":
+		var records []map[string]any
+		if err := decodeJSON(data, &records); err != nil {
+			return nil, i18n.Errorf("%s: expected a JSON array of objects: %w", path, err)
+		}
+		return records, nil
+	case ".jsonl", ".ndjson":
+		var records []map[string]any
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		for line := 1; scanner.Scan(); line++ {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			var record map[string]any
+			if err := decodeJSON(scanner.Bytes(), &record); err != nil {
+				return nil, i18n.Errorf("%s:%d: expected a JSON object: %w", path, line, err)
+			}
+			records = append(records, record)
+		}
+		return records, scanner.Err()
+	}
+	return nil, i18n.Errorf("%s: records must be .csv, .tsv, .json, .jsonl or .ndjson", path)
+}
+
+// decodeJSON lee los números como json.Number: como float64 un ID 1234567 se
+// escribiría 1.234567e+06
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
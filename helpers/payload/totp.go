@@ -0,0 +1,116 @@
+package payload
+
+import (
+	"bufio"
+	"encoding/base32"
+	"os"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// totpType arma la URI otpauth://totp/ que leen las apps de autenticación
+// (Google Authenticator, Authy, 1Password...) para dar de alta una cuenta:
+// otpauth://totp/Issuer:account?secret=BASE32&issuer=Issuer
+var totpType = &Type{
+	Name: "totp",
+	Fields: []Field{
+		{Name: "issuer", Usage: "Service name shown by the authenticator app (--type totp)"},
+		{Name: "account", Usage: "Account name, usually the user's email (--type totp)"},
+		{Name: "secret", Usage: `Base32 shared secret; "-" reads it from standard input so it stays out of the shell history (--type totp)`},
+		{Name: "digits", Usage: "Code length: 6 (default) or 8 (--type totp)"},
+		{Name: "period", Usage: "Seconds each code is valid (default 30) (--type totp)"},
+		{Name: "algorithm", Usage: "HMAC algorithm: sha1 (default, the only one every app supports), sha256 or sha512 (--type totp)"},
+	},
+	Build: buildTOTP,
+}
+
+// minSecretBytes es el largo mínimo del secreto: 80 bits, lo que usan la
+// mayoría de los servicios (RFC 4226 recomienda 160)
+const minSecretBytes = 10
+
+func buildTOTP(values Values) (string, error) {
+	issuer, account := strings.TrimSpace(values["issuer"]), strings.TrimSpace(values["account"])
+	if account == "" {
+		return "", i18n.Errorf("--type totp needs --account")
+	}
+	if strings.Contains(issuer, ":") || strings.Contains(account, ":") {
+		return "", i18n.Errorf("--issuer and --account cannot contain ':', which separates them in the label")
+	}
+
+	secret := values["secret"]
+	if secret == "-" {
+		var err error
+		if secret, err = readSecret(); err != nil {
+			return "", err
+		}
+	}
+	secret, err := normalizeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	label := percentEscape(account, "@")
+	if issuer != "" {
+		label = percentEscape(issuer, "") + ":" + label
+	}
+	var sb strings.Builder
+	sb.WriteString("otpauth://totp/" + label + "?secret=" + secret)
+	if issuer != "" {
+		sb.WriteString("&issuer=" + percentEscape(issuer, ""))
+	}
+
+	switch algorithm := strings.ToUpper(values["algorithm"]); algorithm {
+	case "", "SHA1":
+	case "SHA256", "SHA512":
+		sb.WriteString("&algorithm=" + algorithm)
+	default:
+		return "", i18n.Errorf("unsupported TOTP algorithm: %s (sha1, sha256 or sha512)", values["algorithm"])
+	}
+	switch digits := values["digits"]; digits {
+	case "", "6":
+	case "8":
+		sb.WriteString("&digits=8")
+	default:
+		return "", i18n.Errorf("--digits must be 6 or 8")
+	}
+	if period := values["period"]; period != "" {
+		seconds, err := strconv.Atoi(period)
+		if err != nil || seconds <= 0 {
+			return "", i18n.Errorf("--period must be a positive number of seconds")
+		}
+		if seconds != 30 {
+			sb.WriteString("&period=" + strconv.Itoa(seconds))
+		}
+	}
+	return sb.String(), nil
+}
+
+// readSecret lee el secreto de la primera línea de la entrada estándar
+func readSecret() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", i18n.Errorf("cannot read --secret from standard input: %w", err)
+	}
+	return line, nil
+}
+
+// normalizeSecret acepta el secreto como lo muestran los servicios (en
+// minúsculas, en grupos separados por espacios, con relleno) y lo devuelve en
+// base32 sin relleno, como lo piden las apps
+func normalizeSecret(secret string) (string, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+	if secret == "" {
+		return "", i18n.Errorf("--type totp needs --secret")
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", i18n.Errorf("--secret is not valid base32 (letters A-Z and digits 2-7)")
+	}
+	if len(key) < minSecretBytes {
+		return "", i18n.Errorf("--secret is %d bits; authenticator secrets need at least %d", len(key)*8, minSecretBytes*8)
+	}
+	return secret, nil
+}
@@ -0,0 +1,93 @@
+package payload
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// upiType arma una URI de pago UPI (upi://pay?pa=...&pn=...) de NPCI, que
+// leen todas las apps de pago de India
+var upiType = &Type{
+	Name: "upi",
+	Fields: []Field{
+		{Name: "vpa", Usage: "Payee UPI ID (virtual payment address) such as shop@okaxis (--type upi)"},
+		nameField,
+		amountField,
+		{Name: "note", Usage: "Transaction note shown to the payer, up to 80 characters (--type upi)"},
+	},
+	Build: buildUPI,
+}
+
+// Límites de la especificación de UPI linking
+const (
+	upiMaxAmount = 10_000_000
+	upiMaxName   = 99
+	upiMaxNote   = 80
+)
+
+func buildUPI(values Values) (string, error) {
+	vpa := strings.TrimSpace(values["vpa"])
+	if vpa == "" {
+		return "", i18n.Errorf("--type upi needs --vpa")
+	}
+	if !validVPA(vpa) {
+		return "", i18n.Errorf("invalid --vpa %q; a UPI ID looks like name@bank", vpa)
+	}
+	name, err := upiText(values, "name", upiMaxName)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", i18n.Errorf("--type upi needs --name; payment apps show it to confirm the payee")
+	}
+
+	query := []string{"pa=" + percentEscape(strings.ToLower(vpa), "@"), "pn=" + percentEscape(name, "")}
+	if value := strings.TrimSpace(values["amount"]); value != "" {
+		amount, err := decimalAmount(value, 2, upiMaxAmount)
+		if err != nil {
+			return "", err
+		}
+		// Las apps esperan el importe con dos decimales
+		whole, paise, _ := strings.Cut(amount, ".")
+		query = append(query, "am="+whole+"."+paise+strings.Repeat("0", 2-len(paise)))
+	}
+	query = append(query, "cu=INR")
+	note, err := upiText(values, "note", upiMaxNote)
+	if err != nil {
+		return "", err
+	}
+	if note != "" {
+		query = append(query, "tn="+percentEscape(note, ""))
+	}
+	return "upi://pay?" + strings.Join(query, "&"), nil
+}
+
+// validVPA revisa la forma de una dirección de pago: usuario con letras,
+// dígitos, punto, guion o guion bajo, y el identificador del banco en letras
+func validVPA(vpa string) bool {
+	user, handle, ok := strings.Cut(vpa, "@")
+	if !ok || len(user) < 2 || len(user) > 256 || len(handle) < 2 || len(handle) > 64 {
+		return false
+	}
+	for _, r := range user {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune(".-_", r)) {
+			return false
+		}
+	}
+	return isUpperAlpha(strings.ToUpper(handle))
+}
+
+// upiText valida un campo de texto: una sola línea y el largo máximo, en caracteres
+func upiText(values Values, field string, limit int) (string, error) {
+	text := strings.TrimSpace(values[field])
+	if !utf8.ValidString(text) || strings.IndexFunc(text, unicode.IsControl) >= 0 {
+		return "", i18n.Errorf("--%s must be a single line of text", field)
+	}
+	if n := utf8.RuneCountInString(text); n > limit {
+		return "", i18n.Errorf("--%s has %d characters; UPI allows %d", field, n, limit)
+	}
+	return text, nil
+}
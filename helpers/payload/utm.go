@@ -0,0 +1,84 @@
+package payload
+
+import (
+	"net/url"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// UTM son los parámetros de campaña que las herramientas de analítica leen
+// de la URL para atribuir cada visita (y así cada escaneo) a su origen
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Content  string
+}
+
+// params devuelve los parámetros presentes en el orden habitual, con el
+// nombre de la query y el del flag que lo define
+func (u UTM) params() [][3]string {
+	var params [][3]string
+	for _, p := range [][3]string{
+		{"utm_source", "utm-source", u.Source},
+		{"utm_medium", "utm-medium", u.Medium},
+		{"utm_campaign", "utm-campaign", u.Campaign},
+		{"utm_content", "utm-content", u.Content},
+	} {
+		if p[2] = strings.TrimSpace(p[2]); p[2] != "" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// IsZero informa si no hay ningún parámetro
+func (u UTM) IsZero() bool {
+	return len(u.params()) == 0
+}
+
+// AddUTM agrega los parámetros a la query de una URL http o https,
+// codificados y después de los que ya tiene, que quedan tal cual. El
+// fragmento (#...) se conserva al final. Un parámetro que la URL ya trae es
+// un error: pisarlo en silencio mezclaría dos campañas.
+func AddUTM(raw string, utm UTM) (string, error) {
+	params := utm.params()
+	if len(params) == 0 {
+		return raw, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", i18n.Errorf("--utm-* only apply to http and https URLs, not %q", abbreviate(raw))
+	}
+	existing, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", i18n.Errorf("the query of %s is malformed: %w", abbreviate(raw), err)
+	}
+
+	query := u.RawQuery
+	for _, p := range params {
+		if existing.Has(p[0]) {
+			return "", i18n.Errorf("%s already has %s; drop it from the URL or drop --%s", abbreviate(raw), p[0], p[1])
+		}
+		if query != "" {
+			query += "&"
+		}
+		query += p[0] + "=" + url.QueryEscape(p[2])
+	}
+	if u.Path == "" {
+		// https://example.com?x=1 es válida pero muchos acortadores y CMS esperan la barra
+		u.Path = "/"
+	}
+	u.RawQuery = query
+	return u.String(), nil
+}
+
+// abbreviate acorta un payload largo para mostrarlo en un error
+func abbreviate(s string) string {
+	const limit = 60
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit] + "..."
+}
@@ -0,0 +1,224 @@
+package payload
+
+import (
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// contactFields son los datos de un contacto; los comparten los tipos de
+// tarjeta de contacto. Con -config se pueden escribir como YAML, con listas
+// para phone y email y address como objeto (address: {city: ...}).
+var contactFields = []Field{
+	nameField,
+	{Name: "org", Usage: "Contact organization (--type vcard or mecard)"},
+	titleField,
+	{Name: "phone", Usage: "Comma-separated phone numbers, optionally typed: work:+1 555 0100,cell:+1 555 0101 (--type vcard or mecard)"},
+	{Name: "email", Usage: "Comma-separated email addresses, optionally typed: work:jane@example.com (--type vcard or mecard)"},
+	{Name: "website", Usage: "Contact website URL (--type vcard or mecard)"},
+	{Name: "birthday", Usage: "Contact birthday: 1990-12-31, or in the --locale order (--type vcard or mecard)", Kind: KindDate},
+	{Name: "address-street", Usage: "Street address (--type vcard or mecard)"},
+	{Name: "address-city", Usage: "City (--type vcard or mecard)"},
+	{Name: "address-region", Usage: "State or province (--type vcard or mecard)"},
+	{Name: "address-postcode", Usage: "Postal code (--type vcard or mecard)"},
+	{Name: "address-country", Usage: "Country (--type vcard or mecard)"},
+}
+
+// nameField lo comparten el nombre de un contacto y el beneficiario de una transferencia
+var nameField = Field{Name: "name", Usage: `Contact name; "Last, First" sets the family name explicitly (--type vcard or mecard), or payee name (--type epc, pix or upi)`}
+
+// vcardType arma una vCard 3.0 o 4.0 que las cámaras ofrecen agregar a los contactos
+var vcardType = &Type{
+	Name: "vcard",
+	Fields: append(contactFields[:len(contactFields):len(contactFields)],
+		Field{Name: "vcard-version", Usage: "vCard version: 3.0 (default, widest support) or 4.0"},
+	),
+	Build: buildVCard,
+}
+
+// contactTypes son los tipos aceptados como prefijo de teléfonos y emails
+var contactTypes = map[string]bool{"work": true, "home": true, "cell": true, "mobile": true, "fax": true}
+
+// typedValue es un teléfono o email con su tipo opcional
+type typedValue struct {
+	kind, value string
+}
+
+// typedList separa una lista por comas y el prefijo de tipo de cada elemento
+// ("work:+1 555 0100"); los prefijos desconocidos quedan como parte del valor
+func typedList(list string) []typedValue {
+	var values []typedValue
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kind, value, ok := strings.Cut(item, ":")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !ok || !contactTypes[kind] {
+			kind, value = "", item
+		}
+		if kind == "mobile" {
+			kind = "cell"
+		}
+		values = append(values, typedValue{kind, strings.TrimSpace(value)})
+	}
+	return values
+}
+
+// contact son los datos de un contacto ya validados
+type contact struct {
+	family, given string
+	values        Values
+	phones        []typedValue
+	emails        []typedValue
+	birthday      time.Time // Cero si no se indicó
+}
+
+// parseContact valida los campos de contacto comunes a vCard y MeCard
+func parseContact(values Values, kind string) (*contact, error) {
+	name := strings.TrimSpace(values["name"])
+	if name == "" {
+		return nil, i18n.Errorf("--type %s needs --name", kind)
+	}
+	c := &contact{values: values, phones: typedList(values["phone"]), emails: typedList(values["email"])}
+	if family, given, ok := strings.Cut(name, ","); ok {
+		c.family, c.given = strings.TrimSpace(family), strings.TrimSpace(given)
+	} else if i := strings.LastIndex(name, " "); i >= 0 {
+		c.family, c.given = name[i+1:], strings.TrimSpace(name[:i])
+	} else {
+		c.family = name
+	}
+
+	for _, phone := range c.phones {
+		if !validPhone(phone.value) {
+			return nil, i18n.Errorf("invalid phone number %q", phone.value)
+		}
+	}
+	for _, email := range c.emails {
+		if at := strings.Index(email.value, "@"); at <= 0 || at == len(email.value)-1 {
+			return nil, i18n.Errorf("invalid email address %q", email.value)
+		}
+	}
+	if birthday := strings.TrimSpace(values["birthday"]); birthday != "" {
+		t, err := time.Parse("2006-01-02", birthday)
+		if err != nil {
+			return nil, i18n.Errorf("invalid --birthday %q; use a date such as 1990-12-31", birthday)
+		}
+		c.birthday = t
+	}
+	return c, nil
+}
+
+// fullName es el nombre para mostrar: nombre y apellido
+func (c *contact) fullName() string {
+	return strings.TrimSpace(c.given + " " + c.family)
+}
+
+// hasAddress indica si se indicó algún dato de la dirección
+func (c *contact) hasAddress() bool {
+	for _, part := range []string{"street", "city", "region", "postcode", "country"} {
+		if c.values["address-"+part] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// vcardEscaper escapa los caracteres especiales de los valores de texto de vCard
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `;`, `\;`, "\r\n", `\n`, "\n", `\n`)
+
+func buildVCard(values Values) (string, error) {
+	version := strings.TrimSuffix(values["vcard-version"], ".0")
+	switch version {
+	case "":
+		version = "3"
+	case "3", "4":
+	default:
+		return "", i18n.Errorf("unsupported vCard version %s (3.0 or 4.0)", values["vcard-version"])
+	}
+	c, err := parseContact(values, "vcard")
+	if err != nil {
+		return "", err
+	}
+
+	esc := vcardEscaper.Replace
+	lines := []string{"BEGIN:VCARD", "VERSION:" + version + ".0"}
+	lines = append(lines, "N:"+esc(c.family)+";"+esc(c.given)+";;;", "FN:"+esc(c.fullName()))
+	if org := values["org"]; org != "" {
+		lines = append(lines, "ORG:"+esc(org))
+	}
+	if title := values["title"]; title != "" {
+		lines = append(lines, "TITLE:"+esc(title))
+	}
+	for _, phone := range c.phones {
+		if version == "4" {
+			lines = append(lines, "TEL"+vcardType4(phone.kind, "voice")+";VALUE=uri:tel:"+strings.Map(telURIRune, phone.value))
+		} else {
+			lines = append(lines, "TEL"+vcardType3(phone.kind, "VOICE")+":"+phone.value)
+		}
+	}
+	for _, email := range c.emails {
+		if version == "4" {
+			lines = append(lines, "EMAIL"+vcardType4(email.kind, "")+":"+email.value)
+		} else {
+			lines = append(lines, "EMAIL"+vcardType3(email.kind, "INTERNET")+":"+email.value)
+		}
+	}
+	if website := values["website"]; website != "" {
+		lines = append(lines, "URL:"+website)
+	}
+	if !c.birthday.IsZero() {
+		// vCard 4.0 usa la forma básica de ISO 8601, sin guiones
+		layout := "2006-01-02"
+		if version == "4" {
+			layout = "20060102"
+		}
+		lines = append(lines, "BDAY:"+c.birthday.Format(layout))
+	}
+	if c.hasAddress() {
+		parts := []string{"", ""} // Apartado postal y dirección extendida
+		for _, part := range []string{"street", "city", "region", "postcode", "country"} {
+			parts = append(parts, esc(values["address-"+part]))
+		}
+		lines = append(lines, "ADR:"+strings.Join(parts, ";"))
+	}
+	lines = append(lines, "END:VCARD")
+	return strings.Join(lines, "\r\n"), nil
+}
+
+// vcardType3 arma el parámetro TYPE de vCard 3.0, en mayúsculas y con el tipo
+// genérico (VOICE, INTERNET) que esperan los lectores más viejos
+func vcardType3(kind, generic string) string {
+	switch kind {
+	case "":
+		return ";TYPE=" + generic
+	case "fax":
+		return ";TYPE=FAX"
+	}
+	return ";TYPE=" + generic + "," + strings.ToUpper(kind)
+}
+
+// vcardType4 arma el parámetro TYPE de vCard 4.0, en minúsculas
+func vcardType4(kind, generic string) string {
+	var types []string
+	if kind != "" {
+		types = append(types, kind)
+	}
+	if generic != "" && kind != "fax" {
+		types = append(types, generic)
+	}
+	if len(types) == 0 {
+		return ""
+	}
+	return ";TYPE=" + strings.Join(types, ",")
+}
+
+// telURIRune conserva de un teléfono solo lo que admite una URI tel: (RFC 3966)
+func telURIRune(r rune) rune {
+	if r >= '0' && r <= '9' || r == '+' || r == '-' {
+		return r
+	}
+	return -1
+}
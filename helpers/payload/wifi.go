@@ -0,0 +1,91 @@
+package payload
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// wifiType arma el formato WIFI: que entienden las cámaras de Android e iOS
+// para unirse a una red: WIFI:T:WPA;S:<ssid>;P:<password>;H:true;;
+var wifiType = &Type{
+	Name: "wifi",
+	Fields: []Field{
+		{Name: "ssid", Usage: "Wi-Fi network name (--type wifi)"},
+		{Name: "password", Usage: "Wi-Fi password (--type wifi)"},
+		{Name: "security", Usage: "Wi-Fi security (--type wifi): wpa2 (default with a password), wpa3, wpa, wep or none"},
+		{Name: "hidden", Usage: "The Wi-Fi network does not broadcast its SSID (--type wifi)", Bool: true},
+	},
+	Build: buildWifi,
+}
+
+// wifiSecurity asocia cada seguridad aceptada con su valor en el campo T
+var wifiSecurity = map[string]string{
+	"wpa":    "WPA",
+	"wpa2":   "WPA",
+	"wpa3":   "SAE",
+	"wep":    "WEP",
+	"none":   "nopass",
+	"nopass": "nopass",
+}
+
+func buildWifi(values Values) (string, error) {
+	ssid, password := values["ssid"], values["password"]
+	if ssid == "" {
+		return "", i18n.Errorf("--type wifi needs --ssid")
+	}
+
+	security := strings.ToLower(values["security"])
+	if security == "" {
+		security = "none"
+		if password != "" {
+			security = "wpa2"
+		}
+	}
+	auth, ok := wifiSecurity[security]
+	if !ok {
+		return "", i18n.Errorf("unsupported Wi-Fi security: %s (wpa2, wpa3, wpa, wep or none)", security)
+	}
+
+	switch {
+	case auth == "nopass" && password != "":
+		return "", i18n.Errorf("an open network (--security none) takes no --password")
+	case auth != "nopass" && password == "":
+		return "", i18n.Errorf("--security %s needs --password", security)
+	case (auth == "WPA" || auth == "SAE") && !validPSK(password):
+		return "", i18n.Errorf("WPA passwords are 8 to 63 characters (or 64 hex digits)")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("WIFI:T:" + auth + ";S:" + wifiEscaper.Replace(ssid) + ";")
+	if password != "" {
+		sb.WriteString("P:" + wifiEscaper.Replace(password) + ";")
+	}
+	if values.Bool("hidden") {
+		sb.WriteString("H:true;")
+	}
+	sb.WriteString(";")
+	return sb.String(), nil
+}
+
+// validPSK acepta una contraseña WPA de 8 a 63 caracteres o una clave de 64 dígitos hexadecimales
+func validPSK(password string) bool {
+	n := len(password)
+	return n >= 8 && n <= 63 || n == 64 && isHex(password)
+}
+
+// wifiEscaper escapa los caracteres especiales del formato WIFI: en el SSID y la contraseña
+var wifiEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+
+// isHex indica si el valor tiene solo dígitos hexadecimales, en cantidad par
+func isHex(value string) bool {
+	if value == "" || len(value)%2 != 0 {
+		return false
+	}
+	for _, r := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
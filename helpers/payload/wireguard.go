@@ -0,0 +1,269 @@
+package payload
+
+import (
+	"bufio"
+	"encoding/base64"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// wireguardType codifica la configuración de un cliente de WireGuard (el
+// wg0.conf de wg-quick), que las apps oficiales de Android e iOS importan
+// como túnel al escanearla. Se valida y se escribe sin comentarios ni
+// espacios sobrantes, así el QR queda más chico.
+var wireguardType = &Type{
+	Name: "wireguard",
+	Fields: []Field{
+		{Name: "wg-conf", Usage: "WireGuard client config file such as wg0.conf; also --config wg0.conf or the argument after the flags (--type wireguard)"},
+	},
+	Build: buildWireGuard,
+	Arg:   "wg-conf",
+}
+
+// wgKey es una clave de la configuración: su nombre canónico y cómo se valida
+type wgKey struct {
+	name  string
+	check func(value string) bool
+}
+
+// wgInterfaceKeys son las claves de [Interface] que entienden las apps
+// móviles; los scripts de wg-quick (PostUp...) no los ejecutan
+var wgInterfaceKeys = map[string]wgKey{
+	"privatekey":           {"PrivateKey", validWGKey},
+	"address":              {"Address", listOf(validWGAddress)},
+	"dns":                  {"DNS", listOf(validWGDNS)},
+	"listenport":           {"ListenPort", validWGPort},
+	"mtu":                  {"MTU", validWGMTU},
+	"includedapplications": {"IncludedApplications", listOf(validWGApp)},
+	"excludedapplications": {"ExcludedApplications", listOf(validWGApp)},
+}
+
+// wgPeerKeys son las claves de [Peer]
+var wgPeerKeys = map[string]wgKey{
+	"publickey":           {"PublicKey", validWGKey},
+	"presharedkey":        {"PresharedKey", validWGKey},
+	"allowedips":          {"AllowedIPs", listOf(validWGPrefix)},
+	"endpoint":            {"Endpoint", validWGEndpoint},
+	"persistentkeepalive": {"PersistentKeepalive", validWGKeepalive},
+}
+
+// wgSection es una sección de la configuración con sus claves en orden
+type wgSection struct {
+	name   string
+	line   int
+	keys   []string
+	values map[string]string
+}
+
+func buildWireGuard(values Values) (string, error) {
+	path := values["wg-conf"]
+	if path == "" {
+		return "", i18n.Errorf("--type wireguard needs a config file: --config wg0.conf")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", i18n.Errorf("cannot read the WireGuard config: %w", err)
+	}
+	defer f.Close()
+
+	var sections []*wgSection
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if !strings.EqualFold(name, "Interface") && !strings.EqualFold(name, "Peer") {
+				return "", i18n.Errorf("%s:%d: unknown section [%s]; a WireGuard config has [Interface] and [Peer]", path, n, name)
+			}
+			sections = append(sections, &wgSection{name: strings.ToLower(name), line: n, values: map[string]string{}})
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || len(sections) == 0 {
+			return "", i18n.Errorf("%s:%d: expected Key = Value inside [Interface] or [Peer]", path, n)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		section := sections[len(sections)-1]
+		known := wgInterfaceKeys
+		if section.name == "peer" {
+			known = wgPeerKeys
+		}
+		spec, ok := known[strings.ToLower(key)]
+		if !ok {
+			return "", i18n.Errorf("%s:%d: %s is not supported by the WireGuard mobile apps", path, n, key)
+		}
+		if _, dup := section.values[spec.name]; dup {
+			return "", i18n.Errorf("%s:%d: %s appears twice in the section", path, n, spec.name)
+		}
+		if !spec.check(value) {
+			return "", i18n.Errorf("%s:%d: invalid %s %q", path, n, spec.name, value)
+		}
+		section.keys = append(section.keys, spec.name)
+		section.values[spec.name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return "", i18n.Errorf("cannot read the WireGuard config: %w", err)
+	}
+
+	if len(sections) == 0 || sections[0].name != "interface" {
+		return "", i18n.Errorf("%s: the config must start with [Interface]", path)
+	}
+	peers := map[string]int{}
+	for i, section := range sections {
+		required := []string{"PublicKey", "AllowedIPs", "Endpoint"}
+		if section.name == "interface" {
+			if i > 0 {
+				return "", i18n.Errorf("%s:%d: only one [Interface] is allowed", path, section.line)
+			}
+			required = []string{"PrivateKey", "Address"}
+		}
+		for _, key := range required {
+			if _, ok := section.values[key]; !ok {
+				return "", i18n.Errorf("%s:%d: [%s] needs %s", path, section.line, sectionTitle(section.name), key)
+			}
+		}
+		if key := section.values["PublicKey"]; key != "" {
+			if first, dup := peers[key]; dup {
+				return "", i18n.Errorf("%s:%d: the peer of line %d has the same PublicKey", path, section.line, first)
+			}
+			peers[key] = section.line
+		}
+	}
+	if len(peers) == 0 {
+		return "", i18n.Errorf("%s: the config has no [Peer] to connect to", path)
+	}
+
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+sectionTitle(section.name)+"]")
+		for _, key := range section.keys {
+			lines = append(lines, key+" = "+section.values[key])
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// sectionTitle es el nombre de la sección como se escribe en el archivo
+func sectionTitle(name string) string {
+	if name == "peer" {
+		return "Peer"
+	}
+	return "Interface"
+}
+
+// listOf valida una lista separada por comas con check
+func listOf(check func(string) bool) func(string) bool {
+	return func(value string) bool {
+		for _, item := range strings.Split(value, ",") {
+			if !check(strings.TrimSpace(item)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// validWGKey acepta una clave de Curve25519 en base64 (32 bytes)
+func validWGKey(value string) bool {
+	key, err := base64.StdEncoding.DecodeString(value)
+	return err == nil && len(key) == 32
+}
+
+// validWGAddress acepta una dirección con o sin prefijo: 10.0.0.2/32
+func validWGAddress(value string) bool {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return true
+	}
+	return validWGPrefix(value)
+}
+
+func validWGPrefix(value string) bool {
+	_, err := netip.ParsePrefix(value)
+	return err == nil
+}
+
+// validWGDNS acepta un servidor DNS o un dominio de búsqueda
+func validWGDNS(value string) bool {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return true
+	}
+	return validHostname(value)
+}
+
+// validWGEndpoint acepta host:puerto, con las IPv6 entre corchetes
+func validWGEndpoint(value string) bool {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil || !validWGPort(port) {
+		return false
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	return validHostname(host)
+}
+
+// validHostname acepta un nombre de host con etiquetas de letras, dígitos y guiones
+func validHostname(value string) bool {
+	if value == "" || len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(value, "."), ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func validWGPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port >= 1 && port <= 65535
+}
+
+// validWGMTU acepta los MTU que admiten las apps (1280, el mínimo de IPv6, a 65535)
+func validWGMTU(value string) bool {
+	mtu, err := strconv.Atoi(value)
+	return err == nil && mtu >= 1280 && mtu <= 65535
+}
+
+func validWGKeepalive(value string) bool {
+	seconds, err := strconv.Atoi(value)
+	return err == nil && seconds >= 0 && seconds <= 65535
+}
+
+// validWGApp acepta un nombre de paquete de Android (com.example.app)
+func validWGApp(value string) bool {
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == "" || part[0] >= '0' && part[0] <= '9' {
+			return false
+		}
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+)
+
+// Tamaño de una hoja A4 en puntos (1/72 de pulgada)
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document es un PDF mínimo con texto en Helvetica y bitmaps de 1 bit, lo
+// justo para imprimir códigos con instrucciones. Las coordenadas son en
+// puntos, con el origen en la esquina inferior izquierda de la hoja.
+type Document struct {
+	pages []*Page
+}
+
+// Page es una hoja del documento
+type Page struct {
+	width, height float64
+	content       bytes.Buffer
+	images        [][][]bool
+}
+
+// AddPage agrega una hoja A4 en blanco
+func (d *Document) AddPage() *Page {
+	return d.AddPageSize(A4Width, A4Height)
+}
+
+// AddPageSize agrega una hoja en blanco de width por height puntos
+func (d *Document) AddPageSize(width, height float64) *Page {
+	page := &Page{width: width, height: height}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// SetFill cambia el color del texto, los rectángulos y los bitmaps que se
+// dibujen después; al comienzo es negro
+func (p *Page) SetFill(c color.Color) {
+	r, g, b, _ := c.RGBA()
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f rg\n", float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff)
+}
+
+// Rect pinta un rectángulo con la esquina inferior izquierda en (x, y)
+func (p *Page) Rect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re f\n", x, y, width, height)
+}
+
+// Text escribe una línea con la línea de base en (x, y). Los caracteres
+// fuera de Latin-1 se reemplazan por '?', ya que se usan las fuentes
+// estándar con WinAnsiEncoding.
+func (p *Page) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// Bitmap dibuja la matriz con ancho side, el alto en la proporción de la
+// matriz, y la esquina inferior izquierda en (x, y), sin interpolar para que
+// los módulos queden nítidos a cualquier escala. Es una máscara: los true se
+// pintan del color de SetFill y los false dejan ver lo que hay debajo.
+func (p *Page) Bitmap(x, y, side float64, modules [][]bool) {
+	p.images = append(p.images, modules)
+	height := side * float64(len(modules)) / float64(len(modules[0]))
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", side, height, x, y, len(p.images))
+}
+
+// Wrap parte el texto en líneas que entran en width puntos, estimando con
+// holgura el ancho medio de un carácter de Helvetica
+func Wrap(text string, size, width float64) []string {
+	perLine := max(1, int(width/(size*0.55)))
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > perLine {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Write guarda el documento en path
+func (d *Document) Write(path string) error {
+	return os.WriteFile(path, d.Bytes(), 0o644)
+}
+
+// Bytes serializa el documento. Objetos: 1 catálogo, 2 árbol de páginas, 3 y
+// 4 fuentes; después, por hoja, la página, su contenido y sus imágenes.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	var kids []string
+	next := 5
+	for _, page := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", next))
+		next += 2 + len(page.images)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)), nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+
+	for _, page := range d.pages {
+		self := len(offsets) + 1
+		var images []string
+		for i := range page.images {
+			images = append(images, fmt.Sprintf("/Im%d %d 0 R", i+1, self+2+i))
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Contents %d 0 R /Resources << /Font << /F1 3 0 R /F2 4 0 R >> /XObject << %s >> >> >>",
+			page.width, page.height, self+1, strings.Join(images, " ")), nil)
+		content := deflate(page.content.Bytes())
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", len(content)), content)
+		for _, modules := range page.images {
+			data := deflate(packBits(modules))
+			object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ImageMask true /BitsPerComponent 1 /Decode [1 0] /Interpolate false /Length %d /Filter /FlateDecode >>",
+				len(modules[0]), len(modules), len(data)), data)
+		}
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// packBits empaqueta la matriz en filas de 1 bit alineadas a byte, 1 = se
+// pinta (con /Decode [1 0])
+func packBits(modules [][]bool) []byte {
+	stride := (len(modules[0]) + 7) / 8
+	data := make([]byte, stride*len(modules))
+	for y, row := range modules {
+		for x, dark := range row {
+			if dark {
+				data[y*stride+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return data
+}
+
+// deflate comprime un stream con FlateDecode
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+// escape convierte el texto a WinAnsi y escapa los caracteres especiales de
+// las cadenas de PDF
+func escape(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(byte(r))
+		case r >= 0x20 && r < 0x7f:
+			sb.WriteByte(byte(r))
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+package pdf417
+
+import (
+	"slices"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que la imagen no tiene un PDF417 reconocible
+var errNotFound = i18n.NewError("no PDF417 code found in the image")
+
+// Result es el contenido decodificado de un PDF417
+type Result struct {
+	Data      []byte
+	Size      Size
+	Level     int // Nivel de seguridad (0-8)
+	ECI       int // Designador ECI, 0 si no se declaró
+	Corrected int // Codewords corregidas por Reed-Solomon
+}
+
+// values[c] lleva cada patrón del cluster c a su codeword
+var values = func() (maps [3]map[int]int) {
+	for c := range patterns {
+		maps[c] = make(map[int]int, len(patterns[c]))
+		for value, pattern := range patterns[c] {
+			maps[c][pattern] = value
+		}
+	}
+	return maps
+}()
+
+// DecodeMatrix decodifica una grilla de módulos ya muestreada, sin zona de
+// silencio: una línea por fila del símbolo, o cada fila repetida como las
+// dibuja Encode.
+func DecodeMatrix(matrix [][]bool) (*Result, error) {
+	var lines [][]bool
+	for _, line := range matrix {
+		if len(lines) == 0 || !slices.Equal(line, lines[len(lines)-1]) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < MinRows || len(lines) > MaxRows {
+		return nil, errNotFound
+	}
+	width := len(lines[0])
+	size := Size{Columns: (width - 69) / 17, Rows: len(lines)}
+	if size.Columns < 1 || size.Columns > MaxColumns || size.Modules() != width {
+		return nil, i18n.Errorf("invalid PDF417 width: %d modules", width)
+	}
+
+	// Los indicadores de las tres primeras filas dan las filas, las
+	// columnas y el nivel; una codeword ilegible queda en 0 y la corrige
+	// Reed-Solomon
+	var codewords []int
+	level := -1
+	for row, line := range lines {
+		if len(line) != width || readPattern(line, 0, 17) != startPattern {
+			return nil, errNotFound
+		}
+		words := make([]int, size.Columns+2)
+		for i := range words {
+			words[i] = values[row%3][readPattern(line, 17*(i+1), 17)]
+		}
+		if row < 3 {
+			left, right := rowIndicators(size, 0, row)
+			switch row {
+			case 0:
+				if words[0] != left || words[size.Columns+1] != right {
+					return nil, i18n.NewError("PDF417 row indicators do not match the symbol size")
+				}
+			case 1:
+				level = (words[0] - left) / 3
+			case 2:
+				level = (words[size.Columns+1] - right) / 3
+			}
+		}
+		codewords = append(codewords, words[1:size.Columns+1]...)
+	}
+	if level < 0 || level > MaxLevel || eccCount(level) >= len(codewords) {
+		return nil, i18n.NewError("invalid PDF417 error correction level")
+	}
+
+	ecc := eccCount(level)
+	corrected, err := correct(codewords, ecc)
+	if err != nil {
+		return nil, err
+	}
+	length := codewords[0]
+	if length < 1 || length > len(codewords)-ecc {
+		return nil, i18n.NewError("invalid PDF417 length descriptor")
+	}
+	result := &Result{Size: size, Level: level, Corrected: corrected}
+	if err := decodeHighLevel(codewords[1:length], result); err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	return result, nil
+}
+
+// readPattern lee n módulos desde from como un número, el primero en el bit más alto
+func readPattern(line []bool, from, n int) int {
+	value := 0
+	for _, dark := range line[from : from+n] {
+		value <<= 1
+		if dark {
+			value |= 1
+		}
+	}
+	return value
+}
+
+// Detect busca un PDF417 derecho en una imagen binarizada: la primera barra
+// del patrón de inicio, de 8 módulos, da el ancho del módulo y el recuadro
+// las columnas. Cada línea de píxeles se muestrea y las que comparten el
+// cluster del indicador izquierdo forman una fila; devuelve la línea del
+// medio de cada una.
+func Detect(dark func(x, y int) bool, width, height int) ([][]bool, error) {
+	minX, minY, maxX, maxY := width, height, -1, -1
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if dark(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return nil, errNotFound
+	}
+	bar := 0
+	for x := minX; x <= maxX && dark(x, (minY+maxY)/2); x++ {
+		bar++
+	}
+	module := float64(bar) / 8
+	if module < 1 {
+		return nil, errNotFound
+	}
+	columns := int((float64(maxX-minX+1)/module-69)/17 + 0.5)
+	if columns < 1 || columns > MaxColumns {
+		return nil, errNotFound
+	}
+	size := Size{Columns: columns}
+	step := float64(maxX-minX+1) / float64(size.Modules())
+
+	var rows [][]bool
+	group, last := []([]bool)(nil), -1
+	flush := func() {
+		if len(group) > 0 {
+			rows = append(rows, group[len(group)/2])
+		}
+		group = nil
+	}
+	for y := minY; y <= maxY; y++ {
+		line := make([]bool, size.Modules())
+		for i := range line {
+			line[i] = dark(minX+int((float64(i)+0.5)*step), y)
+		}
+		cluster := -1
+		if readPattern(line, 0, 17) == startPattern {
+			left := readPattern(line, 17, 17)
+			for c := range values {
+				if _, ok := values[c][left]; ok {
+					cluster = c
+				}
+			}
+		}
+		if cluster < 0 {
+			continue
+		}
+		if cluster != last {
+			flush()
+			last = cluster
+		}
+		group = append(group, line)
+	}
+	flush()
+	if len(rows) < MinRows {
+		return nil, errNotFound
+	}
+	return rows, nil
+}
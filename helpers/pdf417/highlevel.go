@@ -0,0 +1,414 @@
+package pdf417
+
+import (
+	"math/big"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Codewords de cambio de modo
+const (
+	cwText        = 900 // Latch a compactación de texto
+	cwByte        = 901 // Latch a compactación de bytes
+	cwNumeric     = 902 // Latch a compactación numérica
+	cwByteShift   = 913 // Un solo byte desde texto
+	cwByte6       = 924 // Latch a bytes, múltiplo de 6
+	cwECI         = 927 // Designador ECI
+	cwPad         = 900 // Relleno: un latch a texto sin datos
+	minNumericRun = 13  // Dígitos seguidos desde los que conviene la compactación numérica
+	minTextRun    = 5   // Caracteres seguidos desde los que conviene volver a texto
+)
+
+// submode es una de las tablas de la compactación de texto
+type submode int
+
+const (
+	subAlpha submode = iota
+	subLower
+	subMixed
+	subPunct
+)
+
+// Valores de control de las tablas de texto
+const (
+	valLL = 27 // Latch a Lower (en Alpha y en Mixed)
+	valAS = 27 // Alpha Shift, en Lower
+	valML = 28 // Latch a Mixed (en Alpha y en Lower)
+	valAL = 28 // Latch a Alpha, en Mixed (en Punct es 29)
+	valPS = 29 // Punct Shift
+	valPL = 25 // Latch a Punct, en Mixed
+)
+
+// mixedChars y punctChars son los caracteres de esas tablas por valor; los
+// de control quedan en 0
+var (
+	mixedChars = []byte("0123456789&\r\t,:#-.$/+%*=^\x00 \x00\x00\x00")
+	punctChars = []byte(";<>@[\\]_`~!\r\t,:\n-.$/\"|*()?{}'\x00")
+)
+
+// textValue devuelve el valor del carácter en la tabla; -1 si no está
+func textValue(sub submode, c byte) int {
+	switch sub {
+	case subAlpha:
+		if c >= 'A' && c <= 'Z' {
+			return int(c - 'A')
+		}
+	case subLower:
+		if c >= 'a' && c <= 'z' {
+			return int(c - 'a')
+		}
+	case subMixed:
+		if i := strings.IndexByte(string(mixedChars[:26]), c); i >= 0 && c != 0 {
+			return i
+		}
+	case subPunct:
+		if i := strings.IndexByte(string(punctChars[:29]), c); i >= 0 {
+			return i
+		}
+	}
+	if c == ' ' && sub != subPunct {
+		return 26
+	}
+	return -1
+}
+
+// isText indica si el byte se puede escribir en la compactación de texto
+func isText(c byte) bool {
+	return c == '\t' || c == '\n' || c == '\r' || (c >= ' ' && c <= '~')
+}
+
+// encodeHighLevel convierte los datos en codewords: los tramos largos de
+// dígitos van en compactación numérica, los de texto en la de texto y el
+// resto en la de bytes. El símbolo empieza en texto, en la tabla Alpha.
+func encodeHighLevel(data []byte, eci int) []int {
+	var out []int
+	if eci > 0 {
+		out = append(out, cwECI, eci)
+	}
+	mode, sub := cwText, subAlpha
+	for i := 0; i < len(data); {
+		if n := digitRun(data[i:]); n >= minNumericRun {
+			out = append(out, cwNumeric)
+			out = append(out, numericCodewords(data[i:i+n])...)
+			mode = cwNumeric
+			i += n
+			continue
+		}
+		if n := textRun(data[i:]); n >= minTextRun || n == len(data)-i {
+			if mode != cwText {
+				out = append(out, cwText)
+				mode, sub = cwText, subAlpha
+			}
+			var words []int
+			words, sub = textCodewords(data[i:i+n], sub)
+			out = append(out, words...)
+			i += n
+			continue
+		}
+		n := max(byteRun(data[i:]), 1)
+		if n == 1 && mode == cwText {
+			out = append(out, cwByteShift, int(data[i]))
+		} else {
+			if n%6 == 0 {
+				out = append(out, cwByte6)
+			} else {
+				out = append(out, cwByte)
+			}
+			out = append(out, byteCodewords(data[i:i+n])...)
+			mode = cwByte
+		}
+		i += n
+	}
+	return out
+}
+
+// digitRun cuenta los dígitos seguidos al comienzo de data
+func digitRun(data []byte) int {
+	n := 0
+	for n < len(data) && data[n] >= '0' && data[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// textRun cuenta los caracteres de texto al comienzo de data, hasta un
+// tramo de dígitos que convenga compactar aparte
+func textRun(data []byte) int {
+	n := 0
+	for n < len(data) && isText(data[n]) {
+		if digitRun(data[n:]) >= minNumericRun {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// byteRun cuenta los bytes al comienzo de data hasta un tramo de texto o
+// de dígitos que convenga compactar aparte
+func byteRun(data []byte) int {
+	n := 0
+	for n < len(data) {
+		if digitRun(data[n:]) >= minNumericRun || textRun(data[n:]) >= minTextRun {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// textCodewords escribe el texto desde la tabla sub, dos valores por
+// codeword, y devuelve la tabla en que termina
+func textCodewords(text []byte, sub submode) ([]int, submode) {
+	var values []int
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if v := textValue(sub, c); v >= 0 {
+			values = append(values, v)
+			continue
+		}
+		switch {
+		case sub == subLower && textValue(subAlpha, c) >= 0 && i+1 < len(text) && textValue(subLower, text[i+1]) >= 0:
+			// Una mayúscula entre minúsculas: Alpha Shift
+			values = append(values, valAS, textValue(subAlpha, c))
+		case sub != subPunct && textValue(subPunct, c) >= 0 && textValue(subMixed, c) < 0 && (i+1 == len(text) || textValue(subPunct, text[i+1]) < 0):
+			values = append(values, valPS, textValue(subPunct, c))
+		default:
+			next := targetSubmode(c)
+			values = append(values, latchValues(sub, next)...)
+			values = append(values, textValue(next, c))
+			sub = next
+		}
+	}
+	if len(values)%2 == 1 {
+		// En Punct el relleno es un latch a Alpha
+		values = append(values, valPS)
+		if sub == subPunct {
+			sub = subAlpha
+		}
+	}
+	words := make([]int, len(values)/2)
+	for i := range words {
+		words[i] = values[2*i]*30 + values[2*i+1]
+	}
+	return words, sub
+}
+
+// targetSubmode elige la tabla para un carácter que no está en la actual
+func targetSubmode(c byte) submode {
+	for _, sub := range []submode{subAlpha, subLower, subMixed, subPunct} {
+		if textValue(sub, c) >= 0 {
+			return sub
+		}
+	}
+	return subPunct
+}
+
+// latchValues son los valores que llevan de una tabla a otra
+func latchValues(from, to submode) []int {
+	switch {
+	case from == to:
+		return nil
+	case from == subPunct:
+		return append([]int{29}, latchValues(subAlpha, to)...)
+	case to == subLower:
+		return []int{valLL}
+	case to == subMixed:
+		return []int{valML}
+	case to == subAlpha && from == subMixed:
+		return []int{valAL}
+	case to == subAlpha:
+		// Lower no tiene latch a Alpha: se pasa por Mixed
+		return []int{valML, valAL}
+	}
+	// A Punct se llega desde Mixed
+	return append(latchValues(from, subMixed), valPL)
+}
+
+// byteCodewords escribe los bytes de a 6 en 5 codewords en base 900; los
+// que sobran van de a uno
+func byteCodewords(data []byte) []int {
+	var out []int
+	for len(data) >= 6 {
+		var v uint64
+		for _, b := range data[:6] {
+			v = v<<8 | uint64(b)
+		}
+		group := make([]int, 5)
+		for i := 4; i >= 0; i-- {
+			group[i] = int(v % 900)
+			v /= 900
+		}
+		out = append(out, group...)
+		data = data[6:]
+	}
+	for _, b := range data {
+		out = append(out, int(b))
+	}
+	return out
+}
+
+// numericCodewords escribe los dígitos de a 44, con un 1 adelante, en base 900
+func numericCodewords(digits []byte) []int {
+	var out []int
+	for len(digits) > 0 {
+		n := min(len(digits), 44)
+		v, _ := new(big.Int).SetString("1"+string(digits[:n]), 10)
+		var group []int
+		base, m := big.NewInt(900), new(big.Int)
+		for v.Sign() > 0 {
+			v.DivMod(v, base, m)
+			group = append([]int{int(m.Int64())}, group...)
+		}
+		out = append(out, group...)
+		digits = digits[n:]
+	}
+	return out
+}
+
+// decodeHighLevel interpreta las codewords de datos, sin el descriptor de largo
+func decodeHighLevel(words []int, result *Result) error {
+	mode := cwText
+	sub, shifted := subAlpha, submode(-1)
+	for i := 0; i < len(words); {
+		w := words[i]
+		switch w {
+		case cwText:
+			mode, sub = cwText, subAlpha
+			i++
+			continue
+		case cwByte, cwByte6, cwNumeric:
+			mode = w
+			i++
+			continue
+		case cwByteShift:
+			if i+1 >= len(words) {
+				return i18n.NewError("truncated byte shift")
+			}
+			result.Data = append(result.Data, byte(words[i+1]))
+			i += 2
+			continue
+		case cwECI:
+			if i+1 >= len(words) {
+				return i18n.NewError("truncated ECI designator")
+			}
+			result.ECI = words[i+1]
+			i += 2
+			continue
+		}
+		if w >= 900 {
+			return i18n.Errorf("unsupported PDF417 codeword %d", w)
+		}
+
+		// Los datos del modo llegan hasta el próximo codeword de control
+		end := i
+		for end < len(words) && words[end] < 900 {
+			end++
+		}
+		switch mode {
+		case cwText:
+			for _, word := range words[i:end] {
+				for _, v := range []int{word / 30, word % 30} {
+					sub, shifted = textChar(v, sub, shifted, result)
+				}
+			}
+			// Un Punct Shift al final del tramo es el relleno del último codeword
+			shifted = -1
+		case cwNumeric:
+			if err := decodeNumeric(words[i:end], result); err != nil {
+				return err
+			}
+		default:
+			decodeBytes(words[i:end], mode == cwByte6, result)
+		}
+		i = end
+	}
+	return nil
+}
+
+// textChar interpreta un valor de la compactación de texto y devuelve la
+// tabla actual y la del shift pendiente (-1 si no hay)
+func textChar(v int, sub, shifted submode, result *Result) (submode, submode) {
+	current := sub
+	if shifted >= 0 {
+		current = shifted
+	}
+	switch current {
+	case subAlpha, subLower:
+		switch {
+		case v < 26:
+			base := byte('A')
+			if current == subLower {
+				base = 'a'
+			}
+			result.Data = append(result.Data, base+byte(v))
+		case v == 26:
+			result.Data = append(result.Data, ' ')
+		case v == valLL && current == subAlpha:
+			return subLower, -1
+		case v == valAS:
+			return sub, subAlpha
+		case v == valML:
+			return subMixed, -1
+		case v == valPS:
+			return sub, subPunct
+		}
+	case subMixed:
+		switch {
+		case v == valPL:
+			return subPunct, -1
+		case v == valLL:
+			return subLower, -1
+		case v == valAL:
+			return subAlpha, -1
+		case v == valPS:
+			return sub, subPunct
+		default:
+			result.Data = append(result.Data, mixedChars[v])
+		}
+	case subPunct:
+		if v == 29 {
+			return subAlpha, -1
+		}
+		result.Data = append(result.Data, punctChars[v])
+	}
+	return sub, -1
+}
+
+// decodeNumeric lee grupos de hasta 15 codewords en base 900, con un 1 adelante
+func decodeNumeric(words []int, result *Result) error {
+	for len(words) > 0 {
+		n := min(len(words), 15)
+		v, base := new(big.Int), big.NewInt(900)
+		for _, w := range words[:n] {
+			v.Mul(v, base).Add(v, big.NewInt(int64(w)))
+		}
+		digits := v.String()
+		if digits[0] != '1' {
+			return i18n.NewError("invalid numeric group")
+		}
+		result.Data = append(result.Data, digits[1:]...)
+		words = words[n:]
+	}
+	return nil
+}
+
+// decodeBytes lee grupos de 5 codewords como 6 bytes; los últimos, si no
+// completan un grupo, son un byte cada uno. Después de 901 el tramo no es
+// múltiplo de 6 y un grupo de 5 al final también son bytes sueltos.
+func decodeBytes(words []int, multipleOf6 bool, result *Result) {
+	for len(words) > 5 || (len(words) == 5 && multipleOf6) {
+		var v uint64
+		for _, w := range words[:5] {
+			v = v*900 + uint64(w)
+		}
+		for i := 5; i >= 0; i-- {
+			result.Data = append(result.Data, byte(v>>(8*i)))
+		}
+		words = words[5:]
+	}
+	for _, w := range words {
+		result.Data = append(result.Data, byte(w))
+	}
+}
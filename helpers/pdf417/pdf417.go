@@ -0,0 +1,199 @@
+// Package pdf417 codifica y decodifica símbolos PDF417 (ISO/IEC 15438), los
+// de los documentos de identidad y las etiquetas de envío: filas de
+// codewords de 17 módulos entre los patrones de inicio y de fin, con
+// compactación de texto, de bytes y numérica y corrección Reed-Solomon en
+// GF(929).
+package pdf417
+
+import (
+	"fmt"
+	"slices"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ErrDataTooLong indica que los datos no entran con las opciones pedidas
+var ErrDataTooLong = i18n.NewError("data too long for a PDF417 symbol")
+
+// Límites del estándar
+const (
+	MaxColumns   = 30
+	MinRows      = 3
+	MaxRows      = 90
+	MaxCodewords = 928 // Codewords de un símbolo, datos y corrección
+	MaxLevel     = 8   // Nivel de seguridad más alto: 512 codewords de corrección
+)
+
+// RowHeight es la altura de cada fila en módulos: el estándar pide al menos 3
+const RowHeight = 3
+
+// Size son las columnas de datos y las filas de un PDF417
+type Size struct {
+	Columns int
+	Rows    int
+}
+
+// String es "columnas x filas", como 5x10
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Columns, s.Rows)
+}
+
+// Modules es el ancho en módulos: las columnas de datos, los indicadores de
+// fila y los patrones de inicio y de fin
+func (s Size) Modules() int {
+	return 17*s.Columns + 69
+}
+
+// Options son los parámetros de codificación
+type Options struct {
+	Columns int // Columnas de datos (1-30); 0 las elige
+	Rows    int // Filas (3-90); 0 las elige
+	ECRatio int // Corrección mínima, en % de las codewords de datos; 0 usa la recomendada
+	ECI     int // Designador ECI; 0 no lo declara
+}
+
+// Level devuelve el nivel de seguridad para n codewords de datos: el más
+// chico con al menos ECRatio% de corrección o, sin ECRatio, el mínimo que
+// recomienda el estándar
+func (o Options) Level(n int) int {
+	if o.ECRatio == 0 {
+		switch {
+		case n <= 40:
+			return 2
+		case n <= 160:
+			return 3
+		case n <= 320:
+			return 4
+		}
+		return 5
+	}
+	for level := 0; level < MaxLevel; level++ {
+		if eccCount(level)*100 >= n*o.ECRatio {
+			return level
+		}
+	}
+	return MaxLevel
+}
+
+// Symbol es un PDF417 codificado
+type Symbol struct {
+	Matrix [][]bool // Filas de módulos, cada fila del símbolo repetida RowHeight veces; true es oscuro
+	Size   Size
+	Level  int // Nivel de seguridad (0-8)
+}
+
+// Codewords devuelve las codewords de datos que ocupa data, con el
+// descriptor de largo
+func Codewords(data []byte, opts Options) int {
+	return len(encodeHighLevel(data, opts.ECI)) + 1
+}
+
+// Capacity devuelve las codewords de datos que entran, con el descriptor de
+// largo, para n codewords de datos con las opciones pedidas
+func Capacity(n int, opts Options) int {
+	total := MaxCodewords
+	if opts.Columns > 0 && opts.Rows > 0 {
+		total = min(total, opts.Columns*opts.Rows)
+	} else if opts.Columns > 0 {
+		total = min(total, opts.Columns*MaxRows)
+	} else if opts.Rows > 0 {
+		total = min(total, MaxColumns*opts.Rows)
+	}
+	return max(0, total-eccCount(opts.Level(n)))
+}
+
+// Encode codifica data con las columnas, las filas y la corrección pedidas;
+// lo que no se fija se elige para que el símbolo sea unas tres veces más
+// ancho que alto. Si no entra devuelve ErrDataTooLong.
+func Encode(data []byte, opts Options) (*Symbol, error) {
+	words := encodeHighLevel(data, opts.ECI)
+	level := opts.Level(len(words) + 1)
+	ecc := eccCount(level)
+	need := len(words) + 1 + ecc
+	size, ok := dimensions(need, opts)
+	if !ok {
+		return nil, ErrDataTooLong
+	}
+
+	message := make([]int, 0, size.Columns*size.Rows)
+	message = append(message, size.Columns*size.Rows-ecc)
+	message = append(message, words...)
+	for len(message) < size.Columns*size.Rows-ecc {
+		message = append(message, cwPad)
+	}
+	message = append(message, withECC(message, ecc)...)
+	return &Symbol{Matrix: render(size, level, message), Size: size, Level: level}, nil
+}
+
+// dimensions elige columnas y filas para need codewords: respeta las fijas
+// y, entre las demás, busca la proporción más cercana a 3:1
+func dimensions(need int, opts Options) (Size, bool) {
+	best, bestRatio := Size{}, -1.0
+	for columns := 1; columns <= MaxColumns; columns++ {
+		if opts.Columns > 0 && columns != opts.Columns {
+			continue
+		}
+		rows := max(MinRows, (need+columns-1)/columns)
+		if opts.Rows > 0 {
+			if opts.Rows < rows {
+				continue
+			}
+			rows = opts.Rows
+		}
+		if rows > MaxRows || rows*columns > MaxCodewords {
+			continue
+		}
+		size := Size{Columns: columns, Rows: rows}
+		ratio := float64(size.Modules())/float64(rows*RowHeight) - 3
+		if ratio < 0 {
+			ratio = -ratio
+		}
+		if bestRatio < 0 || ratio < bestRatio {
+			best, bestRatio = size, ratio
+		}
+	}
+	return best, bestRatio >= 0
+}
+
+// rowIndicators devuelve los indicadores izquierdo y derecho de la fila:
+// entre los tres clusters llevan las filas, las columnas y el nivel
+func rowIndicators(size Size, level, row int) (int, int) {
+	base := 30 * (row / 3)
+	rows := (size.Rows - 1) / 3
+	columns := size.Columns - 1
+	levelRows := level*3 + (size.Rows-1)%3
+	switch row % 3 {
+	case 0:
+		return base + rows, base + columns
+	case 1:
+		return base + levelRows, base + rows
+	}
+	return base + columns, base + levelRows
+}
+
+// render dibuja las filas: inicio, indicador izquierdo, datos, indicador
+// derecho y fin, cada una repetida RowHeight veces
+func render(size Size, level int, codewords []int) [][]bool {
+	matrix := make([][]bool, 0, size.Rows*RowHeight)
+	for row := 0; row < size.Rows; row++ {
+		line := make([]bool, 0, size.Modules())
+		put := func(pattern, modules int) {
+			for i := modules - 1; i >= 0; i-- {
+				line = append(line, pattern>>i&1 == 1)
+			}
+		}
+		cluster := &patterns[row%3]
+		left, right := rowIndicators(size, level, row)
+		put(startPattern, 17)
+		put(cluster[left], 17)
+		for _, cw := range codewords[row*size.Columns : (row+1)*size.Columns] {
+			put(cluster[cw], 17)
+		}
+		put(cluster[right], 17)
+		put(stopPattern, 18)
+		for range RowHeight {
+			matrix = append(matrix, slices.Clone(line))
+		}
+	}
+	return matrix
+}
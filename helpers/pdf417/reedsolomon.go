@@ -0,0 +1,173 @@
+package pdf417
+
+import "qrgenerator_cli/helpers/i18n"
+
+// errTooManyErrors indica que el mensaje tiene más errores de los que se pueden corregir
+var errTooManyErrors = i18n.NewError("too many errors to correct")
+
+// PDF417 corrige en el campo primo GF(929), con 3 como generador
+const (
+	prime     = 929
+	generator = 3
+)
+
+// powers[i] es 3^i y logs su inversa
+var powers, logs = func() (exp, log [prime]int) {
+	x := 1
+	for i := 0; i < prime-1; i++ {
+		exp[i] = x
+		log[x] = i
+		x = x * generator % prime
+	}
+	exp[prime-1] = 1
+	return exp, log
+}()
+
+func mul(a, b int) int {
+	return a * b % prime
+}
+
+func sub(a, b int) int {
+	return (a - b + prime) % prime
+}
+
+// inv es el inverso multiplicativo; a no puede ser cero
+func inv(a int) int {
+	return powers[(prime-1-logs[a])%(prime-1)]
+}
+
+// pow eleva 3 a la potencia indicada (admite exponentes negativos)
+func pow(e int) int {
+	e %= prime - 1
+	if e < 0 {
+		e += prime - 1
+	}
+	return powers[e]
+}
+
+// eval evalúa un polinomio con coeficientes en orden ascendente
+func eval(poly []int, x int) int {
+	y := 0
+	for i := len(poly) - 1; i >= 0; i-- {
+		y = (mul(y, x) + poly[i]) % prime
+	}
+	return y
+}
+
+// eccCount es la cantidad de codewords de corrección de un nivel (0-8)
+func eccCount(level int) int {
+	return 2 << level
+}
+
+// withECC devuelve las n codewords de corrección de data, el resto de
+// dividir por (x-3)(x-3^2)...(x-3^n) cambiado de signo
+func withECC(data []int, n int) []int {
+	g := []int{1}
+	for i := 1; i <= n; i++ {
+		next := make([]int, len(g)+1)
+		for j, c := range g {
+			next[j] = (next[j] + c) % prime
+			next[j+1] = sub(next[j+1], mul(c, pow(i)))
+		}
+		g = next
+	}
+	rem := make([]int, n)
+	for _, d := range data {
+		factor := (d + rem[0]) % prime
+		copy(rem, rem[1:])
+		rem[n-1] = 0
+		for i := range rem {
+			rem[i] = sub(rem[i], mul(g[i+1], factor))
+		}
+	}
+	for i, r := range rem {
+		rem[i] = sub(0, r)
+	}
+	return rem
+}
+
+// correct corrige en el lugar las codewords (datos seguidos de ecLen de
+// corrección) y devuelve la cantidad de codewords corregidas
+func correct(words []int, ecLen int) (int, error) {
+	n := len(words)
+
+	// Síndromes S_i = r(3^(i+1)); words[j] es el coeficiente de x^(n-1-j)
+	syndromes := make([]int, ecLen)
+	clean := true
+	for i := range syndromes {
+		s, a := 0, pow(i+1)
+		for _, c := range words {
+			s = (mul(s, a) + c) % prime
+		}
+		syndromes[i] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return 0, nil
+	}
+
+	// Berlekamp-Massey: polinomio localizador de errores en orden ascendente
+	locator, prev := []int{1}, []int{1}
+	errCount, shift, prevDiscrepancy := 0, 1, 1
+	for k := 0; k < ecLen; k++ {
+		d := syndromes[k]
+		for i := 1; i <= errCount && i < len(locator); i++ {
+			d = (d + mul(locator[i], syndromes[k-i])) % prime
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		scale := mul(d, inv(prevDiscrepancy))
+		next := make([]int, max(len(locator), len(prev)+shift))
+		copy(next, locator)
+		for i, c := range prev {
+			next[i+shift] = sub(next[i+shift], mul(scale, c))
+		}
+		if 2*errCount <= k {
+			prev = locator
+			errCount = k + 1 - errCount
+			prevDiscrepancy = d
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+	if 2*errCount > ecLen {
+		return 0, errTooManyErrors
+	}
+
+	// Búsqueda de Chien: la potencia p tiene error si locator(3^-p) == 0
+	var positions []int
+	for p := 0; p < n; p++ {
+		if eval(locator, pow(-p)) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	if len(positions) != errCount {
+		return 0, errTooManyErrors
+	}
+
+	// Forney: con las raíces desde 3^1 la magnitud es -omega(X^-1)/locator'(X^-1)
+	omega := make([]int, ecLen)
+	for i := range omega {
+		for j := 0; j <= i && j < len(locator); j++ {
+			omega[i] = (omega[i] + mul(locator[j], syndromes[i-j])) % prime
+		}
+	}
+	for _, p := range positions {
+		derivative := 0
+		for i := 1; i < len(locator); i++ {
+			derivative = (derivative + mul(mul(i, locator[i]), pow(-p*(i-1)))) % prime
+		}
+		if derivative == 0 {
+			return 0, errTooManyErrors
+		}
+		magnitude := sub(0, mul(eval(omega, pow(-p)), inv(derivative)))
+		words[n-1-p] = sub(words[n-1-p], magnitude)
+	}
+	return errCount, nil
+}
@@ -0,0 +1,24 @@
+package qrcodec
+
+import "qrgenerator_cli/helpers/aztec"
+
+// DecodeAztec decodifica un Aztec ya muestreado, sin zona de silencio.
+// Version es la posición del tamaño: 1-4 los compactos y 5-36 los completos.
+func DecodeAztec(matrix [][]bool) (*Result, error) {
+	az, err := aztec.DecodeMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		Segments:  []Segment{{Mode: ModeByte, Data: az.Data}},
+		Version:   az.Size.Index(),
+		ECI:       az.ECI,
+		FNC1:      az.GS1,
+		Aztec:     az.Size,
+		Corrected: az.Corrected,
+	}
+	if result.Text, err = decodeText(result.Segments, result.ECI, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,282 @@
+package qrcodec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"qrgenerator_cli/helpers/i18n"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// errTruncated indica que el flujo de bits terminó a mitad de un segmento
+var errTruncated = i18n.NewError("truncated data stream")
+
+// bitReader lee bits MSB primero de una secuencia de codewords
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+// available devuelve los bits pendientes de leer
+func (r *bitReader) available() int {
+	return len(r.data)*8 - r.pos
+}
+
+// read lee n bits (n <= 32)
+func (r *bitReader) read(n int) (int, error) {
+	if n > r.available() {
+		return 0, errTruncated
+	}
+	v := 0
+	for i := 0; i < n; i++ {
+		v <<= 1
+		if r.data[r.pos/8]&(0x80>>(r.pos%8)) != 0 {
+			v |= 1
+		}
+		r.pos++
+	}
+	return v, nil
+}
+
+// Segment es un tramo de datos decodificado con su modo
+type Segment struct {
+	Mode Mode
+	Data []byte // Bytes del segmento (en Shift-JIS para el modo kanji)
+}
+
+// StructuredAppend describe la posición del símbolo dentro de una secuencia
+type StructuredAppend struct {
+	Index  int  // Posición del símbolo, desde 0
+	Total  int  // Cantidad de símbolos de la secuencia
+	Parity byte // XOR de todos los bytes del mensaje completo
+}
+
+// streamFormat describe los encabezados de los segmentos: los QR usan
+// indicadores de modo de 4 bits y los rMQR de 3, cada uno con sus contadores
+type streamFormat struct {
+	modes     []Mode         // Modo de cada indicador; nil si el indicador es el valor del Mode
+	countBits func(Mode) int // Ancho del contador de caracteres de cada modo
+}
+
+// qrStream es el formato de los QR de una versión
+func qrStream(version int) streamFormat {
+	return streamFormat{countBits: func(mode Mode) int { return charCountBits(mode, version) }}
+}
+
+// modeBits devuelve el ancho del indicador de modo
+func (f streamFormat) modeBits() int {
+	if f.modes != nil {
+		return 3
+	}
+	return 4
+}
+
+// readMode lee un indicador de modo
+func (f streamFormat) readMode(r *bitReader) (Mode, error) {
+	m, err := r.read(f.modeBits())
+	if err != nil || f.modes == nil {
+		return Mode(m), err
+	}
+	return f.modes[m], nil
+}
+
+// writeMode escribe el indicador de un modo
+func (f streamFormat) writeMode(w *bitWriter, mode Mode) {
+	if f.modes == nil {
+		w.write(int(mode), 4)
+		return
+	}
+	w.write(slices.Index(f.modes, mode), 3)
+}
+
+// parseData interpreta los codewords de datos como una secuencia de segmentos
+func parseData(data []byte, format streamFormat, result *Result) error {
+	r := &bitReader{data: data}
+	for r.available() >= format.modeBits() {
+		mode, _ := format.readMode(r)
+		switch mode {
+		case ModeTerminator:
+			return nil
+
+		case ModeFNC1First, ModeFNC1Second:
+			result.FNC1 = true
+			if mode == ModeFNC1Second {
+				if _, err := r.read(8); err != nil {
+					return err
+				}
+			}
+
+		case ModeStructuredAppend:
+			v, err := r.read(16)
+			if err != nil {
+				return err
+			}
+			result.StructuredAppend = &StructuredAppend{
+				Index:  v >> 12,
+				Total:  (v>>8)&0xf + 1,
+				Parity: byte(v),
+			}
+
+		case ModeECI:
+			eci, err := readECI(r)
+			if err != nil {
+				return err
+			}
+			result.ECI = eci
+
+		case ModeNumeric, ModeAlphanumeric, ModeByte, ModeKanji:
+			count, err := r.read(format.countBits(mode))
+			if err != nil {
+				return err
+			}
+			seg, err := readSegment(r, mode, count)
+			if err != nil {
+				return err
+			}
+			result.Segments = append(result.Segments, seg)
+
+		default:
+			return i18n.Errorf("unknown data mode: %d", mode)
+		}
+	}
+	return nil
+}
+
+// readECI lee un designador ECI de 1, 2 o 3 bytes
+func readECI(r *bitReader) (int, error) {
+	first, err := r.read(8)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case first&0x80 == 0:
+		return first, nil
+	case first&0xc0 == 0x80:
+		rest, err := r.read(8)
+		return (first&0x3f)<<8 | rest, err
+	case first&0xe0 == 0xc0:
+		rest, err := r.read(16)
+		return (first&0x1f)<<16 | rest, err
+	default:
+		return 0, i18n.Errorf("invalid ECI designator")
+	}
+}
+
+// readSegment lee count caracteres de un segmento del modo indicado
+func readSegment(r *bitReader, mode Mode, count int) (Segment, error) {
+	var buf bytes.Buffer
+	switch mode {
+	case ModeNumeric:
+		for count > 0 {
+			digits := min(count, 3)
+			v, err := r.read([4]int{0, 4, 7, 10}[digits])
+			if err != nil {
+				return Segment{}, err
+			}
+			buf.WriteString(fmt.Sprintf("%0*d", digits, v))
+			count -= digits
+		}
+
+	case ModeAlphanumeric:
+		for count > 0 {
+			if count == 1 {
+				v, err := r.read(6)
+				if err != nil || v >= 45 {
+					return Segment{}, errors.Join(errTruncated, err)
+				}
+				buf.WriteByte(alphanumericChars[v])
+				break
+			}
+			v, err := r.read(11)
+			if err != nil || v >= 45*45 {
+				return Segment{}, errors.Join(errTruncated, err)
+			}
+			buf.WriteByte(alphanumericChars[v/45])
+			buf.WriteByte(alphanumericChars[v%45])
+			count -= 2
+		}
+
+	case ModeByte:
+		for i := 0; i < count; i++ {
+			v, err := r.read(8)
+			if err != nil {
+				return Segment{}, err
+			}
+			buf.WriteByte(byte(v))
+		}
+
+	case ModeKanji:
+		for i := 0; i < count; i++ {
+			v, err := r.read(13)
+			if err != nil {
+				return Segment{}, err
+			}
+			sjis := (v/0xc0)<<8 | v%0xc0
+			if sjis < 0x1f00 {
+				sjis += 0x8140
+			} else {
+				sjis += 0xc140
+			}
+			buf.WriteByte(byte(sjis >> 8))
+			buf.WriteByte(byte(sjis))
+		}
+	}
+	return Segment{Mode: mode, Data: buf.Bytes()}, nil
+}
+
+// decodeText convierte los segmentos en texto usando el ECI declarado.
+// Sin ECI se asume UTF-8 si los bytes son válidos, o ISO-8859-1 si no. Con
+// FNC1, los "%" del modo alfanumérico son separadores GS.
+func decodeText(segments []Segment, eci int, fnc1 bool) (string, error) {
+	var sb bytes.Buffer
+	for _, seg := range segments {
+		switch seg.Mode {
+		case ModeKanji:
+			text, err := japanese.ShiftJIS.NewDecoder().Bytes(seg.Data)
+			if err != nil {
+				return "", i18n.Errorf("invalid kanji: %w", err)
+			}
+			sb.Write(text)
+		case ModeByte:
+			text, err := decodeBytes(seg.Data, eci)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(text)
+		case ModeAlphanumeric:
+			if fnc1 {
+				sb.Write(fnc1Alphanumeric(seg.Data))
+			} else {
+				sb.Write(seg.Data)
+			}
+		default:
+			sb.Write(seg.Data)
+		}
+	}
+	return sb.String(), nil
+}
+
+// decodeBytes interpreta bytes del modo byte según el ECI
+func decodeBytes(data []byte, eci int) (string, error) {
+	switch eci {
+	case 20:
+		text, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+		return string(text), err
+	case 1, 3:
+		text, err := charmap.ISO8859_1.NewDecoder().Bytes(data)
+		return string(text), err
+	case 26:
+		return string(data), nil
+	default:
+		if utf8.Valid(data) {
+			return string(data), nil
+		}
+		text, err := charmap.ISO8859_1.NewDecoder().Bytes(data)
+		return string(text), err
+	}
+}
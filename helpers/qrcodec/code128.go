@@ -0,0 +1,19 @@
+package qrcodec
+
+import "qrgenerator_cli/helpers/code128"
+
+// DecodeCode128 decodifica la línea de módulos de un Code 128, sin zona de
+// silencio. Version es la cantidad de caracteres de símbolo.
+func DecodeCode128(line []bool) (*Result, error) {
+	bars, err := code128.DecodeModules(line)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Text:     string(bars.Data),
+		Segments: []Segment{{Mode: ModeByte, Data: bars.Data}},
+		Version:  bars.Characters,
+		FNC1:     bars.GS1,
+		Barcode:  "code128",
+	}, nil
+}
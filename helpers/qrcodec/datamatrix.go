@@ -0,0 +1,24 @@
+package qrcodec
+
+import "qrgenerator_cli/helpers/datamatrix"
+
+// DecodeDataMatrix decodifica un Data Matrix ya muestreado, sin zona de
+// silencio. Version es la posición del tamaño en datamatrix.Sizes.
+func DecodeDataMatrix(matrix [][]bool) (*Result, error) {
+	dm, err := datamatrix.DecodeMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		Segments:   []Segment{{Mode: ModeByte, Data: dm.Data}},
+		Version:    datamatrix.SizeIndex(dm.Size),
+		ECI:        dm.ECI,
+		FNC1:       dm.GS1,
+		DataMatrix: dm.Size,
+		Corrected:  dm.Corrected,
+	}
+	if result.Text, err = decodeText(result.Segments, result.ECI, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
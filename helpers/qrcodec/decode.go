@@ -0,0 +1,156 @@
+package qrcodec
+
+import (
+	"image"
+	"image/color"
+
+	"qrgenerator_cli/helpers/aztec"
+	"qrgenerator_cli/helpers/code128"
+	"qrgenerator_cli/helpers/code39"
+	"qrgenerator_cli/helpers/datamatrix"
+	"qrgenerator_cli/helpers/ean"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/pdf417"
+)
+
+// Result es el contenido decodificado de un símbolo
+type Result struct {
+	Text             string            // Contenido interpretado como texto
+	Segments         []Segment         // Segmentos en el orden en que aparecen
+	Version          int               // Versión del símbolo (1-40)
+	Level            Level             // Nivel de corrección de errores
+	Mask             int               // Patrón de máscara (0-7)
+	ECI              int               // Designador ECI, 0 si no se declaró
+	FNC1             bool              // El símbolo declara datos GS1/FNC1
+	StructuredAppend *StructuredAppend // Posición en una secuencia, si la hay
+	Shape            RMQRShape         // Forma si el símbolo es un rMQR; cero en los QR
+	DataMatrix       datamatrix.Size   // Tamaño si el símbolo es un Data Matrix; cero en los demás
+	Aztec            aztec.Size        // Tamaño si el símbolo es un Aztec; cero en los demás
+	PDF417           pdf417.Size       // Columnas y filas si el símbolo es un PDF417; cero en los demás
+	PDF417Level      int               // Nivel de seguridad (0-8) del PDF417
+	Barcode          string            // Simbología si es un código de barras lineal (code128, ean13, upca, isbn o code39); "" en los demás
+	Inverted         bool              // El símbolo es claro sobre fondo oscuro
+	Ink, Paper       color.Gray        // Luminancia media de los módulos y del fondo en la imagen
+	Corrected        int               // Codewords corregidos por Reed-Solomon
+}
+
+// Bytes devuelve los datos crudos concatenados de todos los segmentos
+func (r *Result) Bytes() []byte {
+	var out []byte
+	for _, seg := range r.Segments {
+		out = append(out, seg.Data...)
+	}
+	return out
+}
+
+// Decode busca un código QR en la imagen y devuelve su contenido. Admite
+// símbolos alineados con los ejes (o rotados 90°), como los que genera esta
+// herramienta, y rMQR, Data Matrix, Aztec, PDF417, Code 128, EAN-13 y Code
+// 39 derechos; si no lo encuentra, prueba también con los colores invertidos.
+func Decode(img image.Image) (*Result, error) {
+	result, err := decodeImage(img, false)
+	if err == nil {
+		return result, nil
+	}
+	if inverted, errInv := decodeImage(img, true); errInv == nil {
+		inverted.Inverted = true
+		return inverted, nil
+	}
+	return nil, err
+}
+
+// decodeImage detecta y decodifica el símbolo con la polaridad indicada
+func decodeImage(img image.Image, inverted bool) (*Result, error) {
+	bin := binarize(img, inverted)
+	var result *Result
+	matrix, err := bin.detect()
+	if err == nil {
+		result, err = DecodeMatrix(matrix)
+	} else if rmqr, errRMQR := bin.detectRMQR(); errRMQR == nil {
+		// Sin los tres patrones de posición puede ser un rMQR, que tiene uno solo
+		result, err = DecodeRMQRMatrix(rmqr)
+	}
+	// Sin un QR o rMQR legible puede ser un Data Matrix, que se reconoce por
+	// su L sólida, un Aztec, por su ojo de buey central, un PDF417, por su
+	// patrón de inicio, o un Code 128, un EAN-13 o un Code 39, por sus
+	// barras. Los datos de los símbolos grandes pueden parecer patrones de
+	// posición: si había un QR, su error queda salvo que otra simbología se lea.
+	if err != nil {
+		if dm, errDM := datamatrix.Detect(bin.at, bin.width, bin.height); errDM == nil {
+			if dmResult, errDM := DecodeDataMatrix(dm); errDM == nil || matrix == nil {
+				result, err = dmResult, errDM
+			}
+		}
+	}
+	if err != nil {
+		if az, errAz := aztec.Detect(bin.at, bin.width, bin.height); errAz == nil {
+			if azResult, errAz := DecodeAztec(az); errAz == nil || matrix == nil {
+				result, err = azResult, errAz
+			}
+		}
+	}
+	if err != nil {
+		if pdf, errPDF := pdf417.Detect(bin.at, bin.width, bin.height); errPDF == nil {
+			if pdfResult, errPDF := DecodePDF417(pdf); errPDF == nil || matrix == nil {
+				result, err = pdfResult, errPDF
+			}
+		}
+	}
+	if err != nil {
+		if bars, errBars := code128.Detect(bin.at, bin.width, bin.height); errBars == nil {
+			if barsResult, errBars := DecodeCode128(bars); errBars == nil || matrix == nil {
+				result, err = barsResult, errBars
+			}
+		}
+	}
+	if err != nil {
+		if digits, errEAN := ean.Detect(bin.at, bin.width, bin.height); errEAN == nil {
+			result, err = DecodeEAN(digits), nil
+		}
+	}
+	if err != nil {
+		if data, errBars := code39.Detect(bin.at, bin.width, bin.height); errBars == nil {
+			result, err = DecodeCode39(data), nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Ink, result.Paper = color.Gray{Y: bin.ink}, color.Gray{Y: bin.paper}
+	return result, nil
+}
+
+// DecodeMatrix decodifica una grilla de módulos ya muestreada
+func DecodeMatrix(matrix Matrix) (*Result, error) {
+	if matrix.Size() < 21 || (matrix.Size()-17)%4 != 0 {
+		return nil, i18n.Errorf("invalid symbol size: %d", matrix.Size())
+	}
+	version, err := matrix.readVersion()
+	if err != nil {
+		return nil, err
+	}
+	if symbolSize(version) != matrix.Size() {
+		return nil, i18n.Errorf("version %d does not match size %d", version, matrix.Size())
+	}
+	level, mask, err := matrix.readFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	raw := matrix.readCodewords(version, mask)
+	data, corrected, err := deinterleave(raw, blocks(version, level))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Version: version, Level: level, Mask: mask, Corrected: corrected}
+	if err := parseData(data, qrStream(version), result); err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	text, err := decodeText(result.Segments, result.ECI, result.FNC1)
+	if err != nil {
+		return nil, err
+	}
+	result.Text = text
+	return result, nil
+}
@@ -0,0 +1,389 @@
+package qrcodec
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"sort"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// errNotFound indica que no se encontraron los tres patrones de posición
+var errNotFound = i18n.NewError("no QR code found in the image")
+
+// binaryImage es una imagen reducida a píxeles oscuros/claros
+type binaryImage struct {
+	width, height int
+	dark          []bool
+	ink, paper    uint8 // Luminancia media de los píxeles leídos como módulo y como fondo
+}
+
+// at indica si el píxel es oscuro; fuera de la imagen se considera claro
+func (b *binaryImage) at(x, y int) bool {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return false
+	}
+	return b.dark[y*b.width+x]
+}
+
+// binarize convierte la imagen a blanco y negro con un umbral global entre la
+// luminancia mínima y máxima. Los píxeles transparentes se toman como fondo claro.
+func binarize(img image.Image, inverted bool) *binaryImage {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	lum := make([]uint8, w*h)
+	lo, hi := uint8(255), uint8(0)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			// Componer sobre blanco
+			white := 0xffff - a
+			l := color.GrayModel.Convert(color.RGBA64{
+				R: uint16(r + white), G: uint16(g + white), B: uint16(b + white), A: 0xffff,
+			}).(color.Gray).Y
+			lum[y*w+x] = l
+			lo, hi = min(lo, l), max(hi, l)
+		}
+	}
+
+	threshold := (int(lo) + int(hi) + 1) / 2
+	bin := &binaryImage{width: w, height: h, dark: make([]bool, w*h)}
+	var sums, counts [2]int
+	for i, l := range lum {
+		bin.dark[i] = (int(l) < threshold) != inverted
+		class := 0
+		if bin.dark[i] {
+			class = 1
+		}
+		sums[class] += int(l)
+		counts[class]++
+	}
+	bin.paper, bin.ink = meanLuminance(sums[0], counts[0]), meanLuminance(sums[1], counts[1])
+	return bin
+}
+
+// meanLuminance es el promedio de una clase de píxeles, 0 si está vacía
+func meanLuminance(sum, count int) uint8 {
+	if count == 0 {
+		return 0
+	}
+	return uint8(sum / count)
+}
+
+// finderPattern es un candidato a patrón de posición
+type finderPattern struct {
+	x, y       float64
+	moduleSize float64
+	count      int
+}
+
+// ratioMatches comprueba la proporción 1:1:3:1:1 de un patrón de posición
+func ratioMatches(runs [5]int) (float64, bool) {
+	total := 0
+	for _, r := range runs {
+		if r == 0 {
+			return 0, false
+		}
+		total += r
+	}
+	if total < 7 {
+		return 0, false
+	}
+	unit := float64(total) / 7
+	tolerance := unit / 2
+	for i, r := range runs {
+		expected, limit := unit, tolerance
+		if i == 2 {
+			expected, limit = 3*unit, 3*tolerance
+		}
+		if math.Abs(float64(r)-expected) >= limit {
+			return 0, false
+		}
+	}
+	return unit, true
+}
+
+// crossCheck mide el patrón sobre una línea que pasa por (cx, cy) en la dirección
+// (dx, dy) y devuelve el centro corregido sobre esa línea y el tamaño de módulo
+func (b *binaryImage) crossCheck(cx, cy, dx, dy int) (float64, float64, bool) {
+	if !b.at(cx, cy) {
+		return 0, 0, false
+	}
+	var runs [5]int
+	// Hacia atrás desde el centro: módulo central, borde claro, borde oscuro
+	x, y := cx, cy
+	for state := 2; state >= 0; state-- {
+		want := state%2 == 0
+		for b.at(x, y) == want && (x >= 0 && y >= 0 && x < b.width && y < b.height) {
+			runs[state]++
+			x, y = x-dx, y-dy
+		}
+	}
+	start := float64(cx*dx+cy*dy) - float64(runs[0]+runs[1]+runs[2]) + 1
+	// Hacia adelante: resto del módulo central y los bordes del otro lado
+	x, y = cx+dx, cy+dy
+	for state := 2; state <= 4; state++ {
+		want := state%2 == 0
+		for b.at(x, y) == want && (x >= 0 && y >= 0 && x < b.width && y < b.height) {
+			runs[state]++
+			x, y = x+dx, y+dy
+		}
+	}
+	unit, ok := ratioMatches(runs)
+	if !ok {
+		return 0, 0, false
+	}
+	center := start + float64(runs[0]+runs[1]) + float64(runs[2])/2
+	return center, unit, true
+}
+
+// findFinderPatterns recorre las filas buscando la proporción 1:1:3:1:1 y valida
+// cada candidato en vertical y de nuevo en horizontal
+func (b *binaryImage) findFinderPatterns() []finderPattern {
+	var found []finderPattern
+	var starts, lengths []int
+	for y := 0; y < b.height; y++ {
+		// Tramos de la fila alternando colores
+		starts, lengths = starts[:0], lengths[:0]
+		for x := 0; x < b.width; x++ {
+			if x == 0 || b.at(x, y) != b.at(x-1, y) {
+				starts = append(starts, x)
+				lengths = append(lengths, 0)
+			}
+			lengths[len(lengths)-1]++
+		}
+
+		for i := 0; i+4 < len(starts); i++ {
+			if !b.at(starts[i], y) {
+				continue
+			}
+			var runs [5]int
+			copy(runs[:], lengths[i:i+5])
+			if _, ok := ratioMatches(runs); !ok {
+				continue
+			}
+			cx := float64(starts[i+2]) + float64(runs[2])/2
+			fy, unitV, ok := b.crossCheck(int(cx), y, 0, 1)
+			if !ok {
+				continue
+			}
+			fx, unitH, ok := b.crossCheck(int(cx), int(fy), 1, 0)
+			if !ok {
+				continue
+			}
+			found = addCandidate(found, finderPattern{x: fx, y: fy, moduleSize: (unitH + unitV) / 2, count: 1})
+		}
+	}
+	return found
+}
+
+// addCandidate fusiona el candidato con uno cercano o lo agrega a la lista
+func addCandidate(list []finderPattern, c finderPattern) []finderPattern {
+	for i, p := range list {
+		if math.Abs(p.x-c.x) <= p.moduleSize*2 && math.Abs(p.y-c.y) <= p.moduleSize*2 &&
+			math.Abs(p.moduleSize-c.moduleSize) <= p.moduleSize {
+			n := float64(p.count)
+			list[i] = finderPattern{
+				x:          (p.x*n + c.x) / (n + 1),
+				y:          (p.y*n + c.y) / (n + 1),
+				moduleSize: (p.moduleSize*n + c.moduleSize) / (n + 1),
+				count:      p.count + 1,
+			}
+			return list
+		}
+	}
+	return append(list, c)
+}
+
+// orderPatterns elige, entre los candidatos más confirmados, los tres que forman
+// un triángulo rectángulo isósceles y los ordena como superior izquierdo,
+// superior derecho e inferior izquierdo
+func orderPatterns(found []finderPattern) (tl, tr, bl finderPattern, err error) {
+	sort.SliceStable(found, func(i, j int) bool { return found[i].count > found[j].count })
+	if len(found) > maxCandidates {
+		found = found[:maxCandidates]
+	}
+
+	bestScore := -1
+	for i := 0; i < len(found); i++ {
+		for j := i + 1; j < len(found); j++ {
+			for k := j + 1; k < len(found); k++ {
+				a, b, c, ok := triangle(found[i], found[j], found[k])
+				if score := found[i].count + found[j].count + found[k].count; ok && score > bestScore {
+					tl, tr, bl, bestScore = a, b, c, score
+				}
+			}
+		}
+	}
+	if bestScore < 0 {
+		return tl, tr, bl, errNotFound
+	}
+	return tl, tr, bl, nil
+}
+
+// maxCandidates limita la búsqueda de triángulos a los candidatos más confirmados
+const maxCandidates = 12
+
+// triangle ordena tres candidatos y comprueba que tengan la geometría de un
+// símbolo QR: tamaños de módulo parecidos, lados iguales y ángulo recto
+func triangle(p0, p1, p2 finderPattern) (tl, tr, bl finderPattern, ok bool) {
+	dist := func(a, b finderPattern) float64 { return math.Hypot(a.x-b.x, a.y-b.y) }
+	d01, d02, d12 := dist(p0, p1), dist(p0, p2), dist(p1, p2)
+
+	// El superior izquierdo es el opuesto al lado más largo
+	switch {
+	case d12 >= d01 && d12 >= d02:
+		tl, tr, bl = p0, p1, p2
+	case d02 >= d01 && d02 >= d12:
+		tl, tr, bl = p1, p0, p2
+	default:
+		tl, tr, bl = p2, p0, p1
+	}
+
+	// Con el eje y hacia abajo, el producto cruzado es positivo si tr está a la derecha
+	cross := (tr.x-tl.x)*(bl.y-tl.y) - (tr.y-tl.y)*(bl.x-tl.x)
+	if cross < 0 {
+		tr, bl = bl, tr
+	}
+
+	sizes := []float64{tl.moduleSize, tr.moduleSize, bl.moduleSize}
+	minSize, maxSize := slices.Min(sizes), slices.Max(sizes)
+	if maxSize > minSize*1.5 {
+		return tl, tr, bl, false
+	}
+
+	lenU, lenW := dist(tl, tr), dist(tl, bl)
+	if lenU < 7*minSize || math.Abs(lenU-lenW) > 0.15*max(lenU, lenW) {
+		return tl, tr, bl, false
+	}
+	cos := ((tr.x-tl.x)*(bl.x-tl.x) + (tr.y-tl.y)*(bl.y-tl.y)) / (lenU * lenW)
+	return tl, tr, bl, math.Abs(cos) < 0.1
+}
+
+// countTimingModules cuenta los módulos oscuros del patrón de sincronización entre
+// dos patrones de posición, recorriendo la línea desplazada offset módulos
+func (b *binaryImage) countTimingModules(from, to finderPattern, ux, uy, offset float64) int {
+	ms := (from.moduleSize + to.moduleSize) / 2
+	sx, sy := from.x+ux*offset*ms, from.y+uy*offset*ms
+	dx, dy := to.x-from.x, to.y-from.y
+	length := math.Hypot(dx, dy)
+	dx, dy = dx/length, dy/length
+
+	start, end := 4*ms, length-4*ms
+	runs := 0
+	prev := false
+	for t := start; t <= end; t += 0.5 {
+		dark := b.at(int(sx+dx*t), int(sy+dy*t))
+		if dark && !prev {
+			runs++
+		}
+		prev = dark
+	}
+	return runs
+}
+
+// detect localiza el símbolo y muestrea su grilla de módulos
+func (b *binaryImage) detect() (Matrix, error) {
+	tl, tr, bl, err := orderPatterns(b.findFinderPatterns())
+	if err != nil {
+		return nil, err
+	}
+
+	// Vectores unitarios de las filas (u) y columnas (w) del símbolo
+	lenU := math.Hypot(tr.x-tl.x, tr.y-tl.y)
+	lenW := math.Hypot(bl.x-tl.x, bl.y-tl.y)
+	ux, uy := (tr.x-tl.x)/lenU, (tr.y-tl.y)/lenU
+	wx, wy := (bl.x-tl.x)/lenW, (bl.y-tl.y)/lenW
+
+	// La dimensión sale del patrón de sincronización (fila y columna 6)
+	darkH := b.countTimingModules(tl, tr, wx, wy, 3)
+	darkV := b.countTimingModules(tl, bl, ux, uy, 3)
+	dimension := 17 + 2*(max(darkH, darkV)-1)
+	if darkH != darkV {
+		// Si no coinciden, estimar con el tamaño de módulo
+		ms := (tl.moduleSize + tr.moduleSize + bl.moduleSize) / 3
+		dimension = int(math.Round((lenU+lenW)/2/ms)) + 7
+	}
+	version := int(math.Round(float64(dimension-17) / 4))
+	if version < 1 || version > 40 {
+		return nil, errNotFound
+	}
+	dimension = symbolSize(version)
+
+	stepU := lenU / float64(dimension-7)
+	stepW := lenW / float64(dimension-7)
+	matrix := make(Matrix, dimension)
+	for row := range matrix {
+		matrix[row] = make([]bool, dimension)
+		for col := range matrix[row] {
+			fc, fr := float64(col)-3, float64(row)-3
+			px := tl.x + ux*fc*stepU + wx*fr*stepW
+			py := tl.y + uy*fc*stepU + wy*fr*stepW
+			matrix[row][col] = b.at(int(math.Floor(px)), int(math.Floor(py)))
+		}
+	}
+	return matrix, nil
+}
+
+// detectRMQR localiza un rMQR derecho y alineado con los ejes: el recuadro de
+// los píxeles oscuros es el contorno del símbolo, y la primera fila y la
+// primera columna del patrón de posición miden 7 módulos. Con ese tamaño de
+// módulo se elige el alto más cercano y con la proporción del recuadro el
+// ancho de ese alto.
+func (b *binaryImage) detectRMQR() (Matrix, error) {
+	minX, minY, maxX, maxY := b.width, b.height, -1, -1
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			if b.at(x, y) {
+				minX, minY = min(minX, x), min(minY, y)
+				maxX, maxY = max(maxX, x), max(maxY, y)
+			}
+		}
+	}
+	if maxX < 0 {
+		return nil, errNotRMQR
+	}
+
+	run := 0
+	for x := minX; b.at(x, minY); x++ {
+		run++
+	}
+	for y := minY; b.at(minX, y); y++ {
+		run++
+	}
+	boxW, boxH := float64(maxX-minX+1), float64(maxY-minY+1)
+
+	// Los altos van de 2 en 2, así que el error del tamaño de módulo no
+	// alcanza para confundirlos; con pocos píxeles por módulo el ancho sí, y
+	// por eso sale de la proporción y se acepta con un 5% de error
+	height := boxH / (float64(run) / 14)
+	var shape RMQRShape
+	for _, v := range rmqrVersions {
+		if shape.Height == 0 || math.Abs(float64(v.shape.Height)-height) < math.Abs(float64(shape.Height)-height) {
+			shape = v.shape
+		}
+	}
+	width := boxW / boxH * float64(shape.Height)
+	for _, v := range rmqrVersions {
+		if v.shape.Height == shape.Height && math.Abs(float64(v.shape.Width)-width) < math.Abs(float64(shape.Width)-width) {
+			shape = v.shape
+		}
+	}
+	if math.Abs(float64(shape.Width)-width) > 0.05*float64(shape.Width) {
+		return nil, errNotRMQR
+	}
+
+	stepX, stepY := boxW/float64(shape.Width), boxH/float64(shape.Height)
+	matrix := make(Matrix, shape.Height)
+	for row := range matrix {
+		matrix[row] = make([]bool, shape.Width)
+		for col := range matrix[row] {
+			px := float64(minX) + (float64(col)+0.5)*stepX
+			py := float64(minY) + (float64(row)+0.5)*stepY
+			matrix[row][col] = b.at(int(px), int(py))
+		}
+	}
+	return matrix, nil
+}
@@ -0,0 +1,18 @@
+package qrcodec
+
+import "strings"
+
+// DecodeEAN arma el resultado de los 13 dígitos de un EAN-13. Los que
+// empiezan con 0 son UPC-A y se devuelven con sus 12 dígitos, y los que
+// empiezan con 978 o 979, ISBN.
+func DecodeEAN(digits string) *Result {
+	result := &Result{Text: digits, Barcode: "ean13"}
+	switch {
+	case strings.HasPrefix(digits, "0"):
+		result.Text, result.Barcode = digits[1:], "upca"
+	case strings.HasPrefix(digits, "978") || strings.HasPrefix(digits, "979"):
+		result.Barcode = "isbn"
+	}
+	result.Segments = []Segment{{Mode: ModeNumeric, Data: []byte(result.Text)}}
+	return result
+}
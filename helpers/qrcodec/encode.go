@@ -0,0 +1,495 @@
+package qrcodec
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ErrDataTooLong indica que los segmentos no entran en ninguna versión
+var ErrDataTooLong = i18n.NewError("data too long for a QR code")
+
+// EncodeOptions son las opciones del codificador
+type EncodeOptions struct {
+	Level   Level
+	FNC1    bool // FNC1 en primera posición: el símbolo es un GS1 QR Code
+	Version int  // Versión fija (1-40); 0 elige la más chica que contiene los datos
+	ECI     int  // Designador ECI del juego de caracteres de los bytes; 0 no declara ninguno
+	// StructuredAppend ubica el símbolo en una secuencia; nil si está solo
+	StructuredAppend *StructuredAppend
+	// ForceMask usa el patrón Mask (0-7) en lugar del de menor penalización
+	ForceMask bool
+	Mask      int
+}
+
+// Symbol es un símbolo codificado
+type Symbol struct {
+	Matrix  Matrix
+	Version int
+	Level   Level
+	Mask    int
+	Shape   RMQRShape // Forma de los rMQR; cero en los QR
+}
+
+// Encode codifica los segmentos en el símbolo más chico que los contiene, o
+// en el de opts.Version, y con la máscara de menor penalización o la de
+// opts.Mask. Los segmentos usan la misma
+// representación que devuelve el decodificador: dígitos, caracteres del
+// alfabeto alfanumérico, bytes o pares de bytes Shift-JIS (ver KanjiData).
+func Encode(segments []Segment, opts EncodeOptions) (*Symbol, error) {
+	if opts.ECI < 0 || opts.ECI > 999999 {
+		return nil, i18n.Errorf("invalid ECI designator %d", opts.ECI)
+	}
+	if sa := opts.StructuredAppend; sa != nil && (sa.Total < 2 || sa.Total > MaxStructuredAppend || sa.Index < 0 || sa.Index >= sa.Total) {
+		return nil, i18n.Errorf("invalid structured append position %d of %d", sa.Index+1, sa.Total)
+	}
+	for _, seg := range segments {
+		if err := checkSegment(seg); err != nil {
+			return nil, err
+		}
+	}
+
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if opts.Version != 0 && v != opts.Version {
+			continue
+		}
+		if streamBits(segments, qrStream(v), opts) <= DataCapacityBits(v, opts.Level) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	data := dataCodewords(segments, qrStream(version), DataCapacityBits(version, opts.Level), opts)
+	codewords := interleave(data, blocks(version, opts.Level))
+
+	base := functionPatterns(version)
+	base.placeCodewords(codewords, version)
+	best, bestMask, bestPenalty := Matrix(nil), 0, -1
+	for mask := 0; mask < 8; mask++ {
+		if opts.ForceMask && mask != opts.Mask {
+			continue
+		}
+		m := base.masked(version, mask)
+		m.drawFormat(opts.Level, mask)
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestMask, bestPenalty = m, mask, p
+		}
+	}
+	return &Symbol{Matrix: best, Version: version, Level: opts.Level, Mask: bestMask}, nil
+}
+
+// checkSegment revisa que los datos correspondan al modo
+func checkSegment(seg Segment) error {
+	switch seg.Mode {
+	case ModeNumeric:
+		for _, c := range seg.Data {
+			if c < '0' || c > '9' {
+				return i18n.Errorf("%q is not allowed in a numeric segment", c)
+			}
+		}
+	case ModeAlphanumeric:
+		for _, c := range seg.Data {
+			if strings.IndexByte(alphanumericChars, c) < 0 {
+				return i18n.Errorf("%q is not allowed in an alphanumeric segment", c)
+			}
+		}
+	case ModeKanji:
+		if len(seg.Data)%2 != 0 {
+			return i18n.Errorf("a kanji segment needs pairs of Shift-JIS bytes")
+		}
+		for i := 0; i < len(seg.Data); i += 2 {
+			code := int(seg.Data[i])<<8 | int(seg.Data[i+1])
+			if !(code >= 0x8140 && code <= 0x9ffc || code >= 0xe040 && code <= 0xebbf) {
+				return i18n.Errorf("0x%04X is not allowed in a kanji segment", code)
+			}
+		}
+	case ModeByte:
+	default:
+		return i18n.Errorf("cannot encode %s segments", seg.Mode)
+	}
+	return nil
+}
+
+// chars devuelve los caracteres del segmento, los que cuenta su contador
+func (seg Segment) chars() int {
+	if seg.Mode == ModeKanji {
+		return len(seg.Data) / 2
+	}
+	return len(seg.Data)
+}
+
+// streamBits devuelve los bits que ocupan los segmentos con los encabezados de format
+func streamBits(segments []Segment, format streamFormat, opts EncodeOptions) int {
+	total := 0
+	if opts.StructuredAppend != nil {
+		total += format.modeBits() + 16
+	}
+	if opts.ECI != 0 {
+		total += format.modeBits() + eciBits(opts.ECI)
+	}
+	if opts.FNC1 {
+		total += format.modeBits()
+	}
+	for _, seg := range segments {
+		n := seg.chars()
+		total += format.modeBits() + format.countBits(seg.Mode)
+		if n >= 1<<format.countBits(seg.Mode) {
+			// El contador no alcanza: la versión no sirve
+			return 1 << 30
+		}
+		switch seg.Mode {
+		case ModeNumeric:
+			total += n/3*10 + [3]int{0, 4, 7}[n%3]
+		case ModeAlphanumeric:
+			total += n/2*11 + n%2*6
+		case ModeKanji:
+			total += n * 13
+		default:
+			total += n * 8
+		}
+	}
+	return total
+}
+
+// bitWriter acumula bits MSB primero
+type bitWriter struct {
+	data []byte
+	n    int
+}
+
+// write agrega los n bits menos significativos de v
+func (w *bitWriter) write(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if v>>i&1 != 0 {
+			w.data[w.n/8] |= 0x80 >> (w.n % 8)
+		}
+		w.n++
+	}
+}
+
+// dataCodewords arma los capacity bits de datos: segmentos, terminador y relleno
+func dataCodewords(segments []Segment, format streamFormat, capacity int, opts EncodeOptions) []byte {
+	w := &bitWriter{}
+	if sa := opts.StructuredAppend; sa != nil {
+		// Mismo orden que lee parseData: posición, total - 1 y paridad
+		format.writeMode(w, ModeStructuredAppend)
+		w.write(sa.Index<<12|(sa.Total-1)<<8|int(sa.Parity), 16)
+	}
+	if opts.ECI != 0 {
+		format.writeMode(w, ModeECI)
+		writeECI(w, opts.ECI)
+	}
+	if opts.FNC1 {
+		format.writeMode(w, ModeFNC1First)
+	}
+	for _, seg := range segments {
+		format.writeMode(w, seg.Mode)
+		w.write(seg.chars(), format.countBits(seg.Mode))
+		switch seg.Mode {
+		case ModeNumeric:
+			for i := 0; i < len(seg.Data); i += 3 {
+				group := seg.Data[i:min(i+3, len(seg.Data))]
+				v := 0
+				for _, c := range group {
+					v = v*10 + int(c-'0')
+				}
+				w.write(v, [4]int{0, 4, 7, 10}[len(group)])
+			}
+		case ModeAlphanumeric:
+			for i := 0; i < len(seg.Data); i += 2 {
+				v := strings.IndexByte(alphanumericChars, seg.Data[i])
+				if i+1 < len(seg.Data) {
+					w.write(v*45+strings.IndexByte(alphanumericChars, seg.Data[i+1]), 11)
+				} else {
+					w.write(v, 6)
+				}
+			}
+		case ModeKanji:
+			// Inversa de readSegment: se resta el inicio del rango y el
+			// byte alto pesa 0xC0
+			for i := 0; i < len(seg.Data); i += 2 {
+				code := int(seg.Data[i])<<8 | int(seg.Data[i+1])
+				if code <= 0x9ffc {
+					code -= 0x8140
+				} else {
+					code -= 0xc140
+				}
+				w.write((code>>8)*0xc0+code&0xff, 13)
+			}
+		default:
+			for _, c := range seg.Data {
+				w.write(int(c), 8)
+			}
+		}
+	}
+
+	w.write(0, min(format.modeBits(), capacity-w.n))
+	if w.n%8 != 0 {
+		w.write(0, 8-w.n%8)
+	}
+	for pad := 0; w.n < capacity; pad++ {
+		w.write([2]int{0xec, 0x11}[pad%2], 8)
+	}
+	return w.data
+}
+
+// eciBits devuelve el largo del designador ECI: 1, 2 o 3 bytes según el valor
+func eciBits(eci int) int {
+	switch {
+	case eci < 1<<7:
+		return 8
+	case eci < 1<<14:
+		return 16
+	default:
+		return 24
+	}
+}
+
+// writeECI escribe el designador ECI como lo lee readECI
+func writeECI(w *bitWriter, eci int) {
+	switch eciBits(eci) {
+	case 8:
+		w.write(eci, 8)
+	case 16:
+		w.write(0x8000|eci, 16)
+	default:
+		w.write(0xc00000|eci, 24)
+	}
+}
+
+// interleave divide los datos en bloques, agrega la corrección de cada uno y
+// los intercala como lo espera deinterleave
+func interleave(data []byte, spec blockSpec) []byte {
+	numBlocks := spec.g1Blocks + spec.g2Blocks
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for b := 0; b < numBlocks; b++ {
+		size := spec.g1Data
+		if b >= spec.g1Blocks {
+			size = spec.g2Data
+		}
+		block := data[offset : offset+size]
+		offset += size
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, field.EncodeBytes(block, spec.ecPerBlock))
+	}
+
+	out := make([]byte, 0, spec.dataCodewords()+numBlocks*spec.ecPerBlock)
+	for i := 0; i < max(spec.g1Data, spec.g2Data); i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			out = append(out, block[i])
+		}
+	}
+	return out
+}
+
+// functionPatterns dibuja los patrones de posición, sincronización,
+// alineación, el módulo oscuro y la información de versión
+func functionPatterns(version int) Matrix {
+	size := symbolSize(version)
+	m := make(Matrix, size)
+	for i := range m {
+		m[i] = make([]bool, size)
+	}
+
+	// Patrones de posición; los separadores quedan claros
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := 0; dy < 7; dy++ {
+			for dx := 0; dx < 7; dx++ {
+				ring := max(abs(dx-3), abs(dy-3))
+				m[corner[1]+dy][corner[0]+dx] = ring != 2
+			}
+		}
+	}
+
+	// Patrones de sincronización
+	for i := 8; i < size-8; i++ {
+		m[6][i] = i%2 == 0
+		m[i][6] = i%2 == 0
+	}
+
+	// Patrones de alineación, salvo los que se solapan con los de posición
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m[y+dy][x+dx] = max(abs(dx), abs(dy)) != 1
+				}
+			}
+		}
+	}
+
+	m[size-8][8] = true
+
+	// Información de versión, en las mismas posiciones que lee readVersion
+	if version >= 7 {
+		code := bchVersion(version)
+		for i := 0; i < 18; i++ {
+			a, b := i/3, size-11+i%3
+			bit := code>>i&1 != 0
+			m[a][b] = bit
+			m[b][a] = bit
+		}
+	}
+	return m
+}
+
+// placeCodewords recorre el símbolo en zigzag como readCodewords y escribe los codewords
+func (m Matrix) placeCodewords(codewords []byte, version int) {
+	size := m.Size()
+	reserved := functionMask(version)
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			y := vert
+			if upward {
+				y = size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if reserved[y][x] {
+					continue
+				}
+				// Los módulos que sobran después del último codeword quedan claros
+				if i < len(codewords)*8 {
+					m[y][x] = codewords[i/8]&(0x80>>(i%8)) != 0
+				}
+				i++
+			}
+		}
+	}
+}
+
+// masked devuelve una copia con la máscara aplicada a los módulos de datos
+func (m Matrix) masked(version, mask int) Matrix {
+	reserved := functionMask(version)
+	out := make(Matrix, len(m))
+	for y := range m {
+		out[y] = make([]bool, len(m))
+		for x := range m[y] {
+			out[y][x] = m[y][x] != (!reserved[y][x] && maskBit(mask, x, y))
+		}
+	}
+	return out
+}
+
+// drawFormat escribe las dos copias de la información de formato, en las
+// posiciones que lee readFormat
+func (m Matrix) drawFormat(level Level, mask int) {
+	size := m.Size()
+	code := bchFormat(level.formatBits()<<3 | mask)
+	bit := func(i int) bool { return code>>(14-i)&1 != 0 }
+
+	var first [][2]int
+	for x := 0; x <= 5; x++ {
+		first = append(first, [2]int{x, 8})
+	}
+	first = append(first, [2]int{7, 8}, [2]int{8, 8}, [2]int{8, 7})
+	for y := 5; y >= 0; y-- {
+		first = append(first, [2]int{8, y})
+	}
+	var second [][2]int
+	for y := size - 1; y >= size-7; y-- {
+		second = append(second, [2]int{8, y})
+	}
+	for x := size - 8; x < size; x++ {
+		second = append(second, [2]int{x, 8})
+	}
+	for i := 0; i < 15; i++ {
+		m[first[i][1]][first[i][0]] = bit(i)
+		m[second[i][1]][second[i][0]] = bit(i)
+	}
+}
+
+// penalty calcula la penalización del estándar con la que se elige la máscara
+func (m Matrix) penalty() int {
+	size := m.Size()
+	score := 0
+
+	// Tramos de cinco o más módulos iguales, en filas y columnas, y patrones
+	// parecidos a los de posición (1:1:3:1:1 con cuatro claros de un lado)
+	finderLike := []bool{true, false, true, true, true, false, true, false, false, false, false}
+	for _, transposed := range []bool{false, true} {
+		at := func(line, i int) bool {
+			if transposed {
+				return m[i][line]
+			}
+			return m[line][i]
+		}
+		for line := 0; line < size; line++ {
+			run := 1
+			for i := 1; i <= size; i++ {
+				if i < size && at(line, i) == at(line, i-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+			for i := 0; i+len(finderLike) <= size; i++ {
+				forward, backward := true, true
+				for k, dark := range finderLike {
+					forward = forward && at(line, i+k) == dark
+					backward = backward && at(line, i+k) == finderLike[len(finderLike)-1-k]
+				}
+				if forward {
+					score += 40
+				}
+				if backward {
+					score += 40
+				}
+			}
+		}
+	}
+
+	// Bloques de 2x2 del mismo color
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if m[y][x] {
+				dark++
+			}
+			if x+1 < size && y+1 < size && m[y][x] == m[y][x+1] && m[y][x] == m[y+1][x] && m[y][x] == m[y+1][x+1] {
+				score += 3
+			}
+		}
+	}
+
+	// Proporción de módulos oscuros lejos del 50%
+	percent := dark * 100 / (size * size)
+	score += abs(percent-50) / 5 * 10
+	return score
+}
+
+// abs devuelve el valor absoluto
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
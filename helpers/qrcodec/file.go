@@ -0,0 +1,102 @@
+package qrcodec
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+
+	_ "github.com/gen2brain/avif"
+	_ "github.com/gen2brain/heic"
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	_ "golang.org/x/image/tiff"
+)
+
+// DecodeFile decodifica el QR de un archivo de imagen (PNG, JPEG, GIF, TIFF, AVIF, HEIF o SVG)
+func DecodeFile(path string) (*Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeReader(f, path)
+}
+
+// DecodeReader decodifica el QR de una imagen leída de r. name se usa para
+// reconocer SVG por su extensión; también se detecta por el contenido.
+func DecodeReader(r io.Reader, name string) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	img, err := LoadImage(data, name)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(img)
+}
+
+// LoadImage convierte los bytes de un archivo en imagen, rasterizando los SVG
+func LoadImage(data []byte, name string) (image.Image, error) {
+	if isSVG(data, name) {
+		return rasterizeSVG(data)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, i18n.Errorf("error decoding image: %w", err)
+	}
+	return img, nil
+}
+
+// isSVG reconoce un SVG por la extensión o por el comienzo del documento
+func isSVG(data []byte, name string) bool {
+	if strings.EqualFold(filepath.Ext(name), ".svg") {
+		return true
+	}
+	head := strings.TrimSpace(string(data[:min(len(data), 512)]))
+	return strings.HasPrefix(head, "<?xml") || strings.HasPrefix(head, "<svg")
+}
+
+// Los SVG de menos de svgGridSide unidades de lado son de un módulo por
+// unidad, como los que escribe el generador con formas o en tamaños grandes;
+// se rasterizan a svgGridRaster píxeles o más
+const (
+	svgGridSide   = 256
+	svgGridRaster = 1024
+)
+
+// rasterizeSVG dibuja el SVG sobre fondo blanco a su tamaño nominal, o a un
+// múltiplo entero si es de un módulo por unidad
+func rasterizeSVG(data []byte) (image.Image, error) {
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(data), oksvg.IgnoreErrorMode)
+	if err != nil {
+		return nil, i18n.Errorf("error reading SVG: %w", err)
+	}
+	if icon.ViewBox.W <= 0 || icon.ViewBox.H <= 0 {
+		return nil, i18n.Errorf("SVG has no dimensions")
+	}
+	// Con módulos redondos o en rombo, a un píxel por módulo las figuras se
+	// vuelven grises
+	scale := 1.0
+	if side := max(icon.ViewBox.W, icon.ViewBox.H); side < svgGridSide {
+		scale = math.Ceil(svgGridRaster / side)
+	}
+	w, h := int(icon.ViewBox.W*scale), int(icon.ViewBox.H*scale)
+	icon.SetTarget(0, 0, float64(w), float64(h))
+
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(rgba, rgba.Bounds(), image.White, image.Point{}, draw.Src)
+	scanner := rasterx.NewScannerGV(w, h, rgba, rgba.Bounds())
+	icon.Draw(rasterx.NewDasher(w, h, scanner), 1.0)
+	return rgba, nil
+}
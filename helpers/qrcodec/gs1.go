@@ -0,0 +1,88 @@
+package qrcodec
+
+import "strings"
+
+// GS es el separador de grupo (FNC1) que termina los elementos de largo
+// variable de una cadena GS1 en los datos transmitidos
+const GS = '\x1d'
+
+// gs1MinNumericRun es el tramo mínimo de dígitos que conviene codificar en
+// modo numérico entre tramos de otro modo
+const gs1MinNumericRun = 6
+
+// GS1Segments divide una cadena de elementos GS1 (con GS como separador) en
+// segmentos para un símbolo con FNC1: los tramos largos de dígitos en modo
+// numérico y el resto en alfanumérico si se puede, o en byte. En modo
+// alfanumérico el separador se escribe "%" y el "%" literal, "%%".
+func GS1Segments(data string) []Segment {
+	var segments []Segment
+	var pending []byte
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if seg, ok := gs1Alphanumeric(pending); ok {
+			segments = append(segments, seg)
+		} else {
+			segments = append(segments, Segment{Mode: ModeByte, Data: pending})
+		}
+		pending = nil
+	}
+
+	for i := 0; i < len(data); {
+		run := i
+		for run < len(data) && data[run] >= '0' && data[run] <= '9' {
+			run++
+		}
+		if run-i >= gs1MinNumericRun {
+			flush()
+			segments = append(segments, Segment{Mode: ModeNumeric, Data: []byte(data[i:run])})
+			i = run
+			continue
+		}
+		if run == i {
+			run++
+		}
+		pending = append(pending, data[i:run]...)
+		i = run
+	}
+	flush()
+	return segments
+}
+
+// gs1Alphanumeric escribe el tramo en modo alfanumérico, si todos sus
+// caracteres lo admiten
+func gs1Alphanumeric(data []byte) (Segment, bool) {
+	var out []byte
+	for _, c := range data {
+		switch {
+		case c == GS:
+			out = append(out, '%')
+		case c == '%':
+			out = append(out, '%', '%')
+		case strings.IndexByte(alphanumericChars, c) >= 0:
+			out = append(out, c)
+		default:
+			return Segment{}, false
+		}
+	}
+	return Segment{Mode: ModeAlphanumeric, Data: out}, true
+}
+
+// fnc1Alphanumeric es la inversa de gs1Alphanumeric al decodificar: "%" es el
+// separador y "%%", un "%" literal
+func fnc1Alphanumeric(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); i++ {
+		switch {
+		case data[i] != '%':
+			out = append(out, data[i])
+		case i+1 < len(data) && data[i+1] == '%':
+			out = append(out, '%')
+			i++
+		default:
+			out = append(out, GS)
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package qrcodec
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// kanjiChar devuelve los dos bytes Shift-JIS del carácter si el modo kanji
+// lo admite: los rangos 0x8140-0x9FFC y 0xE040-0xEBBF
+func kanjiChar(r rune) ([]byte, bool) {
+	sjis, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(string(r)))
+	if err != nil || len(sjis) != 2 {
+		return nil, false
+	}
+	code := int(sjis[0])<<8 | int(sjis[1])
+	return sjis, code >= 0x8140 && code <= 0x9ffc || code >= 0xe040 && code <= 0xebbf
+}
+
+// KanjiData convierte el texto a Shift-JIS para un segmento de modo kanji;
+// devuelve false y el primer carácter que el modo no admite
+func KanjiData(text string) ([]byte, rune, bool) {
+	var data []byte
+	for _, r := range text {
+		sjis, ok := kanjiChar(r)
+		if !ok {
+			return nil, r, false
+		}
+		data = append(data, sjis...)
+	}
+	return data, 0, true
+}
+
+// KanjiSegments divide texto japonés en tramos de modo kanji y tramos ASCII,
+// cada uno en el modo más denso que lo admite. Devuelve false si el texto no
+// tiene caracteres kanji o tiene alguno que no es ni kanji ni ASCII: esos
+// bytes irían en UTF-8 junto a segmentos Shift-JIS y los lectores no sabrían
+// cómo leerlos.
+func KanjiSegments(text string) ([]Segment, bool) {
+	var segments []Segment
+	var pending []byte
+	kanji := false
+	flush := func(mode Mode) {
+		if len(pending) > 0 {
+			if mode != ModeKanji {
+				mode = ModeFor(string(pending))
+			}
+			segments = append(segments, Segment{Mode: mode, Data: pending})
+			pending = nil
+		}
+	}
+
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			if kanji {
+				flush(ModeKanji)
+				kanji = false
+			}
+			pending = append(pending, byte(r))
+			continue
+		}
+		sjis, ok := kanjiChar(r)
+		if !ok {
+			return nil, false
+		}
+		if !kanji {
+			flush(ModeByte)
+			kanji = true
+		}
+		pending = append(pending, sjis...)
+	}
+	if kanji {
+		flush(ModeKanji)
+	} else {
+		flush(ModeByte)
+	}
+	for _, seg := range segments {
+		if seg.Mode == ModeKanji {
+			return segments, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,267 @@
+package qrcodec
+
+import (
+	"math/bits"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Matrix es la grilla de módulos de un símbolo; true es un módulo oscuro.
+// Se indexa como Matrix[fila][columna] y no incluye la zona de silencio.
+type Matrix [][]bool
+
+// Size devuelve los módulos por lado
+func (m Matrix) Size() int {
+	return len(m)
+}
+
+// maskBit indica si el patrón de máscara invierte el módulo (x=columna, y=fila)
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// formatInfoMask es la máscara XOR que el estándar aplica a la información de formato
+const formatInfoMask = 0x5412
+
+// bchFormat calcula los 15 bits de información de formato para 5 bits de datos
+func bchFormat(data int) int {
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ formatInfoMask
+}
+
+// bchVersion calcula los 18 bits de información de versión
+func bchVersion(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	return version<<12 | rem
+}
+
+// decodeFormatBits busca el código de formato válido más cercano (hasta 3 bits de diferencia)
+func decodeFormatBits(raw ...int) (level Level, mask int, ok bool) {
+	best, bestDist := 0, 16
+	for data := 0; data < 32; data++ {
+		code := bchFormat(data)
+		for _, r := range raw {
+			if d := bits.OnesCount(uint(code ^ r)); d < bestDist {
+				best, bestDist = data, d
+			}
+		}
+	}
+	if bestDist > 3 {
+		return 0, 0, false
+	}
+	return levelFromFormatBits(best >> 3), best & 0x7, true
+}
+
+// readFormat lee ambas copias de la información de formato
+func (m Matrix) readFormat() (Level, int, error) {
+	size := m.Size()
+	bit := func(x, y int) int {
+		if m[y][x] {
+			return 1
+		}
+		return 0
+	}
+
+	first := 0
+	for x := 0; x <= 5; x++ {
+		first = first<<1 | bit(x, 8)
+	}
+	first = first<<1 | bit(7, 8)
+	first = first<<1 | bit(8, 8)
+	first = first<<1 | bit(8, 7)
+	for y := 5; y >= 0; y-- {
+		first = first<<1 | bit(8, y)
+	}
+
+	second := 0
+	for y := size - 1; y >= size-7; y-- {
+		second = second<<1 | bit(8, y)
+	}
+	for x := size - 8; x < size; x++ {
+		second = second<<1 | bit(x, 8)
+	}
+
+	level, mask, ok := decodeFormatBits(first, second)
+	if !ok {
+		return 0, 0, i18n.Errorf("unreadable format information")
+	}
+	return level, mask, nil
+}
+
+// readVersion lee la información de versión (solo presente desde la versión 7)
+func (m Matrix) readVersion() (int, error) {
+	size := m.Size()
+	estimated := (size - 17) / 4
+	if estimated < 7 {
+		return estimated, nil
+	}
+
+	topRight, bottomLeft := 0, 0
+	for i := 17; i >= 0; i-- {
+		a, b := i/3, size-11+i%3
+		topRight <<= 1
+		bottomLeft <<= 1
+		if m[a][b] {
+			topRight |= 1
+		}
+		if m[b][a] {
+			bottomLeft |= 1
+		}
+	}
+
+	best, bestDist := 0, 19
+	for v := 7; v <= 40; v++ {
+		code := bchVersion(v)
+		for _, r := range []int{topRight, bottomLeft} {
+			if d := bits.OnesCount(uint(code ^ r)); d < bestDist {
+				best, bestDist = v, d
+			}
+		}
+	}
+	if bestDist > 3 {
+		return 0, i18n.Errorf("unreadable version information")
+	}
+	return best, nil
+}
+
+// functionMask marca los módulos reservados para patrones de función
+func functionMask(version int) [][]bool {
+	size := symbolSize(version)
+	mask := make([][]bool, size)
+	for i := range mask {
+		mask[i] = make([]bool, size)
+	}
+	fill := func(x, y, w, h int) {
+		for dy := 0; dy < h; dy++ {
+			for dx := 0; dx < w; dx++ {
+				mask[y+dy][x+dx] = true
+			}
+		}
+	}
+
+	// Patrones de posición con separadores e información de formato
+	fill(0, 0, 9, 9)
+	fill(size-8, 0, 8, 9)
+	fill(0, size-8, 9, 8)
+
+	// Patrones de sincronización
+	fill(6, 0, 1, size)
+	fill(0, 6, size, 1)
+
+	// Patrones de alineación, salvo los que se solapan con los de posición
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			fill(x-2, y-2, 5, 5)
+		}
+	}
+
+	// Información de versión
+	if version >= 7 {
+		fill(size-11, 0, 3, 6)
+		fill(0, size-11, 6, 3)
+	}
+	return mask
+}
+
+// readCodewords recorre el símbolo en zigzag, quita la máscara y devuelve los codewords crudos
+func (m Matrix) readCodewords(version, mask int) []byte {
+	size := m.Size()
+	reserved := functionMask(version)
+	codewords := make([]byte, rawDataModules(version)/8)
+
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			y := vert
+			if upward {
+				y = size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if reserved[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				if m[y][x] != maskBit(mask, x, y) {
+					codewords[i/8] |= 0x80 >> (i % 8)
+				}
+				i++
+			}
+		}
+	}
+	return codewords
+}
+
+// deinterleave separa los codewords en bloques, corrige cada uno y devuelve
+// los codewords de datos junto con la cantidad de correcciones realizadas
+func deinterleave(raw []byte, spec blockSpec) ([]byte, int, error) {
+	numBlocks := spec.g1Blocks + spec.g2Blocks
+	blockData := make([][]byte, numBlocks)
+	for b := range blockData {
+		size := spec.g1Data
+		if b >= spec.g1Blocks {
+			size = spec.g2Data
+		}
+		blockData[b] = make([]byte, 0, size+spec.ecPerBlock)
+	}
+
+	idx := 0
+	longest := max(spec.g1Data, spec.g2Data)
+	for i := 0; i < longest; i++ {
+		for b := range blockData {
+			if i < cap(blockData[b])-spec.ecPerBlock {
+				blockData[b] = append(blockData[b], raw[idx])
+				idx++
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for b := range blockData {
+			blockData[b] = append(blockData[b], raw[idx])
+			idx++
+		}
+	}
+
+	data := make([]byte, 0, spec.dataCodewords())
+	corrected := 0
+	for b, block := range blockData {
+		n, err := field.CorrectBytes(block, spec.ecPerBlock)
+		if err != nil {
+			return nil, 0, i18n.Errorf("block %d: %w", b+1, err)
+		}
+		corrected += n
+		data = append(data, block[:len(block)-spec.ecPerBlock]...)
+	}
+	return data, corrected, nil
+}
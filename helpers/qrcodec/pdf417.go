@@ -0,0 +1,24 @@
+package qrcodec
+
+import "qrgenerator_cli/helpers/pdf417"
+
+// DecodePDF417 decodifica un PDF417 ya muestreado, sin zona de silencio.
+// Version es la cantidad de columnas de datos.
+func DecodePDF417(matrix [][]bool) (*Result, error) {
+	pdf, err := pdf417.DecodeMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		Segments:    []Segment{{Mode: ModeByte, Data: pdf.Data}},
+		Version:     pdf.Size.Columns,
+		ECI:         pdf.ECI,
+		PDF417:      pdf.Size,
+		PDF417Level: pdf.Level,
+		Corrected:   pdf.Corrected,
+	}
+	if result.Text, err = decodeText(result.Segments, result.ECI, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
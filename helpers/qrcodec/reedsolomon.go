@@ -0,0 +1,7 @@
+package qrcodec
+
+import "qrgenerator_cli/helpers/reedsolomon"
+
+// field es GF(256) con el polinomio primitivo 0x11d y el generador desde
+// alfa^0, como pide el estándar del QR
+var field = reedsolomon.NewField(0x11d, 256, 0)
@@ -0,0 +1,497 @@
+package qrcodec
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// RMQRShape es la forma de un símbolo rMQR (ISO/IEC 23941), en módulos sin
+// la zona de silencio
+type RMQRShape struct {
+	Height, Width int
+}
+
+// String devuelve el nombre de la forma, como R13x43
+func (s RMQRShape) String() string {
+	return fmt.Sprintf("R%dx%d", s.Height, s.Width)
+}
+
+// rmqrVersion es una de las 32 formas con sus bloques de corrección
+type rmqrVersion struct {
+	shape  RMQRShape
+	blocks [2]blockSpec // Niveles M y H
+}
+
+// rmqrVersions están en el orden del indicador de versión de la información de formato
+var rmqrVersions = [32]rmqrVersion{
+	{RMQRShape{7, 43}, [2]blockSpec{{7, 1, 6, 0, 0}, {10, 1, 3, 0, 0}}},
+	{RMQRShape{7, 59}, [2]blockSpec{{9, 1, 12, 0, 0}, {14, 1, 7, 0, 0}}},
+	{RMQRShape{7, 77}, [2]blockSpec{{12, 1, 20, 0, 0}, {22, 1, 10, 0, 0}}},
+	{RMQRShape{7, 99}, [2]blockSpec{{16, 1, 28, 0, 0}, {30, 1, 14, 0, 0}}},
+	{RMQRShape{7, 139}, [2]blockSpec{{24, 1, 44, 0, 0}, {22, 2, 12, 0, 0}}},
+	{RMQRShape{9, 43}, [2]blockSpec{{9, 1, 12, 0, 0}, {14, 1, 7, 0, 0}}},
+	{RMQRShape{9, 59}, [2]blockSpec{{12, 1, 21, 0, 0}, {22, 1, 11, 0, 0}}},
+	{RMQRShape{9, 77}, [2]blockSpec{{18, 1, 31, 0, 0}, {16, 1, 8, 1, 9}}},
+	{RMQRShape{9, 99}, [2]blockSpec{{24, 1, 42, 0, 0}, {22, 2, 11, 0, 0}}},
+	{RMQRShape{9, 139}, [2]blockSpec{{18, 1, 31, 1, 32}, {22, 3, 11, 0, 0}}},
+	{RMQRShape{11, 27}, [2]blockSpec{{8, 1, 7, 0, 0}, {10, 1, 5, 0, 0}}},
+	{RMQRShape{11, 43}, [2]blockSpec{{12, 1, 19, 0, 0}, {20, 1, 11, 0, 0}}},
+	{RMQRShape{11, 59}, [2]blockSpec{{16, 1, 31, 0, 0}, {16, 1, 7, 1, 8}}},
+	{RMQRShape{11, 77}, [2]blockSpec{{24, 1, 43, 0, 0}, {22, 1, 11, 1, 12}}},
+	{RMQRShape{11, 99}, [2]blockSpec{{16, 1, 28, 1, 29}, {30, 1, 14, 1, 15}}},
+	{RMQRShape{11, 139}, [2]blockSpec{{24, 2, 42, 0, 0}, {30, 3, 14, 0, 0}}},
+	{RMQRShape{13, 27}, [2]blockSpec{{9, 1, 12, 0, 0}, {14, 1, 7, 0, 0}}},
+	{RMQRShape{13, 43}, [2]blockSpec{{14, 1, 27, 0, 0}, {28, 1, 13, 0, 0}}},
+	{RMQRShape{13, 59}, [2]blockSpec{{22, 1, 38, 0, 0}, {20, 2, 10, 0, 0}}},
+	{RMQRShape{13, 77}, [2]blockSpec{{16, 1, 26, 1, 27}, {28, 1, 14, 1, 15}}},
+	{RMQRShape{13, 99}, [2]blockSpec{{20, 1, 36, 1, 37}, {26, 1, 11, 2, 12}}},
+	{RMQRShape{13, 139}, [2]blockSpec{{20, 2, 35, 1, 36}, {28, 2, 13, 2, 14}}},
+	{RMQRShape{15, 43}, [2]blockSpec{{18, 1, 33, 0, 0}, {18, 1, 7, 1, 8}}},
+	{RMQRShape{15, 59}, [2]blockSpec{{26, 1, 48, 0, 0}, {24, 2, 13, 0, 0}}},
+	{RMQRShape{15, 77}, [2]blockSpec{{18, 1, 33, 1, 34}, {24, 2, 10, 1, 11}}},
+	{RMQRShape{15, 99}, [2]blockSpec{{24, 2, 44, 0, 0}, {22, 4, 12, 0, 0}}},
+	{RMQRShape{15, 139}, [2]blockSpec{{24, 2, 42, 1, 43}, {26, 1, 13, 4, 14}}},
+	{RMQRShape{17, 43}, [2]blockSpec{{22, 1, 39, 0, 0}, {20, 1, 10, 1, 11}}},
+	{RMQRShape{17, 59}, [2]blockSpec{{16, 2, 28, 0, 0}, {30, 2, 14, 0, 0}}},
+	{RMQRShape{17, 77}, [2]blockSpec{{22, 2, 39, 0, 0}, {28, 1, 12, 2, 13}}},
+	{RMQRShape{17, 99}, [2]blockSpec{{20, 2, 33, 1, 34}, {26, 4, 14, 0, 0}}},
+	{RMQRShape{17, 139}, [2]blockSpec{{20, 4, 38, 0, 0}, {22, 2, 16, 4, 17}}},
+}
+
+// rmqrAlignment son las columnas de los patrones de alineación según el ancho
+var rmqrAlignment = map[int][]int{
+	27:  nil,
+	43:  {21},
+	59:  {19, 39},
+	77:  {25, 51},
+	99:  {23, 49, 75},
+	139: {27, 55, 83, 111},
+}
+
+// rmqrCountBits son los anchos del contador de caracteres por versión, para
+// los modos numérico, alfanumérico, byte y kanji
+var rmqrCountBits = [4][32]int{
+	{4, 5, 6, 7, 7, 5, 6, 7, 7, 8, 4, 6, 7, 7, 8, 8, 5, 6, 7, 7, 8, 8, 7, 7, 8, 8, 9, 7, 8, 8, 8, 9},
+	{3, 5, 5, 6, 6, 5, 5, 6, 6, 7, 4, 5, 6, 6, 7, 7, 5, 6, 6, 7, 7, 8, 6, 7, 7, 7, 8, 6, 7, 7, 8, 8},
+	{3, 4, 5, 5, 6, 4, 5, 5, 6, 6, 3, 5, 5, 6, 6, 7, 4, 5, 6, 6, 7, 7, 6, 6, 7, 7, 7, 6, 6, 7, 7, 8},
+	{2, 3, 4, 5, 5, 3, 4, 5, 5, 6, 2, 4, 5, 5, 6, 6, 3, 5, 5, 6, 6, 7, 5, 5, 6, 6, 7, 5, 6, 6, 6, 7},
+}
+
+// rmqrModes son los modos de cada indicador de 3 bits; rMQR no tiene
+// structured append
+var rmqrModes = []Mode{ModeTerminator, ModeNumeric, ModeAlphanumeric, ModeByte, ModeKanji, ModeFNC1First, ModeFNC1Second, ModeECI}
+
+// Máscaras XOR de las dos copias de la información de formato
+const (
+	rmqrFormatMaskTopLeft     = 0x1fab2
+	rmqrFormatMaskBottomRight = 0x20a7b
+)
+
+// rmqrMask es el único patrón de máscara de rMQR, el 4 de los QR
+const rmqrMask = 4
+
+// rmqrStream es el formato del flujo de bits de un índice de rmqrVersions
+func rmqrStream(index int) streamFormat {
+	return streamFormat{
+		modes: rmqrModes,
+		countBits: func(mode Mode) int {
+			switch mode {
+			case ModeNumeric:
+				return rmqrCountBits[0][index]
+			case ModeAlphanumeric:
+				return rmqrCountBits[1][index]
+			case ModeByte:
+				return rmqrCountBits[2][index]
+			case ModeKanji:
+				return rmqrCountBits[3][index]
+			default:
+				return 0
+			}
+		},
+	}
+}
+
+// rmqrBlocks devuelve los bloques de un índice de rmqrVersions; solo hay M y H
+func rmqrBlocks(index int, level Level) blockSpec {
+	if level == LevelH {
+		return rmqrVersions[index].blocks[1]
+	}
+	return rmqrVersions[index].blocks[0]
+}
+
+// RMQRShapes devuelve las 32 formas de rMQR, de la más baja a la más alta y
+// de la más angosta a la más ancha
+func RMQRShapes() []RMQRShape {
+	shapes := make([]RMQRShape, len(rmqrVersions))
+	for i, v := range rmqrVersions {
+		shapes[i] = v.shape
+	}
+	return shapes
+}
+
+// ParseRMQRShape lee una forma como R13x43. Con solo el alto (R13) devuelve
+// Width 0, para que EncodeRMQR elija el ancho.
+func ParseRMQRShape(name string) (RMQRShape, error) {
+	text := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "R")
+	height, width, hasWidth := strings.Cut(text, "X")
+	var shape RMQRShape
+	var err error
+	if shape.Height, err = strconv.Atoi(height); err == nil && hasWidth {
+		shape.Width, err = strconv.Atoi(width)
+	}
+	if err == nil {
+		for _, v := range rmqrVersions {
+			if v.shape.Height == shape.Height && (shape.Width == 0 || v.shape.Width == shape.Width) {
+				return shape, nil
+			}
+		}
+	}
+	return RMQRShape{}, i18n.Errorf("unknown rMQR shape %s", name)
+}
+
+// RMQRCapacity devuelve cuántos caracteres (en modo kanji, caracteres de dos
+// bytes) entran en un solo segmento del modo en un rMQR de esa forma y nivel;
+// 0 si la forma no existe
+func RMQRCapacity(mode Mode, level Level, shape RMQRShape) int {
+	for i, v := range rmqrVersions {
+		if v.shape == shape {
+			return segmentCapacity(mode, rmqrBlocks(i, level).dataCodewords()*8-3, rmqrStream(i).countBits(mode))
+		}
+	}
+	return 0
+}
+
+// RMQROptions son las opciones del codificador de rMQR
+type RMQROptions struct {
+	Level Level // LevelM o LevelH, los únicos niveles de rMQR
+	// Shape fija la forma; con Width 0 se elige la más angosta de ese alto
+	// y sin Height la de menor superficie que contiene los datos
+	Shape RMQRShape
+	ECI   int // Designador ECI del juego de caracteres de los bytes; 0 no declara ninguno
+}
+
+// EncodeRMQR codifica los segmentos, con la misma representación que Encode,
+// en un rMQR. Symbol.Version es la posición de la forma en RMQRShapes, desde 1.
+func EncodeRMQR(segments []Segment, opts RMQROptions) (*Symbol, error) {
+	if opts.Level != LevelM && opts.Level != LevelH {
+		return nil, i18n.Errorf("rMQR only has error correction levels M and H")
+	}
+	if opts.ECI < 0 || opts.ECI > 999999 {
+		return nil, i18n.Errorf("invalid ECI designator %d", opts.ECI)
+	}
+	for _, seg := range segments {
+		if err := checkSegment(seg); err != nil {
+			return nil, err
+		}
+	}
+
+	codecOpts := EncodeOptions{Level: opts.Level, ECI: opts.ECI}
+	index := -1
+	for i, v := range rmqrVersions {
+		if opts.Shape.Height != 0 && v.shape.Height != opts.Shape.Height || opts.Shape.Width != 0 && v.shape.Width != opts.Shape.Width {
+			continue
+		}
+		if streamBits(segments, rmqrStream(i), codecOpts) > rmqrBlocks(i, opts.Level).dataCodewords()*8 {
+			continue
+		}
+		if index < 0 || v.shape.Height*v.shape.Width < rmqrVersions[index].shape.Height*rmqrVersions[index].shape.Width {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, ErrDataTooLong
+	}
+
+	spec := rmqrBlocks(index, opts.Level)
+	data := dataCodewords(segments, rmqrStream(index), spec.dataCodewords()*8, codecOpts)
+	codewords := interleave(data, spec)
+
+	shape := rmqrVersions[index].shape
+	m := rmqrFunctionPatterns(shape)
+	reserved := rmqrFunctionMask(shape)
+	m.placeRMQR(codewords, reserved)
+	m.drawRMQRFormat(index, opts.Level)
+	return &Symbol{Matrix: m, Version: index + 1, Level: opts.Level, Mask: rmqrMask, Shape: shape}, nil
+}
+
+// rmqrFunctionPatterns dibuja los patrones de posición, de esquina, de
+// alineación y de sincronización; la información de formato se escribe aparte
+func rmqrFunctionPatterns(shape RMQRShape) Matrix {
+	w, h := shape.Width, shape.Height
+	m := make(Matrix, h)
+	for y := range m {
+		m[y] = make([]bool, w)
+	}
+
+	// Sincronización de los bordes y de las columnas de alineación
+	for x := 0; x < w; x++ {
+		m[0][x] = x%2 == 0
+		m[h-1][x] = x%2 == 0
+	}
+	columns := append([]int{0, w - 1}, rmqrAlignment[w]...)
+	for _, x := range columns {
+		for y := 0; y < h; y++ {
+			m[y][x] = y%2 == 0
+		}
+	}
+
+	// Patrones de alineación arriba y abajo de cada columna: borde oscuro y centro claro
+	for _, cx := range rmqrAlignment[w] {
+		for _, top := range []int{0, h - 3} {
+			for dy := 0; dy < 3; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					m[top+dy][cx+dx] = dx != 0 || dy != 1
+				}
+			}
+		}
+	}
+
+	// Patrón de posición arriba a la izquierda con su separador claro
+	for y := 0; y < min(h, 8); y++ {
+		for x := 0; x < 8; x++ {
+			ring := max(abs(x-3), abs(y-3))
+			m[y][x] = x < 7 && y < 7 && ring != 2
+		}
+	}
+
+	// Subpatrón de posición abajo a la derecha
+	for dy := 0; dy < 5; dy++ {
+		for dx := 0; dx < 5; dx++ {
+			m[h-5+dy][w-5+dx] = max(abs(dx-2), abs(dy-2)) != 1
+		}
+	}
+
+	// Patrones de esquina arriba a la derecha y abajo a la izquierda
+	m[0][w-2], m[1][w-1], m[1][w-2] = true, true, false
+	m[h-1][1], m[h-1][2] = true, true
+	if h >= 11 {
+		m[h-2][0], m[h-2][1] = true, false
+	}
+	return m
+}
+
+// rmqrFunctionMask marca los módulos reservados para patrones de función e
+// información de formato
+func rmqrFunctionMask(shape RMQRShape) [][]bool {
+	w, h := shape.Width, shape.Height
+	mask := make([][]bool, h)
+	for y := range mask {
+		mask[y] = make([]bool, w)
+	}
+	fill := func(x, y, fw, fh int) {
+		for dy := 0; dy < fh; dy++ {
+			for dx := 0; dx < fw; dx++ {
+				mask[y+dy][x+dx] = true
+			}
+		}
+	}
+
+	// Bordes
+	fill(0, 0, w, 1)
+	fill(0, h-1, w, 1)
+	fill(0, 0, 1, h)
+	fill(w-1, 0, 1, h)
+
+	// Alineación y sincronización vertical
+	for _, cx := range rmqrAlignment[w] {
+		fill(cx-1, 1, 3, 2)
+		fill(cx-1, h-3, 3, 2)
+		fill(cx, 3, 1, h-6)
+	}
+
+	// Patrón de posición con separador e información de formato; en R7 el
+	// patrón llega al borde de abajo
+	fill(1, 1, 7, min(7, h-2))
+	fill(8, 1, 3, 5)
+	fill(11, 1, 1, 3)
+
+	// Subpatrón de posición e información de formato
+	fill(w-5, h-5, 4, 4)
+	fill(w-8, h-6, 3, 5)
+	fill(w-5, h-6, 3, 1)
+
+	// Patrones de esquina
+	mask[1][w-2] = true
+	if h >= 11 {
+		mask[h-2][1] = true
+	}
+	return mask
+}
+
+// placeRMQR recorre el símbolo en zigzag desde la derecha, sin la columna del
+// borde, y escribe los codewords con la máscara aplicada; los módulos que
+// sobran quedan claros antes de la máscara
+func (m Matrix) placeRMQR(codewords []byte, reserved [][]bool) {
+	h, w := len(m), len(m[0])
+	i := 0
+	upward := true
+	for right := w - 2; right > 0; right -= 2 {
+		for vert := 0; vert < h; vert++ {
+			y := vert
+			if upward {
+				y = h - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if reserved[y][x] {
+					continue
+				}
+				dark := i < len(codewords)*8 && codewords[i/8]&(0x80>>(i%8)) != 0
+				m[y][x] = dark != maskBit(rmqrMask, x, y)
+				i++
+			}
+		}
+		upward = !upward
+	}
+}
+
+// readRMQRCodewords es la inversa de placeRMQR
+func (m Matrix) readRMQRCodewords(reserved [][]bool, count int) []byte {
+	h, w := len(m), len(m[0])
+	codewords := make([]byte, count)
+	i := 0
+	upward := true
+	for right := w - 2; right > 0; right -= 2 {
+		for vert := 0; vert < h; vert++ {
+			y := vert
+			if upward {
+				y = h - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if reserved[y][x] || i >= count*8 {
+					continue
+				}
+				if m[y][x] != maskBit(rmqrMask, x, y) {
+					codewords[i/8] |= 0x80 >> (i % 8)
+				}
+				i++
+			}
+		}
+		upward = !upward
+	}
+	return codewords
+}
+
+// rmqrFormatPositions devuelve las posiciones (x, y) de los 18 bits de cada
+// copia de la información de formato, desde el menos significativo
+func rmqrFormatPositions(w, h int) (topLeft, bottomRight [18][2]int) {
+	for n := 0; n < 18; n++ {
+		topLeft[n] = [2]int{8 + n/5, 1 + n%5}
+		if n < 15 {
+			bottomRight[n] = [2]int{w - 8 + n/5, h - 6 + n%5}
+		} else {
+			bottomRight[n] = [2]int{w - 5 + n - 15, h - 6}
+		}
+	}
+	return topLeft, bottomRight
+}
+
+// rmqrFormatData son los 6 bits de datos de la información de formato: el
+// nivel (0 para M, 1 para H) y el indicador de versión
+func rmqrFormatData(index int, level Level) int {
+	data := index
+	if level == LevelH {
+		data |= 1 << 5
+	}
+	return data
+}
+
+// drawRMQRFormat escribe las dos copias de la información de formato
+func (m Matrix) drawRMQRFormat(index int, level Level) {
+	code := bchVersion(rmqrFormatData(index, level))
+	topLeft, bottomRight := rmqrFormatPositions(len(m[0]), len(m))
+	for n := 0; n < 18; n++ {
+		m[topLeft[n][1]][topLeft[n][0]] = (code^rmqrFormatMaskTopLeft)>>n&1 != 0
+		m[bottomRight[n][1]][bottomRight[n][0]] = (code^rmqrFormatMaskBottomRight)>>n&1 != 0
+	}
+}
+
+// readRMQRFormat lee la información de formato y devuelve el índice de la
+// versión y el nivel; la forma tiene que coincidir con la de la matriz
+func (m Matrix) readRMQRFormat() (int, Level, error) {
+	h, w := len(m), len(m[0])
+	topLeft, bottomRight := rmqrFormatPositions(w, h)
+	first, second := 0, 0
+	for n := 0; n < 18; n++ {
+		if m[topLeft[n][1]][topLeft[n][0]] {
+			first |= 1 << n
+		}
+		if m[bottomRight[n][1]][bottomRight[n][0]] {
+			second |= 1 << n
+		}
+	}
+	first ^= rmqrFormatMaskTopLeft
+	second ^= rmqrFormatMaskBottomRight
+
+	best, bestDist := 0, 19
+	for data := 0; data < 64; data++ {
+		code := bchVersion(data)
+		for _, raw := range []int{first, second} {
+			if d := bits.OnesCount(uint(code ^ raw)); d < bestDist {
+				best, bestDist = data, d
+			}
+		}
+	}
+	index := best & 0x1f
+	if bestDist > 3 || index >= len(rmqrVersions) {
+		return 0, 0, i18n.Errorf("unreadable format information")
+	}
+	if shape := rmqrVersions[index].shape; shape.Width != w || shape.Height != h {
+		return 0, 0, i18n.Errorf("version %s does not match size %dx%d", shape, h, w)
+	}
+	level := LevelM
+	if best>>5 != 0 {
+		level = LevelH
+	}
+	return index, level, nil
+}
+
+// errNotRMQR indica que la grilla no tiene la forma de ningún rMQR
+var errNotRMQR = i18n.NewError("no rMQR code found in the image")
+
+// DecodeRMQRMatrix decodifica la grilla de módulos ya muestreada de un rMQR
+func DecodeRMQRMatrix(matrix Matrix) (*Result, error) {
+	shape, _, err := RMQRFormat(matrix)
+	if err != nil {
+		return nil, err
+	}
+	index, level, _ := matrix.readRMQRFormat()
+
+	spec := rmqrBlocks(index, level)
+	total := spec.dataCodewords() + (spec.g1Blocks+spec.g2Blocks)*spec.ecPerBlock
+	raw := matrix.readRMQRCodewords(rmqrFunctionMask(shape), total)
+	data, corrected, err := deinterleave(raw, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Version: index + 1, Level: level, Mask: rmqrMask, Shape: shape, Corrected: corrected}
+	if err := parseData(data, rmqrStream(index), result); err != nil {
+		return nil, i18n.Errorf("corrupted data: %w", err)
+	}
+	text, err := decodeText(result.Segments, result.ECI, result.FNC1)
+	if err != nil {
+		return nil, err
+	}
+	result.Text = text
+	return result, nil
+}
+
+// RMQRFormat lee la forma y el nivel de la información de formato de la
+// grilla de un rMQR, sin decodificar los datos
+func RMQRFormat(matrix Matrix) (RMQRShape, Level, error) {
+	if len(matrix) == 0 {
+		return RMQRShape{}, 0, errNotRMQR
+	}
+	shape := RMQRShape{len(matrix), len(matrix[0])}
+	if _, err := ParseRMQRShape(shape.String()); err != nil {
+		return RMQRShape{}, 0, i18n.Errorf("invalid rMQR size: %dx%d", shape.Height, shape.Width)
+	}
+	_, level, err := matrix.readRMQRFormat()
+	return shape, level, err
+}
@@ -0,0 +1,60 @@
+package qrcodec_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// unicodePayloads son textos que solo entran en modo byte: emoji de 4 bytes,
+// secuencias ZWJ, banderas y € mezclado con ASCII
+var unicodePayloads = map[string]string{
+	"zwj":           "👩‍💻 👨‍👩‍👧",
+	"flag":          "🇦🇷🇯🇵",
+	"supplementary": "𝄞 clef",
+	"mixed":         "Precio: 1234567890 € total",
+}
+
+// TestUnicodeRoundTrip genera cada payload en cada --mode y lo decodifica:
+// auto y byte devuelven los mismos bytes, y los modos que no admiten esos
+// caracteres fallan con ErrInvalidInput
+func TestUnicodeRoundTrip(t *testing.T) {
+	for name, content := range unicodePayloads {
+		for _, mode := range qrgenerator.Modes() {
+			t.Run(name+"/"+mode, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "qr.png")
+				_, err := qrgenerator.GenerateQR(qrgenerator.QRConfig{
+					URL:         content,
+					Size:        256,
+					OutputPath:  path,
+					Format:      qrgenerator.FormatPNG,
+					ExtraParams: map[string]string{"mode": mode},
+				})
+
+				if mode != "auto" && mode != "byte" {
+					if !errors.Is(err, qrgenerator.ErrInvalidInput) {
+						t.Fatalf("--mode %s: got %v, want ErrInvalidInput", mode, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+
+				result, err := qrcodec.DecodeFile(path)
+				if err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if result.Text != content {
+					t.Errorf("decoded %q, want %q", result.Text, content)
+				}
+				if got := string(result.Bytes()); got != content {
+					t.Errorf("raw bytes %q, want the UTF-8 of %q", got, content)
+				}
+			})
+		}
+	}
+}
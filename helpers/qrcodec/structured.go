@@ -0,0 +1,79 @@
+package qrcodec
+
+import (
+	"slices"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// MaxStructuredAppend es la cantidad máxima de símbolos de una secuencia:
+// el encabezado guarda el total en 4 bits
+const MaxStructuredAppend = 16
+
+// Parity calcula la paridad de una secuencia: el XOR de todos los bytes de
+// los segmentos de todos los símbolos, en su representación del modo
+// (Shift-JIS en los segmentos kanji)
+func Parity(symbols ...[]Segment) byte {
+	var parity byte
+	for _, segments := range symbols {
+		for _, seg := range segments {
+			for _, b := range seg.Data {
+				parity ^= b
+			}
+		}
+	}
+	return parity
+}
+
+// Missing devuelve las posiciones (desde 0) de la secuencia de parts que
+// todavía no se leyeron
+func Missing(parts []*Result) []int {
+	if len(parts) == 0 {
+		return nil
+	}
+	var missing []int
+	for i := 0; i < parts[0].StructuredAppend.Total; i++ {
+		if !slices.ContainsFunc(parts, func(r *Result) bool { return r.StructuredAppend.Index == i }) {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// Join junta los símbolos de una secuencia, en cualquier orden, en un solo
+// resultado con los segmentos de todos y el texto completo. Falla si
+// falta alguno, si son de secuencias distintas o si la paridad no coincide.
+func Join(parts []*Result) (*Result, error) {
+	if len(parts) == 0 || parts[0].StructuredAppend == nil {
+		return nil, i18n.Errorf("no structured append symbols")
+	}
+	first := parts[0].StructuredAppend
+	ordered := make([]*Result, first.Total)
+	for _, part := range parts {
+		sa := part.StructuredAppend
+		if sa == nil || sa.Total != first.Total || sa.Parity != first.Parity {
+			return nil, i18n.Errorf("the symbols belong to different structured append sequences")
+		}
+		ordered[sa.Index] = part
+	}
+	if missing := Missing(parts); len(missing) > 0 {
+		return nil, i18n.Errorf("%d of %d structured append symbols missing", len(missing), first.Total)
+	}
+
+	joined := &Result{Version: ordered[0].Version, Level: ordered[0].Level, ECI: ordered[0].ECI, FNC1: ordered[0].FNC1}
+	var symbols [][]Segment
+	for _, part := range ordered {
+		joined.Segments = append(joined.Segments, part.Segments...)
+		joined.Corrected += part.Corrected
+		symbols = append(symbols, part.Segments)
+	}
+	if Parity(symbols...) != first.Parity {
+		return nil, i18n.Errorf("structured append parity mismatch: the symbols do not form one message")
+	}
+	text, err := decodeText(joined.Segments, joined.ECI, joined.FNC1)
+	if err != nil {
+		return nil, err
+	}
+	joined.Text = text
+	return joined, nil
+}
@@ -0,0 +1,275 @@
+package qrcodec
+
+import "strings"
+
+// Level es el nivel de corrección de errores del símbolo
+type Level int
+
+// Niveles de corrección en el orden de la tabla de bloques
+const (
+	LevelL Level = iota // ~7% de recuperación
+	LevelM              // ~15% de recuperación
+	LevelQ              // ~25% de recuperación
+	LevelH              // ~30% de recuperación
+)
+
+// String devuelve la letra del nivel
+func (l Level) String() string {
+	return [...]string{"L", "M", "Q", "H"}[l]
+}
+
+// formatBits devuelve los dos bits con los que el nivel se codifica en la información de formato
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// levelFromFormatBits es la inversa de formatBits
+func levelFromFormatBits(bits int) Level {
+	return [...]Level{LevelM, LevelL, LevelH, LevelQ}[bits&0x3]
+}
+
+// blockSpec describe los bloques de un nivel en una versión:
+// codewords de corrección por bloque y dos grupos de bloques (cantidad, codewords de datos)
+type blockSpec struct {
+	ecPerBlock int
+	g1Blocks   int
+	g1Data     int
+	g2Blocks   int
+	g2Data     int
+}
+
+// blockTable contiene, por versión (índice 0 = versión 1), los bloques de L, M, Q y H
+var blockTable = [40][4]blockSpec{
+	{{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+	{{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+	{{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+	{{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+	{{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+	{{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+	{{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+	{{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+	{{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+	{{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+	{{20, 4, 81, 0, 0}, {30, 1, 50, 4, 51}, {28, 4, 22, 4, 23}, {24, 3, 12, 8, 13}},
+	{{24, 2, 92, 2, 93}, {22, 6, 36, 2, 37}, {26, 4, 20, 6, 21}, {28, 7, 14, 4, 15}},
+	{{26, 4, 107, 0, 0}, {22, 8, 37, 1, 38}, {24, 8, 20, 4, 21}, {22, 12, 11, 4, 12}},
+	{{30, 3, 115, 1, 116}, {24, 4, 40, 5, 41}, {20, 11, 16, 5, 17}, {24, 11, 12, 5, 13}},
+	{{22, 5, 87, 1, 88}, {24, 5, 41, 5, 42}, {30, 5, 24, 7, 25}, {24, 11, 12, 7, 13}},
+	{{24, 5, 98, 1, 99}, {28, 7, 45, 3, 46}, {24, 15, 19, 2, 20}, {30, 3, 15, 13, 16}},
+	{{28, 1, 107, 5, 108}, {28, 10, 46, 1, 47}, {28, 1, 22, 15, 23}, {28, 2, 14, 17, 15}},
+	{{30, 5, 120, 1, 121}, {26, 9, 43, 4, 44}, {28, 17, 22, 1, 23}, {28, 2, 14, 19, 15}},
+	{{28, 3, 113, 4, 114}, {26, 3, 44, 11, 45}, {26, 17, 21, 4, 22}, {26, 9, 13, 16, 14}},
+	{{28, 3, 107, 5, 108}, {26, 3, 41, 13, 42}, {30, 15, 24, 5, 25}, {28, 15, 15, 10, 16}},
+	{{28, 4, 116, 4, 117}, {26, 17, 42, 0, 0}, {28, 17, 22, 6, 23}, {30, 19, 16, 6, 17}},
+	{{28, 2, 111, 7, 112}, {28, 17, 46, 0, 0}, {30, 7, 24, 16, 25}, {24, 34, 13, 0, 0}},
+	{{30, 4, 121, 5, 122}, {28, 4, 47, 14, 48}, {30, 11, 24, 14, 25}, {30, 16, 15, 14, 16}},
+	{{30, 6, 117, 4, 118}, {28, 6, 45, 14, 46}, {30, 11, 24, 16, 25}, {30, 30, 16, 2, 17}},
+	{{26, 8, 106, 4, 107}, {28, 8, 47, 13, 48}, {30, 7, 24, 22, 25}, {30, 22, 15, 13, 16}},
+	{{28, 10, 114, 2, 115}, {28, 19, 46, 4, 47}, {28, 28, 22, 6, 23}, {30, 33, 16, 4, 17}},
+	{{30, 8, 122, 4, 123}, {28, 22, 45, 3, 46}, {30, 8, 23, 26, 24}, {30, 12, 15, 28, 16}},
+	{{30, 3, 117, 10, 118}, {28, 3, 45, 23, 46}, {30, 4, 24, 31, 25}, {30, 11, 15, 31, 16}},
+	{{30, 7, 116, 7, 117}, {28, 21, 45, 7, 46}, {30, 1, 23, 37, 24}, {30, 19, 15, 26, 16}},
+	{{30, 5, 115, 10, 116}, {28, 19, 47, 10, 48}, {30, 15, 24, 25, 25}, {30, 23, 15, 25, 16}},
+	{{30, 13, 115, 3, 116}, {28, 2, 46, 29, 47}, {30, 42, 24, 1, 25}, {30, 23, 15, 28, 16}},
+	{{30, 17, 115, 0, 0}, {28, 10, 46, 23, 47}, {30, 10, 24, 35, 25}, {30, 19, 15, 35, 16}},
+	{{30, 17, 115, 1, 116}, {28, 14, 46, 21, 47}, {30, 29, 24, 19, 25}, {30, 11, 15, 46, 16}},
+	{{30, 13, 115, 6, 116}, {28, 14, 46, 23, 47}, {30, 44, 24, 7, 25}, {30, 59, 16, 1, 17}},
+	{{30, 12, 121, 7, 122}, {28, 12, 47, 26, 48}, {30, 39, 24, 14, 25}, {30, 22, 15, 41, 16}},
+	{{30, 6, 121, 14, 122}, {28, 6, 47, 34, 48}, {30, 46, 24, 10, 25}, {30, 2, 15, 64, 16}},
+	{{30, 17, 122, 4, 123}, {28, 29, 46, 14, 47}, {30, 49, 24, 10, 25}, {30, 24, 15, 46, 16}},
+	{{30, 4, 122, 18, 123}, {28, 13, 46, 32, 47}, {30, 48, 24, 14, 25}, {30, 42, 15, 32, 16}},
+	{{30, 20, 117, 4, 118}, {28, 40, 47, 7, 48}, {30, 43, 24, 22, 25}, {30, 10, 15, 67, 16}},
+	{{30, 19, 118, 6, 119}, {28, 18, 47, 31, 48}, {30, 34, 24, 34, 25}, {30, 20, 15, 61, 16}},
+}
+
+// blocks devuelve la especificación de bloques de una versión y nivel
+func blocks(version int, level Level) blockSpec {
+	return blockTable[version-1][level]
+}
+
+// dataCodewords devuelve la cantidad total de codewords de datos
+func (b blockSpec) dataCodewords() int {
+	return b.g1Blocks*b.g1Data + b.g2Blocks*b.g2Data
+}
+
+// DataCapacityBits devuelve los bits de datos disponibles para una versión y nivel
+func DataCapacityBits(version int, level Level) int {
+	return blocks(version, level).dataCodewords() * 8
+}
+
+// ByteCapacity devuelve cuántos bytes entran en un solo segmento de modo
+// byte en el símbolo más grande (versión 40) con ese nivel
+func ByteCapacity(level Level) int {
+	return Capacity(ModeByte, level)
+}
+
+// Capacity devuelve cuántos caracteres (dígitos, caracteres alfanuméricos o
+// bytes) entran en un solo segmento del modo en el símbolo más grande
+// (versión 40) con ese nivel
+func Capacity(mode Mode, level Level) int {
+	return VersionCapacity(mode, level, 40)
+}
+
+// VersionCapacity devuelve cuántos caracteres (en modo kanji, caracteres de
+// dos bytes) entran en un solo segmento del modo en un símbolo de esa
+// versión y nivel
+func VersionCapacity(mode Mode, level Level, version int) int {
+	return segmentCapacity(mode, DataCapacityBits(version, level)-4, charCountBits(mode, version))
+}
+
+// MinBits es una cota inferior de los bits de datos que ocupa el contenido
+// en un símbolo de esa versión con cualquier división en segmentos: cada
+// carácter en el modo más denso que lo admite y un solo encabezado. Si supera
+// DataCapacityBits, el contenido no entra de ninguna forma.
+func MinBits(content string, version int) int {
+	digits, alphanumeric, bytes := 0, 0, 0
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case strings.IndexByte(alphanumericChars, c) >= 0:
+			alphanumeric++
+		default:
+			bytes++
+		}
+	}
+	return 4 + charCountBits(ModeNumeric, version) + digits*10/3 + alphanumeric*11/2 + bytes*8
+}
+
+// segmentCapacity devuelve cuántos caracteres del modo entran en bits de
+// datos después del indicador de modo, con un contador de countBits bits
+func segmentCapacity(mode Mode, bits, countBits int) int {
+	bits -= countBits
+	var n int
+	switch mode {
+	case ModeNumeric:
+		// 10 bits cada 3 dígitos; un resto de 4 o 7 bits lleva 1 o 2 más
+		n = bits/10*3 + [10]int{0, 0, 0, 0, 1, 1, 1, 2, 2, 2}[bits%10]
+	case ModeAlphanumeric:
+		// 11 bits cada 2 caracteres; un resto de 6 bits lleva 1 más
+		n = bits/11*2 + bits%11/6
+	case ModeKanji:
+		n = bits / 13
+	default:
+		n = bits / 8
+	}
+	// El contador de caracteres también pone un límite
+	return min(n, 1<<countBits-1)
+}
+
+// ModeFor devuelve el modo más denso que admite todo el contenido en un
+// solo segmento
+func ModeFor(content string) Mode {
+	mode := ModeNumeric
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c >= '0' && c <= '9':
+		case strings.IndexByte(alphanumericChars, c) >= 0:
+			mode = ModeAlphanumeric
+		default:
+			return ModeByte
+		}
+	}
+	return mode
+}
+
+// symbolSize devuelve los módulos por lado de una versión
+func symbolSize(version int) int {
+	return 17 + 4*version
+}
+
+// rawDataModules devuelve los módulos disponibles para datos y corrección
+// (todo el símbolo menos los patrones de función)
+func rawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// alignmentPositions devuelve las coordenadas de los centros de los patrones de alineación
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+	positions := make([]int, numAlign)
+	positions[0] = 6
+	for i, pos := numAlign-1, symbolSize(version)-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// Mode es el modo de un segmento de datos
+type Mode int
+
+// Indicadores de modo definidos por el estándar
+const (
+	ModeTerminator       Mode = 0x0
+	ModeNumeric          Mode = 0x1
+	ModeAlphanumeric     Mode = 0x2
+	ModeStructuredAppend Mode = 0x3
+	ModeByte             Mode = 0x4
+	ModeFNC1First        Mode = 0x5
+	ModeECI              Mode = 0x7
+	ModeKanji            Mode = 0x8
+	ModeFNC1Second       Mode = 0x9
+)
+
+// String devuelve el nombre del modo
+func (m Mode) String() string {
+	switch m {
+	case ModeNumeric:
+		return "numeric"
+	case ModeAlphanumeric:
+		return "alphanumeric"
+	case ModeByte:
+		return "byte"
+	case ModeKanji:
+		return "kanji"
+	case ModeECI:
+		return "eci"
+	case ModeStructuredAppend:
+		return "structured-append"
+	case ModeFNC1First, ModeFNC1Second:
+		return "fnc1"
+	default:
+		return "terminator"
+	}
+}
+
+// charCountBits devuelve el ancho del contador de caracteres de un modo según la versión
+func charCountBits(mode Mode, version int) int {
+	i := 0
+	switch {
+	case version >= 27:
+		i = 2
+	case version >= 10:
+		i = 1
+	}
+	switch mode {
+	case ModeNumeric:
+		return [3]int{10, 12, 14}[i]
+	case ModeAlphanumeric:
+		return [3]int{9, 11, 13}[i]
+	case ModeByte:
+		return [3]int{8, 16, 16}[i]
+	case ModeKanji:
+		return [3]int{8, 10, 12}[i]
+	default:
+		return 0
+	}
+}
+
+// alphanumericChars es el alfabeto del modo alfanumérico, en orden de valor
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
@@ -0,0 +1,71 @@
+package qrgenerator
+
+import (
+	"errors"
+
+	"qrgenerator_cli/helpers/aztec"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// isAztec indica si la configuración pide un Aztec
+func isAztec(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "aztec"
+}
+
+// aztecInput devuelve los bytes a codificar y las opciones: las cadenas GS1
+// llevan FLG(0) y los textos con --charset van convertidos y con su ECI
+func aztecInput(config QRConfig, content string) ([]byte, aztec.Options, error) {
+	opts := aztec.Options{GS1: config.ExtraParams["gs1"] == "true"}
+	data := []byte(content)
+	if config.ExtraParams["binary"] == "true" {
+		return data, opts, nil
+	}
+	cs, err := charsetFor(config)
+	if err != nil || cs == nil {
+		return data, opts, err
+	}
+	if data, err = cs.encode(content); err != nil {
+		return nil, opts, err
+	}
+	opts.ECI = cs.eci
+	return data, opts, nil
+}
+
+// aztecSymbol codifica content en un Aztec (ISO/IEC 24778), el de los
+// pasajes de avión y de transporte, en el tamaño más chico que lo contiene
+// con la corrección recomendada. Si no entra devuelve qrcodec.ErrDataTooLong
+// (ver capacityError).
+func aztecSymbol(config QRConfig, content string) (encodedSymbol, error) {
+	data, opts, err := aztecInput(config, content)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	symbol, err := aztec.Encode(data, opts)
+	if errors.Is(err, aztec.ErrDataTooLong) {
+		return encodedSymbol{}, qrcodec.ErrDataTooLong
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating Aztec code: %w", ErrEncode, err)
+	}
+	return encodedSymbol{bitmap: withMatte(symbol.Matrix, qrBorder), version: symbol.Size.Index()}, nil
+}
+
+// aztecCapacityError arma el error de un contenido que no entra en el Aztec
+// más grande
+func aztecCapacityError(config QRConfig, content string, level qrcodec.Level) *CapacityError {
+	data, opts, _ := aztecInput(config, content)
+	largest := aztec.Largest()
+	e := &CapacityError{Size: len(data), Mode: qrcodec.ModeByte, Shape: largest.String(), Bits: aztec.Bits(data, opts), Max: aztec.Capacity(largest, 0)}
+	mode, size := payloadMode(config, content)
+	if max := qrcodec.VersionCapacity(mode, level, 40); size <= max {
+		e.Suggestions = append(e.Suggestions, i18n.Sprintf("--symbol qr (holds %d)", max))
+	}
+	if payloadLooksLikeURL(content) {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter URL (a redirect from your own domain)"))
+	} else {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter payload"))
+	}
+	return e
+}
@@ -0,0 +1,105 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"image"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// pagedGenerator lo implementan los formatos que guardan varias imágenes en un
+// mismo archivo
+type pagedGenerator interface {
+	GeneratePages(images []image.Image, config QRConfig) error
+}
+
+// BatchPath numera la ruta de salida para el elemento i (desde 1) de un lote
+// de n: qr.png pasa a qr-001.png
+func BatchPath(path string, i, n int) string {
+	ext := filepath.Ext(path)
+	digits := max(3, len(strconv.Itoa(n)))
+	return fmt.Sprintf("%s-%0*d%s", strings.TrimSuffix(path, ext), digits, i, ext)
+}
+
+// GenerateBatch genera un QR por payload con la misma configuración. Los
+// formatos con páginas (TIFF) escriben un solo archivo con una página por
+// payload; el resto, un archivo numerado por payload (ver BatchPath). Las
+// advertencias de la configuración se informan una vez, en el primer resultado.
+func GenerateBatch(config QRConfig, payloads []string) ([]*QRResult, error) {
+	if len(payloads) == 0 {
+		return nil, i18n.Errorf("%w: the batch has no payloads", ErrInvalidInput)
+	}
+	newGenerator, ok := generators[config.Format]
+	if !ok {
+		return nil, i18n.Errorf("%w: unsupported format: %s", ErrInvalidInput, config.Format)
+	}
+	if config.ExtraParams == nil {
+		config.ExtraParams = make(map[string]string)
+	}
+
+	paged, ok := newGenerator().(pagedGenerator)
+	if !ok {
+		var results []*QRResult
+		for i, payload := range payloads {
+			cfg := config
+			cfg.URL = payload
+			cfg.OutputPath = BatchPath(config.OutputPath, i+1, len(payloads))
+			result, err := GenerateQR(cfg)
+			if err != nil {
+				return results, i18n.Errorf("payload %d: %w", i+1, err)
+			}
+			if i > 0 {
+				result.Warnings = nil
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	results := make([]*QRResult, len(payloads))
+	images := make([]image.Image, len(payloads))
+	for i, payload := range payloads {
+		cfg := config
+		cfg.URL = payload
+		result := &QRResult{OutputPath: config.OutputPath, Format: config.Format}
+		for _, problem := range Validate(cfg) {
+			if !problem.Warning {
+				return nil, i18n.Errorf("payload %d: %w", i+1, problem.Err)
+			}
+			if i == 0 {
+				result.Warnings = append(result.Warnings, problem.Err.Error())
+			}
+		}
+
+		start := time.Now()
+		img, _, err := generateQRImage(cfg, result)
+		if err != nil {
+			return nil, i18n.Errorf("payload %d: %w", i+1, err)
+		}
+		result.EncodeTime = time.Since(start)
+		images[i], results[i] = img, result
+	}
+
+	start := time.Now()
+	if err := paged.GeneratePages(images, config); err != nil {
+		return nil, err
+	}
+	// Un archivo con páginas tiene una sola miniatura, la de la primera
+	if side, _ := thumbnailSize(config); side > 0 {
+		if err := writeThumbnail(images[0], ThumbnailPath(config.OutputPath), side); err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			result.Thumbnail = ThumbnailPath(config.OutputPath)
+		}
+	}
+	writeTime := time.Since(start)
+	for _, result := range results {
+		result.WriteTime = writeTime
+	}
+	return results, nil
+}
@@ -0,0 +1,22 @@
+package qrgenerator
+
+import "qrgenerator_cli/helpers/qrcodec"
+
+// binaryBitmap codifica un payload binario (ExtraParams "binary") en un solo
+// segmento de modo byte: el codificador genérico elige el modo por el
+// contenido y un archivo que por casualidad fuera solo dígitos no volvería
+// igual. Si no entra devuelve qrcodec.ErrDataTooLong (ver capacityError).
+func binaryBitmap(content string, opts qrcodec.EncodeOptions) ([][]bool, int, error) {
+	segments := []qrcodec.Segment{{Mode: qrcodec.ModeByte, Data: []byte(content)}}
+	return symbolBitmap(segments, opts)
+}
+
+// textBitmap codifica el contenido con qrcodec, para lo que go-qrcode no
+// permite elegir (la máscara) o no sabe escribir (el modo kanji): en los
+// segmentos de textSegments o, si no hay, en uno solo del modo más denso
+func textBitmap(content string, segments []qrcodec.Segment, opts qrcodec.EncodeOptions) ([][]bool, int, error) {
+	if segments == nil {
+		segments = []qrcodec.Segment{{Mode: qrcodec.ModeFor(content), Data: []byte(content)}}
+	}
+	return symbolBitmap(segments, opts)
+}
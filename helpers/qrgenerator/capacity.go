@@ -0,0 +1,153 @@
+package qrgenerator
+
+import (
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// CapacityError es el error de un payload que no entra en un QR: cuánto
+// ocupa, cuánto admite el nivel de corrección elegido y qué se puede probar.
+// errors.Is lo reconoce como ErrCapacityExceeded.
+type CapacityError struct {
+	Size        int          // Largo del payload en unidades del modo: dígitos, caracteres, kanji o bytes
+	Mode        qrcodec.Mode // Modo en que se codifica
+	Level       qrcodec.Level
+	Version     int      // Versión fija (--qr-version); 0 si se elige sola
+	Shape       string   // Forma más grande que se probó con --symbol rmqr, datamatrix o aztec; "" en los QR
+	Codewords   int      // Codewords de datos que ocupa en un Data Matrix o un PDF417; 0 en los demás
+	Bits        int      // Bits de datos que ocupa en un Aztec; 0 en los demás
+	Security    int      // Nivel de seguridad (0-8) en un PDF417, que no tiene Shape
+	Max         int      // Lo que entra en ese modo y nivel, en Version, en Shape o en la versión 40; codewords en un Data Matrix o un PDF417 y bits en un Aztec
+	Suggestions []string // Alternativas que lo harían entrar, ya traducidas
+}
+
+func (e *CapacityError) Error() string {
+	var size string
+	switch e.Mode {
+	case qrcodec.ModeNumeric:
+		size = i18n.Sprintf("%d digits", e.Size)
+	case qrcodec.ModeAlphanumeric:
+		size = i18n.Sprintf("%d alphanumeric characters", e.Size)
+	case qrcodec.ModeKanji:
+		size = i18n.Sprintf("%d kanji characters", e.Size)
+	default:
+		size = i18n.Sprintf("%d bytes", e.Size)
+	}
+	msg := i18n.Sprintf("%v: the payload is %s and a QR code at EC level %s holds at most %d", ErrCapacityExceeded, size, e.Level, e.Max)
+	if e.Bits != 0 {
+		msg = i18n.Sprintf("%v: the payload is %s, %d bits, and an Aztec %s holds at most %d", ErrCapacityExceeded, size, e.Bits, e.Shape, e.Max)
+	} else if e.Codewords != 0 && e.Shape == "" {
+		msg = i18n.Sprintf("%v: the payload is %s, %d codewords, and a PDF417 at security level %d holds at most %d", ErrCapacityExceeded, size, e.Codewords, e.Security, e.Max)
+	} else if e.Codewords != 0 {
+		msg = i18n.Sprintf("%v: the payload is %s, %d codewords, and a Data Matrix %s holds at most %d", ErrCapacityExceeded, size, e.Codewords, e.Shape, e.Max)
+	} else if e.Shape != "" {
+		msg = i18n.Sprintf("%v: the payload is %s and an rMQR %s at EC level %s holds at most %d", ErrCapacityExceeded, size, e.Shape, e.Level, e.Max)
+	} else if e.Version != 0 {
+		msg = i18n.Sprintf("%v: the payload is %s and a version %d QR code at EC level %s holds at most %d", ErrCapacityExceeded, size, e.Version, e.Level, e.Max)
+	}
+	if n := len(e.Suggestions); n > 1 {
+		msg += "; " + i18n.Sprintf("try %s or %s", strings.Join(e.Suggestions[:n-1], ", "), e.Suggestions[n-1])
+	} else if n == 1 {
+		msg += "; " + i18n.Sprintf("try %s", e.Suggestions[0])
+	}
+	return msg
+}
+
+func (e *CapacityError) Unwrap() error {
+	return ErrCapacityExceeded
+}
+
+// capacityError arma el error de un contenido que no entra con el nivel
+// elegido. Solo sugiere lo que la configuración admite: las cadenas GS1 no
+// se pueden transformar, y --split no se combina con --compress,
+// --encrypt-to, --encrypt, --check-digit ni --sign.
+func capacityError(config QRConfig, content string, level qrcodec.Level) *CapacityError {
+	if isRMQR(config) {
+		return rmqrCapacityError(config, content, level)
+	}
+	if isDataMatrix(config) {
+		return dataMatrixCapacityError(config, content, level)
+	}
+	if isAztec(config) {
+		return aztecCapacityError(config, content, level)
+	}
+	if isPDF417(config) {
+		return pdf417CapacityError(config, content, level)
+	}
+	mode, size := payloadMode(config, content)
+	version := config.Version
+	if version == 0 {
+		version = 40
+	}
+	e := &CapacityError{Size: size, Mode: mode, Level: level, Version: config.Version, Max: qrcodec.VersionCapacity(mode, level, version)}
+
+	// El nivel más alto que todavía lo contiene; con --fit auto, level ya es
+	// el mínimo permitido
+	levels := []qrcodec.Level{qrcodec.LevelQ, qrcodec.LevelM, qrcodec.LevelL}
+	for _, lower := range levels[slices.Index(levels, level)+1:] {
+		if max := qrcodec.VersionCapacity(mode, lower, version); e.Size <= max {
+			flag := "--ec"
+			if strings.ToLower(config.ExtraParams["fit"]) == "auto" {
+				flag = "--fit-min-ec"
+			}
+			e.Suggestions = append(e.Suggestions, i18n.Sprintf("%s %s (holds %d)", flag, lower, max))
+			break
+		}
+	}
+
+	// Con la versión fija, la más chica que lo contiene con el mismo nivel
+	if config.Version != 0 {
+		for v := config.Version + 1; v <= 40; v++ {
+			if max := qrcodec.VersionCapacity(mode, level, v); e.Size <= max {
+				e.Suggestions = append(e.Suggestions, i18n.Sprintf("--qr-version %d (holds %d)", v, max))
+				break
+			}
+		}
+	}
+
+	gs1 := config.ExtraParams["gs1"] == "true"
+	transformed := config.ExtraParams["compress"] != "" || config.ExtraParams["encrypt-to"] != "" || config.ExtraParams["check-digit"] != "" || config.ExtraParams["sign"] != "" || config.Passphrase != ""
+	if !gs1 && !transformed && config.ExtraParams["binary"] != "true" {
+		if packed, err := compress.Compress(content, "base45"); err == nil && len(packed) <= qrcodec.VersionCapacity(qrcodec.ModeAlphanumeric, level, version) {
+			e.Suggestions = append(e.Suggestions, i18n.Sprintf("--compress base45 (%d characters)", len(packed)))
+		}
+	}
+	// Con la versión fija, un trozo de DefaultChunkSize bytes puede no entrar
+	if !gs1 && !transformed && config.Version == 0 {
+		parts := (len(content) + transfer.DefaultChunkSize - 1) / transfer.DefaultChunkSize
+		e.Suggestions = append(e.Suggestions, i18n.Sprintf("--split %d (one QR per %d bytes)", parts, transfer.DefaultChunkSize))
+	}
+	if payloadLooksLikeURL(content) {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter URL (a redirect from your own domain)"))
+	} else {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter payload"))
+	}
+	return e
+}
+
+// payloadMode devuelve el modo en que se mide el contenido y su largo en
+// unidades de ese modo. Con un solo segmento (--mode, --charset o texto
+// japonés) se mide ese; con varios, los bytes del contenido son una cota.
+func payloadMode(config QRConfig, content string) (qrcodec.Mode, int) {
+	mode, size := qrcodec.ModeFor(content), len(content)
+	if config.ExtraParams["binary"] == "true" {
+		mode = qrcodec.ModeByte
+	} else if segments, err := textSegments(config, content); err == nil && len(segments) == 1 && config.ExtraParams["gs1"] != "true" {
+		mode, size = segments[0].Mode, len(segments[0].Data)
+		if mode == qrcodec.ModeKanji {
+			size /= 2
+		}
+	}
+	return mode, size
+}
+
+// payloadLooksLikeURL indica si el contenido es una URL http(s)
+func payloadLooksLikeURL(content string) bool {
+	lower := strings.ToLower(content)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
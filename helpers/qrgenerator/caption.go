@@ -0,0 +1,162 @@
+package qrgenerator
+
+import (
+	"image"
+	"math"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/payload"
+)
+
+// Medidas del texto legible debajo de las barras, en módulos
+const (
+	captionSize = 9 // Alto de la letra; se achica si el texto es más ancho que las barras
+	captionGap  = 1 // Separación entre las barras y el texto
+)
+
+// captionText es una línea del texto legible, ubicada en módulos
+type captionText struct {
+	text     string
+	x        float64 // Centro horizontal
+	baseline float64 // Línea de base, desde arriba
+	size     float64 // Tamaño de la letra
+}
+
+// caption es el texto legible de un código de barras lineal, ubicado en
+// módulos para que los formatos raster y los vectoriales coincidan. Los
+// códigos lo arman sin la zona de silencio y shift lo lleva a la matriz.
+type caption struct {
+	texts  []captionText
+	bottom float64 // Parte más baja del texto
+	height float64 // Alto total de la imagen con el texto, incluida la zona de silencio de abajo
+}
+
+// captionFont es la fuente del texto legible, cargada una sola vez
+var captionFont = sync.OnceValues(func() (*opentype.Font, error) {
+	return opentype.Parse(goregular.TTF)
+})
+
+// captionFace carga la fuente al tamaño pedido, en píxeles
+func captionFace(size float64) (font.Face, error) {
+	parsed, err := captionFont()
+	if err != nil {
+		return nil, i18n.Errorf("%w: error loading the caption font: %w", ErrEncode, err)
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, i18n.Errorf("%w: error loading the caption font: %w", ErrEncode, err)
+	}
+	return face, nil
+}
+
+// fontMetrics son las medidas de la fuente por unidad de tamaño
+type fontMetrics struct {
+	ascent, descent, capHeight float64
+	face                       font.Face // A 100 px, para medir los textos
+}
+
+// width es el ancho de text por unidad de tamaño
+func (m fontMetrics) width(text string) float64 {
+	return float64(font.MeasureString(m.face, text)) / 64 / 100
+}
+
+// captionMetrics mide la fuente a 100 px para escalarla; hay que cerrar face
+func captionMetrics() (fontMetrics, error) {
+	face, err := captionFace(100)
+	if err != nil {
+		return fontMetrics{}, err
+	}
+	metrics := face.Metrics()
+	return fontMetrics{
+		ascent:    float64(metrics.Ascent) / 64 / 100,
+		descent:   float64(metrics.Descent) / 64 / 100,
+		capHeight: float64(metrics.CapHeight) / 64 / 100,
+		face:      face,
+	}, nil
+}
+
+// captionFor devuelve el texto legible con ExtraParams "show-text": el
+// contenido, con las cadenas GS1 con los AI entre paréntesis y sin
+// caracteres de control; "" sin la opción
+func captionFor(config QRConfig, content string) string {
+	if config.ExtraParams["show-text"] != "true" {
+		return ""
+	}
+	if readable, ok := payload.GS1Readable(content); ok && config.ExtraParams["gs1"] == "true" {
+		content = readable
+	}
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, content)
+}
+
+// centeredCaption ubica text debajo de las barras de bitmap, sin zona de
+// silencio: centrado y con la letra achicada si no entra en su ancho
+func centeredCaption(bitmap [][]bool, text string) (*caption, error) {
+	metrics, err := captionMetrics()
+	if err != nil {
+		return nil, err
+	}
+	defer metrics.face.Close()
+
+	rows, width := float64(len(bitmap)), float64(len(bitmap[0]))
+	line := captionText{text: text, x: width / 2, size: captionSize}
+	if w := metrics.width(text); w*line.size > width {
+		line.size = width / w
+	}
+	line.baseline = rows + captionGap + metrics.ascent*line.size
+	bottom := line.baseline + metrics.descent*line.size
+	return &caption{texts: []captionText{line}, bottom: bottom, height: max(rows, bottom)}, nil
+}
+
+// shift corre el texto offset módulos hacia abajo y a la derecha, para una
+// zona de silencio de offset módulos alrededor
+func (c *caption) shift(offset int) *caption {
+	shifted := &caption{bottom: c.bottom + float64(offset), height: c.height + 2*float64(offset)}
+	for _, line := range c.texts {
+		line.x += float64(offset)
+		line.baseline += float64(offset)
+		shifted.texts = append(shifted.texts, line)
+	}
+	return shifted
+}
+
+// render dibuja el texto a width píxeles de ancho y unit píxeles por módulo,
+// desde la fila de píxeles top hasta el alto total; lo demás queda
+// transparente para que se vean las barras
+func (c *caption) render(width int, unit float64) (overlay *image.Alpha, top int, err error) {
+	metrics, err := captionMetrics()
+	if err != nil {
+		return nil, 0, err
+	}
+	metrics.face.Close()
+	top = int(c.height * unit)
+	for _, line := range c.texts {
+		top = min(top, int(math.Floor((line.baseline-metrics.ascent*line.size)*unit)))
+	}
+	top = max(0, top)
+	overlay = image.NewAlpha(image.Rect(0, 0, width, max(1, int(c.height*unit+0.5)-top)))
+
+	for _, line := range c.texts {
+		face, err := captionFace(line.size * unit)
+		if err != nil {
+			return nil, 0, err
+		}
+		drawer := &font.Drawer{Dst: overlay, Src: image.Opaque, Face: face}
+		x := fixed.Int26_6(line.x*unit*64) - drawer.MeasureString(line.text)/2
+		drawer.Dot = fixed.Point26_6{X: x, Y: fixed.Int26_6((line.baseline*unit - float64(top)) * 64)}
+		drawer.DrawString(line.text)
+		face.Close()
+	}
+	return overlay, top, nil
+}
@@ -0,0 +1,66 @@
+package qrgenerator
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// charset es un juego de caracteres de ExtraParams "charset" con su
+// designador ECI; encoding nil es UTF-8, el texto tal cual
+type charset struct {
+	name     string
+	eci      int
+	encoding encoding.Encoding
+}
+
+// charsets son los juegos de caracteres que se pueden declarar, por nombre y alias
+var charsets = map[string]charset{
+	"utf-8":      {"utf-8", 26, nil},
+	"utf8":       {"utf-8", 26, nil},
+	"iso-8859-1": {"iso-8859-1", 3, charmap.ISO8859_1},
+	"latin1":     {"iso-8859-1", 3, charmap.ISO8859_1},
+	"shift-jis":  {"shift-jis", 20, japanese.ShiftJIS},
+	"sjis":       {"shift-jis", 20, japanese.ShiftJIS},
+}
+
+// Charsets devuelve los nombres de los juegos de caracteres, sin los alias
+func Charsets() []string {
+	return []string{"utf-8", "iso-8859-1", "shift-jis"}
+}
+
+// charsetFor lee el juego de caracteres de ExtraParams ("charset"); nil si no
+// se pidió ninguno y el símbolo no declara ECI
+func charsetFor(config QRConfig) (*charset, error) {
+	name := strings.ToLower(strings.TrimSpace(config.ExtraParams["charset"]))
+	if name == "" {
+		return nil, nil
+	}
+	cs, ok := charsets[strings.ReplaceAll(name, "_", "-")]
+	if !ok {
+		return nil, i18n.Errorf("%w: unknown charset %s (%s)", ErrInvalidInput, config.ExtraParams["charset"], strings.Join(Charsets(), ", "))
+	}
+	return &cs, nil
+}
+
+// encode convierte el contenido al juego de caracteres
+func (cs *charset) encode(content string) ([]byte, error) {
+	if cs.encoding == nil {
+		return []byte(content), nil
+	}
+	data, err := cs.encoding.NewEncoder().Bytes([]byte(content))
+	if err != nil {
+		// Se busca el carácter que falta para nombrarlo en el error
+		for _, r := range content {
+			if _, err := cs.encoding.NewEncoder().String(string(r)); err != nil {
+				return nil, i18n.Errorf("%w: --charset %s cannot encode %q", ErrInvalidInput, cs.name, r)
+			}
+		}
+		return nil, i18n.Errorf("%w: --charset %s: %w", ErrInvalidInput, cs.name, err)
+	}
+	return data, nil
+}
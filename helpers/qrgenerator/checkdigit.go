@@ -0,0 +1,36 @@
+package qrgenerator
+
+import (
+	"maps"
+	"strings"
+
+	"qrgenerator_cli/helpers/checkdigit"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// checkedConfig agrega los caracteres de control de ExtraParams
+// ("check-digit": luhn o crc) al número de serie del final del contenido. El
+// texto impreso (caption) los recibe también si termina en la misma serie,
+// así lo que se tipea a mano coincide con lo codificado.
+func checkedConfig(config QRConfig) (QRConfig, error) {
+	scheme := strings.ToLower(config.ExtraParams["check-digit"])
+	if scheme == "" {
+		return config, nil
+	}
+	_, serial := checkdigit.Serial(config.URL)
+	if serial == "" {
+		return config, i18n.Errorf("%w: --check-digit: the payload does not end in a serial number", ErrInvalidInput)
+	}
+	check, err := checkdigit.Compute(serial, scheme)
+	if err != nil {
+		return config, i18n.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	config.URL += check
+
+	caption := config.ExtraParams["caption"]
+	if _, captionSerial := checkdigit.Serial(caption); captionSerial == serial {
+		config.ExtraParams = maps.Clone(config.ExtraParams)
+		config.ExtraParams["caption"] = caption + check
+	}
+	return config, nil
+}
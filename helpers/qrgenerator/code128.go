@@ -0,0 +1,35 @@
+package qrgenerator
+
+import (
+	"errors"
+
+	"qrgenerator_cli/helpers/code128"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// isCode128 indica si la configuración pide un Code 128
+func isCode128(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "code128"
+}
+
+// code128Symbol codifica content en un Code 128 (ISO/IEC 15417), el código
+// de barras de los SKU y los números de seguimiento; las cadenas GS1 van
+// como GS1-128, con FNC1. Version son los caracteres de símbolo.
+func code128Symbol(config QRConfig, content string) (encodedSymbol, error) {
+	symbol, err := code128.Encode([]byte(content), code128.Options{GS1: config.ExtraParams["gs1"] == "true"})
+	if errors.Is(err, code128.ErrInvalidData) {
+		return encodedSymbol{}, i18n.Errorf("%w: %w; use a 2D symbol such as --symbol qr for other text", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating Code 128: %w", ErrEncode, err)
+	}
+	bitmap := linearBitmap(symbol.Modules)
+	encoded := encodedSymbol{bitmap: withMatte(bitmap, qrBorder), version: symbol.Characters}
+	if text := captionFor(config, content); text != "" {
+		if encoded.caption, err = centeredCaption(bitmap, text); err != nil {
+			return encodedSymbol{}, err
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,36 @@
+package qrgenerator
+
+import (
+	"errors"
+
+	"qrgenerator_cli/helpers/code39"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// isCode39 indica si la configuración pide un Code 39
+func isCode39(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "code39"
+}
+
+// code39Symbol codifica content en un Code 39 (ISO/IEC 16388), el código de
+// barras de los sistemas de inventario y credenciales más viejos, con el
+// dígito de control módulo 43 si ExtraParams "mod43" lo pide. Version son
+// los caracteres entre los asteriscos.
+func code39Symbol(config QRConfig, content string) (encodedSymbol, error) {
+	symbol, err := code39.Encode([]byte(content), code39.Options{Checksum: config.ExtraParams["mod43"] == "true"})
+	if errors.Is(err, code39.ErrInvalidData) {
+		return encodedSymbol{}, i18n.Errorf("%w: %w; use --symbol code128 for lowercase and other text", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating Code 39: %w", ErrEncode, err)
+	}
+	bitmap := linearBitmap(symbol.Modules)
+	encoded := encodedSymbol{bitmap: withMatte(bitmap, qrBorder), version: symbol.Characters}
+	if text := captionFor(config, content); text != "" {
+		if encoded.caption, err = centeredCaption(bitmap, text); err != nil {
+			return encodedSymbol{}, err
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,38 @@
+package qrgenerator
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// compressedContent aplica la compresión de ExtraParams ("compress": base64 o
+// base45) al contenido. Si comprimido ocupa más bits en el símbolo, se
+// codifica sin comprimir y se advierte.
+func compressedContent(config QRConfig, result *QRResult) (string, error) {
+	method := strings.ToLower(config.ExtraParams["compress"])
+	if method == "" {
+		return config.URL, nil
+	}
+	packed, err := compress.Compress(config.URL, method)
+	if err != nil {
+		return "", i18n.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	if symbolBits(packed) >= symbolBits(config.URL) {
+		result.Warnings = append(result.Warnings, i18n.Sprintf("--compress does not shrink this payload (%d -> %d bytes); encoding it uncompressed", len(config.URL), len(packed)))
+		return config.URL, nil
+	}
+	return packed, nil
+}
+
+// symbolBits estima los bits de datos que ocupa el contenido: 5.5 por
+// carácter si entra en el modo alfanumérico del QR, 8 por byte si no
+func symbolBits(content string) int {
+	for _, r := range content {
+		if !strings.ContainsRune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:", r) {
+			return len(content) * 8
+		}
+	}
+	return (len(content)*11 + 1) / 2
+}
@@ -0,0 +1,134 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Umbrales de contraste (relación WCAG entre luminancias) entre los módulos
+// oscuros y el fondo. Por debajo del mínimo muchos lectores no separan los
+// módulos; por debajo del recomendado fallan con poca luz o pantallas con brillo.
+const (
+	MinContrast         = 3.0
+	RecommendedContrast = 4.5
+)
+
+// symbolColors devuelve los colores con los que se dibujan los módulos y el
+// fondo: ExtraParams "fg" y "bg", o negro y blanco; con un degradado, los
+// módulos se dibujan con su color inicial y se pintan después. Todos los generadores
+// comparten esta elección, así que las reglas de contraste se revisan una
+// sola vez, aquí.
+func symbolColors(config QRConfig) (dark, light color.Color, err error) {
+	dark, light = color.Black, color.White
+	if value := config.ExtraParams["fg"]; value != "" {
+		if dark, err = parseColor("fg", value); err != nil {
+			return nil, nil, err
+		}
+	}
+	if g, err := gradientFor(config); err != nil {
+		return nil, nil, err
+	} else if g != nil {
+		dark = g.from
+	}
+	if transparentBackground(config) {
+		light = color.Transparent
+	} else if value := config.ExtraParams["bg"]; value != "" {
+		if light, err = parseColor("bg", value); err != nil {
+			return nil, nil, err
+		}
+	}
+	return dark, light, nil
+}
+
+// transparentBackground indica si ExtraParams "bg" pide un fondo
+// transparente, para componer el código sobre otro diseño
+func transparentBackground(config QRConfig) bool {
+	return strings.EqualFold(strings.TrimSpace(config.ExtraParams["bg"]), "transparent")
+}
+
+// isTransparent indica si un color es del todo transparente
+func isTransparent(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a == 0
+}
+
+// symbolPalette es la paleta de las imágenes del símbolo: el fondo en el
+// índice 0 y los módulos en el 1, como la de la librería de QR
+func symbolPalette(config QRConfig) (color.Palette, error) {
+	dark, light, err := symbolColors(config)
+	if err != nil {
+		return nil, err
+	}
+	return color.Palette{light, dark}, nil
+}
+
+// parseColor lee el color de la opción flag: #rgb o #rrggbb, o un nombre
+// de CSS como navy o darkslategray
+func parseColor(flag, value string) (color.Color, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if c, ok := colornames.Map[name]; ok {
+		return c, nil
+	}
+	hex := strings.TrimPrefix(name, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return nil, i18n.Errorf("%w: invalid --%s color %s; use #rrggbb, #rgb or a CSS color name such as navy", ErrInvalidInput, flag, value)
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
+// cssColor escribe el color como #rrggbb, o transparent, para SVG y CSS
+func cssColor(c color.Color) string {
+	if isTransparent(c) {
+		return "transparent"
+	}
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// ColorProblems es el conjunto de reglas de accesibilidad de los colores de
+// un símbolo: rechaza los códigos invertidos (claro sobre oscuro), que muchos
+// lectores viejos no reconocen, salvo con allowInverted, y rechaza o advierte
+// el contraste bajo
+func ColorProblems(dark, light color.Color, allowInverted bool) []Problem {
+	var problems []Problem
+	lDark, lLight := luminance(dark), luminance(light)
+	if lDark > lLight {
+		if !allowInverted {
+			problems = append(problems, Problem{Err: i18n.Errorf("%w: inverted symbol (light modules on a dark background); many older scanner apps cannot read it, pass --allow-inverted to accept it", ErrInvalidInput)})
+		}
+		lDark, lLight = lLight, lDark
+	}
+
+	ratio := (lLight + 0.05) / (lDark + 0.05)
+	switch {
+	case ratio < MinContrast:
+		problems = append(problems, Problem{Err: i18n.Errorf("%w: contrast %.1f:1 between modules and background is below %.0f:1; scanners cannot tell them apart", ErrInvalidInput, ratio, MinContrast)})
+	case ratio < RecommendedContrast:
+		problems = append(problems, Problem{Err: i18n.Errorf("contrast %.1f:1 between modules and background is below the recommended %.1f:1; the code may fail in poor light", ratio, RecommendedContrast), Warning: true})
+	}
+	return problems
+}
+
+// luminance es la luminancia relativa de sRGB que usa WCAG, de 0 a 1
+func luminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	channel := func(v uint32) float64 {
+		s := float64(v) / 0xffff
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+}
@@ -0,0 +1,124 @@
+package qrgenerator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// GenerateFromMatrix escribe un símbolo ya codificado (sus módulos, sin la
+// zona de silencio) en cada salida, sin volver a codificarlo: la versión, el
+// nivel y la máscara quedan iguales. config.URL es el contenido del símbolo,
+// que se usa para validar; las opciones que transforman el payload
+// (compresión, cifrado, presets) no se aplican.
+func GenerateFromMatrix(symbol [][]bool, config QRConfig, outputs []Output) ([]*QRResult, error) {
+	size := len(symbol)
+	if size < 21 || size > 177 || (size-17)%4 != 0 {
+		return nil, i18n.Errorf("%w: a QR symbol has 21 to 177 modules per side in steps of 4, not %d", ErrInvalidInput, size)
+	}
+	for _, row := range symbol {
+		if len(row) != size {
+			return nil, i18n.Errorf("%w: the module matrix is not square", ErrInvalidInput)
+		}
+	}
+	return generateOutputs(config, outputs, func(config QRConfig, result *QRResult) (image.Image, [][]bool, error) {
+		if config.Size == 0 {
+			config.Size = 256
+		}
+		bitmap := withMatte(symbol, qrBorder)
+		result.Version = (size - 17) / 4
+		result.Modules = size
+		result.Level, result.Mask = readFormatInfo(bitmap, qrBorder)
+		result.Streamed = config.Size > largeImageThreshold
+		palette, err := symbolPalette(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		return newModuleImage(bitmap, config.Size, palette), bitmap, nil
+	})
+}
+
+// IsMatrixFile informa si la ruta es una exportación de la matriz que
+// ParseMatrix puede leer
+func IsMatrixFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".pbm", ".txt":
+		return true
+	}
+	return false
+}
+
+// ParseMatrix lee los módulos de una exportación de la matriz en JSON, PBM
+// o bits (ver matrixContent); la codificación se elige por la extensión
+func ParseMatrix(data []byte, name string) ([][]bool, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		var doc struct {
+			Modules [][]bool `json:"modules"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, i18n.Errorf("%w: %s is not a module matrix: %w", ErrInvalidInput, name, err)
+		}
+		if len(doc.Modules) == 0 {
+			return nil, i18n.Errorf("%w: %s has no modules", ErrInvalidInput, name)
+		}
+		return doc.Modules, nil
+	case ".pbm":
+		return parsePBM(data, name)
+	}
+	return parseBits(data, name)
+}
+
+// parsePBM lee un PBM en texto (P1): cabecera, comentarios y un dígito por módulo
+func parsePBM(data []byte, name string) ([][]bool, error) {
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	if len(tokens) < 3 || tokens[0] != "P1" {
+		return nil, i18n.Errorf("%w: %s is not a text PBM (P1)", ErrInvalidInput, name)
+	}
+	width, err1 := strconv.Atoi(tokens[1])
+	height, err2 := strconv.Atoi(tokens[2])
+	if err1 != nil || err2 != nil || width <= 0 || width != height {
+		return nil, i18n.Errorf("%w: %s is not a square PBM", ErrInvalidInput, name)
+	}
+	// Los píxeles pueden ir pegados (0101) o separados (0 1 0 1)
+	bits := strings.Join(tokens[3:], "")
+	if len(bits) != width*height || strings.Trim(bits, "01") != "" {
+		return nil, i18n.Errorf("%w: %s has %d pixels, not %dx%d", ErrInvalidInput, name, len(bits), width, height)
+	}
+	symbol := make([][]bool, height)
+	for y := range symbol {
+		symbol[y] = make([]bool, width)
+		for x := range symbol[y] {
+			symbol[y][x] = bits[y*width+x] == '1'
+		}
+	}
+	return symbol, nil
+}
+
+// parseBits lee una línea de 0 y 1 por fila
+func parseBits(data []byte, name string) ([][]bool, error) {
+	var symbol [][]bool
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Trim(line, "01") != "" {
+			return nil, i18n.Errorf("%w: %s is not a module matrix of 0 and 1", ErrInvalidInput, name)
+		}
+		row := make([]bool, len(line))
+		for x := range line {
+			row[x] = line[x] == '1'
+		}
+		symbol = append(symbol, row)
+	}
+	return symbol, nil
+}
@@ -0,0 +1,104 @@
+package qrgenerator
+
+import (
+	"errors"
+	"strings"
+
+	"qrgenerator_cli/helpers/datamatrix"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// isDataMatrix indica si la configuración pide un Data Matrix
+func isDataMatrix(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "datamatrix"
+}
+
+// dataMatrixOptions lee la forma de ExtraParams ("shape"): square (por
+// defecto) o rectangle eligen el tamaño más chico de esa forma, y un tamaño
+// como 16x48 lo fija
+func dataMatrixOptions(config QRConfig) (datamatrix.Options, error) {
+	value := strings.ToLower(strings.TrimSpace(config.ExtraParams["shape"]))
+	switch value {
+	case "", "square":
+		return datamatrix.Options{Shape: datamatrix.ShapeSquare}, nil
+	case "rectangle":
+		return datamatrix.Options{Shape: datamatrix.ShapeRectangle}, nil
+	}
+	size, err := datamatrix.ParseSize(value)
+	if err != nil {
+		return datamatrix.Options{}, i18n.Errorf("%w: unknown Data Matrix shape %s (square, rectangle or a size such as 16x48)", ErrInvalidInput, config.ExtraParams["shape"])
+	}
+	return datamatrix.Options{Size: size}, nil
+}
+
+// dataMatrixInput devuelve los bytes a codificar y las opciones: las cadenas
+// GS1 llevan FNC1 y los textos con --charset van convertidos y con su ECI
+func dataMatrixInput(config QRConfig, content string) ([]byte, datamatrix.Options, error) {
+	opts, err := dataMatrixOptions(config)
+	if err != nil {
+		return nil, opts, err
+	}
+	opts.GS1 = config.ExtraParams["gs1"] == "true"
+	data := []byte(content)
+	if config.ExtraParams["binary"] == "true" {
+		return data, opts, nil
+	}
+	cs, err := charsetFor(config)
+	if err != nil || cs == nil {
+		return data, opts, err
+	}
+	if data, err = cs.encode(content); err != nil {
+		return nil, opts, err
+	}
+	opts.ECI = cs.eci
+	return data, opts, nil
+}
+
+// dataMatrixSymbol codifica content en un Data Matrix ECC 200 (ISO/IEC
+// 16022), el de las etiquetas industriales, en el tamaño más chico de la
+// forma pedida. Si no entra devuelve qrcodec.ErrDataTooLong (ver
+// capacityError).
+func dataMatrixSymbol(config QRConfig, content string) (encodedSymbol, error) {
+	data, opts, err := dataMatrixInput(config, content)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	symbol, err := datamatrix.Encode(data, opts)
+	if errors.Is(err, datamatrix.ErrDataTooLong) {
+		return encodedSymbol{}, qrcodec.ErrDataTooLong
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating Data Matrix: %w", ErrEncode, err)
+	}
+	return encodedSymbol{bitmap: withMatte(symbol.Matrix, qrBorder), version: datamatrix.SizeIndex(symbol.Size)}, nil
+}
+
+// dataMatrixCapacityError arma el error de un contenido que no entra en el
+// Data Matrix más grande que admite la configuración
+func dataMatrixCapacityError(config QRConfig, content string, level qrcodec.Level) *CapacityError {
+	data, opts, _ := dataMatrixInput(config, content)
+	largest := opts.Largest()
+	e := &CapacityError{Size: len(data), Mode: qrcodec.ModeByte, Shape: largest.String(), Codewords: datamatrix.Codewords(data, opts), Max: largest.Data}
+
+	// Con la forma fija o rectangular, el cuadrado más chico que lo contiene
+	if !largest.Square() || opts.Size != (datamatrix.Size{}) {
+		for _, size := range datamatrix.Sizes() {
+			if size.Square() && e.Codewords <= size.Data {
+				e.Suggestions = append(e.Suggestions, i18n.Sprintf("--shape %s (holds %d codewords)", size, size.Data))
+				break
+			}
+		}
+	}
+	mode, size := payloadMode(config, content)
+	if max := qrcodec.VersionCapacity(mode, level, 40); size <= max {
+		e.Suggestions = append(e.Suggestions, i18n.Sprintf("--symbol qr (holds %d)", max))
+	}
+	if payloadLooksLikeURL(content) {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter URL (a redirect from your own domain)"))
+	} else {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter payload"))
+	}
+	return e
+}
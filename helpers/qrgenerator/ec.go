@@ -0,0 +1,56 @@
+package qrgenerator
+
+import (
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// ecLevel es un nivel de corrección de errores con su equivalente en cada codificador
+type ecLevel struct {
+	recovery qrcode.RecoveryLevel
+	codec    qrcodec.Level
+}
+
+// ecLevels son los niveles de ExtraParams "ec"
+var ecLevels = map[string]ecLevel{
+	"L": {qrcode.Low, qrcodec.LevelL},
+	"M": {qrcode.Medium, qrcodec.LevelM},
+	"Q": {qrcode.High, qrcodec.LevelQ},
+	"H": {qrcode.Highest, qrcodec.LevelH},
+}
+
+// ECLevels devuelve los niveles de corrección de menor a mayor
+func ECLevels() []string {
+	return []string{"L", "M", "Q", "H"}
+}
+
+// levelFor lee el nivel de corrección de ExtraParams ("ec"); sin él se usa H,
+// el que más daño tolera y el que permite superponer un logo
+func levelFor(config QRConfig) (ecLevel, error) {
+	name := strings.ToUpper(strings.TrimSpace(config.ExtraParams["ec"]))
+	if name == "" {
+		name = "H"
+	}
+	level, ok := ecLevels[name]
+	if !ok {
+		return ecLevel{}, i18n.Errorf("%w: unknown EC level %s (%s)", ErrInvalidInput, config.ExtraParams["ec"], strings.Join(ECLevels(), ", "))
+	}
+	return level, nil
+}
+
+// maskFor lee el patrón de máscara de ExtraParams ("mask"): 0 a 7 lo fijan;
+// sin él o con "auto" se elige el de menor penalización
+func maskFor(config QRConfig) (mask int, forced bool, err error) {
+	value := strings.ToLower(strings.TrimSpace(config.ExtraParams["mask"]))
+	if value == "" || value == "auto" {
+		return 0, false, nil
+	}
+	if len(value) != 1 || value[0] < '0' || value[0] > '7' {
+		return 0, false, i18n.Errorf("%w: unknown mask %s (0-7 or auto)", ErrInvalidInput, config.ExtraParams["mask"])
+	}
+	return int(value[0] - '0'), true, nil
+}
@@ -0,0 +1,28 @@
+package qrgenerator
+
+import (
+	"qrgenerator_cli/helpers/encrypt"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// encryptedContent cifra el contenido para el destinatario de ExtraParams
+// ("encrypt-to") con age o gpg, o con la frase de config.Passphrase. Se
+// aplica después de --compress: comprimir un texto ya cifrado no achica nada.
+func encryptedContent(config QRConfig, content string) (string, error) {
+	if config.Passphrase != "" {
+		sealed, err := encrypt.EncryptPassphrase(content, config.Passphrase)
+		if err != nil {
+			return "", i18n.Errorf("%w: --encrypt: %w", ErrInvalidInput, err)
+		}
+		return sealed, nil
+	}
+	recipient := config.ExtraParams["encrypt-to"]
+	if recipient == "" {
+		return content, nil
+	}
+	sealed, err := encrypt.Encrypt(content, recipient)
+	if err != nil {
+		return "", i18n.Errorf("%w: %w", ErrEncode, err)
+	}
+	return sealed, nil
+}
@@ -0,0 +1,12 @@
+package qrgenerator
+
+import "qrgenerator_cli/helpers/i18n"
+
+// Errores base para clasificar los fallos de generación. Los errores devueltos
+// por el paquete los envuelven, así que se pueden comprobar con errors.Is.
+var (
+	ErrInvalidInput     = i18n.NewError("invalid input")
+	ErrCapacityExceeded = i18n.NewError("QR capacity exceeded")
+	ErrEncode           = i18n.NewError("encoding error")
+	ErrIO               = i18n.NewError("input/output error")
+)
@@ -0,0 +1,168 @@
+package qrgenerator
+
+import (
+	"image"
+	"math"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// eyeShape es la forma de los tres patrones de búsqueda de un QR, los
+// "ojos" (ExtraParams "eye-style"): un anillo de 7 módulos de lado y un
+// centro de 3, que se dibujan como figuras enteras y no módulo por módulo
+type eyeShape string
+
+const (
+	eyeSquare  eyeShape = "square"  // Los cuadrados del estándar
+	eyeRounded eyeShape = "rounded" // Cuadrados con las esquinas redondeadas
+	eyeCircle  eyeShape = "circle"  // Un aro y un círculo
+	eyeLeaf    eyeShape = "leaf"    // Dos esquinas opuestas redondeadas y dos en punta
+)
+
+// eyeShapes son las formas en el orden de la ayuda
+var eyeShapes = []eyeShape{eyeSquare, eyeRounded, eyeCircle, eyeLeaf}
+
+// eyeRadii son los radios de las esquinas redondeadas, en módulos, del borde
+// de afuera del anillo, del de adentro y del centro; el de adentro es uno
+// menos que el de afuera para que el anillo tenga el mismo grosor en las esquinas
+var eyeRadii = map[eyeShape][3]float64{
+	eyeRounded: {2, 1, 1},
+	eyeCircle:  {3.5, 2.5, 1.5},
+	eyeLeaf:    {3, 2, 1.5},
+}
+
+// eyeShapeFor lee ExtraParams "eye-style"; sin él, cuadrados
+func eyeShapeFor(config QRConfig) (eyeShape, error) {
+	value := eyeShape(strings.ToLower(strings.TrimSpace(config.ExtraParams["eye-style"])))
+	if value == "" {
+		return eyeSquare, nil
+	}
+	for _, shape := range eyeShapes {
+		if shape == value {
+			return shape, nil
+		}
+	}
+	names := make([]string, len(eyeShapes))
+	for i, shape := range eyeShapes {
+		names[i] = string(shape)
+	}
+	return "", i18n.Errorf("%w: unknown eye style %s (%s)", ErrInvalidInput, value, strings.Join(names, ", "))
+}
+
+// qrFinders son los tres patrones de búsqueda de un QR, en módulos de
+// bitmap, que tiene quiet módulos claros alrededor del símbolo: arriba a la
+// izquierda, arriba a la derecha y abajo a la izquierda. Se ubican por la
+// matriz y no por la imagen, así que no dependen del tamaño ni del color.
+func qrFinders(bitmap [][]bool, quiet int) []image.Rectangle {
+	n := len(bitmap) - 2*quiet
+	return []image.Rectangle{
+		image.Rect(quiet, quiet, quiet+7, quiet+7),
+		image.Rect(quiet+n-7, quiet, quiet+n, quiet+7),
+		image.Rect(quiet, quiet+n-7, quiet+7, quiet+n),
+	}
+}
+
+// finderAt es el índice del patrón de búsqueda que contiene el módulo (row,
+// col); -1 si no es de ninguno
+func finderAt(finders []image.Rectangle, row, col int) int {
+	for i, r := range finders {
+		if (image.Point{col, row}).In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// corners son los radios de las esquinas de arriba a la izquierda, arriba a
+// la derecha, abajo a la derecha y abajo a la izquierda de una de las tres
+// figuras (0 el borde de afuera, 1 el de adentro, 2 el centro) del patrón
+// finder. La hoja deja en punta la esquina que mira al centro del símbolo y
+// la opuesta, así que los tres ojos quedan simétricos.
+func (e eyeShape) corners(finder, figure int) [4]float64 {
+	r := eyeRadii[e][figure]
+	if e != eyeLeaf {
+		return [4]float64{r, r, r, r}
+	}
+	if finder == 0 {
+		return [4]float64{0, r, 0, r}
+	}
+	return [4]float64{r, 0, r, 0}
+}
+
+// covers indica si el punto (u, v) del patrón de búsqueda finder, medido en
+// módulos desde su esquina de arriba a la izquierda, es oscuro
+func (e eyeShape) covers(finder int, u, v float64) bool {
+	inside := func(figure int) bool {
+		lo := float64(figure)
+		return inRoundedSquare(u, v, lo, 7-lo, e.corners(finder, figure))
+	}
+	return inside(0) && !inside(1) || inside(2)
+}
+
+// inRoundedSquare indica si (u, v) cae en el cuadrado de lo a hi con las
+// esquinas de radios r (ver corners)
+func inRoundedSquare(u, v, lo, hi float64, r [4]float64) bool {
+	if u < lo || u > hi || v < lo || v > hi {
+		return false
+	}
+	centers := [4][2]float64{{lo + r[0], lo + r[0]}, {hi - r[1], lo + r[1]}, {hi - r[2], hi - r[2]}, {lo + r[3], hi - r[3]}}
+	for i, c := range centers {
+		// Fuera del cuadrado de la esquina el borde es recto
+		left, top := i == 0 || i == 3, i < 2
+		if (left && u >= c[0]) || (!left && u <= c[0]) || (top && v >= c[1]) || (!top && v <= c[1]) {
+			continue
+		}
+		if math.Hypot(u-c[0], v-c[1]) > r[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// svg escribe el patrón de búsqueda finder que ocupa r como un <path> de
+// tres figuras, sin cerrar. El borde de adentro va al revés que los otros,
+// así que con la regla nonzero por defecto el anillo queda hueco también en
+// los programas que ignoran fill-rule.
+func (e eyeShape) svg(buf []byte, finder int, r image.Rectangle) []byte {
+	buf = append(buf, `<path d="`...)
+	for figure := 0; figure < 3; figure++ {
+		lo := float64(figure)
+		buf = roundedSquarePath(buf, float64(r.Min.X)+lo, float64(r.Min.Y)+lo, 7-2*lo, e.corners(finder, figure), figure != 1)
+	}
+	return append(buf, '"')
+}
+
+// roundedSquarePath escribe el contorno del cuadrado de lado side desde
+// (x, y), con las esquinas de radios r, en el sentido de las agujas del
+// reloj si clockwise o en el contrario
+func roundedSquarePath(buf []byte, x, y, side float64, r [4]float64, clockwise bool) []byte {
+	point := func(prefix string, p [2]float64) {
+		buf = append(buf, prefix...)
+		buf = strconv.AppendFloat(buf, p[0], 'f', -1, 64)
+		buf = append(buf, ',')
+		buf = strconv.AppendFloat(buf, p[1], 'f', -1, 64)
+	}
+	// Dónde empieza y dónde termina cada esquina en el sentido de las agujas
+	// del reloj, en el orden de r
+	corners := [4][2][2]float64{
+		{{x, y + r[0]}, {x + r[0], y}},
+		{{x + side - r[1], y}, {x + side, y + r[1]}},
+		{{x + side, y + side - r[2]}, {x + side - r[2], y + side}},
+		{{x + r[3], y + side}, {x, y + side - r[3]}},
+	}
+	order, from, to, sweep := []int{1, 2, 3, 0}, 0, 1, " 0 0 1 "
+	if !clockwise {
+		order, from, to, sweep = []int{3, 2, 1, 0}, 1, 0, " 0 0 0 "
+	}
+	point("M", corners[0][to])
+	for _, i := range order {
+		point("L", corners[i][from])
+		if r[i] > 0 {
+			point("A", [2]float64{r[i], r[i]})
+			point(sweep, corners[i][to])
+		}
+	}
+	return append(buf, 'Z')
+}
@@ -0,0 +1,108 @@
+package qrgenerator
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Codificaciones de la matriz como código fuente para firmware
+const (
+	MatrixC    = "c"    // Arreglo uint8_t con #define de ancho, alto y bytes por fila
+	MatrixRust = "rust" // Arreglo [u8; N] con constantes de ancho, alto y bytes por fila
+)
+
+// bytesPerLine es la cantidad de bytes por línea del arreglo generado
+const bytesPerLine = 12
+
+// packRows empaqueta cada fila en bytes, un bit por módulo con el más
+// significativo primero y 1 para los oscuros; cada fila empieza en un byte
+// nuevo, como esperan la mayoría de las bibliotecas de pantallas
+func packRows(symbol [][]bool) (rows [][]byte, stride int) {
+	stride = (len(symbol[0]) + 7) / 8
+	rows = make([][]byte, len(symbol))
+	for y, row := range symbol {
+		rows[y] = make([]byte, stride)
+		for x, dark := range row {
+			if dark {
+				rows[y][x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return rows, stride
+}
+
+// sourceIdentifier deriva el nombre de los símbolos del nombre del archivo:
+// "qr-code.h" da "qr_code"; los nombres sin letras usan "qr"
+func sourceIdentifier(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var name strings.Builder
+	for _, r := range strings.ToLower(base) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9' && name.Len() > 0:
+			name.WriteRune(r)
+		case name.Len() > 0 && !strings.HasSuffix(name.String(), "_"):
+			name.WriteByte('_')
+		}
+	}
+	if id := strings.TrimSuffix(name.String(), "_"); id != "" {
+		return id
+	}
+	return "qr"
+}
+
+// writeByteRows escribe las filas empaquetadas como literales hexadecimales,
+// una fila por línea (partida cada bytesPerLine bytes)
+func writeByteRows(buf *bytes.Buffer, rows [][]byte) {
+	for _, row := range rows {
+		for i := 0; i < len(row); i += bytesPerLine {
+			buf.WriteString("    ")
+			for j, b := range row[i:min(i+bytesPerLine, len(row))] {
+				if j > 0 {
+					buf.WriteByte(' ')
+				}
+				fmt.Fprintf(buf, "0x%02x,", b)
+			}
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// matrixC escribe un encabezado C con la matriz empaquetada (ver packRows)
+func matrixC(symbol [][]bool, info symbolInfo, path string) []byte {
+	rows, stride := packRows(symbol)
+	name := sourceIdentifier(path)
+	macro := strings.ToUpper(name)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// %s: %dx%d modules without the quiet zone.\n", info.describe(), len(symbol[0]), len(symbol))
+	buf.WriteString("// One bit per module, most significant bit first, 1 = dark; each row starts\n")
+	fmt.Fprintf(&buf, "// on a new byte. Leave %d light modules around the symbol when drawing it.\n\n", info.border)
+	fmt.Fprintf(&buf, "#ifndef %s_H\n#define %s_H\n\n#include <stdint.h>\n\n", macro, macro)
+	fmt.Fprintf(&buf, "#define %s_WIDTH %d\n", macro, len(symbol[0]))
+	fmt.Fprintf(&buf, "#define %s_HEIGHT %d\n", macro, len(symbol))
+	fmt.Fprintf(&buf, "#define %s_STRIDE %d\n\n", macro, stride)
+	fmt.Fprintf(&buf, "static const uint8_t %s_bitmap[%s_HEIGHT * %s_STRIDE] = {\n", name, macro, macro)
+	writeByteRows(&buf, rows)
+	fmt.Fprintf(&buf, "};\n\n#endif // %s_H\n", macro)
+	return buf.Bytes()
+}
+
+// matrixRust escribe un módulo Rust con la matriz empaquetada (ver packRows)
+func matrixRust(symbol [][]bool, info symbolInfo, path string) []byte {
+	rows, stride := packRows(symbol)
+	name := strings.ToUpper(sourceIdentifier(path))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "//! %s: %dx%d modules without the quiet zone.\n", info.describe(), len(symbol[0]), len(symbol))
+	buf.WriteString("//! One bit per module, most significant bit first, 1 = dark; each row starts\n")
+	fmt.Fprintf(&buf, "//! on a new byte. Leave %d light modules around the symbol when drawing it.\n\n", info.border)
+	fmt.Fprintf(&buf, "pub const %s_WIDTH: usize = %d;\n", name, len(symbol[0]))
+	fmt.Fprintf(&buf, "pub const %s_HEIGHT: usize = %d;\n", name, len(symbol))
+	fmt.Fprintf(&buf, "pub const %s_STRIDE: usize = %d;\n\n", name, stride)
+	fmt.Fprintf(&buf, "pub static %s_BITMAP: [u8; %s_HEIGHT * %s_STRIDE] = [\n", name, name, name)
+	writeByteRows(&buf, rows)
+	buf.WriteString("];\n")
+	return buf.Bytes()
+}
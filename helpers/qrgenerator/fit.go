@@ -0,0 +1,87 @@
+package qrgenerator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// FitStrategies son los valores de ExtraParams "fit"
+func FitStrategies() []string {
+	return []string{"none", "auto"}
+}
+
+// fitLimits son los límites de --fit auto: el nivel de corrección más bajo al
+// que puede bajar y la versión más grande que acepta
+type fitLimits struct {
+	minLevel   ecLevel
+	maxVersion int
+}
+
+// fitFor lee la estrategia de ExtraParams "fit" y sus límites ("fit-min-ec",
+// por defecto L, y "fit-max-version", por defecto 40). Devuelve nil si no se
+// pidió --fit auto; level es el nivel pedido con --ec.
+func fitFor(config QRConfig, level ecLevel) (*fitLimits, error) {
+	minEC, maxVersion := config.ExtraParams["fit-min-ec"], config.ExtraParams["fit-max-version"]
+	switch strings.ToLower(config.ExtraParams["fit"]) {
+	case "", "none":
+		if minEC != "" || maxVersion != "" {
+			return nil, i18n.Errorf("%w: --fit-min-ec and --fit-max-version need --fit auto", ErrInvalidInput)
+		}
+		return nil, nil
+	case "auto":
+	default:
+		return nil, i18n.Errorf("%w: unknown fit strategy %s (%s)", ErrInvalidInput, config.ExtraParams["fit"], strings.Join(FitStrategies(), ", "))
+	}
+
+	limits := &fitLimits{minLevel: ecLevels["L"], maxVersion: 40}
+	if minEC != "" {
+		var ok bool
+		if limits.minLevel, ok = ecLevels[strings.ToUpper(strings.TrimSpace(minEC))]; !ok {
+			return nil, i18n.Errorf("%w: unknown EC level %s (%s)", ErrInvalidInput, minEC, strings.Join(ECLevels(), ", "))
+		}
+		if limits.minLevel.codec > level.codec {
+			return nil, i18n.Errorf("%w: --fit-min-ec %s is above the EC level %s", ErrInvalidInput, limits.minLevel.codec, level.codec)
+		}
+	}
+	if maxVersion != "" {
+		if config.Version != 0 {
+			return nil, i18n.Errorf("%w: --fit-max-version cannot be combined with --qr-version", ErrInvalidInput)
+		}
+		var err error
+		if limits.maxVersion, err = strconv.Atoi(maxVersion); err != nil || limits.maxVersion < 1 || limits.maxVersion > 40 {
+			return nil, i18n.Errorf("%w: --fit-max-version must be a number from 1 to 40", ErrInvalidInput)
+		}
+	}
+	return limits, nil
+}
+
+// fitSymbol codifica content con el nivel pedido y, si no entra o necesita
+// una versión mayor que la permitida, prueba los niveles más bajos hasta el
+// mínimo. Cada intento ya usa la versión más chica que contiene el payload,
+// así que bajar el nivel es lo único que queda por elegir. Devuelve el nivel
+// elegido; si ninguno sirve, el mínimo con qrcodec.ErrDataTooLong o un error
+// de versión.
+func fitSymbol(config QRConfig, content string, level ecLevel, limits *fitLimits) (encodedSymbol, ecLevel, error) {
+	smallest := 0
+	for codec := level.codec; codec >= limits.minLevel.codec; codec-- {
+		current := ecLevels[codec.String()]
+		symbol, err := encodeSymbol(config, content, current)
+		switch {
+		case errors.Is(err, qrcodec.ErrDataTooLong):
+			continue
+		case err != nil:
+			return encodedSymbol{}, current, err
+		case symbol.version <= limits.maxVersion:
+			return symbol, current, nil
+		}
+		smallest = symbol.version
+	}
+	if smallest > 0 {
+		return encodedSymbol{}, limits.minLevel, i18n.Errorf("%w: at EC level %s the payload needs version %d, above --fit-max-version %d", ErrCapacityExceeded, limits.minLevel.codec, smallest, limits.maxVersion)
+	}
+	return encodedSymbol{}, limits.minLevel, qrcodec.ErrDataTooLong
+}
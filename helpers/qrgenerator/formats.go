@@ -0,0 +1,140 @@
+package qrgenerator
+
+import (
+	"image"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Output es un archivo a escribir con su formato
+type Output struct {
+	Path   string
+	Format OutputFormat
+}
+
+// Extension devuelve la extensión con la que se nombran los archivos del formato
+func (f OutputFormat) Extension() string {
+	switch f {
+	case FormatJPEG:
+		return ".jpg"
+	case FormatMatrix:
+		return ".txt"
+	default:
+		return "." + string(f)
+	}
+}
+
+// OutputsFor deriva una salida por formato cambiando la extensión de path:
+// qr.png con svg y tiff da qr.svg y qr.tiff
+func OutputsFor(path string, formats []OutputFormat) []Output {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	outputs := make([]Output, len(formats))
+	for i, format := range formats {
+		outputs[i] = Output{Path: base + format.Extension(), Format: format}
+	}
+	return outputs
+}
+
+// GenerateFormats codifica el QR una sola vez y lo escribe en cada salida;
+// config.OutputPath y config.Format se reemplazan por los de cada una. Todas
+// las salidas se validan antes de escribir la primera. Si falla una escritura
+// se devuelven también los resultados de los archivos ya escritos.
+func GenerateFormats(config QRConfig, outputs []Output) ([]*QRResult, error) {
+	return generateOutputs(config, outputs, generateQRImage)
+}
+
+// generateOutputs valida las salidas, dibuja el símbolo una vez con render y
+// lo escribe en cada una (ver GenerateFormats)
+func generateOutputs(config QRConfig, outputs []Output, render func(config QRConfig, result *QRResult) (image.Image, [][]bool, error)) ([]*QRResult, error) {
+	if len(outputs) == 0 {
+		return nil, i18n.Errorf("%w: no output to write", ErrInvalidInput)
+	}
+	if config.ExtraParams == nil {
+		config.ExtraParams = make(map[string]string)
+	}
+
+	// Validar configuración: el primer error corta, las advertencias se informan
+	configs := make([]QRConfig, len(outputs))
+	warnings := make([][]string, len(outputs))
+	seen := make(map[string]bool, len(outputs))
+	for i, output := range outputs {
+		if seen[output.Path] {
+			return nil, i18n.Errorf("%w: output %s is listed more than once", ErrInvalidInput, output.Path)
+		}
+		seen[output.Path] = true
+
+		cfg := config
+		cfg.OutputPath, cfg.Format = output.Path, output.Format
+		for _, problem := range Validate(cfg) {
+			if !problem.Warning {
+				return nil, problem.Err
+			}
+			warnings[i] = append(warnings[i], problem.Err.Error())
+		}
+		configs[i] = cfg
+	}
+	thumbSide, _ := thumbnailSize(config)
+	if thumbSide > 0 {
+		// La miniatura no puede pisar otra salida: -o qr.png -o qr_thumb.png
+		for _, output := range outputs {
+			if thumb := ThumbnailPath(output.Path); seen[thumb] {
+				return nil, i18n.Errorf("%w: the thumbnail of %s would overwrite the output %s", ErrInvalidInput, output.Path, thumb)
+			}
+		}
+	}
+
+	// Generar la imagen base del QR, compartida por todos los formatos
+	var encoded QRResult
+	start := time.Now()
+	qrImage, bitmap, err := render(config, &encoded)
+	if err != nil {
+		return nil, err
+	}
+	encoded.EncodeTime = time.Since(start)
+
+	results := make([]*QRResult, 0, len(configs))
+	thumbnails := map[string]bool{}
+	for i, cfg := range configs {
+		// Seleccionar el generador según el formato
+		newGenerator, ok := generators[cfg.Format]
+		if !ok {
+			return results, i18n.Errorf("%w: unsupported format: %s", ErrInvalidInput, cfg.Format)
+		}
+
+		// Generar el archivo de salida; las exportaciones de la matriz usan los módulos
+		start = time.Now()
+		if config.ExtraParams["manifest"] != "" && slices.Contains(manifestFormats, cfg.Format) {
+			cfg.manifest = newManifest(cfg, &encoded, bitmap)
+		}
+		cfg.caption = encoded.caption
+		generator := newGenerator()
+		if matrix, ok := generator.(matrixGenerator); ok {
+			err = matrix.GenerateMatrix(bitmap, cfg)
+		} else {
+			err = generator.Generate(qrImage, cfg)
+		}
+		if err != nil {
+			return results, err
+		}
+		result := encoded
+		result.OutputPath, result.Format = cfg.OutputPath, cfg.Format
+		if thumbSide > 0 {
+			// qr.png y qr.svg comparten la miniatura: se escribe una sola vez
+			result.Thumbnail = ThumbnailPath(cfg.OutputPath)
+			if !thumbnails[result.Thumbnail] {
+				if err := writeThumbnail(qrImage, result.Thumbnail, thumbSide); err != nil {
+					return results, err
+				}
+				thumbnails[result.Thumbnail] = true
+			}
+		}
+		result.Warnings = append(warnings[i], encoded.Warnings...)
+		result.WriteTime = time.Since(start)
+		results = append(results, &result)
+	}
+	return results, nil
+}
@@ -0,0 +1,164 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// gradientFormats son los formatos que pintan los módulos con --fg-gradient:
+// los de mapa de bits en color y SVG, que lo escribe como un gradiente
+var gradientFormats = []OutputFormat{FormatPNG, FormatJPEG, FormatAVIF, FormatHEIF, FormatSVG}
+
+// gradient es un degradado de los módulos (ExtraParams "fg-gradient"), en
+// sRGB como los de SVG. Cubre la imagen entera, zona de silencio incluida,
+// así que el mismo código tiene el mismo degradado en cualquier tamaño.
+type gradient struct {
+	radial   bool
+	from, to color.Color
+	angle    float64 // Dirección del lineal en grados: 0 de izquierda a derecha, 90 de arriba abajo
+}
+
+// gradientFor lee ExtraParams "fg-gradient": linear:DESDE,HASTA[,ÁNGULOdeg]
+// o radial:DESDE,HASTA, del centro a las esquinas; nil si no hay
+func gradientFor(config QRConfig) (*gradient, error) {
+	value := config.ExtraParams["fg-gradient"]
+	if value == "" {
+		return nil, nil
+	}
+	invalid := i18n.Errorf("%w: invalid --fg-gradient %s; use linear:FROM,TO[,ANGLEdeg] or radial:FROM,TO", ErrInvalidInput, value)
+	kind, stops, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, invalid
+	}
+	parts := strings.Split(stops, ",")
+	g := &gradient{}
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "linear":
+		if len(parts) == 3 {
+			angle, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(parts[2])), "deg"), 64)
+			if err != nil || math.IsInf(angle, 0) || math.IsNaN(angle) {
+				return nil, invalid
+			}
+			g.angle, parts = angle, parts[:2]
+		}
+	case "radial":
+		g.radial = true
+	default:
+		return nil, invalid
+	}
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+	var err error
+	if g.from, err = parseColor("fg-gradient", parts[0]); err != nil {
+		return nil, err
+	}
+	if g.to, err = parseColor("fg-gradient", parts[1]); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// offset es la posición (0 a 1) del punto (x, y) en el degradado de una
+// imagen de w por h
+func (g *gradient) offset(x, y, w, h float64) float64 {
+	dx, dy := x-w/2, y-h/2
+	var t float64
+	if g.radial {
+		t = math.Hypot(dx, dy) / (math.Hypot(w, h) / 2)
+	} else {
+		x1, y1, x2, y2 := g.line(w, h)
+		vx, vy := x2-x1, y2-y1
+		t = ((x-x1)*vx + (y-y1)*vy) / (vx*vx + vy*vy)
+	}
+	return min(max(t, 0), 1)
+}
+
+// line son los extremos del degradado lineal en una imagen de w por h: la
+// recta por el centro en la dirección del ángulo, cortada donde las
+// perpendiculares pasan por las esquinas, como en CSS
+func (g *gradient) line(w, h float64) (x1, y1, x2, y2 float64) {
+	sin, cos := math.Sincos(g.angle * math.Pi / 180)
+	half := (w*math.Abs(cos) + h*math.Abs(sin)) / 2
+	return w/2 - cos*half, h/2 - sin*half, w/2 + cos*half, h/2 + sin*half
+}
+
+// at es el color en la posición t del degradado
+func (g *gradient) at(t float64) color.RGBA {
+	r1, g1, b1, _ := g.from.RGBA()
+	r2, g2, b2, _ := g.to.RGBA()
+	mix := func(a, b uint32) uint8 {
+		return uint8(math.Round((float64(a>>8)*(1-t) + float64(b>>8)*t)))
+	}
+	return color.RGBA{R: mix(r1, r2), G: mix(g1, g2), B: mix(b1, b2), A: 0xff}
+}
+
+// samples son colores del degradado a intervalos regulares, para revisar
+// el contraste y los colores del chroma key en todo el recorrido y no solo
+// en los extremos
+func (g *gradient) samples() color.Palette {
+	const steps = 10
+	palette := make(color.Palette, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		palette = append(palette, g.at(float64(i)/steps))
+	}
+	return palette
+}
+
+// weakest es el color del degradado con menos contraste contra light
+func (g *gradient) weakest(light color.Color) color.Color {
+	lLight := luminance(light)
+	return slices.MinFunc(g.samples(), func(a, b color.Color) int {
+		da, db := math.Abs(luminance(a)-lLight), math.Abs(luminance(b)-lLight)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		}
+		return 0
+	})
+}
+
+// svg escribe el degradado como un elemento de <defs> con el id dado, en
+// las coordenadas del viewBox de w por h
+func (g *gradient) svg(id string, w, h float64) string {
+	stops := fmt.Sprintf(`<stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/>`, cssColor(g.from), cssColor(g.to))
+	if g.radial {
+		return fmt.Sprintf(`<radialGradient id="%s" gradientUnits="userSpaceOnUse" cx="%.2f" cy="%.2f" r="%.2f">%s</radialGradient>`,
+			id, w/2, h/2, math.Hypot(w, h)/2, stops)
+	}
+	x1, y1, x2, y2 := g.line(w, h)
+	return fmt.Sprintf(`<linearGradient id="%s" gradientUnits="userSpaceOnUse" x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f">%s</linearGradient>`,
+		id, x1, y1, x2, y2, stops)
+}
+
+// gradientImage pinta con el degradado los píxeles de los módulos de la
+// imagen, que se dibujó con el color inicial; se calcula bajo demanda, así
+// que sirve también para las imágenes grandes
+type gradientImage struct {
+	image.Image
+	ink      color.Color
+	gradient *gradient
+}
+
+func (m *gradientImage) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (m *gradientImage) At(x, y int) color.Color {
+	c := m.Image.At(x, y)
+	if !isInk(c, m.ink) {
+		return c
+	}
+	b := m.Bounds()
+	t := m.gradient.offset(float64(x-b.Min.X)+0.5, float64(y-b.Min.Y)+0.5, float64(b.Dx()), float64(b.Dy()))
+	return m.gradient.at(t)
+}
@@ -0,0 +1,142 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// GridCell es una variante de la hoja de comparación: el mismo payload con
+// otras opciones y la etiqueta que las describe
+type GridCell struct {
+	Config QRConfig
+	Label  string
+}
+
+// Medidas de la hoja de comparación, en píxeles
+const (
+	gridMargin   = 24 // Separación entre celdas y alrededor de la hoja
+	gridFontSize = 14
+	gridMaxSide  = 8192 // Lado máximo de la hoja, para no agotar la memoria
+)
+
+var (
+	gridBackground = color.RGBA{0xf2, 0xf2, 0xf2, 0xff}
+	gridText       = color.RGBA{0x20, 0x20, 0x20, 0xff}
+	gridFailed     = color.RGBA{0xc0, 0x20, 0x20, 0xff}
+)
+
+// RenderGrid dibuja las variantes en una hoja de columns columnas, cada una
+// con su etiqueta y los datos del símbolo (versión, módulos, máscara) debajo.
+// Una variante que no se puede generar (por ejemplo, porque el payload no
+// entra con ese nivel de corrección) ocupa su celda con el error en lugar de
+// cortar la hoja; solo falla si no se pudo generar ninguna. Los resultados
+// de las variantes fallidas son nil.
+func RenderGrid(cells []GridCell, columns int) (image.Image, []*QRResult, error) {
+	if len(cells) == 0 {
+		return nil, nil, i18n.Errorf("%w: no variants to compare", ErrInvalidInput)
+	}
+	if columns <= 0 || columns > len(cells) {
+		columns = len(cells)
+	}
+
+	images := make([]image.Image, len(cells))
+	results := make([]*QRResult, len(cells))
+	failures := make([]error, len(cells))
+	failed, side := 0, 0
+	for i, cell := range cells {
+		images[i], results[i], failures[i] = Render(cell.Config)
+		if failures[i] != nil {
+			failed++
+			side = max(side, cell.Config.Size)
+			continue
+		}
+		bounds := images[i].Bounds()
+		side = max(side, bounds.Dx(), bounds.Dy())
+	}
+	if failed == len(cells) {
+		return nil, nil, failures[0]
+	}
+
+	parsed, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, nil, i18n.Errorf("%w: error loading the label font: %w", ErrEncode, err)
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: gridFontSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, nil, i18n.Errorf("%w: error loading the label font: %w", ErrEncode, err)
+	}
+	defer face.Close()
+
+	metrics := face.Metrics()
+	lineHeight := (metrics.Ascent + metrics.Descent).Ceil() + 2
+	labels := make([][2]string, len(cells))
+	cellWidth := side
+	for i, cell := range cells {
+		labels[i][0] = cell.Label
+		if failures[i] != nil {
+			labels[i][1] = failures[i].Error()
+		} else {
+			labels[i][1] = i18n.Sprintf("v%d, %dx%d modules, mask %d", results[i].Version, results[i].Modules, results[i].Modules, results[i].Mask)
+		}
+		for _, line := range labels[i] {
+			cellWidth = max(cellWidth, font.MeasureString(face, line).Ceil())
+		}
+	}
+	cellHeight := side + gridMargin/2 + 2*lineHeight
+
+	rows := (len(cells) + columns - 1) / columns
+	width := gridMargin + columns*(cellWidth+gridMargin)
+	height := gridMargin + rows*(cellHeight+gridMargin)
+	if width > gridMaxSide || height > gridMaxSide {
+		return nil, nil, i18n.Errorf("%w: the comparison sheet would be %dx%dpx (max %dpx per side); compare fewer or smaller variants", ErrInvalidInput, width, height, gridMaxSide)
+	}
+
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(gridBackground), image.Point{}, draw.Src)
+	for i := range cells {
+		at := image.Pt(gridMargin+(i%columns)*(cellWidth+gridMargin), gridMargin+(i/columns)*(cellHeight+gridMargin))
+		textColor := gridText
+		if failures[i] != nil {
+			textColor = gridFailed
+		} else {
+			// Cada QR se apoya sobre su etiqueta; los de overlay son transparentes
+			bounds := images[i].Bounds()
+			offset := image.Pt(0, side-bounds.Dy())
+			draw.Draw(sheet, bounds.Sub(bounds.Min).Add(at.Add(offset)), images[i], bounds.Min, draw.Over)
+		}
+		for line, text := range labels[i] {
+			drawer := &font.Drawer{
+				Dst:  sheet,
+				Src:  image.NewUniform(textColor),
+				Face: face,
+				Dot:  fixed.P(at.X, at.Y+side+gridMargin/2+line*lineHeight+metrics.Ascent.Ceil()),
+			}
+			drawer.DrawString(text)
+		}
+	}
+	return sheet, results, nil
+}
+
+// GenerateGrid dibuja la hoja de comparación y la escribe como PNG o JPEG
+func GenerateGrid(cells []GridCell, columns int, output Output) ([]*QRResult, error) {
+	if output.Format != FormatPNG && output.Format != FormatJPEG {
+		return nil, i18n.Errorf("%w: the comparison sheet is written as PNG or JPEG, not %s", ErrInvalidInput, output.Format)
+	}
+	sheet, results, err := RenderGrid(cells, columns)
+	if err != nil {
+		return nil, err
+	}
+	config := QRConfig{OutputPath: output.Path, Format: output.Format, ExtraParams: map[string]string{}}
+	if err := generators[output.Format]().Generate(sheet, config); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,39 @@
+package qrgenerator
+
+import (
+	"errors"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// gs1Bitmap codifica una cadena de elementos GS1 (ExtraParams "gs1") con FNC1
+// en primera posición, que el codificador genérico no puede escribir, y
+// devuelve el mapa de módulos con la zona de silencio y la versión
+func gs1Bitmap(content string, opts qrcodec.EncodeOptions) ([][]bool, int, error) {
+	opts.FNC1 = true
+	return symbolBitmap(qrcodec.GS1Segments(content), opts)
+}
+
+// symbolBitmap codifica los segmentos con qrcodec y devuelve el mapa de
+// módulos con la zona de silencio y la versión. Si no entran devuelve
+// qrcodec.ErrDataTooLong, para que generateQRImage explique el límite.
+func symbolBitmap(segments []qrcodec.Segment, opts qrcodec.EncodeOptions) ([][]bool, int, error) {
+	symbol, err := qrcodec.Encode(segments, opts)
+	if errors.Is(err, qrcodec.ErrDataTooLong) {
+		return nil, 0, err
+	}
+	if err != nil {
+		return nil, 0, i18n.Errorf("%w: error generating QR: %w", ErrEncode, err)
+	}
+
+	size := len(symbol.Matrix) + 2*qrBorder
+	bitmap := make([][]bool, size)
+	for y := range bitmap {
+		bitmap[y] = make([]bool, size)
+		if y >= qrBorder && y < size-qrBorder {
+			copy(bitmap[y][qrBorder:], symbol.Matrix[y-qrBorder])
+		}
+	}
+	return bitmap, symbol.Version, nil
+}
@@ -0,0 +1,105 @@
+package qrgenerator
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// FormatHEIF es HEIF/HEIC con HEVC, el formato de imágenes de los pipelines de iOS
+const FormatHEIF OutputFormat = "heic"
+
+// heifEncoder es el codificador de libheif. No hay un codificador HEVC en Go,
+// así que se usa el programa del sistema si está instalado.
+const heifEncoder = "heif-enc"
+
+// heifMaxSize es el lado máximo aceptado en HEIF; x265 trabaja con la imagen
+// completa en memoria
+const heifMaxSize = 8192
+
+type heifGenerator struct{}
+
+// Available informa si heif-enc está instalado
+func (g *heifGenerator) Available() error {
+	if _, err := exec.LookPath(heifEncoder); err != nil {
+		return i18n.Errorf("%w: HEIF output needs %s (libheif); install libheif-examples or use AVIF", ErrEncode, heifEncoder)
+	}
+	return nil
+}
+
+// Implementación para HEIF. Por defecto es sin pérdida (4:4:4, sin
+// cuantización); con -quality se usa HEVC con pérdida manteniendo el croma completo.
+func (g *heifGenerator) Generate(qrImage image.Image, config QRConfig) error {
+	bounds := qrImage.Bounds()
+	if bounds.Dx() > heifMaxSize || bounds.Dy() > heifMaxSize {
+		return i18n.Errorf("%w: HEIF supports up to %dpx per side; use PNG or SVG", ErrInvalidInput, heifMaxSize)
+	}
+	if err := g.Available(); err != nil {
+		return err
+	}
+	quality, err := heifQuality(config)
+	if err != nil {
+		return err
+	}
+
+	// heif-enc lee la imagen de un archivo; PNG la pasa sin pérdida
+	tmp, err := os.CreateTemp("", "qrgenerator-*.png")
+	if err != nil {
+		return i18n.Errorf("%w: error creating temporary file: %w", ErrIO, err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := png.Encode(tmp, qrImage); err != nil {
+		tmp.Close()
+		return i18n.Errorf("%w: error encoding PNG: %w", ErrEncode, err)
+	}
+	if err := closeOutput(tmp); err != nil {
+		return err
+	}
+
+	args := []string{"-o", config.OutputPath}
+	if quality == heifLosslessQuality {
+		args = append(args, "-L")
+	} else {
+		args = append(args, "-q", strconv.Itoa(quality), "-p", "chroma=444")
+	}
+	args = append(args, tmp.Name())
+
+	var output bytes.Buffer
+	cmd := exec.Command(heifEncoder, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("%w: error encoding HEIF: %w: %s", ErrEncode, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// heifLosslessQuality es la calidad que selecciona el modo sin pérdida
+const heifLosslessQuality = 100
+
+// heifQuality lee la calidad de ExtraParams ("quality"); sin ella se codifica sin pérdida
+func heifQuality(config QRConfig) (int, error) {
+	quality := heifLosslessQuality
+	if qualityStr, ok := config.ExtraParams["quality"]; ok {
+		quality, _ = strconv.Atoi(qualityStr)
+	}
+	if quality < 1 || quality > 100 {
+		return quality, i18n.Errorf("%w: HEIF quality %d out of range (1-100)", ErrInvalidInput, quality)
+	}
+	return quality, nil
+}
+
+// heifWarnings advierte cuando se pide HEIF con pérdida
+func heifWarnings(config QRConfig) []string {
+	quality, err := heifQuality(config)
+	if err != nil || quality == heifLosslessQuality {
+		return nil
+	}
+	return []string{i18n.Sprintf("HEIF quality %d is lossy and blurs module edges; omit -quality for lossless encoding", quality)}
+}
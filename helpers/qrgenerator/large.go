@@ -0,0 +1,112 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/color"
+)
+
+// largeImageThreshold es el lado, en píxeles, a partir del cual la imagen no se
+// reserva entera en memoria sino que cada píxel se calcula desde los módulos
+const largeImageThreshold = 4096
+
+// DefaultMaxSize es el lado máximo aceptado si QRConfig.MaxSize no se indica
+const DefaultMaxSize = 32768
+
+// jpegMaxSize es el lado máximo que admite el formato JPEG
+const jpegMaxSize = 65535
+
+// moduleImage es una imagen de dos colores que calcula cada píxel a partir de la
+// matriz de módulos. Los encoders PNG y JPEG la recorren fila a fila, así que
+// la memoria usada no depende del tamaño de salida.
+type moduleImage struct {
+	bitmap  [][]bool          // Módulos incluida la zona de silencio
+	size    int               // Ancho en píxeles; el alto sigue la proporción de la matriz (rMQR)
+	palette color.Palette     // Fondo y módulos (ver symbolPalette)
+	shape   moduleShape       // Forma de los módulos oscuros; vacía es cuadrada
+	eye     eyeShape          // Forma de los patrones de búsqueda
+	finders []image.Rectangle // Patrones de búsqueda, en módulos; nil si no hay formas
+
+	caption    *caption     // Texto legible de los códigos lineales, en módulos de bitmap; nil sin texto
+	overlay    *image.Alpha // El texto dibujado, desde la fila de píxeles overlayTop
+	overlayTop int
+}
+
+// newModuleImage crea la imagen; como la librería de QR, agranda size si no alcanza
+// para un píxel por módulo
+func newModuleImage(bitmap [][]bool, size int, palette color.Palette) *moduleImage {
+	return &moduleImage{
+		bitmap:  bitmap,
+		size:    max(size, len(bitmap[0])),
+		palette: palette,
+	}
+}
+
+// ColorModel implementa image.Image; al ser una paleta, el encoder PNG escribe 1 bit por píxel
+func (m *moduleImage) ColorModel() color.Model {
+	return m.palette
+}
+
+// Bounds implementa image.Image
+func (m *moduleImage) Bounds() image.Rectangle {
+	if m.overlay != nil {
+		return image.Rect(0, 0, m.size, m.overlayTop+m.overlay.Bounds().Dy())
+	}
+	return image.Rect(0, 0, m.size, m.size*len(m.bitmap)/len(m.bitmap[0]))
+}
+
+// At implementa image.Image
+func (m *moduleImage) At(x, y int) color.Color {
+	return m.palette[m.ColorIndexAt(x, y)]
+}
+
+// ColorIndexAt implementa image.PalettedImage con el mismo redondeo que la
+// librería de QR, en enteros para que con --scale cada módulo tenga
+// exactamente los mismos píxeles
+func (m *moduleImage) ColorIndexAt(x, y int) uint8 {
+	if !(image.Point{x, y}.In(m.Bounds())) {
+		return 0
+	}
+	if m.overlay != nil && y >= m.overlayTop && m.overlay.AlphaAt(x, y-m.overlayTop).A >= 128 {
+		return 1
+	}
+	modules := len(m.bitmap[0])
+	if m.finders != nil {
+		// Las formas se miden desde el centro del píxel, en módulos
+		fx := (float64(x) + 0.5) * float64(modules) / float64(m.size)
+		fy := (float64(y) + 0.5) * float64(modules) / float64(m.size)
+		row, col := int(fy), int(fx)
+		if i := finderAt(m.finders, row, col); i >= 0 {
+			if m.eye.covers(i, fx-float64(m.finders[i].Min.X), fy-float64(m.finders[i].Min.Y)) {
+				return 1
+			}
+			return 0
+		}
+		if isDarkAt(m.bitmap, row, col) && m.shape.covers(m.bitmap, row, col, fx-float64(col), fy-float64(row)) {
+			return 1
+		}
+		return 0
+	}
+	if row := y * modules / m.size; row < len(m.bitmap) && m.bitmap[row][x*modules/m.size] {
+		return 1
+	}
+	return 0
+}
+
+// moduleGrid devuelve la misma matriz con un píxel por módulo, para que los
+// formatos vectoriales la escalen en lugar de dibujar cada píxel. No lleva
+// el texto legible: esos formatos lo escriben como texto.
+func (m *moduleImage) moduleGrid() *moduleImage {
+	return newModuleImage(m.bitmap, len(m.bitmap[0]), m.palette)
+}
+
+// setCaption agrega el texto legible, ubicado en módulos de bitmap y
+// dibujado al ancho de la imagen; el que pasa de las barras ocupa la zona de
+// silencio de abajo y la agranda
+func (m *moduleImage) setCaption(c *caption) error {
+	overlay, top, err := c.render(m.size, float64(m.size)/float64(len(m.bitmap[0])))
+	if err != nil {
+		return err
+	}
+	m.caption, m.overlay, m.overlayTop = c, overlay, top
+	return nil
+}
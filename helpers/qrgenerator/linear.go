@@ -0,0 +1,31 @@
+package qrgenerator
+
+import (
+	"slices"
+)
+
+// minBarHeight es el alto mínimo de las barras, en módulos; el estándar
+// pide al menos el 15% del ancho del símbolo
+const minBarHeight = 20
+
+// linearSymbols son las simbologías lineales: una fila de barras, sin
+// corrección de errores, que se lee de lado a lado
+func linearSymbols() []string {
+	return append([]string{"code128", "code39"}, retailSymbols...)
+}
+
+// isLinear indica si la configuración pide un código de barras lineal
+func isLinear(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return slices.Contains(linearSymbols(), symbol)
+}
+
+// linearBitmap repite la fila de barras hasta el alto del símbolo
+func linearBitmap(bars []bool) [][]bool {
+	height := max(minBarHeight, (len(bars)*15+99)/100)
+	bitmap := make([][]bool, height)
+	for y := range bitmap {
+		bitmap[y] = slices.Clone(bars)
+	}
+	return bitmap
+}
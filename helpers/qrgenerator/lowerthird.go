@@ -0,0 +1,173 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Presets de overlay para transmisiones: un PNG transparente del tamaño del
+// video con el QR en una esquina y un texto opcional al lado
+const (
+	PresetLowerThird   = "lowerthird"    // 1920x1080
+	PresetLowerThird4K = "lowerthird-4k" // 3840x2160
+)
+
+// overlayBaseHeight es el alto de video al que corresponde -size en los overlays;
+// en 4K el QR y el texto se escalan para verse igual
+const overlayBaseHeight = 1080
+
+// corners son las esquinas aceptadas para el QR del overlay
+var corners = []string{"bottom-right", "bottom-left", "top-right", "top-left"}
+
+// duplicates son las ubicaciones aceptadas para la segunda copia del QR
+// (ExtraParams "duplicate"): la esquina opuesta, la del otro lado a la misma
+// altura o la del mismo lado en el otro borde
+var duplicates = []string{"opposite", "horizontal", "vertical"}
+
+// overlayParams devuelve la esquina, el texto y la ubicación de la copia del
+// overlay, validados; duplicate es vacío si no se pidió copia
+func overlayParams(config QRConfig) (corner, caption, duplicate string, err error) {
+	corner = strings.ToLower(config.ExtraParams["corner"])
+	if corner == "" {
+		corner = corners[0]
+	}
+	if !slices.Contains(corners, corner) {
+		return "", "", "", i18n.Errorf("%w: unknown corner %s (%s)", ErrInvalidInput, corner, strings.Join(corners, ", "))
+	}
+	caption = strings.TrimSpace(config.ExtraParams["caption"])
+	if strings.ContainsAny(caption, "\r\n") {
+		return "", "", "", i18n.Errorf("%w: the caption must be a single line", ErrInvalidInput)
+	}
+	duplicate = strings.ToLower(config.ExtraParams["duplicate"])
+	if duplicate != "" && !slices.Contains(duplicates, duplicate) {
+		return "", "", "", i18n.Errorf("%w: unknown duplicate placement %s (%s)", ErrInvalidInput, duplicate, strings.Join(duplicates, ", "))
+	}
+	return corner, caption, duplicate, nil
+}
+
+// overlayProblems revisa las opciones de overlay: solo se usan con un preset
+// de overlay y este solo se escribe como PNG, el único formato con alfa
+func overlayProblems(config QRConfig, style preset) []error {
+	_, hasCorner := config.ExtraParams["corner"]
+	_, hasCaption := config.ExtraParams["caption"]
+	_, hasDuplicate := config.ExtraParams["duplicate"]
+	if style.canvas == (image.Point{}) {
+		if hasCorner || hasCaption || hasDuplicate {
+			return []error{i18n.Errorf("%w: --corner, --caption and --duplicate need --preset %s or %s", ErrInvalidInput, PresetLowerThird, PresetLowerThird4K)}
+		}
+		return nil
+	}
+
+	var problems []error
+	if _, _, _, err := overlayParams(config); err != nil {
+		problems = append(problems, err)
+	}
+	if config.Format != "" && config.Format != FormatPNG {
+		problems = append(problems, i18n.Errorf("%w: preset %s writes a transparent PNG; use a .png output", ErrInvalidInput, config.ExtraParams["preset"]))
+	}
+	return problems
+}
+
+// lowerThird dibuja el QR en una esquina de un lienzo transparente del tamaño
+// del video, dentro del margen seguro del 5%, con el texto sobre una placa
+// semitransparente del lado de adentro. Con duplicate se dibuja una segunda
+// copia en otra esquina: en pantallas grandes vistas de costado, la copia más
+// cercana al espectador se escanea con menos distorsión.
+func lowerThird(qrImage image.Image, config QRConfig, canvas image.Point) (image.Image, error) {
+	corner, caption, duplicate, err := overlayParams(config)
+	if err != nil {
+		return nil, err
+	}
+	side := qrImage.Bounds().Dx()
+	marginX, marginY := canvas.X/20, canvas.Y/20
+	if side > canvas.Y-2*marginY {
+		return nil, i18n.Errorf("%w: the %dpx QR does not fit the %dx%d overlay; lower -size", ErrInvalidInput, side, canvas.X, canvas.Y)
+	}
+
+	img := image.NewNRGBA(image.Rectangle{Max: canvas})
+	left := strings.HasSuffix(corner, "left")
+	top := strings.HasPrefix(corner, "top")
+	qrAt := image.Pt(canvas.X-marginX-side, canvas.Y-marginY-side)
+	if left {
+		qrAt.X = marginX
+	}
+	if top {
+		qrAt.Y = marginY
+	}
+	qrRect := image.Rectangle{Min: qrAt, Max: qrAt.Add(image.Pt(side, side))}
+	draw.Draw(img, qrRect, qrImage, qrImage.Bounds().Min, draw.Src)
+
+	// La copia refleja la posición del QR respecto del centro del lienzo
+	var copyRect image.Rectangle
+	if duplicate != "" {
+		copyAt := qrAt
+		if duplicate != "vertical" {
+			copyAt.X = canvas.X - side - qrAt.X
+		}
+		if duplicate != "horizontal" {
+			copyAt.Y = canvas.Y - side - qrAt.Y
+		}
+		copyRect = image.Rectangle{Min: copyAt, Max: copyAt.Add(image.Pt(side, side))}
+		if copyRect.Overlaps(qrRect) {
+			return nil, i18n.Errorf("%w: the two copies of the %dpx QR overlap in the %dx%d overlay; lower -size", ErrInvalidInput, side, canvas.X, canvas.Y)
+		}
+		draw.Draw(img, copyRect, qrImage, qrImage.Bounds().Min, draw.Src)
+	}
+	if caption == "" {
+		return img, nil
+	}
+
+	parsed, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, i18n.Errorf("%w: error loading the caption font: %w", ErrEncode, err)
+	}
+	// El texto mide un sexto del lado del QR
+	fontSize := float64(side) / 6
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: fontSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, i18n.Errorf("%w: error loading the caption font: %w", ErrEncode, err)
+	}
+	defer face.Close()
+
+	metrics := face.Metrics()
+	padding := int(fontSize / 2)
+	textWidth := font.MeasureString(face, caption).Ceil()
+	plate := image.Rect(0, 0, textWidth+2*padding, (metrics.Ascent+metrics.Descent).Ceil()+2*padding)
+	gap := padding
+	if plate.Dx() > canvas.X-2*marginX-side-gap {
+		return nil, i18n.Errorf("%w: the caption is too wide for the overlay; shorten it or lower -size", ErrInvalidInput)
+	}
+
+	// La placa queda al ras del borde exterior del QR, del lado de adentro
+	plateAt := image.Pt(qrAt.X-gap-plate.Dx(), qrAt.Y+side-plate.Dy())
+	if left {
+		plateAt.X = qrAt.X + side + gap
+	}
+	if top {
+		plateAt.Y = qrAt.Y
+	}
+	plate = plate.Add(plateAt)
+	if plate.Overlaps(copyRect) {
+		return nil, i18n.Errorf("%w: the caption would cover the copy of the QR; shorten it, lower -size or use another --duplicate", ErrInvalidInput)
+	}
+	draw.Draw(img, plate, image.NewUniform(color.NRGBA{A: 0xb0}), image.Point{}, draw.Over)
+
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot:  fixed.P(plate.Min.X+padding, plate.Min.Y+padding+metrics.Ascent.Ceil()),
+	}
+	drawer.DrawString(caption)
+	return img, nil
+}
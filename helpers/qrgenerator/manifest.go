@@ -0,0 +1,203 @@
+package qrgenerator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc32"
+	"html"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// manifestKeyword es la clave del chunk tEXt de PNG y el id del elemento
+// metadata de SVG que guardan el manifiesto
+const manifestKeyword = "qrgenerator-manifest"
+
+// Manifest describe cómo se generó un archivo: con qué herramienta, qué
+// payload (solo su hash), qué opciones y qué símbolo resultó. Se embebe en
+// los PNG y SVG con ExtraParams "manifest" (el nombre y la versión de la
+// herramienta) para rastrear un archivo encontrado suelto hasta su origen.
+type Manifest struct {
+	Tool          string            `json:"tool"`
+	PayloadSHA256 string            `json:"payload_sha256"`
+	Format        OutputFormat      `json:"format"`
+	Size          int               `json:"size"`
+	Options       map[string]string `json:"options,omitempty"`
+	Version       int               `json:"version"`
+	Level         string            `json:"level"`
+	Mask          int               `json:"mask"`
+	Modules       string            `json:"modules"` // Módulos sin zona de silencio, un bit por módulo por filas, en base64
+}
+
+// manifestFormats son los formatos que pueden llevar el manifiesto
+var manifestFormats = []OutputFormat{FormatPNG, FormatSVG}
+
+// newManifest arma el manifiesto de una salida. bitmap incluye la zona de silencio.
+func newManifest(config QRConfig, result *QRResult, bitmap [][]bool) []byte {
+	options := maps.Clone(config.ExtraParams)
+	delete(options, "manifest")
+	if len(options) == 0 {
+		options = nil
+	}
+	sum := sha256.Sum256([]byte(config.URL))
+
+	border, _ := borderFor(config)
+	symbol := bitmap[border : len(bitmap)-border]
+	packed := make([]byte, (len(symbol)*len(symbol)+7)/8)
+	for y, row := range symbol {
+		for x, dark := range row[border : len(row)-border] {
+			if i := y*len(symbol) + x; dark {
+				packed[i/8] |= 0x80 >> (i % 8)
+			}
+		}
+	}
+
+	manifest := Manifest{
+		Tool:          config.ExtraParams["manifest"],
+		PayloadSHA256: hex.EncodeToString(sum[:]),
+		Format:        config.Format,
+		Size:          config.Size,
+		Options:       options,
+		Version:       result.Version,
+		Level:         result.Level,
+		Mask:          result.Mask,
+		Modules:       base64.StdEncoding.EncodeToString(packed),
+	}
+	data, _ := json.Marshal(manifest)
+	return asciiJSON(data)
+}
+
+// Symbol devuelve los módulos guardados en el manifiesto, sin zona de silencio
+func (m *Manifest) Symbol() ([][]bool, error) {
+	packed, err := base64.StdEncoding.DecodeString(m.Modules)
+	size := 17 + 4*m.Version
+	if err != nil || m.Version < 1 || m.Version > 40 || len(packed) != (size*size+7)/8 {
+		return nil, i18n.Errorf("%w: the manifest has no valid module matrix", ErrInvalidInput)
+	}
+	symbol := make([][]bool, size)
+	for y := range symbol {
+		symbol[y] = make([]bool, size)
+		for x := range symbol[y] {
+			i := y*size + x
+			symbol[y][x] = packed[i/8]&(0x80>>(i%8)) != 0
+		}
+	}
+	return symbol, nil
+}
+
+// ReadManifest lee el manifiesto embebido en un PNG o un SVG; devuelve nil
+// sin error si el archivo no tiene uno
+func ReadManifest(data []byte) (*Manifest, error) {
+	var raw []byte
+	switch {
+	case bytes.HasPrefix(data, pngSignature):
+		raw = pngText(data, manifestKeyword)
+	default:
+		open := []byte(`<metadata id="` + manifestKeyword + `">`)
+		if start := bytes.Index(data, open); start >= 0 {
+			rest := data[start+len(open):]
+			if end := bytes.Index(rest, []byte("</metadata>")); end >= 0 {
+				raw = []byte(html.UnescapeString(string(rest[:end])))
+			}
+		}
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, i18n.Errorf("%w: the embedded manifest is damaged: %w", ErrInvalidInput, err)
+	}
+	return manifest, nil
+}
+
+// asciiJSON escapa los caracteres no ASCII como \uXXXX: tEXt de PNG es Latin-1
+func asciiJSON(data []byte) []byte {
+	var out strings.Builder
+	for _, r := range string(data) {
+		switch {
+		case r < 0x80:
+			out.WriteRune(r)
+		case r > 0xffff:
+			// Fuera del plano básico JSON usa un par sustituto
+			r -= 0x10000
+			out.WriteString(`\u` + strconv.FormatInt(int64(0xd800+(r>>10)), 16) + `\u` + strconv.FormatInt(int64(0xdc00+(r&0x3ff)), 16))
+		default:
+			out.WriteString(`\u` + strconv.FormatInt(0x10000+int64(r), 16)[1:])
+		}
+	}
+	return []byte(out.String())
+}
+
+// pngSignature son los primeros bytes de todo PNG
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngChunkWriter agrega chunks después de IHDR, el primero del PNG, mientras
+// el encoder escribe: las imágenes grandes no se arman en memoria. Cada chunk
+// es el tipo seguido de los datos, como el tEXt del manifiesto o el pHYs de
+// la resolución.
+type pngChunkWriter struct {
+	w       io.Writer
+	chunks  [][]byte
+	written int
+}
+
+// pngTextChunk arma un chunk tEXt con esa clave
+func pngTextChunk(keyword string, text []byte) []byte {
+	return append([]byte("tEXt"+keyword+"\x00"), text...)
+}
+
+// pngHeaderLen es el largo de la firma y el chunk IHDR (largo, tipo, datos y CRC)
+const pngHeaderLen = 8 + 4 + 4 + 13 + 4
+
+func (p *pngChunkWriter) Write(data []byte) (int, error) {
+	if p.written >= pngHeaderLen {
+		return p.w.Write(data)
+	}
+	head := min(len(data), pngHeaderLen-p.written)
+	n, err := p.w.Write(data[:head])
+	p.written += n
+	if err != nil || p.written < pngHeaderLen {
+		return n, err
+	}
+
+	for _, body := range p.chunks {
+		chunk := binary.BigEndian.AppendUint32(nil, uint32(len(body)-4))
+		chunk = append(chunk, body...)
+		chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(body))
+		if _, err := p.w.Write(chunk); err != nil {
+			return n, err
+		}
+	}
+	rest, err := p.w.Write(data[head:])
+	return n + rest, err
+}
+
+// pngText devuelve el texto del chunk tEXt con esa clave, o nil
+func pngText(data []byte, keyword string) []byte {
+	for pos := len(pngSignature); pos+12 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		if length < 0 || pos+12+length > len(data) {
+			return nil
+		}
+		kind, body := string(data[pos+4:pos+8]), data[pos+8:pos+8+length]
+		if kind == "tEXt" {
+			if key, text, ok := bytes.Cut(body, []byte{0}); ok && string(key) == keyword {
+				return text
+			}
+		}
+		if kind == "IEND" {
+			return nil
+		}
+		pos += 12 + length
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package qrgenerator
+
+import (
+	"bytes"
+	"image"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// FormatMatrix exporta la matriz de módulos en lugar de una imagen, para
+// alimentar directamente el firmware de pantallas e-ink o LED
+const FormatMatrix OutputFormat = "matrix"
+
+// Codificaciones de la matriz
+const (
+	MatrixJSON = "json" // Objeto con versión, nivel y filas de booleanos
+	MatrixPBM  = "pbm"  // Netpbm bitonal en texto (P1)
+	MatrixBits = "bits" // Una línea de 0 y 1 por fila
+)
+
+// pbmLineLength es el largo máximo de línea que admite PBM en texto
+const pbmLineLength = 70
+
+// matrixGenerator lo implementan los formatos que se escriben a partir de la
+// matriz de módulos (incluida la zona de silencio) y no de la imagen
+type matrixGenerator interface {
+	GenerateMatrix(bitmap [][]bool, config QRConfig) error
+}
+
+type moduleMatrixGenerator struct{}
+
+// Generate implementa QRGenerator para las imágenes que conservan sus módulos
+func (g *moduleMatrixGenerator) Generate(qrImage image.Image, config QRConfig) error {
+	modules, ok := qrImage.(*moduleImage)
+	if !ok {
+		return i18n.Errorf("%w: the module matrix is not available for this image", ErrEncode)
+	}
+	return g.GenerateMatrix(modules.bitmap, config)
+}
+
+// GenerateMatrix escribe los módulos del símbolo, sin la zona de silencio, en
+// la codificación elegida (ver matrixEncoding)
+func (g *moduleMatrixGenerator) GenerateMatrix(bitmap [][]bool, config QRConfig) error {
+	content, err := matrixContent(bitmap, config)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(config.OutputPath)
+	if err != nil {
+		return i18n.Errorf("%w: error creating matrix file: %w", ErrIO, err)
+	}
+	defer f.Close()
+	return writeOutput(f, content)
+}
+
+// Matrix valida el contenido y devuelve la matriz de módulos en la
+// codificación indicada, sin escribir archivos (para publicarla, por ejemplo)
+func Matrix(config QRConfig, encoding string) ([]byte, error) {
+	config.Format = ""
+	for _, problem := range Validate(config) {
+		if !problem.Warning {
+			return nil, problem.Err
+		}
+	}
+	config.ExtraParams = map[string]string{"matrix": encoding}
+	_, bitmap, err := generateQRImage(config, &QRResult{})
+	if err != nil {
+		return nil, err
+	}
+	return matrixContent(bitmap, config)
+}
+
+// matrixContent codifica los módulos del símbolo, sin la zona de silencio
+func matrixContent(bitmap [][]bool, config QRConfig) ([]byte, error) {
+	encoding, err := matrixEncoding(config)
+	if err != nil {
+		return nil, err
+	}
+
+	border, err := borderFor(config)
+	if err != nil {
+		return nil, err
+	}
+	symbol := make([][]bool, 0, len(bitmap)-2*border)
+	for _, row := range bitmap[border : len(bitmap)-border] {
+		symbol = append(symbol, row[border:len(row)-border])
+	}
+	info := readSymbolInfo(config, bitmap, border)
+
+	var content []byte
+	switch encoding {
+	case MatrixJSON:
+		content = matrixJSON(symbol, info)
+	case MatrixPBM:
+		content = matrixPBM(symbol, info)
+	case MatrixC:
+		content = matrixC(symbol, info, config.OutputPath)
+	case MatrixRust:
+		content = matrixRust(symbol, info, config.OutputPath)
+	default:
+		content = matrixBits(symbol)
+	}
+	return content, nil
+}
+
+// matrixEncoding lee la codificación de ExtraParams ("matrix": json, pbm,
+// bits, c o rust); sin ella se elige por la extensión de la salida
+func matrixEncoding(config QRConfig) (string, error) {
+	encoding := strings.ToLower(config.ExtraParams["matrix"])
+	switch encoding {
+	case MatrixJSON, MatrixPBM, MatrixBits, MatrixC, MatrixRust:
+		return encoding, nil
+	case "":
+	default:
+		return "", i18n.Errorf("%w: unsupported matrix encoding: %s (json, pbm, bits, c or rust)", ErrInvalidInput, encoding)
+	}
+
+	switch strings.ToLower(filepath.Ext(config.OutputPath)) {
+	case ".json":
+		return MatrixJSON, nil
+	case ".pbm":
+		return MatrixPBM, nil
+	case ".h", ".c":
+		return MatrixC, nil
+	case ".rs":
+		return MatrixRust, nil
+	}
+	return MatrixBits, nil
+}
+
+// matrixJSON escribe una fila de booleanos por línea, más legible que el
+// indentado de encoding/json para matrices de hasta 177 columnas. Los rMQR
+// llevan la forma, el ancho y el alto en lugar de la versión y el lado, y
+// los Data Matrix y los Aztec además la simbología y no el nivel; los PDF417,
+// la simbología y el nivel de seguridad, los Code 128 y los Code 39 la
+// simbología y los caracteres, y los códigos de productos la simbología y el
+// número.
+func matrixJSON(symbol [][]bool, info symbolInfo) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	if info.symbol != "qr" && info.symbol != "rmqr" {
+		buf.WriteString(`  "symbol": "` + info.symbol + "\",\n")
+	}
+	switch {
+	case info.shape != "":
+		buf.WriteString(`  "shape": "` + info.shape + "\",\n")
+	case info.symbol == "code128" || info.symbol == "code39":
+		buf.WriteString(`  "characters": ` + strconv.Itoa(info.version) + ",\n")
+	case info.number != "":
+		buf.WriteString(`  "number": "` + info.number + "\",\n")
+	default:
+		buf.WriteString(`  "version": ` + strconv.Itoa(info.version) + ",\n")
+	}
+	if info.level != "" {
+		buf.WriteString(`  "level": "` + info.level + "\",\n")
+	}
+	if info.symbol != "qr" {
+		buf.WriteString(`  "width": ` + strconv.Itoa(len(symbol[0])) + ",\n")
+		buf.WriteString(`  "height": ` + strconv.Itoa(len(symbol)) + ",\n")
+	} else {
+		buf.WriteString(`  "size": ` + strconv.Itoa(len(symbol)) + ",\n")
+	}
+	buf.WriteString(`  "quiet_zone": ` + strconv.Itoa(info.border) + ",\n")
+	buf.WriteString(`  "modules": [` + "\n")
+	for y, row := range symbol {
+		buf.WriteString("    [")
+		for x, dark := range row {
+			if x > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.FormatBool(dark))
+		}
+		buf.WriteByte(']')
+		if y < len(symbol)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("  ]\n}\n")
+	return buf.Bytes()
+}
+
+// matrixPBM escribe un PBM en texto (P1) con un píxel por módulo; las filas
+// largas se parten para respetar el largo de línea del formato
+func matrixPBM(symbol [][]bool, info symbolInfo) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("P1\n")
+	buf.WriteString("# " + info.describe() + "\n")
+	buf.WriteString(strconv.Itoa(len(symbol[0])) + " " + strconv.Itoa(len(symbol)) + "\n")
+	for _, row := range symbol {
+		for x, dark := range row {
+			if x > 0 && x%pbmLineLength == 0 {
+				buf.WriteByte('\n')
+			}
+			buf.WriteByte(bit(dark))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// matrixBits escribe una línea de 0 y 1 por fila, 1 para los módulos oscuros
+func matrixBits(symbol [][]bool) []byte {
+	buf := make([]byte, 0, len(symbol)*(len(symbol[0])+1))
+	for _, row := range symbol {
+		for _, dark := range row {
+			buf = append(buf, bit(dark))
+		}
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// bit devuelve el carácter de un módulo: '1' si es oscuro
+func bit(dark bool) byte {
+	if dark {
+		return '1'
+	}
+	return '0'
+}
@@ -0,0 +1,82 @@
+package qrgenerator
+
+import (
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// Modes son los valores de ExtraParams "mode"
+func Modes() []string {
+	return []string{"auto", "numeric", "alphanumeric", "byte", "kanji"}
+}
+
+// forcedModes son los modos que se pueden pedir, con su indicador de qrcodec
+var forcedModes = map[string]qrcodec.Mode{
+	"numeric":      qrcodec.ModeNumeric,
+	"alphanumeric": qrcodec.ModeAlphanumeric,
+	"byte":         qrcodec.ModeByte,
+	"kanji":        qrcodec.ModeKanji,
+}
+
+// modeFor lee el modo de codificación de ExtraParams ("mode"); sin él se usa auto
+func modeFor(config QRConfig) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(config.ExtraParams["mode"]))
+	if mode == "" || mode == "auto" {
+		return "auto", nil
+	}
+	if _, ok := forcedModes[mode]; !ok {
+		return "", i18n.Errorf("%w: unknown mode %s (%s)", ErrInvalidInput, config.ExtraParams["mode"], strings.Join(Modes(), ", "))
+	}
+	return mode, nil
+}
+
+// textSegments elige los segmentos del contenido según el modo. Con auto, el
+// texto japonés que Shift-JIS puede escribir va en modo kanji, a 13 bits por
+// carácter en lugar de los 24 de UTF-8, y para el resto devuelve nil: lo
+// codifica go-qrcode, que parte el texto en tramos de distinto modo. Un modo
+// fijo pone todo el contenido en un solo segmento de ese modo y falla si
+// algún carácter no lo admite; en modo byte los lectores reciben el UTF-8
+// entero, sin tramos numéricos en medio de un emoji. Con --charset el
+// contenido va convertido a ese juego de caracteres.
+func textSegments(config QRConfig, content string) ([]qrcodec.Segment, error) {
+	mode, err := modeFor(config)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := charsetFor(config)
+	if err != nil {
+		return nil, err
+	}
+	data := []byte(content)
+	if cs != nil {
+		if data, err = cs.encode(content); err != nil {
+			return nil, err
+		}
+	}
+
+	switch mode {
+	case "auto":
+		if cs != nil {
+			return []qrcodec.Segment{{Mode: qrcodec.ModeFor(string(data)), Data: data}}, nil
+		}
+		segments, _ := qrcodec.KanjiSegments(content)
+		return segments, nil
+	case "kanji":
+		data, r, ok := qrcodec.KanjiData(content)
+		if !ok {
+			return nil, i18n.Errorf("%w: --mode kanji: %q has no Shift-JIS kanji code", ErrInvalidInput, r)
+		}
+		return []qrcodec.Segment{{Mode: qrcodec.ModeKanji, Data: data}}, nil
+	case "numeric":
+		if i := strings.IndexFunc(content, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			return nil, i18n.Errorf("%w: --mode numeric: %q is not a digit", ErrInvalidInput, []rune(content[i:])[0])
+		}
+	case "alphanumeric":
+		if i := strings.IndexFunc(content, func(r rune) bool { return qrcodec.ModeFor(string(r)) == qrcodec.ModeByte }); i >= 0 {
+			return nil, i18n.Errorf("%w: --mode alphanumeric: %q is not allowed; the mode only has 0-9, A-Z, space and $%%*+-./:", ErrInvalidInput, []rune(content[i:])[0])
+		}
+	}
+	return []qrcodec.Segment{{Mode: forcedModes[mode], Data: data}}, nil
+}
@@ -0,0 +1,142 @@
+package qrgenerator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// moduleShape es la forma con la que se dibuja cada módulo oscuro fuera de
+// los patrones de búsqueda (ExtraParams "module-style"), que tienen la suya
+// (ver eyeShape): los lectores buscan en ellos las proporciones 1:1:3:1:1
+// de barras continuas, que los puntos y los rombos cortarían
+type moduleShape string
+
+const (
+	shapeSquare  moduleShape = "square"  // Cuadrados que se tocan, la forma del estándar
+	shapeRounded moduleShape = "rounded" // Esquinas redondeadas salvo donde sigue otro módulo
+	shapeDots    moduleShape = "dots"    // Un círculo por módulo
+	shapeDiamond moduleShape = "diamond" // Un rombo por módulo
+)
+
+// moduleShapes son las formas en el orden de la ayuda
+var moduleShapes = []moduleShape{shapeSquare, shapeRounded, shapeDots, shapeDiamond}
+
+// styleFormats son los formatos que dibujan los módulos y los patrones de
+// búsqueda con forma: los de mapa de bits y SVG; CSS y PDF dibujan cuadrados
+var styleFormats = []OutputFormat{FormatPNG, FormatJPEG, FormatAVIF, FormatHEIF, FormatTIFF, FormatSVG}
+
+// moduleShapeFor lee ExtraParams "module-style"; sin él, cuadrados
+func moduleShapeFor(config QRConfig) (moduleShape, error) {
+	value := moduleShape(strings.ToLower(strings.TrimSpace(config.ExtraParams["module-style"])))
+	if value == "" {
+		return shapeSquare, nil
+	}
+	for _, shape := range moduleShapes {
+		if shape == value {
+			return shape, nil
+		}
+	}
+	names := make([]string, len(moduleShapes))
+	for i, shape := range moduleShapes {
+		names[i] = string(shape)
+	}
+	return "", i18n.Errorf("%w: unknown module style %s (%s)", ErrInvalidInput, value, strings.Join(names, ", "))
+}
+
+// isDarkAt indica si el módulo (row, col) es oscuro; fuera de la matriz es claro
+func isDarkAt(bitmap [][]bool, row, col int) bool {
+	return row >= 0 && row < len(bitmap) && col >= 0 && col < len(bitmap[row]) && bitmap[row][col]
+}
+
+// covers indica si el punto (u, v) del módulo oscuro (row, col), medido de
+// 0 a 1 desde su esquina de arriba a la izquierda, cae dentro de la forma
+func (s moduleShape) covers(bitmap [][]bool, row, col int, u, v float64) bool {
+	du, dv := u-0.5, v-0.5
+	switch s {
+	case shapeDots:
+		return du*du+dv*dv <= 0.25
+	case shapeDiamond:
+		return math.Abs(du)+math.Abs(dv) <= 0.5
+	case shapeRounded:
+		// Cada esquina se redondea solo si los dos módulos vecinos de ese
+		// lado son claros, así que los módulos seguidos forman una sola pieza
+		side, edge := col+1, row+1
+		if du < 0 {
+			side = col - 1
+		}
+		if dv < 0 {
+			edge = row - 1
+		}
+		if isDarkAt(bitmap, row, side) || isDarkAt(bitmap, edge, col) {
+			return true
+		}
+		return du*du+dv*dv <= 0.25
+	}
+	return true
+}
+
+// svg escribe la forma del módulo oscuro (row, col), de un lado de 1, sin
+// cerrar: el que llama agrega el relleno y "/>"
+func (s moduleShape) svg(buf []byte, bitmap [][]bool, row, col int) []byte {
+	x, y := float64(col), float64(row)
+	num := func(buf []byte, v float64) []byte {
+		return strconv.AppendFloat(buf, v, 'f', -1, 64)
+	}
+	point := func(buf []byte, px, py float64) []byte {
+		buf = num(buf, px)
+		buf = append(buf, ',')
+		return num(buf, py)
+	}
+	switch s {
+	case shapeDots:
+		buf = append(buf, `<circle cx="`...)
+		buf = num(buf, x+0.5)
+		buf = append(buf, `" cy="`...)
+		buf = num(buf, y+0.5)
+		return append(buf, `" r="0.5"`...)
+	case shapeDiamond:
+		buf = append(buf, `<polygon points="`...)
+		buf = point(buf, x+0.5, y)
+		buf = append(buf, ' ')
+		buf = point(buf, x+1, y+0.5)
+		buf = append(buf, ' ')
+		buf = point(buf, x+0.5, y+1)
+		buf = append(buf, ' ')
+		buf = point(buf, x, y+0.5)
+		return append(buf, '"')
+	case shapeRounded:
+		// Desde el centro del lado de arriba, en el sentido de las agujas del
+		// reloj: cada esquina es un arco o las dos rectas hasta el siguiente
+		// centro de lado, con el mismo criterio que covers
+		corners := []struct {
+			round                          bool
+			cornerX, cornerY, nextX, nextY float64
+		}{
+			{!isDarkAt(bitmap, row-1, col) && !isDarkAt(bitmap, row, col+1), x + 1, y, x + 1, y + 0.5},
+			{!isDarkAt(bitmap, row+1, col) && !isDarkAt(bitmap, row, col+1), x + 1, y + 1, x + 0.5, y + 1},
+			{!isDarkAt(bitmap, row+1, col) && !isDarkAt(bitmap, row, col-1), x, y + 1, x, y + 0.5},
+			{!isDarkAt(bitmap, row-1, col) && !isDarkAt(bitmap, row, col-1), x, y, x + 0.5, y},
+		}
+		buf = append(buf, `<path d="M`...)
+		buf = point(buf, x+0.5, y)
+		for _, c := range corners {
+			if c.round {
+				buf = append(buf, "A0.5,0.5 0 0 1 "...)
+			} else {
+				buf = append(buf, 'L')
+				buf = point(buf, c.cornerX, c.cornerY)
+				buf = append(buf, 'L')
+			}
+			buf = point(buf, c.nextX, c.nextY)
+		}
+		return append(buf, `Z"`...)
+	}
+	buf = append(buf, `<rect x="`...)
+	buf = num(buf, x)
+	buf = append(buf, `" y="`...)
+	buf = num(buf, y)
+	return append(buf, `" width="1" height="1"`...)
+}
@@ -0,0 +1,70 @@
+package qrgenerator
+
+import (
+	"image"
+	"os"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/pdf"
+)
+
+// FormatPDF es un PDF de una página del tamaño del código, para imprenta:
+// los módulos se dibujan sin interpolar y quedan nítidos a cualquier escala
+const FormatPDF OutputFormat = "pdf"
+
+type pdfGenerator struct{}
+
+// Generate implementa QRGenerator para las imágenes que conservan sus módulos
+func (g *pdfGenerator) Generate(qrImage image.Image, config QRConfig) error {
+	modules, ok := qrImage.(*moduleImage)
+	if !ok {
+		return i18n.Errorf("%w: the module matrix is not available for this image", ErrEncode)
+	}
+	return g.GenerateMatrix(modules.bitmap, config)
+}
+
+// GenerateMatrix escribe el símbolo con la zona de silencio. La página mide
+// el ancho de la imagen (ver imageWidth) a --dpi, por defecto
+// DefaultPrintDPI como --scan-distance; en los rMQR -size es el ancho. El
+// texto legible de los códigos lineales va en Helvetica debajo de las barras,
+// del color de los módulos.
+func (g *pdfGenerator) GenerateMatrix(bitmap [][]bool, config QRConfig) error {
+	dpi, err := printDPI(config)
+	if err != nil {
+		return err
+	}
+	dark, light, err := symbolColors(config)
+	if err != nil {
+		return err
+	}
+	side := float64(imageWidth(config, len(bitmap[0]))) / float64(dpi) * 72
+	unit := side / float64(len(bitmap[0]))
+	symbolHeight := unit * float64(len(bitmap))
+	height := symbolHeight
+	if config.caption != nil {
+		height = unit * config.caption.height
+	}
+	var doc pdf.Document
+	page := doc.AddPageSize(side, height)
+	if !isTransparent(light) {
+		page.SetFill(light)
+		page.Rect(0, 0, side, height)
+	}
+	page.SetFill(dark)
+	page.Bitmap(0, height-symbolHeight, side, bitmap)
+	if c := config.caption; c != nil {
+		for _, line := range c.texts {
+			// Centrado con el ancho medio de un carácter, como pdf.Wrap
+			size := line.size * unit
+			width := 0.55 * size * float64(len([]rune(line.text)))
+			page.Text(line.x*unit-width/2, height-line.baseline*unit, size, false, line.text)
+		}
+	}
+
+	f, err := os.Create(config.OutputPath)
+	if err != nil {
+		return i18n.Errorf("%w: error creating PDF file: %w", ErrIO, err)
+	}
+	defer f.Close()
+	return writeOutput(f, doc.Bytes())
+}
@@ -0,0 +1,120 @@
+package qrgenerator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/pdf417"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// MaxECRatio es la corrección más alta que admite --ec-ratio, en % de las
+// codewords de datos
+const MaxECRatio = 400
+
+// isPDF417 indica si la configuración pide un PDF417
+func isPDF417(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "pdf417"
+}
+
+// pdf417Options lee las columnas (ExtraParams "columns"), las filas ("rows")
+// y la corrección mínima ("ec-ratio", como 25 o 25%); las que faltan las
+// elige el codificador
+func pdf417Options(config QRConfig) (pdf417.Options, error) {
+	var opts pdf417.Options
+	if value := strings.TrimSpace(config.ExtraParams["columns"]); value != "" {
+		columns, err := strconv.Atoi(value)
+		if err != nil || columns < 1 || columns > pdf417.MaxColumns {
+			return opts, i18n.Errorf("%w: invalid PDF417 columns %s (1 to %d)", ErrInvalidInput, value, pdf417.MaxColumns)
+		}
+		opts.Columns = columns
+	}
+	if value := strings.TrimSpace(config.ExtraParams["rows"]); value != "" {
+		rows, err := strconv.Atoi(value)
+		if err != nil || rows < pdf417.MinRows || rows > pdf417.MaxRows {
+			return opts, i18n.Errorf("%w: invalid PDF417 rows %s (%d to %d)", ErrInvalidInput, value, pdf417.MinRows, pdf417.MaxRows)
+		}
+		opts.Rows = rows
+	}
+	if opts.Columns*opts.Rows > pdf417.MaxCodewords {
+		return opts, i18n.Errorf("%w: a PDF417 of %d columns and %d rows exceeds the %d codewords of the standard", ErrInvalidInput, opts.Columns, opts.Rows, pdf417.MaxCodewords)
+	}
+	if value := strings.TrimSpace(config.ExtraParams["ec-ratio"]); value != "" {
+		ratio, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || ratio < 1 || ratio > MaxECRatio {
+			return opts, i18n.Errorf("%w: invalid EC ratio %s (1%% to %d%% of the data codewords)", ErrInvalidInput, value, MaxECRatio)
+		}
+		opts.ECRatio = ratio
+	}
+	return opts, nil
+}
+
+// pdf417Input devuelve los bytes a codificar y las opciones: los textos con
+// --charset van convertidos y con su ECI
+func pdf417Input(config QRConfig, content string) ([]byte, pdf417.Options, error) {
+	opts, err := pdf417Options(config)
+	if err != nil {
+		return nil, opts, err
+	}
+	data := []byte(content)
+	if config.ExtraParams["binary"] == "true" {
+		return data, opts, nil
+	}
+	cs, err := charsetFor(config)
+	if err != nil || cs == nil {
+		return data, opts, err
+	}
+	if data, err = cs.encode(content); err != nil {
+		return nil, opts, err
+	}
+	opts.ECI = cs.eci
+	return data, opts, nil
+}
+
+// pdf417Symbol codifica content en un PDF417 (ISO/IEC 15438), el de los
+// documentos de identidad y las etiquetas de envío, con las columnas, las
+// filas y la corrección pedidas. Si no entra devuelve qrcodec.ErrDataTooLong
+// (ver capacityError).
+func pdf417Symbol(config QRConfig, content string) (encodedSymbol, error) {
+	data, opts, err := pdf417Input(config, content)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	symbol, err := pdf417.Encode(data, opts)
+	if errors.Is(err, pdf417.ErrDataTooLong) {
+		return encodedSymbol{}, qrcodec.ErrDataTooLong
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating PDF417: %w", ErrEncode, err)
+	}
+	return encodedSymbol{bitmap: withMatte(symbol.Matrix, qrBorder), version: symbol.Size.Columns}, nil
+}
+
+// pdf417CapacityError arma el error de un contenido que no entra en el
+// PDF417 más grande que admite la configuración
+func pdf417CapacityError(config QRConfig, content string, level qrcodec.Level) *CapacityError {
+	data, opts, _ := pdf417Input(config, content)
+	codewords := pdf417.Codewords(data, opts)
+	e := &CapacityError{Size: len(data), Mode: qrcodec.ModeByte, Codewords: codewords, Security: opts.Level(codewords), Max: pdf417.Capacity(codewords, opts)}
+
+	// Con las columnas o las filas fijas, dejar que las elija el codificador
+	if opts.Columns > 0 || opts.Rows > 0 {
+		free := pdf417.Options{ECRatio: opts.ECRatio, ECI: opts.ECI}
+		if max := pdf417.Capacity(codewords, free); codewords <= max {
+			e.Suggestions = append(e.Suggestions, i18n.Sprintf("dropping --columns and --rows (holds %d codewords)", max))
+		}
+	}
+	mode, size := payloadMode(config, content)
+	if max := qrcodec.VersionCapacity(mode, level, 40); size <= max {
+		e.Suggestions = append(e.Suggestions, i18n.Sprintf("--symbol qr (holds %d)", max))
+	}
+	if payloadLooksLikeURL(content) {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter URL (a redirect from your own domain)"))
+	} else {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter payload"))
+	}
+	return e
+}
@@ -0,0 +1,199 @@
+package qrgenerator
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// DistancePerModule es cuántas veces el ancho de un módulo puede medir la
+// distancia de escaneo: a 3 m un módulo necesita 1 cm. Con un código chico
+// (versión 2 y la zona de silencio, 33 módulos) es la regla habitual de 10:1,
+// un código de 30 cm para escanear desde 3 m.
+const DistancePerModule = 300
+
+// DefaultPrintDPI es la resolución de impresión que se supone si no se indica otra
+const DefaultPrintDPI = 300
+
+// MaxPrintDPI es la resolución más alta aceptada
+const MaxPrintDPI = 9600
+
+// MaxScale es la escala más grande aceptada, en píxeles por módulo
+const MaxScale = 100
+
+// printDPI lee la resolución de impresión de ExtraParams ("dpi"); sin ella
+// es DefaultPrintDPI
+func printDPI(config QRConfig) (int, error) {
+	value := strings.TrimSpace(config.ExtraParams["dpi"])
+	if value == "" {
+		return DefaultPrintDPI, nil
+	}
+	dpi, err := strconv.Atoi(value)
+	if err != nil || dpi < 1 || dpi > MaxPrintDPI {
+		return 0, i18n.Errorf("%w: invalid DPI %s (1 to %d)", ErrInvalidInput, value, MaxPrintDPI)
+	}
+	return dpi, nil
+}
+
+// printWidth lee el ancho impreso de ExtraParams ("width"), en milímetros;
+// sin él es 0 y el ancho sale de -size o --scale
+func printWidth(config QRConfig) (float64, error) {
+	value := strings.TrimSpace(config.ExtraParams["width"])
+	if value == "" {
+		return 0, nil
+	}
+	mm, err := ParseLength(value)
+	if err != nil {
+		return 0, i18n.Errorf("%w: --width %s is not a length; use a positive number with mm, cm, m, in or ft", ErrInvalidInput, value)
+	}
+	return mm, nil
+}
+
+// resolutionFor es la resolución que se declara en los archivos: la de
+// --dpi, o DefaultPrintDPI con --width; 0 sin ninguno de los dos, y los
+// archivos quedan como antes
+func resolutionFor(config QRConfig) int {
+	if config.ExtraParams["dpi"] == "" && config.ExtraParams["width"] == "" {
+		return 0
+	}
+	dpi, _ := printDPI(config)
+	return dpi
+}
+
+// pngPhysChunk arma el chunk pHYs de un PNG: la resolución en píxeles por metro
+func pngPhysChunk(dpi int) []byte {
+	perMeter := uint32(math.Round(float64(dpi) / 0.0254))
+	chunk := binary.BigEndian.AppendUint32([]byte("pHYs"), perMeter)
+	chunk = binary.BigEndian.AppendUint32(chunk, perMeter)
+	return append(chunk, 1) // Unidad: metro
+}
+
+// scaleFor lee la escala de ExtraParams ("scale"), en píxeles por módulo;
+// sin ella es 0 y el ancho sale de -size
+func scaleFor(config QRConfig) (int, error) {
+	value := strings.TrimSpace(config.ExtraParams["scale"])
+	if value == "" {
+		return 0, nil
+	}
+	scale, err := strconv.Atoi(value)
+	if err != nil || scale < 1 || scale > MaxScale {
+		return 0, i18n.Errorf("%w: invalid scale %s (1 to %d pixels per module)", ErrInvalidInput, value, MaxScale)
+	}
+	return scale, nil
+}
+
+// imageWidth es el ancho en píxeles de un símbolo de modules módulos de ancho,
+// con la zona de silencio: con --scale un número entero de píxeles por
+// módulo, para que los bordes salgan nítidos, con --width los píxeles que
+// mide impreso a --dpi, y si no -size; en los códigos lineales, -size
+// redondeado hacia arriba a un número entero de píxeles por módulo, porque
+// los lectores miden el ancho de cada barra
+func imageWidth(config QRConfig, modules int) int {
+	if scale, _ := scaleFor(config); scale > 0 {
+		return scale * modules
+	}
+	if mm, _ := printWidth(config); mm > 0 {
+		dpi, _ := printDPI(config)
+		return int(math.Round(mm / 25.4 * float64(dpi)))
+	}
+	if isLinear(config) {
+		return (config.Size + modules - 1) / modules * modules
+	}
+	return config.Size
+}
+
+// lengthUnits son las unidades aceptadas, en milímetros
+var lengthUnits = map[string]float64{"mm": 1, "cm": 10, "m": 1000, "in": 25.4, "ft": 304.8}
+
+// ParseLength lee una longitud con unidad (30mm, 2.5cm, 3m, 1.5in, 10ft) y
+// la devuelve en milímetros
+func ParseLength(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	number, unit := value, ""
+	if i := strings.IndexFunc(value, func(r rune) bool { return r >= 'a' && r <= 'z' }); i >= 0 {
+		number, unit = strings.TrimSpace(value[:i]), value[i:]
+	}
+	mm, err := strconv.ParseFloat(number, 64)
+	perUnit, ok := lengthUnits[unit]
+	if err != nil || !ok || mm <= 0 || math.IsInf(mm, 0) {
+		return 0, i18n.Errorf("%w: %q is not a length; use a positive number with mm, cm, m, in or ft", ErrInvalidInput, value)
+	}
+	return mm * perUnit, nil
+}
+
+// FormatLength escribe una longitud en milímetros, o en metros desde un metro
+func FormatLength(mm float64) string {
+	if mm >= 1000 {
+		return strconv.FormatFloat(mm/1000, 'f', 1, 64) + "m"
+	}
+	return strconv.FormatFloat(mm, 'f', 1, 64) + "mm"
+}
+
+// PrintSize es el tamaño de impresión de un código para una distancia de escaneo
+type PrintSize struct {
+	Modules         int     // Módulos por lado, con la zona de silencio
+	ModuleMM        float64 // Ancho mínimo de un módulo
+	SideMM          float64 // Lado mínimo del código
+	DPI             int
+	PixelsPerModule int     // Puntos por módulo, enteros para que los bordes salgan nítidos
+	Pixels          int     // Lado en píxeles (-size) a ese DPI
+	PrintedMM       float64 // Lado que mide impreso: Pixels redondea hacia arriba
+}
+
+// SizeFor calcula el tamaño mínimo de un código de modules módulos por lado
+// (con la zona de silencio) para escanearlo desde distanceMM, y los píxeles
+// que necesita a dpi
+func SizeFor(distanceMM float64, modules, dpi int) PrintSize {
+	moduleMM := distanceMM / DistancePerModule
+	perModule := max(1, int(math.Ceil(moduleMM/25.4*float64(dpi))))
+	pixels := perModule * modules
+	return PrintSize{
+		Modules:         modules,
+		ModuleMM:        moduleMM,
+		SideMM:          moduleMM * float64(modules),
+		DPI:             dpi,
+		PixelsPerModule: perModule,
+		Pixels:          pixels,
+		PrintedMM:       float64(pixels) / float64(dpi) * 25.4,
+	}
+}
+
+// MaxScanDistance es la distancia máxima desde la que se escanea un código
+// impreso de sideMM de lado con modules módulos por lado (con la zona de silencio)
+func MaxScanDistance(sideMM float64, modules int) float64 {
+	return sideMM / float64(modules) * DistancePerModule
+}
+
+// SymbolModules codifica el payload con las opciones de config y devuelve los
+// módulos por lado del símbolo, con la zona de silencio
+func SymbolModules(config QRConfig) (int, error) {
+	var result QRResult
+	_, bitmap, err := generateQRImage(config, &result)
+	if err != nil {
+		return 0, err
+	}
+	return len(bitmap), nil
+}
+
+// scanDistanceWarning advierte si el código, impreso a --dpi (por defecto
+// DefaultPrintDPI), queda chico para escanearlo desde la distancia de
+// ExtraParams "scan-distance"
+func scanDistanceWarning(config QRConfig, modules int) string {
+	distance, err := ParseLength(config.ExtraParams["scan-distance"])
+	if err != nil {
+		return ""
+	}
+	dpi, err := printDPI(config)
+	if err != nil {
+		return ""
+	}
+	printed := float64(config.Size) / float64(dpi) * 25.4
+	need := SizeFor(distance, modules, dpi)
+	if printed >= need.SideMM {
+		return ""
+	}
+	return i18n.Sprintf("at %d DPI the %dpx code prints %s wide, but scanning from %s needs at least %s; use -size %d or more", dpi, config.Size, FormatLength(printed), FormatLength(distance), FormatLength(need.SideMM), need.Pixels)
+}
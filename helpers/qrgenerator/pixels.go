@@ -0,0 +1,41 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/color"
+)
+
+// isInk indica si un color es el de los módulos (--fg), opaco: el criterio
+// con el que los formatos vectoriales deciden qué píxeles dibujar
+func isInk(c, ink color.Color) bool {
+	r, g, b, a := c.RGBA()
+	inkR, inkG, inkB, _ := ink.RGBA()
+	return a > 0 && r == inkR && g == inkG && b == inkB
+}
+
+// inkPixels devuelve una función que indica si el píxel (x, y) es del color
+// de los módulos. Para imágenes con paleta resuelve el color una vez por
+// índice en lugar de por píxel.
+func inkPixels(img image.Image, ink color.Color) func(x, y int) bool {
+	palettedImg, ok := img.(image.PalettedImage)
+	palette, isPalette := img.ColorModel().(color.Palette)
+	if !ok || !isPalette {
+		return func(x, y int) bool {
+			return isInk(img.At(x, y), ink)
+		}
+	}
+
+	dark := make([]bool, len(palette))
+	for i, c := range palette {
+		dark[i] = isInk(c, ink)
+	}
+	if p, ok := img.(*image.Paletted); ok {
+		// Acceso directo al buffer de píxeles
+		return func(x, y int) bool {
+			return dark[p.Pix[p.PixOffset(x, y)]]
+		}
+	}
+	return func(x, y int) bool {
+		return dark[palettedImg.ColorIndexAt(x, y)]
+	}
+}
@@ -0,0 +1,107 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/color"
+	"sort"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// PresetChromaKey prepara el QR para superponerlo en video sobre un fondo de
+// croma: un marco opaco del color del fondo alrededor de la zona de silencio y
+// colores que el keyer no puede recortar
+const PresetChromaKey = "chromakey"
+
+// preset es un conjunto de ajustes de estilo con un propósito
+type preset struct {
+	matte   int         // Módulos de fondo opaco agregados fuera de la zona de silencio
+	keySafe bool        // Los colores deben quedar fuera de los rangos de verde y azul de croma
+	canvas  image.Point // Tamaño del overlay transparente en el que se ubica el QR (ver lowerThird)
+}
+
+// presets asocia cada nombre de preset con sus ajustes
+var presets = map[string]preset{
+	// El marco absorbe el derrame y el suavizado de bordes del keyer sin comerse
+	// la zona de silencio
+	PresetChromaKey:    {matte: 4, keySafe: true},
+	PresetLowerThird:   {canvas: image.Pt(1920, 1080)},
+	PresetLowerThird4K: {canvas: image.Pt(3840, 2160)},
+}
+
+// Presets devuelve los nombres de los presets, ordenados
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// presetFor lee el preset de ExtraParams ("preset"); sin él no hay ajustes
+func presetFor(config QRConfig) (preset, error) {
+	name := strings.ToLower(config.ExtraParams["preset"])
+	if name == "" {
+		return preset{}, nil
+	}
+	p, ok := presets[name]
+	if !ok {
+		return preset{}, i18n.Errorf("%w: unknown preset %s (%s)", ErrInvalidInput, name, strings.Join(Presets(), ", "))
+	}
+	return p, nil
+}
+
+// withMatte agrega n módulos claros alrededor de la matriz
+func withMatte(bitmap [][]bool, n int) [][]bool {
+	height, width := len(bitmap)+2*n, len(bitmap[0])+2*n
+	padded := make([][]bool, height)
+	for y := range padded {
+		padded[y] = make([]bool, width)
+		if y >= n && y < height-n {
+			copy(padded[y][n:], bitmap[y-n])
+		}
+	}
+	return padded
+}
+
+// checkKeySafe revisa que los colores sean opacos y no caigan en los rangos
+// que un keyer de croma recorta
+func checkKeySafe(palette color.Palette) error {
+	for _, c := range palette {
+		if _, _, _, a := c.RGBA(); a != 0xffff {
+			return i18n.Errorf("%w: preset %s needs opaque colors", ErrInvalidInput, PresetChromaKey)
+		}
+		if keyColor(c) {
+			r, g, b, _ := c.RGBA()
+			return i18n.Errorf("%w: color #%02x%02x%02x is too close to a chroma key green or blue", ErrInvalidInput, r>>8, g>>8, b>>8)
+		}
+	}
+	return nil
+}
+
+// keyColor indica si un color es lo bastante saturado y tiene un tono verde
+// (75°-165°) o azul (195°-265°) como para que un keyer lo tome por el fondo
+func keyColor(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	red, green, blue := float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff
+	hi, lo := max(red, green, blue), min(red, green, blue)
+	if hi < 0.2 || hi-lo < 0.25*hi {
+		// Los oscuros y los poco saturados no se recortan
+		return false
+	}
+	var hue float64
+	switch hi {
+	case red:
+		hue = 60 * (green - blue) / (hi - lo)
+	case green:
+		hue = 60*(blue-red)/(hi-lo) + 120
+	default:
+		hue = 60*(red-green)/(hi-lo) + 240
+	}
+	if hue < 0 {
+		hue += 360
+	}
+	return hue >= 75 && hue <= 165 || hue >= 195 && hue <= 265
+}
@@ -2,15 +2,26 @@ package qrgenerator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"html"
 	"image"
 	"image/draw"
-	"image/jpeg"
 	"image/png"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	"qrgenerator_cli/helpers/code128"
+	"qrgenerator_cli/helpers/code39"
+	"qrgenerator_cli/helpers/ean"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/jpegenc"
+	"qrgenerator_cli/helpers/qrcodec"
+
 	"github.com/skip2/go-qrcode"
 	"github.com/srwiley/oksvg"
 	"github.com/srwiley/rasterx"
@@ -27,6 +38,42 @@ const (
 	FormatCSS  OutputFormat = "css"
 )
 
+// generators asocia cada formato con el constructor de su generador
+var generators = map[OutputFormat]func() QRGenerator{
+	FormatPNG:    func() QRGenerator { return &pngGenerator{} },
+	FormatJPEG:   func() QRGenerator { return &jpegGenerator{} },
+	FormatSVG:    func() QRGenerator { return &svgGenerator{} },
+	FormatCSS:    func() QRGenerator { return &cssGenerator{} },
+	FormatAVIF:   func() QRGenerator { return &avifGenerator{} },
+	FormatHEIF:   func() QRGenerator { return &heifGenerator{} },
+	FormatTIFF:   func() QRGenerator { return &tiffGenerator{} },
+	FormatMatrix: func() QRGenerator { return &moduleMatrixGenerator{} },
+	FormatPDF:    func() QRGenerator { return &pdfGenerator{} },
+}
+
+// Formats devuelve los formatos soportados, ordenados por nombre
+func Formats() []OutputFormat {
+	formats := make([]OutputFormat, 0, len(generators))
+	for format := range generators {
+		formats = append(formats, format)
+	}
+	slices.Sort(formats)
+	return formats
+}
+
+// Available informa si el formato se puede generar en este sistema; los
+// formatos que dependen de un programa externo devuelven un error si falta
+func Available(format OutputFormat) error {
+	newGenerator, ok := generators[format]
+	if !ok {
+		return i18n.Errorf("%w: unsupported format: %s", ErrInvalidInput, format)
+	}
+	if checker, ok := newGenerator().(interface{ Available() error }); ok {
+		return checker.Available()
+	}
+	return nil
+}
+
 // QRConfig contiene la configuración para generar el código QR
 type QRConfig struct {
 	URL         string
@@ -34,7 +81,16 @@ type QRConfig struct {
 	Size        int               // Tamaño del QR en píxeles
 	OutputPath  string            // Ruta de salida
 	Format      OutputFormat      // Formato de salida
+	MaxSize     int               // Tamaño máximo aceptado en píxeles (0 = DefaultMaxSize)
 	ExtraParams map[string]string // Parámetros adicionales para formatos especiales
+	Version     int               // Versión fija del QR (1-40), para tiradas con la misma densidad; 0 elige la más chica
+	// Passphrase cifra el payload con una frase (--encrypt). No va en
+	// ExtraParams para que el manifiesto no la copie.
+	Passphrase string
+
+	manifest         []byte                    // Manifiesto a embeber en la salida (ver Manifest)
+	caption          *caption                  // Texto legible de los códigos lineales, para los formatos que escriben la matriz
+	structuredAppend *qrcodec.StructuredAppend // Posición en una secuencia (ver GenerateStructuredAppend)
 }
 
 // QRGenerator interface define los métodos que debe implementar cada generador de formato
@@ -47,31 +103,319 @@ type pngGenerator struct{}
 type jpegGenerator struct{}
 type svgGenerator struct{}
 
-// generateQRImage genera la imagen base del QR con o sin logo
-func generateQRImage(config QRConfig) (image.Image, error) {
+// qrBorder es la zona de silencio, en módulos, que agrega la librería de QR
+const qrBorder = 4
+
+const (
+	DefaultBorder = qrBorder // Zona de silencio por defecto, en módulos: la que pide el estándar del QR
+	MaxBorder     = 40       // Zona de silencio más ancha aceptada, en módulos
+)
+
+// borderFor lee la zona de silencio de ExtraParams ("border"), en módulos;
+// sin ella es DefaultBorder, o la del estándar si pide más (códigos de barras)
+func borderFor(config QRConfig) (int, error) {
+	value := strings.TrimSpace(config.ExtraParams["border"])
+	if value == "" {
+		return max(DefaultBorder, minBorder(config)), nil
+	}
+	border, err := strconv.Atoi(value)
+	if err != nil || border < 0 || border > MaxBorder {
+		return 0, i18n.Errorf("%w: invalid quiet zone %s (0 to %d modules)", ErrInvalidInput, value, MaxBorder)
+	}
+	return border, nil
+}
+
+// minBorder es la zona de silencio que pide el estándar del símbolo: 4
+// módulos en los QR, 2 en los rMQR y los PDF417, 1 en los Data Matrix,
+// ninguna en los Aztec, que se encuentran por el centro, 10 en los Code 128
+// y los Code 39, 11 en los EAN-13 y 9 en los UPC-A
+func minBorder(config QRConfig) int {
+	switch symbol, _ := symbolFor(config); symbol {
+	case "code128":
+		return code128.QuietZone
+	case "code39":
+		return code39.QuietZone
+	case "ean13", "isbn":
+		return ean.QuietZone
+	case "upca":
+		return ean.UPCQuietZone
+	case "rmqr", "pdf417":
+		return 2
+	case "datamatrix":
+		return 1
+	case "aztec":
+		return 0
+	}
+	return DefaultBorder
+}
+
+// withBorder cambia la zona de silencio de from a to módulos
+func withBorder(bitmap [][]bool, from, to int) [][]bool {
+	symbol := make([][]bool, 0, len(bitmap)-2*from)
+	for _, row := range bitmap[from : len(bitmap)-from] {
+		symbol = append(symbol, row[from:len(row)-from])
+	}
+	return withMatte(symbol, to)
+}
+
+// encodedSymbol es un QR codificado: la matriz con zona de silencio, su
+// versión y, si lo codificó go-qrcode, el símbolo para dibujarlo con la librería
+type encodedSymbol struct {
+	qr      *qrcode.QRCode
+	bitmap  [][]bool
+	version int
+	caption *caption // Texto legible de los códigos lineales, sin la zona de silencio
+}
+
+// encodeSymbol codifica content con el nivel dado, en la versión más chica
+// que lo contiene o en la de config.Version. Las cadenas GS1 necesitan FNC1,
+// que el codificador genérico no escribe, los payloads binarios el modo byte
+// y una máscara fija (ExtraParams "mask"), el modo kanji, un juego de
+// caracteres declarado con ECI (ExtraParams "charset") o el encabezado de
+// structured append el codificador propio. Si no entra devuelve qrcodec.ErrDataTooLong.
+func encodeSymbol(config QRConfig, content string, level ecLevel) (encodedSymbol, error) {
+	if isRMQR(config) {
+		return rmqrSymbol(config, content, level)
+	}
+	if isDataMatrix(config) {
+		return dataMatrixSymbol(config, content)
+	}
+	if isAztec(config) {
+		return aztecSymbol(config, content)
+	}
+	if isPDF417(config) {
+		return pdf417Symbol(config, content)
+	}
+	if isCode128(config) {
+		return code128Symbol(config, content)
+	}
+	if isRetail(config) {
+		return retailSymbol(config, content)
+	}
+	if isCode39(config) {
+		return code39Symbol(config, content)
+	}
+	opts := qrcodec.EncodeOptions{Level: level.codec, Version: config.Version, StructuredAppend: config.structuredAppend}
+	var err error
+	if opts.Mask, opts.ForceMask, err = maskFor(config); err != nil {
+		return encodedSymbol{}, err
+	}
+	if cs, err := charsetFor(config); err != nil {
+		return encodedSymbol{}, err
+	} else if cs != nil {
+		opts.ECI = cs.eci
+	}
+	segments, err := textSegments(config, content)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	var symbol encodedSymbol
+	switch {
+	case config.ExtraParams["gs1"] == "true":
+		symbol.bitmap, symbol.version, err = gs1Bitmap(content, opts)
+	case config.ExtraParams["binary"] == "true":
+		symbol.bitmap, symbol.version, err = binaryBitmap(content, opts)
+	case segments != nil || opts.ForceMask || opts.StructuredAppend != nil:
+		symbol.bitmap, symbol.version, err = textBitmap(content, segments, opts)
+	default:
+		// La capacidad se revisa con las tablas de qrcodec antes de llamar a
+		// go-qrcode, cuyos errores no se distinguen más que por el texto
+		version := config.Version
+		if version == 0 {
+			version = 40
+		}
+		if qrcodec.MinBits(content, version) > qrcodec.DataCapacityBits(version, level.codec) {
+			return symbol, qrcodec.ErrDataTooLong
+		}
+		if config.Version != 0 {
+			symbol.qr, err = qrcode.NewWithForcedVersion(content, config.Version, level.recovery)
+		} else {
+			symbol.qr, err = qrcode.New(content, level.recovery)
+		}
+		if err == nil {
+			symbol.bitmap = symbol.qr.Bitmap()
+			symbol.version = symbol.qr.VersionNumber
+		} else {
+			// Cerca del límite la cota puede pasar y go-qrcode fallar: qrcodec
+			// lo intenta en un solo segmento y, si no entra, devuelve
+			// ErrDataTooLong
+			symbol.qr = nil
+			symbol.bitmap, symbol.version, err = textBitmap(content, nil, opts)
+		}
+	}
+	return symbol, err
+}
+
+// generateQRImage genera la imagen base del QR con o sin logo y completa en
+// result los datos del símbolo elegido. También devuelve la matriz de módulos,
+// incluida la zona de silencio.
+func generateQRImage(config QRConfig, result *QRResult) (image.Image, [][]bool, error) {
 	if config.Size == 0 {
 		config.Size = 256 // Tamaño por defecto
 	}
 
-	// Generar el código QR
-	qr, err := qrcode.New(config.URL, qrcode.Highest)
+	config, err := checkedConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("error generando QR: %w", err)
+		return nil, nil, err
+	}
+	if config, err = signedConfig(config); err != nil {
+		return nil, nil, err
+	}
+	content, err := compressedContent(config, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content, err = encryptedContent(config, content); err != nil {
+		return nil, nil, err
+	}
+
+	level, err := levelFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fit, err := fitFor(config, level)
+	if err != nil {
+		return nil, nil, err
+	}
+	var symbol encodedSymbol
+	if fit != nil {
+		var fitted ecLevel
+		if symbol, fitted, err = fitSymbol(config, content, level, fit); err == nil && fitted != level {
+			result.FitFrom = level.codec.String()
+		}
+		level = fitted
+	} else {
+		symbol, err = encodeSymbol(config, content, level)
+	}
+	if errors.Is(err, qrcodec.ErrDataTooLong) {
+		return nil, nil, capacityError(config, content, level.codec)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	qr, bitmap := symbol.qr, symbol.bitmap
+	result.Version = symbol.version
+	border, err := borderFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if border != qrBorder {
+		// go-qrcode dibuja siempre su zona de silencio: se dibuja desde la matriz
+		bitmap, qr = withBorder(bitmap, qrBorder, border), nil
+	}
+	style, err := presetFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if style.canvas != (image.Point{}) {
+		// -size es el lado del QR a 1080p
+		config.Size = config.Size * style.canvas.Y / overlayBaseHeight
+	}
+	// --scale y --width calculan el ancho con los módulos del símbolo
+	if modules := len(bitmap[0]) + 2*style.matte; imageWidth(config, modules) != config.Size {
+		config.Size = imageWidth(config, modules)
+		maxSize := config.MaxSize
+		if maxSize <= 0 {
+			maxSize = DefaultMaxSize
+		}
+		switch {
+		case config.Size > maxSize:
+			return nil, nil, i18n.Errorf("%w: the code would be %dpx wide, over the %dpx limit (-max-size)", ErrInvalidInput, config.Size, maxSize)
+		case config.Size < modules:
+			return nil, nil, i18n.Errorf("%w: the code would be %dpx wide, less than one pixel for each of its %d modules; raise --width or --dpi", ErrInvalidInput, config.Size, modules)
+		}
+	}
+
+	// Generar la imagen del QR; las muy grandes se calculan bajo demanda
+	palette, err := symbolPalette(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	shape, err := moduleShapeFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	eye, err := eyeShapeFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	styled := shape != shapeSquare || eye != eyeSquare
+	var qrImage image.Image
+	switch {
+	case style.matte > 0 || style.keySafe:
+		modules := newModuleImage(withMatte(bitmap, style.matte), config.Size, palette)
+		if style.keySafe {
+			if err := checkKeySafe(modules.palette); err != nil {
+				return nil, nil, err
+			}
+		}
+		qrImage = modules
+		result.Streamed = config.Size > largeImageThreshold
+	case config.Size > largeImageThreshold || qr == nil || styled:
+		qrImage = newModuleImage(bitmap, config.Size, palette)
+		result.Streamed = config.Size > largeImageThreshold
+	default:
+		qr.BackgroundColor, qr.ForegroundColor = palette[0], palette[1]
+		qrImage = qr.Image(config.Size)
+	}
+	if modules, ok := qrImage.(*moduleImage); ok && styled {
+		modules.shape, modules.eye, modules.finders = shape, eye, qrFinders(modules.bitmap, border+style.matte)
+	}
+
+	// El texto legible de los códigos lineales se corre con la zona de
+	// silencio y, en los presets con fondo, con el fondo
+	if symbol.caption != nil {
+		result.caption = symbol.caption.shift(border)
+		if modules, ok := qrImage.(*moduleImage); ok {
+			matte := (len(modules.bitmap[0]) - len(bitmap[0])) / 2
+			if err := modules.setCaption(result.caption.shift(matte)); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	// SVG escribe el degradado; los mapas de bits lo pintan sobre los módulos
+	if g, err := gradientFor(config); err != nil {
+		return nil, nil, err
+	} else if g != nil && config.Format != FormatSVG {
+		if style.keySafe {
+			if err := checkKeySafe(g.samples()); err != nil {
+				return nil, nil, err
+			}
+		}
+		qrImage = &gradientImage{Image: qrImage, ink: palette[1], gradient: g}
+	}
+
+	if style.canvas != (image.Point{}) {
+		if qrImage, err = lowerThird(qrImage, config, style.canvas); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	// Generar la imagen del QR
-	qrImage := qr.Image(config.Size)
+	result.Modules = len(bitmap[0]) - 2*border
+	if result.Symbol, _ = symbolFor(config); result.Symbol == "qr" {
+		result.Level, result.Mask = readFormatInfo(bitmap, border)
+	} else {
+		info := readSymbolInfo(config, bitmap, border)
+		result.Shape, result.Level = info.shape, info.level
+	}
+	if config.ExtraParams["scan-distance"] != "" && style.canvas == (image.Point{}) {
+		if warning := scanDistanceWarning(config, len(bitmap[0])); warning != "" {
+			result.Warnings = append(result.Warnings, warning)
+		}
+	}
 
 	// // Si hay un logo, procesarlo y superponerlo
 	// TODO
 	// if config.LogoPath != "" {
 	// 	err = overlayLogo(qrImage, config.LogoPath, config.Size)
 	// 	if err != nil {
-	// 		return nil, fmt.Errorf("error superponiendo logo: %w", err)
+	// 		return nil, i18n.Errorf("error overlaying logo: %w", err)
 	// 	}
 	// }
 
-	return qrImage, nil
+	return qrImage, bitmap, nil
 }
 
 // overlayLogo superpone un logo en el centro del QR
@@ -83,7 +427,7 @@ func overlayLogo(qrImage *image.RGBA, logoPath string, size int) error {
 	case ".svg":
 		icon, err := oksvg.ReadIcon(logoPath, oksvg.StrictErrorMode)
 		if err != nil {
-			return fmt.Errorf("error leyendo SVG: %w", err)
+			return i18n.Errorf("error reading SVG: %w", err)
 		}
 
 		logoSize := int(float64(size) * 0.3)
@@ -98,17 +442,17 @@ func overlayLogo(qrImage *image.RGBA, logoPath string, size int) error {
 	case ".png", ".jpg", ".jpeg":
 		f, err := os.Open(logoPath)
 		if err != nil {
-			return fmt.Errorf("error abriendo imagen: %w", err)
+			return i18n.Errorf("error opening image: %w", err)
 		}
 		defer f.Close()
 
 		logoImg, _, err = image.Decode(f)
 		if err != nil {
-			return fmt.Errorf("error decodificando imagen: %w", err)
+			return i18n.Errorf("error decoding image: %w", err)
 		}
 
 	default:
-		return fmt.Errorf("formato de logo no soportado: %s", ext)
+		return i18n.Errorf("unsupported logo format: %s", ext)
 	}
 
 	// Calcular posición central
@@ -124,7 +468,7 @@ func overlayLogo(qrImage *image.RGBA, logoPath string, size int) error {
 func (g *pngGenerator) Generate(qrImage image.Image, config QRConfig) error {
 	f, err := os.Create(config.OutputPath)
 	if err != nil {
-		return fmt.Errorf("error creando archivo PNG: %w", err)
+		return i18n.Errorf("%w: error creating PNG file: %w", ErrIO, err)
 	}
 	defer f.Close()
 
@@ -137,68 +481,268 @@ func (g *pngGenerator) Generate(qrImage image.Image, config QRConfig) error {
 	enc := &png.Encoder{
 		CompressionLevel: png.BestCompression,
 	}
-	return enc.Encode(f, qrImage)
+	var chunks [][]byte
+	if config.manifest != nil {
+		chunks = append(chunks, pngTextChunk(manifestKeyword, config.manifest))
+	}
+	if dpi := resolutionFor(config); dpi > 0 {
+		chunks = append(chunks, pngPhysChunk(dpi))
+	}
+	var w io.Writer = f
+	if chunks != nil {
+		w = &pngChunkWriter{w: f, chunks: chunks}
+	}
+	if err := enc.Encode(w, qrImage); err != nil {
+		return i18n.Errorf("%w: error encoding PNG: %w", ErrEncode, err)
+	}
+	return closeOutput(f)
 }
 
 // Implementación para JPEG
 func (g *jpegGenerator) Generate(qrImage image.Image, config QRConfig) error {
+	bounds := qrImage.Bounds()
+	if bounds.Dx() > jpegMaxSize || bounds.Dy() > jpegMaxSize {
+		return i18n.Errorf("%w: JPEG supports up to %dpx per side; use PNG or SVG", ErrInvalidInput, jpegMaxSize)
+	}
+
+	opts, err := jpegOptions(config)
+	if err != nil {
+		return err
+	}
+	if opts.Progressive && bounds.Dx() > largeImageThreshold {
+		// El modo progresivo guarda todos los coeficientes en memoria
+		return i18n.Errorf("%w: progressive JPEG supports up to %dpx per side", ErrInvalidInput, largeImageThreshold)
+	}
+
 	f, err := os.Create(config.OutputPath)
 	if err != nil {
-		return fmt.Errorf("error creando archivo JPEG: %w", err)
+		return i18n.Errorf("%w: error creating JPEG file: %w", ErrIO, err)
 	}
 	defer f.Close()
 
-	quality := 90
+	if err := jpegenc.Encode(f, qrImage, &opts); err != nil {
+		return i18n.Errorf("%w: error encoding JPEG: %w", ErrEncode, err)
+	}
+	return closeOutput(f)
+}
+
+// jpegOptions lee de ExtraParams la calidad ("quality"), el submuestreo de croma
+// ("subsampling": 444, 422 o 420) y el modo progresivo ("progressive": true)
+func jpegOptions(config QRConfig) (jpegenc.Options, error) {
+	opts := jpegenc.Options{Quality: jpegenc.DefaultQuality, Subsampling: jpegenc.Subsampling444}
 	if qualityStr, ok := config.ExtraParams["quality"]; ok {
-		fmt.Sscanf(qualityStr, "%d", &quality)
+		fmt.Sscanf(qualityStr, "%d", &opts.Quality)
+	}
+	if opts.Quality < 1 || opts.Quality > 100 {
+		return opts, i18n.Errorf("%w: JPEG quality %d out of range (1-100)", ErrInvalidInput, opts.Quality)
+	}
+	if subsampling, ok := config.ExtraParams["subsampling"]; ok {
+		opts.Subsampling = jpegenc.Subsampling(strings.ReplaceAll(subsampling, ":", ""))
+		switch opts.Subsampling {
+		case jpegenc.Subsampling444, jpegenc.Subsampling422, jpegenc.Subsampling420:
+		default:
+			return opts, i18n.Errorf("%w: unsupported JPEG subsampling: %s (444, 422 or 420)", ErrInvalidInput, subsampling)
+		}
 	}
+	opts.Progressive = config.ExtraParams["progressive"] == "true"
+	opts.DPI = resolutionFor(config)
+	return opts, nil
+}
+
+// jpegScanWarningQuality es la calidad por debajo de la cual los artefactos
+// de JPEG empiezan a desdibujar los bordes de los módulos
+const jpegScanWarningQuality = 80
 
-	return jpeg.Encode(f, qrImage, &jpeg.Options{Quality: quality})
+// jpegWarnings advierte sobre opciones JPEG que pueden dificultar el escaneo
+func jpegWarnings(config QRConfig) []string {
+	opts, err := jpegOptions(config)
+	if err != nil {
+		return nil
+	}
+	var warnings []string
+	if opts.Quality < jpegScanWarningQuality {
+		warnings = append(warnings, i18n.Sprintf("JPEG quality %d blurs module edges and can make the code harder to scan; use %d or higher, or PNG/SVG", opts.Quality, jpegScanWarningQuality))
+	}
+	if opts.Subsampling != jpegenc.Subsampling444 {
+		warnings = append(warnings, i18n.Sprintf("chroma subsampling %s softens module edges; 444 keeps them sharp", opts.Subsampling))
+	}
+	return warnings
 }
 
 // Implementación para SVG
 func (g *svgGenerator) Generate(qrImage image.Image, config QRConfig) error {
 	f, err := os.Create(config.OutputPath)
 	if err != nil {
-		return fmt.Errorf("error creando archivo SVG: %w", err)
+		return i18n.Errorf("%w: error creating SVG file: %w", ErrIO, err)
 	}
 	defer f.Close()
 
 	// Convertir la imagen a una representación SVG
+	width, height := qrImage.Bounds().Dx(), qrImage.Bounds().Dy()
+	var text *caption
+	var bitmap [][]bool
+	var finders []image.Rectangle
+	shape, eye := shapeSquare, eyeSquare
+	if modules, ok := qrImage.(*moduleImage); ok {
+		// En tamaños grandes, y con --module-style o --eye-style, se dibuja
+		// una figura por módulo y el viewBox escala
+		text, bitmap, finders = modules.caption, modules.bitmap, modules.finders
+		if modules.finders != nil {
+			shape, eye = modules.shape, modules.eye
+		}
+		qrImage = modules.moduleGrid()
+	}
 	bounds := qrImage.Bounds()
-	svgContent := bytes.Buffer{}
+	viewHeight := strconv.Itoa(bounds.Dy())
+	if text != nil {
+		// El texto legible agranda el viewBox hacia abajo
+		viewHeight = strconv.FormatFloat(text.height, 'f', 2, 64)
+	}
+	dark, light, err := symbolColors(config)
+	if err != nil {
+		return err
+	}
+	ink := cssColor(dark)
+	isDark := inkPixels(qrImage, dark)
+	grad, err := gradientFor(config)
+	if err != nil {
+		return err
+	}
 
-	svgContent.WriteString(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
-		<svg width="%d" height="%d" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">
-		<rect width="100%%" height="100%%" fill="white"/>`,
-		bounds.Dx(), bounds.Dy(), bounds.Dx(), bounds.Dy()))
+	// Reservar de antemano: en un QR cerca de la mitad de los píxeles son oscuros
+	const rectLen = len(`<rect x="0000" y="0000" width="1" height="1" fill="#000000"/>`)
+	svgContent := make([]byte, 0, 256+bounds.Dx()*bounds.Dy()/2*rectLen)
 
-	// Convertir píxeles a rectángulos SVG
+	// Con --dpi o --width el SVG mide lo mismo impreso, en milímetros
+	svgWidth, svgHeight := strconv.Itoa(width), strconv.Itoa(height)
+	if dpi := resolutionFor(config); dpi > 0 {
+		svgWidth = strconv.FormatFloat(float64(width)/float64(dpi)*25.4, 'f', 2, 64) + "mm"
+		svgHeight = strconv.FormatFloat(float64(height)/float64(dpi)*25.4, 'f', 2, 64) + "mm"
+	}
+	svgContent = fmt.Appendf(svgContent, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+		<svg width="%s" height="%s" viewBox="0 0 %d %s" xmlns="http://www.w3.org/2000/svg">`,
+		svgWidth, svgHeight, bounds.Dx(), viewHeight)
+	if !isTransparent(light) {
+		// Con --bg transparent no hay fondo: se ve lo que haya debajo
+		svgContent = fmt.Appendf(svgContent, `
+		<rect width="100%%" height="100%%" fill="%s"/>`, cssColor(light))
+	}
+	if grad != nil {
+		// El degradado ocupa la imagen entera, con el texto, como en los mapas de bits
+		h, _ := strconv.ParseFloat(viewHeight, 64)
+		svgContent = append(svgContent, "<defs>"+grad.svg("fg", float64(bounds.Dx()), h)+"</defs>"...)
+		ink = "url(#fg)"
+	}
+	if config.manifest != nil {
+		svgContent = append(svgContent, `<metadata id="`+manifestKeyword+`">`...)
+		svgContent = append(svgContent, html.EscapeString(string(config.manifest))...)
+		svgContent = append(svgContent, "</metadata>"...)
+	}
+
+	// Convertir píxeles a rectángulos SVG, solo los de los módulos. Los cierres
+	// se arman una vez: concatenar el color en cada rectángulo reserva memoria
+	// por píxel.
+	fillTail := []byte(` fill="` + ink + `"/>`)
+	rectTail := []byte(`" width="1" height="1"` + string(fillTail))
 	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
 		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			color := qrImage.At(x, y)
-			r, g, b, a := color.RGBA()
-			if a > 0 && r == 0 && g == 0 && b == 0 { // Solo dibujar píxeles negros
-				svgContent.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="1" height="1" fill="black"/>`, x, y))
+			finder := finderAt(finders, y, x)
+			switch {
+			case !isDark(x, y) || (finder >= 0 && eye != eyeSquare):
+				// Los ojos con forma se dibujan enteros después
+			case finder < 0 && shape != shapeSquare:
+				svgContent = shape.svg(svgContent, bitmap, y, x)
+				svgContent = append(svgContent, fillTail...)
+			default:
+				svgContent = append(svgContent, `<rect x="`...)
+				svgContent = strconv.AppendInt(svgContent, int64(x), 10)
+				svgContent = append(svgContent, `" y="`...)
+				svgContent = strconv.AppendInt(svgContent, int64(y), 10)
+				svgContent = append(svgContent, rectTail...)
 			}
 		}
 	}
+	if eye != eyeSquare {
+		for i, r := range finders {
+			svgContent = eye.svg(svgContent, i, r)
+			svgContent = append(svgContent, fillTail...)
+		}
+	}
+	if text != nil {
+		for _, line := range text.texts {
+			svgContent = fmt.Appendf(svgContent, `<text x="%.2f" y="%.2f" font-size="%.2f" font-family="sans-serif" text-anchor="middle" fill="%s">%s</text>`,
+				line.x, line.baseline, line.size, ink, html.EscapeString(line.text))
+		}
+	}
 
-	svgContent.WriteString("</svg>")
-	_, err = f.Write(svgContent.Bytes())
-	return err
+	svgContent = append(svgContent, "</svg>"...)
+	return writeOutput(f, svgContent)
 }
 
 // Implementación del generador CSS
 type cssGenerator struct{}
 
+// cssQuote escapa el texto de una cadena CSS entre comillas dobles
+var cssQuote = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// cssCaptionLine es el texto de un pseudoelemento del CSS
+type cssCaptionLine struct {
+	pseudo         string
+	text           string
+	baseline, size float64
+}
+
+// cssCaptionLines junta las líneas del texto legible por línea de base: el
+// CSS tiene dos pseudoelementos, ::after para la de más abajo y ::before
+// para las demás
+func cssCaptionLines(c *caption) []cssCaptionLine {
+	lowest := 0.0
+	for _, line := range c.texts {
+		lowest = max(lowest, line.baseline)
+	}
+	groups := []cssCaptionLine{{pseudo: "before"}, {pseudo: "after"}}
+	for _, line := range c.texts {
+		group := &groups[0]
+		if line.baseline == lowest {
+			group = &groups[1]
+		}
+		if group.text != "" {
+			group.text += " "
+		}
+		group.text += line.text
+		group.baseline, group.size = max(group.baseline, line.baseline), max(group.size, line.size)
+	}
+	if groups[0].text == "" {
+		return groups[1:]
+	}
+	return groups
+}
+
 func (g *cssGenerator) Generate(qrImage image.Image, config QRConfig) error {
 	f, err := os.Create(config.OutputPath)
 	if err != nil {
-		return fmt.Errorf("error creando archivo CSS: %w", err)
+		return i18n.Errorf("%w: error creating CSS file: %w", ErrIO, err)
 	}
 	defer f.Close()
 
+	pixelSize := 1
+	if size, ok := config.ExtraParams["pixel-size"]; ok {
+		fmt.Sscanf(size, "%d", &pixelSize)
+	}
+	var text *caption
+	if modules, ok := qrImage.(*moduleImage); ok {
+		// En tamaños grandes cada módulo es un píxel del CSS
+		pixelSize = max(pixelSize, modules.size/len(modules.bitmap[0]))
+		text = modules.caption
+		qrImage = modules.moduleGrid()
+	}
+
+	dark, light, err := symbolColors(config)
+	if err != nil {
+		return err
+	}
+	ink, paper := cssColor(dark), cssColor(light)
+
 	bounds := qrImage.Bounds()
 	var cssContent bytes.Buffer
 
@@ -208,35 +752,60 @@ func (g *cssGenerator) Generate(qrImage image.Image, config QRConfig) error {
     width: 1px;
     height: 1px;
     position: relative;
-    background: white;
+    background: ` + paper + `;
     box-shadow: `)
 
-	// Variables para tracking
-	var shadows []string
-	pixelSize := 1
-	if size, ok := config.ExtraParams["pixel-size"]; ok {
-		fmt.Sscanf(size, "%d", &pixelSize)
-	}
-
-	// Generar box-shadows para cada pixel negro
+	// Generar box-shadows para cada pixel de los módulos, separados por ",\n    "
+	isDark := inkPixels(qrImage, dark)
+	const shadowLen = len("00000px 00000px 0 00px #000000,\n    ")
+	shadows := make([]byte, 0, bounds.Dx()*bounds.Dy()/2*shadowLen)
+	shadowTail := []byte("px " + ink)
 	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
 		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			color := qrImage.At(x, y)
-			r, g, b, a := color.RGBA()
-			if a > 0 && r == 0 && g == 0 && b == 0 { // Solo pixeles negros
-				shadow := fmt.Sprintf("%dpx %dpx 0 %dpx black",
-					x*pixelSize,
-					y*pixelSize,
-					pixelSize/2)
-				shadows = append(shadows, shadow)
+			if !isDark(x, y) {
+				continue
+			}
+			if len(shadows) > 0 {
+				shadows = append(shadows, ",\n    "...)
 			}
+			shadows = strconv.AppendInt(shadows, int64(x*pixelSize), 10)
+			shadows = append(shadows, "px "...)
+			shadows = strconv.AppendInt(shadows, int64(y*pixelSize), 10)
+			shadows = append(shadows, "px 0 "...)
+			shadows = strconv.AppendInt(shadows, int64(pixelSize/2), 10)
+			shadows = append(shadows, shadowTail...)
 		}
 	}
 
-	// Unir todos los box-shadows
-	cssContent.WriteString(strings.Join(shadows, ",\n    "))
+	cssContent.Write(shadows)
 	cssContent.WriteString(";\n}\n\n")
 
+	// El texto legible va en las mismas unidades que las sombras: las líneas
+	// de más abajo en ::after y las de arriba de las barras (ISBN) en
+	// ::before, cada grupo en una sola línea centrada
+	if text != nil {
+		for _, group := range cssCaptionLines(text) {
+			cssContent.WriteString(fmt.Sprintf(`.qr-code::%s {
+    content: "%s";
+    position: absolute;
+    left: 0;
+    top: %dpx;
+    width: %dpx;
+    font: %dpx/1 sans-serif;
+    text-align: center;
+    color: %s;
+}
+
+`,
+				group.pseudo,
+				cssQuote.Replace(group.text),
+				int((group.baseline-group.size)*float64(pixelSize)),
+				bounds.Dx()*pixelSize,
+				max(1, int(group.size*float64(pixelSize))),
+				ink))
+		}
+	}
+
 	// Agregar reglas de tamaño y centrado
 	cssContent.WriteString(fmt.Sprintf(`
 .qr-container {
@@ -244,7 +813,7 @@ func (g *cssGenerator) Generate(qrImage image.Image, config QRConfig) error {
     justify-content: center;
     align-items: center;
     min-height: 100vh;
-    background: white;
+    background: %s;
     padding: 20px;
 }
 
@@ -252,6 +821,7 @@ func (g *cssGenerator) Generate(qrImage image.Image, config QRConfig) error {
     transform: scale(%d);
     margin: %dpx;
 }`,
+		paper,
 		pixelSize,
 		bounds.Dx()*pixelSize/2))
 
@@ -278,42 +848,47 @@ func (g *cssGenerator) Generate(qrImage image.Image, config QRConfig) error {
 `)
 	}
 
-	_, err = f.Write(cssContent.Bytes())
-	return err
+	return writeOutput(f, cssContent.Bytes())
 }
 
-// GenerateQR es la función principal que genera el código QR en el formato especificado
-func GenerateQR(config QRConfig) error {
-	// Validar configuración
-	if config.URL == "" {
-		return fmt.Errorf("URL es requerida")
+// writeOutput escribe el contenido generado y cierra el archivo de salida
+func writeOutput(f *os.File, content []byte) error {
+	if _, err := f.Write(content); err != nil {
+		return i18n.Errorf("%w: error writing %s: %w", ErrIO, f.Name(), err)
 	}
+	return closeOutput(f)
+}
 
-	if config.ExtraParams == nil {
-		config.ExtraParams = make(map[string]string)
+// closeOutput cierra el archivo de salida reportando fallos de escritura diferidos
+func closeOutput(f *os.File) error {
+	if err := f.Close(); err != nil {
+		return i18n.Errorf("%w: error closing %s: %w", ErrIO, f.Name(), err)
 	}
+	return nil
+}
 
-	// Generar la imagen base del QR
-	qrImage, err := generateQRImage(config)
+// GenerateQR es la función principal que genera el código QR en el formato especificado
+func GenerateQR(config QRConfig) (*QRResult, error) {
+	results, err := GenerateFormats(config, []Output{{Path: config.OutputPath, Format: config.Format}})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return results[0], nil
+}
 
-	// Seleccionar el generador según el formato
-	var generator QRGenerator
-	switch config.Format {
-	case FormatPNG:
-		generator = &pngGenerator{}
-	case FormatJPEG:
-		generator = &jpegGenerator{}
-	case FormatSVG:
-		generator = &svgGenerator{}
-	case FormatCSS:
-		generator = &cssGenerator{}
-	default:
-		return fmt.Errorf("formato no soportado: %s", config.Format)
+// Render valida el contenido y el tamaño y devuelve la imagen del QR sin
+// escribirla, para los destinos que no son archivos (por ejemplo, displays)
+func Render(config QRConfig) (image.Image, *QRResult, error) {
+	config.Format = ""
+	for _, problem := range Validate(config) {
+		if !problem.Warning {
+			return nil, nil, problem.Err
+		}
 	}
-
-	// Generar el archivo de salida
-	return generator.Generate(qrImage, config)
+	result := &QRResult{}
+	qrImage, _, err := generateQRImage(config, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return qrImage, result, nil
 }
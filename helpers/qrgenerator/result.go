@@ -0,0 +1,175 @@
+package qrgenerator
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"qrgenerator_cli/helpers/aztec"
+	"qrgenerator_cli/helpers/code128"
+	"qrgenerator_cli/helpers/code39"
+	"qrgenerator_cli/helpers/ean"
+	"qrgenerator_cli/helpers/pdf417"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// QRResult describe el código generado, útil para diagnóstico
+type QRResult struct {
+	Symbol     string        // Simbología: qr, rmqr, datamatrix, aztec, pdf417, code128, code39, ean13, upca o isbn
+	Version    int           // Versión del QR (1-40), o posición de la forma en los rMQR (1-32) y del tamaño en los Data Matrix (1-30) y los Aztec (1-36), o columnas del PDF417, o caracteres de símbolo del Code 128 y caracteres del Code 39
+	Shape      string        // Forma del rMQR, como R13x43, o tamaño del Data Matrix, del Aztec o del PDF417, como 16x16, 19x19 compact o 5x10; "" en los QR y los lineales
+	Level      string        // Nivel de corrección de errores (L, M, Q, H), o de seguridad del PDF417 (0-8); "" en los Data Matrix, los Aztec y los lineales
+	Mask       int           // Patrón de máscara elegido por el codificador (0-7)
+	FitFrom    string        // Nivel pedido que --fit auto tuvo que bajar; "" si no hizo falta
+	Modules    int           // Módulos por lado, sin zona de silencio; el ancho en los rMQR
+	OutputPath string        // Archivo escrito
+	Format     OutputFormat  // Formato escrito
+	Thumbnail  string        // Miniatura PNG escrita junto al archivo; "" sin --thumbnail
+	Streamed   bool          // La imagen se calculó bajo demanda por ser muy grande
+	EncodeTime time.Duration // Tiempo de codificación del QR
+	WriteTime  time.Duration // Tiempo de escritura del archivo
+	Warnings   []string      // Advertencias sobre opciones que pueden dificultar el escaneo
+
+	caption *caption // Texto legible de los códigos lineales, en módulos de la matriz con la zona de silencio
+}
+
+// formatInfoMask es la máscara XOR que el estándar aplica a la información de formato
+const formatInfoMask = 0x5412
+
+// readFormatInfo lee el nivel de corrección y la máscara de la primera copia de
+// la información de formato. bitmap incluye la zona de silencio de border módulos.
+func readFormatInfo(bitmap [][]bool, border int) (level string, mask int) {
+	bit := func(x, y int) int {
+		if bitmap[y+border][x+border] {
+			return 1
+		}
+		return 0
+	}
+
+	bits := 0
+	for x := 0; x <= 5; x++ {
+		bits = bits<<1 | bit(x, 8)
+	}
+	bits = bits<<1 | bit(7, 8)
+	bits = bits<<1 | bit(8, 8)
+	bits = bits<<1 | bit(8, 7)
+	for y := 5; y >= 0; y-- {
+		bits = bits<<1 | bit(8, y)
+	}
+
+	data := (bits ^ formatInfoMask) >> 10
+	levels := [4]string{"M", "L", "H", "Q"}
+	return levels[data>>3], data & 0x7
+}
+
+// symbolInfo describe el símbolo de una matriz en los encabezados de las exportaciones
+type symbolInfo struct {
+	symbol  string // Simbología: qr, rmqr, datamatrix, aztec, pdf417, code128, code39, ean13, upca o isbn
+	version int    // Versión del QR o caracteres de los Code 128 y los Code 39; 0 en los demás
+	number  string // Los 13 dígitos de los EAN-13, los UPC-A y los ISBN
+	shape   string // Forma del rMQR o tamaño del Data Matrix, del Aztec o del PDF417; "" en los QR
+	level   string // Nivel de corrección, o de seguridad del PDF417; "" en los Data Matrix y los Aztec
+	border  int    // Zona de silencio, en módulos
+}
+
+// String nombra el símbolo: "QR version 5", "rMQR R13x43", "Data Matrix
+// 16x16", "Aztec 19x19 compact", "PDF417 5x10", "Code 128", "Code 39" o
+// "EAN-13 4006381333931"
+func (s symbolInfo) String() string {
+	switch s.symbol {
+	case "code128":
+		return "Code 128"
+	case "code39":
+		return "Code 39"
+	case "ean13", "upca", "isbn":
+		return retailName(s.symbol) + " " + s.number
+	case "rmqr":
+		return "rMQR " + s.shape
+	case "datamatrix":
+		return "Data Matrix " + s.shape
+	case "aztec":
+		return "Aztec " + s.shape
+	case "pdf417":
+		return "PDF417 " + s.shape
+	}
+	return "QR version " + strconv.Itoa(s.version)
+}
+
+// describe nombra el símbolo con su corrección, para los encabezados:
+// "QR version 5, EC level H", "Data Matrix 16x16, ECC 200", "Aztec 19x19
+// compact, 23% EC", "PDF417 5x10, security level 2", "Code 128, 11
+// symbol characters" o "Code 39, 9 characters"; los códigos de productos no
+// tienen corrección
+func (s symbolInfo) describe() string {
+	switch s.symbol {
+	case "ean13", "upca", "isbn":
+		return s.String()
+	case "code128":
+		return s.String() + ", " + strconv.Itoa(s.version) + " symbol characters"
+	case "code39":
+		return s.String() + ", " + strconv.Itoa(s.version) + " characters"
+	case "datamatrix":
+		return s.String() + ", ECC 200"
+	case "aztec":
+		return s.String() + ", " + strconv.Itoa(aztec.DefaultECPercent) + "% EC"
+	case "pdf417":
+		return s.String() + ", security level " + s.level
+	}
+	return s.String() + ", EC level " + s.level
+}
+
+// readSymbolInfo lee la versión o la forma y el nivel de la matriz, que
+// incluye la zona de silencio de border módulos
+func readSymbolInfo(config QRConfig, bitmap [][]bool, border int) symbolInfo {
+	height, width := len(bitmap)-2*border, len(bitmap[0])-2*border
+	name, _ := symbolFor(config)
+	if name == "datamatrix" {
+		return symbolInfo{symbol: name, shape: strconv.Itoa(height) + "x" + strconv.Itoa(width), border: border}
+	}
+	if name == "aztec" {
+		size, _ := aztec.SizeOf(height)
+		return symbolInfo{symbol: name, shape: size.String(), border: border}
+	}
+	if name == "pdf417" {
+		// El nivel de seguridad va en los indicadores de fila
+		symbol := make([][]bool, height)
+		for y := range symbol {
+			symbol[y] = bitmap[y+border][border : border+width]
+		}
+		pdf, err := pdf417.DecodeMatrix(symbol)
+		if err != nil {
+			return symbolInfo{symbol: name, border: border}
+		}
+		return symbolInfo{symbol: name, shape: pdf.Size.String(), level: strconv.Itoa(pdf.Level), border: border}
+	}
+	if name == "code128" {
+		// Todas las filas son iguales: la primera alcanza
+		if bars, err := code128.DecodeModules(bitmap[border][border : border+width]); err == nil {
+			return symbolInfo{symbol: name, version: bars.Characters, border: border}
+		}
+		return symbolInfo{symbol: name, border: border}
+	}
+	if name == "code39" {
+		data, _ := code39.DecodeModules(bitmap[border][border : border+width])
+		return symbolInfo{symbol: name, version: len(data), border: border}
+	}
+	if slices.Contains(retailSymbols, name) {
+		// Las guardas bajan más y el ISBN tiene el texto arriba: la fila del
+		// medio tiene todas las barras
+		number, _ := ean.DecodeModules(bitmap[border+height/2][border : border+width])
+		if name == "upca" && number != "" {
+			number = number[1:]
+		}
+		return symbolInfo{symbol: name, number: number, border: border}
+	}
+	if name == "qr" {
+		level, _ := readFormatInfo(bitmap, border)
+		return symbolInfo{symbol: name, version: (height - 17) / 4, level: level, border: border}
+	}
+	symbol := make(qrcodec.Matrix, height)
+	for y := range symbol {
+		symbol[y] = bitmap[y+border][border : border+width]
+	}
+	shape, level, _ := qrcodec.RMQRFormat(symbol)
+	return symbolInfo{symbol: name, shape: shape.String(), level: level.String(), border: border}
+}
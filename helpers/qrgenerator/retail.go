@@ -0,0 +1,145 @@
+package qrgenerator
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/ean"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// retailSymbols son los códigos de los productos; los tres se dibujan como
+// un EAN-13 y cambian el número que aceptan y el texto legible
+var retailSymbols = []string{"ean13", "upca", "isbn"}
+
+// Medidas del dibujo estándar en módulos, con módulos de 0,33 mm
+const (
+	retailBarHeight  = 69  // Alto de las barras de los dígitos: 22,85 mm
+	retailGuardDrop  = 5   // Lo que bajan las guardas debajo de las barras
+	retailDigitSize  = 11  // Tamaño de los dígitos debajo de las barras
+	retailOuterSize  = 7.5 // Tamaño del primer dígito y del último del UPC-A, fuera de las barras
+	retailOuterGap   = 4.5 // Distancia del centro de los dígitos de afuera a las barras
+	retailHeaderSize = 9   // Tamaño del "ISBN ..." arriba de las barras
+)
+
+// isRetail indica si la configuración pide un código de producto
+func isRetail(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return slices.Contains(retailSymbols, symbol)
+}
+
+// retailDigits valida el número de content según la simbología y devuelve
+// los 13 dígitos del EAN-13
+func retailDigits(symbol, content string) (string, error) {
+	var digits string
+	var err error
+	switch symbol {
+	case "upca":
+		digits, err = ean.UPCA(content)
+	case "isbn":
+		digits, err = ean.ISBN(content)
+	default:
+		digits, err = ean.EAN13(content)
+	}
+	if err != nil {
+		return "", i18n.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	return digits, nil
+}
+
+// retailSymbol codifica content en un EAN-13 (ISO/IEC 15420), el código de
+// barras de los productos, en un UPC-A o en el EAN-13 de un ISBN, con el
+// dibujo del estándar: las guardas más largas que las barras de los dígitos
+// y los dígitos debajo, más el ISBN arriba en los libros
+func retailSymbol(config QRConfig, content string) (encodedSymbol, error) {
+	symbol, _ := symbolFor(config)
+	digits, err := retailDigits(symbol, content)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	bars, err := ean.Encode(digits)
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating %s: %w", ErrEncode, retailName(symbol), err)
+	}
+	metrics, err := captionMetrics()
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	defer metrics.face.Close()
+
+	c := &caption{}
+	header := 0
+	if symbol == "isbn" {
+		line := captionText{text: isbnHeader(content, digits), x: ean.Modules / 2.0, size: retailHeaderSize}
+		if w := metrics.width(line.text); w*line.size > ean.Modules {
+			line.size = ean.Modules / w
+		}
+		line.baseline = metrics.ascent * line.size
+		header = int(math.Ceil(line.baseline + metrics.descent*line.size + captionGap))
+		c.texts = append(c.texts, line)
+	}
+
+	isGuard := ean.IsGuard
+	if symbol == "upca" {
+		isGuard = ean.IsUPCGuard
+	}
+	bitmap := make([][]bool, header+retailBarHeight+retailGuardDrop)
+	for y := range bitmap {
+		bitmap[y] = make([]bool, ean.Modules)
+		for x, bar := range bars {
+			switch {
+			case y < header:
+			case y < header+retailBarHeight:
+				bitmap[y][x] = bar
+			default:
+				bitmap[y][x] = bar && isGuard(x)
+			}
+		}
+	}
+
+	// Los dígitos empiezan un módulo debajo de las barras, entre las guardas
+	baseline := float64(header+retailBarHeight) + captionGap + metrics.capHeight*retailDigitSize
+	group := func(text string, first, last int, size float64) captionText {
+		return captionText{text: text, x: (ean.DigitCenter(first) + ean.DigitCenter(last)) / 2, baseline: baseline, size: size}
+	}
+	if symbol == "upca" {
+		c.texts = append(c.texts,
+			captionText{text: digits[1:2], x: -retailOuterGap, baseline: baseline, size: retailOuterSize},
+			group(digits[2:7], 2, 6, retailDigitSize),
+			group(digits[7:12], 7, 11, retailDigitSize),
+			captionText{text: digits[12:], x: ean.Modules + retailOuterGap, baseline: baseline, size: retailOuterSize})
+	} else {
+		c.texts = append(c.texts,
+			captionText{text: digits[:1], x: -retailOuterGap, baseline: baseline, size: retailDigitSize},
+			group(digits[1:7], 1, 6, retailDigitSize),
+			group(digits[7:], 7, 12, retailDigitSize))
+	}
+	c.bottom = baseline + metrics.descent*retailDigitSize
+	c.height = max(float64(len(bitmap)), c.bottom)
+	return encodedSymbol{bitmap: withMatte(bitmap, qrBorder), caption: c}, nil
+}
+
+// isbnHeader es el texto de arriba de un ISBN: el número como lo escribió el
+// usuario si es el ISBN-13, con sus guiones, o los 13 dígitos
+func isbnHeader(content, digits string) string {
+	number := strings.TrimSpace(content)
+	if len(number) >= 4 && strings.EqualFold(number[:4], "ISBN") {
+		number = strings.TrimSpace(strings.TrimPrefix(number[4:], ":"))
+	}
+	if strings.NewReplacer("-", "", " ", "").Replace(number) != digits {
+		number = digits
+	}
+	return "ISBN " + number
+}
+
+// retailName es el nombre de la simbología en los mensajes
+func retailName(symbol string) string {
+	switch symbol {
+	case "upca":
+		return "UPC-A"
+	case "isbn":
+		return "ISBN"
+	}
+	return "EAN-13"
+}
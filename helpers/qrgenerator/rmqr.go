@@ -0,0 +1,125 @@
+package qrgenerator
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// isRMQR indica si la configuración pide un rMQR
+func isRMQR(config QRConfig) bool {
+	symbol, _ := symbolFor(config)
+	return symbol == "rmqr"
+}
+
+// RMQRShapes devuelve los nombres de las formas de rMQR, como R13x43
+func RMQRShapes() []string {
+	var names []string
+	for _, shape := range qrcodec.RMQRShapes() {
+		names = append(names, shape.String())
+	}
+	return names
+}
+
+// rmqrShapeFor lee la forma de ExtraParams ("shape"): R13x43 la fija, R13
+// fija el alto para una cinta de etiquetas y deja elegir el ancho, y sin ella
+// o con auto se elige la de menor superficie
+func rmqrShapeFor(config QRConfig) (qrcodec.RMQRShape, error) {
+	value := strings.TrimSpace(config.ExtraParams["shape"])
+	if value == "" || strings.EqualFold(value, "auto") {
+		return qrcodec.RMQRShape{}, nil
+	}
+	shape, err := qrcodec.ParseRMQRShape(value)
+	if err != nil {
+		return shape, i18n.Errorf("%w: unknown rMQR shape %s (R7x43 to R17x139, a height such as R13, or auto)", ErrInvalidInput, value)
+	}
+	return shape, nil
+}
+
+// rmqrShapes devuelve las formas que admite la configuración, de la de
+// menor a la de mayor superficie
+func rmqrShapes(config QRConfig) []qrcodec.RMQRShape {
+	fixed, _ := rmqrShapeFor(config)
+	var shapes []qrcodec.RMQRShape
+	for _, shape := range qrcodec.RMQRShapes() {
+		if (fixed.Height == 0 || shape.Height == fixed.Height) && (fixed.Width == 0 || shape.Width == fixed.Width) {
+			shapes = append(shapes, shape)
+		}
+	}
+	slices.SortStableFunc(shapes, func(a, b qrcodec.RMQRShape) int {
+		return a.Height*a.Width - b.Height*b.Width
+	})
+	return shapes
+}
+
+// rmqrSymbol codifica content en un rMQR (ISO/IEC 23941), el QR rectangular
+// para etiquetas angostas: con los segmentos de textSegments, en uno solo del
+// modo más denso o, para los payloads binarios, en modo byte. Si no entra
+// devuelve qrcodec.ErrDataTooLong (ver capacityError).
+func rmqrSymbol(config QRConfig, content string, level ecLevel) (encodedSymbol, error) {
+	shape, err := rmqrShapeFor(config)
+	if err != nil {
+		return encodedSymbol{}, err
+	}
+	opts := qrcodec.RMQROptions{Level: level.codec, Shape: shape}
+	if cs, err := charsetFor(config); err != nil {
+		return encodedSymbol{}, err
+	} else if cs != nil {
+		opts.ECI = cs.eci
+	}
+
+	segments := []qrcodec.Segment{{Mode: qrcodec.ModeByte, Data: []byte(content)}}
+	if config.ExtraParams["binary"] != "true" {
+		if segments, err = textSegments(config, content); err != nil {
+			return encodedSymbol{}, err
+		}
+		if segments == nil {
+			segments = []qrcodec.Segment{{Mode: qrcodec.ModeFor(content), Data: []byte(content)}}
+		}
+	}
+
+	symbol, err := qrcodec.EncodeRMQR(segments, opts)
+	if errors.Is(err, qrcodec.ErrDataTooLong) {
+		return encodedSymbol{}, err
+	}
+	if err != nil {
+		return encodedSymbol{}, i18n.Errorf("%w: error generating rMQR: %w", ErrEncode, err)
+	}
+	return encodedSymbol{bitmap: withMatte(symbol.Matrix, qrBorder), version: symbol.Version}, nil
+}
+
+// rmqrCapacityError arma el error de un contenido que no entra en ninguna
+// de las formas de rMQR que admite la configuración
+func rmqrCapacityError(config QRConfig, content string, level qrcodec.Level) *CapacityError {
+	mode, size := payloadMode(config, content)
+	shapes := rmqrShapes(config)
+	largest := shapes[len(shapes)-1]
+	e := &CapacityError{Size: size, Mode: mode, Level: level, Shape: largest.String(), Max: qrcodec.RMQRCapacity(mode, level, largest)}
+
+	if level == qrcodec.LevelH {
+		if max := qrcodec.RMQRCapacity(mode, qrcodec.LevelM, largest); size <= max {
+			e.Suggestions = append(e.Suggestions, i18n.Sprintf("%s %s (holds %d)", "--ec", qrcodec.LevelM, max))
+		}
+	}
+	// Con la forma o el alto fijos, la forma más chica que lo contiene
+	if len(shapes) < len(qrcodec.RMQRShapes()) {
+		for _, shape := range rmqrShapes(QRConfig{}) {
+			if max := qrcodec.RMQRCapacity(mode, level, shape); size <= max {
+				e.Suggestions = append(e.Suggestions, i18n.Sprintf("--shape %s (holds %d)", shape, max))
+				break
+			}
+		}
+	}
+	if max := qrcodec.VersionCapacity(mode, level, 40); size <= max {
+		e.Suggestions = append(e.Suggestions, i18n.Sprintf("--symbol qr (holds %d)", max))
+	}
+	if payloadLooksLikeURL(content) {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter URL (a redirect from your own domain)"))
+	} else {
+		e.Suggestions = append(e.Suggestions, i18n.T("a shorter payload"))
+	}
+	return e
+}
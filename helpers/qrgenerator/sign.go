@@ -0,0 +1,22 @@
+package qrgenerator
+
+import (
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/sign"
+)
+
+// signedConfig firma el payload con la clave privada de ExtraParams ("sign")
+// y lo reemplaza por el JWS compacto. Se aplica antes de --compress y
+// --encrypt-to, así lo que se firma es el payload que lee el usuario.
+func signedConfig(config QRConfig) (QRConfig, error) {
+	keyPath := config.ExtraParams["sign"]
+	if keyPath == "" {
+		return config, nil
+	}
+	token, err := sign.Sign(config.URL, keyPath)
+	if err != nil {
+		return config, i18n.Errorf("%w: --sign: %w", ErrEncode, err)
+	}
+	config.URL = token
+	return config, nil
+}
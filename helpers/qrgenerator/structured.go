@@ -0,0 +1,89 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrcodec"
+)
+
+// StructuredAppendPath numera la ruta de salida para el símbolo i (desde 1)
+// de una secuencia de n: qr.png pasa a qr_1of4.png
+func StructuredAppendPath(path string, i, n int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s_%dof%d%s", strings.TrimSuffix(path, ext), i, n, ext)
+}
+
+// splitContent parte el contenido en parts trozos de largo parecido. El texto
+// se corta entre caracteres UTF-8, así un lector sin structured append
+// muestra cada trozo bien; los datos binarios, en cualquier byte. Devuelve
+// nil si no alcanza para parts trozos.
+func splitContent(content string, parts int, binary bool) []string {
+	chunks := make([]string, 0, parts)
+	for left := parts; left > 0; left-- {
+		n := (len(content) + left - 1) / left
+		for !binary && n < len(content) && !utf8.RuneStart(content[n]) {
+			n++
+		}
+		if n == 0 {
+			return nil
+		}
+		chunks = append(chunks, content[:n])
+		content = content[n:]
+	}
+	return chunks
+}
+
+// GenerateStructuredAppend parte el payload en parts QR (2 a 16) enlazados
+// con el encabezado de structured append del estándar: decode y los lectores
+// que lo admiten juntan el mensaje, y el resto lee cada trozo por separado.
+// Escribe un archivo por símbolo (ver StructuredAppendPath); las advertencias
+// de la configuración se informan una vez, en el primer resultado.
+func GenerateStructuredAppend(config QRConfig, parts int) ([]*QRResult, error) {
+	if parts < 2 || parts > qrcodec.MaxStructuredAppend {
+		return nil, i18n.Errorf("%w: structured append needs 2 to %d QR codes", ErrInvalidInput, qrcodec.MaxStructuredAppend)
+	}
+	binary := config.ExtraParams["binary"] == "true"
+	chunks := splitContent(config.URL, parts, binary)
+	if chunks == nil {
+		return nil, i18n.Errorf("%w: the payload has %d bytes; it cannot be split into %d QR codes", ErrInvalidInput, len(config.URL), parts)
+	}
+
+	// La paridad cubre los bytes de todos los segmentos tal como los escribe
+	// encodeSymbol: convertidos con --charset y en Shift-JIS los kanji
+	symbols := make([][]qrcodec.Segment, len(chunks))
+	for i, chunk := range chunks {
+		symbols[i] = []qrcodec.Segment{{Data: []byte(chunk)}}
+		if binary {
+			continue
+		}
+		segments, err := textSegments(config, chunk)
+		if err != nil {
+			return nil, err
+		}
+		if segments != nil {
+			symbols[i] = segments
+		}
+	}
+	parity := qrcodec.Parity(symbols...)
+
+	var results []*QRResult
+	for i, chunk := range chunks {
+		cfg := config
+		cfg.URL = chunk
+		cfg.OutputPath = StructuredAppendPath(config.OutputPath, i+1, len(chunks))
+		cfg.structuredAppend = &qrcodec.StructuredAppend{Index: i, Total: len(chunks), Parity: parity}
+		result, err := GenerateQR(cfg)
+		if err != nil {
+			return results, i18n.Errorf("QR %d of %d: %w", i+1, len(chunks), err)
+		}
+		if i > 0 {
+			result.Warnings = nil
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
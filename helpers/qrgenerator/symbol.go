@@ -0,0 +1,90 @@
+package qrgenerator
+
+import (
+	"image"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Symbols son los valores de ExtraParams "symbol"
+func Symbols() []string {
+	return append([]string{"qr", "rmqr", "datamatrix", "aztec", "pdf417"}, linearSymbols()...)
+}
+
+// symbolFor lee la simbología de ExtraParams ("symbol"); sin ella es qr
+func symbolFor(config QRConfig) (string, error) {
+	symbol := strings.ToLower(strings.TrimSpace(config.ExtraParams["symbol"]))
+	if symbol == "" {
+		return "qr", nil
+	}
+	if slices.Contains(Symbols(), symbol) {
+		return symbol, nil
+	}
+	return "", i18n.Errorf("%w: unknown symbol %s (%s)", ErrInvalidInput, config.ExtraParams["symbol"], strings.Join(Symbols(), ", "))
+}
+
+// symbolProblems valida las opciones de las simbologías que no son el QR
+// cuadrado: la forma, y las opciones del QR que no tienen. El manifiesto y
+// la placa del lowerthird esperan un QR.
+func symbolProblems(config QRConfig, symbol string) []error {
+	var errs []error
+	switch symbol {
+	case "rmqr":
+		if _, err := rmqrShapeFor(config); err != nil {
+			errs = append(errs, err)
+		}
+		if level := strings.ToUpper(strings.TrimSpace(config.ExtraParams["ec"])); level == "L" || level == "Q" {
+			errs = append(errs, i18n.Errorf("%w: rMQR only has EC levels M and H", ErrInvalidInput))
+		}
+	case "datamatrix":
+		if _, err := dataMatrixOptions(config); err != nil {
+			errs = append(errs, err)
+		}
+	case "pdf417":
+		if _, err := pdf417Options(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	style, _ := presetFor(config)
+	shape, _ := moduleShapeFor(config)
+	eye, _ := eyeShapeFor(config)
+	mode := strings.ToLower(strings.TrimSpace(config.ExtraParams["mode"]))
+	linear := slices.Contains(linearSymbols(), symbol)
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"qr-version", config.Version != 0},
+		{"mask", config.ExtraParams["mask"] != "" && !strings.EqualFold(config.ExtraParams["mask"], "auto")},
+		{"fit", config.ExtraParams["fit"] != "" && !strings.EqualFold(config.ExtraParams["fit"], "none")},
+		// FNC1 en el rMQR y el PDF417 no está implementado; Data Matrix, Aztec
+		// y Code 128 lo escriben. Los códigos de productos llevan solo el GTIN
+		// y Code 39 no tiene FNC1.
+		{"type gs1", (symbol == "rmqr" || symbol == "pdf417" || symbol == "code39" || slices.Contains(retailSymbols, symbol)) && config.ExtraParams["gs1"] == "true"},
+		// Data Matrix ECC 200 tiene una sola corrección, Aztec usa la
+		// recomendada y PDF417 la pide con --ec-ratio; los tres eligen la
+		// codificación solos. Los lineales no tienen corrección.
+		{"ec", (symbol == "datamatrix" || symbol == "aztec" || symbol == "pdf417" || linear) && config.ExtraParams["ec"] != ""},
+		{"mode " + mode, (symbol == "datamatrix" || symbol == "aztec" || symbol == "pdf417" || linear) && mode != "" && mode != "auto"},
+		// Los Aztec son siempre cuadrados, el PDF417 se mide con --columns y
+		// --rows y los lineales con su contenido
+		{"shape", (symbol == "aztec" || symbol == "pdf417" || linear) && config.ExtraParams["shape"] != ""},
+		// Los lineales escriben ASCII, sin ECI
+		{"charset", linear && config.ExtraParams["charset"] != ""},
+		// Las barras se leen por su ancho y los bordes de los demás símbolos
+		// tienen que ser líneas continuas; las formas son solo para QR
+		{"module-style " + string(shape), shape != "" && shape != shapeSquare},
+		{"eye-style " + string(eye), eye != "" && eye != eyeSquare},
+		{"manifest", config.ExtraParams["manifest"] != ""},
+		{"preset " + config.ExtraParams["preset"], style.canvas != (image.Point{})},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			errs = append(errs, i18n.Errorf("%w: --symbol %s cannot be combined with --%s", ErrInvalidInput, symbol, conflict.flag))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,67 @@
+package qrgenerator
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Lados aceptados para la miniatura (ExtraParams "thumbnail"), en píxeles
+const (
+	MinThumbnail = 16
+	MaxThumbnail = 1024
+)
+
+// ThumbnailPath es la ruta de la miniatura de un archivo: qr.svg da qr_thumb.png
+func ThumbnailPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "_thumb.png"
+}
+
+// thumbnailSize lee el lado de la miniatura; 0 si no se pidió
+func thumbnailSize(config QRConfig) (int, error) {
+	value := config.ExtraParams["thumbnail"]
+	if value == "" {
+		return 0, nil
+	}
+	side, err := strconv.Atoi(value)
+	if err != nil || side < MinThumbnail || side > MaxThumbnail {
+		return 0, i18n.Errorf("%w: thumbnail size %s out of range (%d-%dpx)", ErrInvalidInput, value, MinThumbnail, MaxThumbnail)
+	}
+	return side, nil
+}
+
+// writeThumbnail reduce la imagen para que entre en un cuadrado de side
+// píxeles, sin agrandarla ni deformarla, y la escribe como PNG. El mismo
+// payload y las mismas opciones dan siempre los mismos bytes, así un sistema
+// de assets puede detectar cambios por hash sin volver a dibujar.
+func writeThumbnail(qrImage image.Image, path string, side int) error {
+	bounds := qrImage.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > side || height > side {
+		if width >= height {
+			width, height = side, max(1, height*side/width)
+		} else {
+			width, height = max(1, width*side/height), side
+		}
+	}
+	thumb := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(thumb, thumb.Bounds(), qrImage, bounds, xdraw.Src, nil)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return i18n.Errorf("%w: error creating thumbnail: %w", ErrIO, err)
+	}
+	defer f.Close()
+	enc := &png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(f, thumb); err != nil {
+		return i18n.Errorf("%w: error encoding thumbnail: %w", ErrEncode, err)
+	}
+	return closeOutput(f)
+}
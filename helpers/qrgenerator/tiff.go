@@ -0,0 +1,78 @@
+package qrgenerator
+
+import (
+	"image"
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/tiffenc"
+)
+
+// FormatTIFF es TIFF bitonal, el formato que aceptan muchos sistemas de etiquetado
+const FormatTIFF OutputFormat = "tiff"
+
+type tiffGenerator struct{}
+
+// Implementación para TIFF
+func (g *tiffGenerator) Generate(qrImage image.Image, config QRConfig) error {
+	return g.GeneratePages([]image.Image{qrImage}, config)
+}
+
+// GeneratePages escribe las imágenes como páginas de un único TIFF
+func (g *tiffGenerator) GeneratePages(images []image.Image, config QRConfig) error {
+	opts, err := tiffOptions(config)
+	if err != nil {
+		return err
+	}
+	dark, _, err := symbolColors(config)
+	if err != nil {
+		return err
+	}
+
+	pages := make([]tiffenc.Page, len(images))
+	for i, img := range images {
+		bounds := img.Bounds()
+		pages[i] = tiffenc.Page{Width: bounds.Dx(), Height: bounds.Dy(), Black: inkPixels(img, dark)}
+	}
+
+	f, err := os.Create(config.OutputPath)
+	if err != nil {
+		return i18n.Errorf("%w: error creating TIFF file: %w", ErrIO, err)
+	}
+	defer f.Close()
+
+	if err := tiffenc.Encode(f, pages, &opts); err != nil {
+		return i18n.Errorf("%w: error encoding TIFF: %w", ErrEncode, err)
+	}
+	return closeOutput(f)
+}
+
+// tiffOptions lee la compresión de ExtraParams ("compression": g4 o lzw).
+// Con --fg o --bg el TIFF lleva una paleta, que G4 no admite: la compresión
+// por defecto pasa a ser LZW.
+func tiffOptions(config QRConfig) (tiffenc.Options, error) {
+	opts := tiffenc.Options{Compression: tiffenc.CompressionG4, DPI: resolutionFor(config)}
+	colored := config.ExtraParams["fg"] != "" || config.ExtraParams["bg"] != ""
+	switch compression := strings.ToLower(config.ExtraParams["compression"]); compression {
+	case "", "g4", "ccitt":
+		if colored && compression != "" {
+			return opts, i18n.Errorf("%w: TIFF %s compression is black and white only; use --tiff-compression lzw with --fg and --bg", ErrInvalidInput, compression)
+		}
+		if colored {
+			opts.Compression = tiffenc.CompressionLZW
+		}
+	case "lzw":
+		opts.Compression = tiffenc.CompressionLZW
+	default:
+		return opts, i18n.Errorf("%w: unsupported TIFF compression: %s (g4 or lzw)", ErrInvalidInput, compression)
+	}
+	if colored {
+		palette, err := symbolPalette(config)
+		if err != nil {
+			return opts, err
+		}
+		opts.Palette = palette
+	}
+	return opts, nil
+}
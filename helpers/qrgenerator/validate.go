@@ -0,0 +1,335 @@
+package qrgenerator
+
+import (
+	"errors"
+	"image/color"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/checkdigit"
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/encrypt"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/paperkey"
+	"qrgenerator_cli/helpers/sign"
+)
+
+// MinSize es el tamaño mínimo aceptado: el QR más chico (versión 1) mide 29
+// módulos con la zona de silencio, así que por debajo no entra un píxel por módulo
+const MinSize = 21 + 2*qrBorder
+
+// Problem es un problema encontrado al validar la configuración
+type Problem struct {
+	Err     error
+	Warning bool // true si no impide generar
+}
+
+// extensions asocia cada extensión de archivo con su formato
+var extensions = map[string]OutputFormat{
+	".jpg":  FormatJPEG,
+	".jpeg": FormatJPEG,
+	".png":  FormatPNG,
+	".svg":  FormatSVG,
+	".css":  FormatCSS,
+	".avif": FormatAVIF,
+	".heic": FormatHEIF,
+	".heif": FormatHEIF,
+	".tif":  FormatTIFF,
+	".tiff": FormatTIFF,
+	".pdf":  FormatPDF,
+	".json": FormatMatrix,
+	".pbm":  FormatMatrix,
+	".txt":  FormatMatrix,
+	".h":    FormatMatrix,
+	".c":    FormatMatrix,
+	".rs":   FormatMatrix,
+}
+
+// ParseFormat interpreta un nombre de formato; acepta también las extensiones
+// alternativas (jpg, heif)
+func ParseFormat(name string) (OutputFormat, bool) {
+	name = strings.ToLower(strings.TrimPrefix(name, "."))
+	if _, ok := generators[OutputFormat(name)]; ok {
+		return OutputFormat(name), true
+	}
+	format, ok := extensions["."+name]
+	return format, ok
+}
+
+// ResolveFormat elige el formato de salida. Con un formato explícito la
+// extensión solo se compara para advertir si no coinciden; sin él, el formato
+// sale de la extensión y una ruta sin extensión es un error.
+func ResolveFormat(path, explicit string) (OutputFormat, []Problem) {
+	ext := filepath.Ext(path)
+	fromExt, known := extensions[strings.ToLower(ext)]
+
+	if explicit != "" {
+		format, ok := ParseFormat(explicit)
+		if !ok {
+			return "", []Problem{{Err: i18n.Errorf("%w: unsupported format: %s", ErrInvalidInput, explicit)}}
+		}
+		switch {
+		case ext == "":
+			return format, nil
+		case !known || fromExt != format:
+			return format, []Problem{{Err: i18n.Errorf("output extension %s does not match --format %s", ext, format), Warning: true}}
+		}
+		return format, nil
+	}
+
+	switch {
+	case ext == "":
+		return "", []Problem{{Err: i18n.Errorf("%w: output path %q has no extension; add one (.png, .svg, .jpg...) or pass --format", ErrInvalidInput, path)}}
+	case !known:
+		// Compatibilidad: las extensiones desconocidas siempre generaron JPEG
+		return FormatJPEG, []Problem{{Err: i18n.Errorf("unknown output extension %s; writing JPEG", ext), Warning: true}}
+	}
+	return fromExt, nil
+}
+
+// Validate revisa la configuración antes de generar y devuelve todos los
+// problemas encontrados, errores y advertencias, en lugar de cortar en el
+// primero. Un formato vacío se considera sin resolver (ver ResolveFormat) y
+// solo se revisa el resto.
+func Validate(config QRConfig) []Problem {
+	var problems []Problem
+	fail := func(err error) {
+		problems = append(problems, Problem{Err: err})
+	}
+	warn := func(msg string) {
+		problems = append(problems, Problem{Err: errors.New(msg), Warning: true})
+	}
+
+	if config.URL == "" {
+		fail(i18n.Errorf("%w: URL is required", ErrInvalidInput))
+	}
+
+	maxSize := config.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if config.Size != 0 && (config.Size < MinSize || config.Size > maxSize) {
+		fail(i18n.Errorf("%w: size %dpx out of range (%d-%dpx)", ErrInvalidInput, config.Size, MinSize, maxSize))
+	}
+
+	if _, _, err := maskFor(config); err != nil {
+		fail(err)
+	}
+	if _, err := scaleFor(config); err != nil {
+		fail(err)
+	}
+	if _, err := printWidth(config); err != nil {
+		fail(err)
+	}
+	if _, err := printDPI(config); err != nil {
+		fail(err)
+	}
+	if border, err := borderFor(config); err != nil {
+		fail(err)
+	} else if border < minBorder(config) {
+		// Una zona de silencio angosta es la causa más común de códigos impresos ilegibles
+		warn(i18n.Sprintf("a quiet zone of %d modules is narrower than the %d the standard asks for; many scanners will not read the code", border, minBorder(config)))
+	}
+	if mode, err := modeFor(config); err != nil {
+		fail(err)
+	} else if mode != "auto" {
+		// Las cadenas GS1 arman sus propios segmentos y los datos binarios
+		// van en modo byte. El modo kanji solo escribe el texto tal cual: los
+		// payloads transformados no son kanji.
+		kanji := mode == "kanji"
+		conflicts := []struct {
+			flag string
+			set  bool
+		}{
+			{"type gs1", config.ExtraParams["gs1"] == "true"},
+			{"input-file", config.ExtraParams["binary"] == "true" && mode != "byte"},
+			{"compress", kanji && config.ExtraParams["compress"] != ""},
+			{"encrypt-to", kanji && config.ExtraParams["encrypt-to"] != ""},
+			{"encrypt", kanji && config.Passphrase != ""},
+			{"check-digit", kanji && config.ExtraParams["check-digit"] != ""},
+			{"sign", kanji && config.ExtraParams["sign"] != ""},
+			{"charset", kanji && config.ExtraParams["charset"] != ""},
+		}
+		for _, conflict := range conflicts {
+			if conflict.set {
+				fail(i18n.Errorf("%w: --mode %s cannot be combined with --%s", ErrInvalidInput, mode, conflict.flag))
+			}
+		}
+	}
+	if _, err := charsetFor(config); err != nil {
+		fail(err)
+	} else if config.ExtraParams["charset"] != "" && config.ExtraParams["binary"] == "true" {
+		// Los bytes del archivo se escriben tal cual: no hay texto que convertir
+		fail(i18n.Errorf("%w: --input-file cannot be combined with --charset", ErrInvalidInput))
+	}
+	if symbol, err := symbolFor(config); err != nil {
+		fail(err)
+	} else if symbol != "qr" {
+		for _, err := range symbolProblems(config, symbol) {
+			fail(err)
+		}
+	} else if config.ExtraParams["shape"] != "" {
+		fail(i18n.Errorf("%w: --shape needs --symbol rmqr or datamatrix", ErrInvalidInput))
+	}
+	if !isPDF417(config) {
+		for _, flag := range []string{"columns", "rows", "ec-ratio"} {
+			if config.ExtraParams[flag] != "" {
+				fail(i18n.Errorf("%w: --%s needs --symbol pdf417", ErrInvalidInput, flag))
+			}
+		}
+	}
+	if config.ExtraParams["show-text"] == "true" && !isLinear(config) {
+		fail(i18n.Errorf("%w: --show-text needs a barcode symbol (%s)", ErrInvalidInput, strings.Join(linearSymbols(), ", ")))
+	}
+	if config.ExtraParams["mod43"] == "true" && !isCode39(config) {
+		fail(i18n.Errorf("%w: --mod43 needs --symbol code39", ErrInvalidInput))
+	}
+	if config.Version < 0 || config.Version > 40 {
+		fail(i18n.Errorf("%w: --qr-version must be a number from 1 to 40", ErrInvalidInput))
+	}
+	if level, err := levelFor(config); err != nil {
+		fail(err)
+	} else if _, err := fitFor(config, level); err != nil {
+		fail(err)
+	}
+	if _, err := thumbnailSize(config); err != nil {
+		fail(err)
+	}
+	if distance := config.ExtraParams["scan-distance"]; distance != "" {
+		if _, err := ParseLength(distance); err != nil {
+			fail(err)
+		}
+	}
+	if method := config.ExtraParams["compress"]; method != "" {
+		if err := compress.CheckMethod(strings.ToLower(method)); err != nil {
+			fail(i18n.Errorf("%w: %w", ErrInvalidInput, err))
+		}
+	}
+	if scheme := config.ExtraParams["check-digit"]; scheme != "" {
+		if err := checkdigit.CheckScheme(strings.ToLower(scheme)); err != nil {
+			fail(i18n.Errorf("%w: %w", ErrInvalidInput, err))
+		}
+	}
+	if keyPath := config.ExtraParams["sign"]; keyPath != "" {
+		if err := sign.CheckKey(keyPath); err != nil {
+			fail(i18n.Errorf("%w: --sign: %w", ErrInvalidInput, err))
+		}
+	}
+	if config.ExtraParams["gs1"] == "true" {
+		// Los datos de una cadena GS1 los interpreta el lector: no se pueden transformar
+		for _, param := range []string{"compress", "encrypt-to", "check-digit", "sign", "charset"} {
+			if config.ExtraParams[param] != "" {
+				fail(i18n.Errorf("%w: --type gs1 cannot be combined with --%s", ErrInvalidInput, param))
+			}
+		}
+	}
+	if config.ExtraParams["binary"] == "true" && config.ExtraParams["check-digit"] != "" {
+		// Los caracteres de control se calculan sobre un serial en texto
+		fail(i18n.Errorf("%w: --input-file cannot be combined with --check-digit", ErrInvalidInput))
+	}
+	if config.Passphrase != "" && len([]rune(config.Passphrase)) < paperkey.MinPassphrase {
+		fail(i18n.Errorf("%w: --encrypt: the passphrase needs at least %d characters", ErrInvalidInput, paperkey.MinPassphrase))
+	}
+	if config.Passphrase != "" && config.ExtraParams["gs1"] == "true" {
+		fail(i18n.Errorf("%w: --type gs1 cannot be combined with --encrypt", ErrInvalidInput))
+	}
+	if config.ExtraParams["binary"] == "true" && config.ExtraParams["sign"] != "" {
+		// decode -binary escribe los bytes del símbolo; no vería el JWS
+		fail(i18n.Errorf("%w: --input-file cannot be combined with --sign", ErrInvalidInput))
+	}
+	if dark, light, err := symbolColors(config); err != nil {
+		fail(err)
+	} else {
+		if isTransparent(light) {
+			// El fondo es lo que quede debajo del código; en el mejor caso, papel blanco
+			light = color.White
+		}
+		if g, _ := gradientFor(config); g != nil {
+			// El degradado se revisa en su punto más parecido al fondo
+			dark = g.weakest(light)
+		}
+		problems = append(problems, ColorProblems(dark, light, config.ExtraParams["allow-inverted"] == "true")...)
+	}
+	if recipient := config.ExtraParams["encrypt-to"]; recipient != "" {
+		if err := encrypt.Available(recipient); err != nil {
+			fail(i18n.Errorf("%w: %w", ErrEncode, err))
+		}
+	}
+	if style, err := presetFor(config); err != nil {
+		fail(err)
+	} else {
+		for _, err := range overlayProblems(config, style) {
+			fail(err)
+		}
+	}
+
+	if config.Format == "" {
+		return problems
+	}
+	if _, ok := generators[config.Format]; !ok {
+		fail(i18n.Errorf("%w: unsupported format: %s", ErrInvalidInput, config.Format))
+		return problems
+	}
+	if err := Available(config.Format); err != nil {
+		fail(err)
+	}
+
+	var warnings []string
+	switch config.Format {
+	case FormatJPEG:
+		if _, err := jpegOptions(config); err != nil {
+			fail(err)
+		}
+		warnings = jpegWarnings(config)
+	case FormatAVIF:
+		if _, err := avifOptions(config); err != nil {
+			fail(err)
+		}
+		warnings = avifWarnings(config)
+	case FormatHEIF:
+		if _, err := heifQuality(config); err != nil {
+			fail(err)
+		}
+		warnings = heifWarnings(config)
+	case FormatTIFF:
+		if _, err := tiffOptions(config); err != nil {
+			fail(err)
+		}
+	case FormatMatrix:
+		if _, err := matrixEncoding(config); err != nil {
+			fail(err)
+		}
+	}
+	if config.ExtraParams["fg-gradient"] != "" {
+		if config.ExtraParams["fg"] != "" {
+			fail(i18n.Errorf("%w: --fg and --fg-gradient cannot be combined; the gradient sets the module colors", ErrInvalidInput))
+		}
+		if config.Format != "" && !slices.Contains(gradientFormats, config.Format) {
+			fail(i18n.Errorf("%w: %s takes a single module color; use --fg, or PNG, JPEG, AVIF, HEIF or SVG for --fg-gradient", ErrInvalidInput, config.Format))
+		}
+	}
+	if shape, err := moduleShapeFor(config); err != nil {
+		fail(err)
+	} else if shape != shapeSquare && config.Format != "" && !slices.Contains(styleFormats, config.Format) {
+		fail(i18n.Errorf("%w: %s draws square modules; use PNG, JPEG, AVIF, HEIF, TIFF or SVG for --%s", ErrInvalidInput, config.Format, "module-style"))
+	}
+	if eye, err := eyeShapeFor(config); err != nil {
+		fail(err)
+	} else if eye != eyeSquare && config.Format != "" && !slices.Contains(styleFormats, config.Format) {
+		fail(i18n.Errorf("%w: %s draws square modules; use PNG, JPEG, AVIF, HEIF, TIFF or SVG for --%s", ErrInvalidInput, config.Format, "eye-style"))
+	}
+	if transparentBackground(config) && (config.Format == FormatJPEG || config.Format == FormatTIFF) {
+		fail(i18n.Errorf("%w: %s has no transparency; use PNG, SVG, AVIF or HEIF with --bg transparent", ErrInvalidInput, config.Format))
+	}
+	if dpi := resolutionFor(config); dpi > 0 && slices.Contains([]OutputFormat{FormatAVIF, FormatHEIF, FormatCSS}, config.Format) {
+		warnings = append(warnings, i18n.Sprintf("%s cannot record the print resolution; print it at %d DPI", config.Format, dpi))
+	}
+	if config.ExtraParams["manifest"] != "" && !slices.Contains(manifestFormats, config.Format) {
+		warnings = append(warnings, i18n.Sprintf("%s cannot carry a manifest; only PNG and SVG embed one", config.Format))
+	}
+	for _, warning := range warnings {
+		warn(warning)
+	}
+	return problems
+}
@@ -0,0 +1,33 @@
+package qrgenerator_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// benchPayload llena un QR versión 10 aproximadamente, para que el SVG y el
+// CSS tengan miles de módulos oscuros
+const benchPayload = "https://example.com/benchmark?id=0123456789abcdef0123456789abcdef&lang=es&ref=newsletter-2026"
+
+// benchmarkWriter genera el payload a 4096px en el formato indicado
+func benchmarkWriter(b *testing.B, format qrgenerator.OutputFormat) {
+	path := filepath.Join(b.TempDir(), "qr."+string(format))
+	config := qrgenerator.QRConfig{
+		URL:        benchPayload,
+		Size:       4096,
+		OutputPath: path,
+		Format:     format,
+	}
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := qrgenerator.GenerateQR(config); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSVG(b *testing.B) { benchmarkWriter(b, qrgenerator.FormatSVG) }
+
+func BenchmarkCSS(b *testing.B) { benchmarkWriter(b, qrgenerator.FormatCSS) }
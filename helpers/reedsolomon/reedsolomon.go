@@ -0,0 +1,218 @@
+// Package reedsolomon implementa la corrección Reed-Solomon sobre campos de
+// Galois GF(2^m) que comparten el QR, Data Matrix y Aztec; cada simbología
+// elige el polinomio primitivo, el tamaño del campo y la primera raíz del
+// generador.
+package reedsolomon
+
+import "qrgenerator_cli/helpers/i18n"
+
+// ErrTooManyErrors indica que un bloque tiene más errores de los que se pueden corregir
+var ErrTooManyErrors = i18n.NewError("too many errors to correct")
+
+// Field es un campo de Galois GF(2^m) con el generador
+// (x-alfa^base)(x-alfa^(base+1))...: el QR empieza en alfa^0, Data Matrix y
+// Aztec en alfa^1
+type Field struct {
+	size int // 2^m
+	base int // Exponente de la primera raíz del generador
+	exp  []int
+	log  []int
+}
+
+// NewField arma las tablas de exponentes y logaritmos del campo con el
+// polinomio primitivo poly
+func NewField(poly, size, base int) *Field {
+	f := &Field{size: size, base: base, exp: make([]int, 2*size), log: make([]int, size)}
+	x := 1
+	for i := 0; i < size-1; i++ {
+		f.exp[i] = x
+		f.log[x] = i
+		x <<= 1
+		if x >= size {
+			x ^= poly
+		}
+	}
+	for i := size - 1; i < 2*size; i++ {
+		f.exp[i] = f.exp[i-(size-1)]
+	}
+	return f
+}
+
+// mul multiplica dos elementos del campo
+func (f *Field) mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+// div divide dos elementos del campo; b no puede ser cero
+func (f *Field) div(a, b int) int {
+	if a == 0 {
+		return 0
+	}
+	return f.exp[(f.log[a]+f.size-1-f.log[b])%(f.size-1)]
+}
+
+// pow eleva alfa a la potencia indicada (admite exponentes negativos)
+func (f *Field) pow(e int) int {
+	e %= f.size - 1
+	if e < 0 {
+		e += f.size - 1
+	}
+	return f.exp[e]
+}
+
+// eval evalúa un polinomio con coeficientes en orden ascendente
+func (f *Field) eval(poly []int, x int) int {
+	y := 0
+	for i := len(poly) - 1; i >= 0; i-- {
+		y = f.mul(y, x) ^ poly[i]
+	}
+	return y
+}
+
+// Encode devuelve las n palabras de corrección de data
+func (f *Field) Encode(data []int, n int) []int {
+	g := []int{1}
+	for i := 0; i < n; i++ {
+		next := make([]int, len(g)+1)
+		for j, c := range g {
+			next[j] ^= c
+			next[j+1] ^= f.mul(c, f.pow(f.base+i))
+		}
+		g = next
+	}
+	ecc := make([]int, n)
+	for _, d := range data {
+		factor := d ^ ecc[0]
+		copy(ecc, ecc[1:])
+		ecc[n-1] = 0
+		for i := range ecc {
+			ecc[i] ^= f.mul(g[i+1], factor)
+		}
+	}
+	return ecc
+}
+
+// Correct corrige en el lugar las palabras (datos seguidos de ecLen de
+// corrección) y devuelve la cantidad de palabras corregidas
+func (f *Field) Correct(words []int, ecLen int) (int, error) {
+	n := len(words)
+
+	// Síndromes S_i = r(alfa^(base+i)); words[j] es el coeficiente de x^(n-1-j)
+	syndromes := make([]int, ecLen)
+	clean := true
+	for i := range syndromes {
+		s, a := 0, f.pow(f.base+i)
+		for _, c := range words {
+			s = f.mul(s, a) ^ c
+		}
+		syndromes[i] = s
+		if s != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return 0, nil
+	}
+
+	// Berlekamp-Massey: polinomio localizador de errores en orden ascendente
+	locator, prev := []int{1}, []int{1}
+	errCount, shift, prevDiscrepancy := 0, 1, 1
+	for k := 0; k < ecLen; k++ {
+		d := syndromes[k]
+		for i := 1; i <= errCount && i < len(locator); i++ {
+			d ^= f.mul(locator[i], syndromes[k-i])
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		scale := f.div(d, prevDiscrepancy)
+		next := make([]int, max(len(locator), len(prev)+shift))
+		copy(next, locator)
+		for i, c := range prev {
+			next[i+shift] ^= f.mul(scale, c)
+		}
+		if 2*errCount <= k {
+			prev = locator
+			errCount = k + 1 - errCount
+			prevDiscrepancy = d
+			shift = 1
+		} else {
+			shift++
+		}
+		locator = next
+	}
+	if 2*errCount > ecLen {
+		return 0, ErrTooManyErrors
+	}
+
+	// Búsqueda de Chien: la potencia p tiene error si locator(alfa^-p) == 0
+	var positions []int
+	for p := 0; p < n; p++ {
+		if f.eval(locator, f.pow(-p)) == 0 {
+			positions = append(positions, p)
+		}
+	}
+	if len(positions) != errCount {
+		return 0, ErrTooManyErrors
+	}
+
+	// Forney: evaluador omega = S(x)·locator(x) mod x^ecLen; la magnitud es
+	// X^(1-base)·omega(X^-1)/locator'(X^-1)
+	omega := make([]int, ecLen)
+	for i := range omega {
+		for j := 0; j <= i && j < len(locator); j++ {
+			omega[i] ^= f.mul(locator[j], syndromes[i-j])
+		}
+	}
+	for _, p := range positions {
+		// Derivada formal: en característica 2 solo sobreviven los términos impares
+		derivative := 0
+		for i := 1; i < len(locator); i += 2 {
+			derivative ^= f.mul(locator[i], f.pow(-p*(i-1)))
+		}
+		if derivative == 0 {
+			return 0, ErrTooManyErrors
+		}
+		magnitude := f.div(f.eval(omega, f.pow(-p)), derivative)
+		words[n-1-p] ^= f.mul(f.pow(p*(1-f.base)), magnitude)
+	}
+	return errCount, nil
+}
+
+// EncodeBytes es Encode para campos de 8 bits con codewords en bytes
+func (f *Field) EncodeBytes(data []byte, n int) []byte {
+	return toBytes(f.Encode(toInts(data), n))
+}
+
+// CorrectBytes es Correct para campos de 8 bits: corrige block en el lugar
+func (f *Field) CorrectBytes(block []byte, ecLen int) (int, error) {
+	words := toInts(block)
+	n, err := f.Correct(words, ecLen)
+	if err != nil {
+		return 0, err
+	}
+	copy(block, toBytes(words))
+	return n, nil
+}
+
+// toInts pasa codewords de bytes a palabras
+func toInts(data []byte) []int {
+	words := make([]int, len(data))
+	for i, b := range data {
+		words[i] = int(b)
+	}
+	return words
+}
+
+// toBytes pasa palabras de 8 bits a codewords
+func toBytes(words []int) []byte {
+	data := make([]byte, len(words))
+	for i, w := range words {
+		data[i] = byte(w)
+	}
+	return data
+}
@@ -0,0 +1,32 @@
+package selftest
+
+// Archivos de entrada de los tipos que leen una clave o una configuración.
+// Las claves son de prueba, generadas solo para estos casos.
+const (
+	// pgpKey es una clave pública ed25519 exportada con gpg --export --armor
+	pgpKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mDMEatE8ZRYJKwYBBAHaRw8BAQdA7OS3auSUBWf7vN4qrBGmysBfug9ktlk/AD8J
+oJjovPS0H1NlbGZ0ZXN0IDxzZWxmdGVzdEBleGFtcGxlLmNvbT6IkAQTFggAOBYh
+BHmdUS40d6XsvFKOC7JSv5EFFaGFBQJq0TxlAhsDBQsJCAcCBhUKCQgLAgQWAgMB
+Ah4BAheAAAoJELJSv5EFFaGFxSYBAOKOKSYvAWtqnYk87GN/c2bDMWi4kx39rSOO
+SD2Ji9rQAQDL6naTiNiNRYBFqwsWT8viFTsPtL7+ctMlw84L8OffAA==
+=xxOY
+-----END PGP PUBLIC KEY BLOCK-----
+`
+
+	// sshKey es una clave pública ed25519 de ssh-keygen
+	sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINaa6GGKsBbmYRHCXiDesI6Z1t+DfoybtIrhNertt4+W deploy@example.com\n"
+
+	// wgConf es la configuración de un cliente con un solo peer
+	wgConf = `[Interface]
+PrivateKey = IxxOpXkaol1hCcHcwEaKoTzuo7Sp6aQVAcc+KA2RP1M=
+Address = 10.0.0.2/32
+DNS = 10.0.0.1
+
+[Peer]
+PublicKey = 01OZl1RqZv/esWjHDX1cC4JbadSWVWRGJhPKdjQaGU8=
+AllowedIPs = 0.0.0.0/0
+Endpoint = vpn.example.com:51820
+`
+)
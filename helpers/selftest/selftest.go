@@ -0,0 +1,378 @@
+package selftest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/draw"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/encrypt"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/payload"
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// Case es un payload de prueba con los valores esperados al decodificarlo
+type Case struct {
+	Name        string
+	Payload     string
+	WantVersion int               // Versión esperada del símbolo con corrección H
+	WantText    string            // Contenido esperado si no es Payload, como con un dígito de control
+	Params      map[string]string // ExtraParams con los que se genera, como --mode
+	Type        string            // Tipo de payload que arma el contenido a partir de Values, como --type
+	Values      payload.Values
+	Files       map[string]string // Archivos que se escriben en el directorio de la prueba; el campo recibe su ruta
+	WantPattern string            // Expresión del contenido esperado cuando cambia en cada corrida, como --type uuid
+	Passphrase  string            // Frase con la que se cifra (--encrypt) y se descifra al decodificar
+}
+
+// Cases son los payloads de referencia que se prueban en cada formato
+var Cases = []Case{
+	{Name: "url", Payload: "https://example.com/path?q=1", WantVersion: 4},
+	{Name: "text", Payload: "Hola, mundo", WantVersion: 2},
+	{Name: "numeric", Payload: "01234567890123456789", WantVersion: 2},
+	{Name: "unicode", Payload: "ñandú ☃ 😀", WantVersion: 3},
+	{Name: "kanji", Payload: "東京都渋谷区神南一丁目", WantVersion: 3},
+	// Emoji de 4 bytes, secuencias ZWJ y banderas, con tramos numéricos en medio
+	{Name: "emoji", Payload: "👩‍💻 🇦🇷 𝄞 😀 0123456789", WantVersion: 5},
+	{Name: "emoji-byte", Payload: "Precio: 1234567890 € 🍕", WantVersion: 4, Params: map[string]string{"mode": "byte"}},
+	{Name: "long", Payload: strings.Repeat("0123456789abcdef", 16), WantVersion: 16},
+	{Name: "scale", Payload: "https://example.com/s", WantVersion: 3, Params: map[string]string{"scale": "3"}},
+	{Name: "print", Payload: "https://example.com/p", WantVersion: 3, Params: map[string]string{"width": "25mm", "dpi": "300"}},
+	{Name: "border", Payload: "https://example.com/b", WantVersion: 3, Params: map[string]string{"border": "8"}},
+	// Colores de marca: el TIFF pasa a una paleta con LZW y el PDF pinta el fondo
+	{Name: "colors", Payload: "https://example.com/c", WantVersion: 3, Params: map[string]string{"fg": "midnightblue", "bg": "#fdf6e3"}},
+	// rMQR R11x43, la versión 12 de las 32 formas
+	{Name: "rmqr", Payload: "CABLE-0042-A", WantVersion: 12, Params: map[string]string{"symbol": "rmqr"}},
+	// Data Matrix 16x16, el cuarto de los 30 tamaños
+	{Name: "datamatrix", Payload: "MFG-2026-000123", WantVersion: 4, Params: map[string]string{"symbol": "datamatrix"}},
+	// Aztec compacto de 23x23, el tercero de los 36 tamaños, con un pasaje de avión
+	{Name: "aztec", Payload: "M1DOE/JOHN EABC123 JFKLAXAA 0123 123Y012A0001 100", WantVersion: 3, Params: map[string]string{"symbol": "aztec"}},
+	// PDF417 de 4 columnas con una etiqueta de envío; Version son las columnas
+	{Name: "pdf417", Payload: "SHIP TO: JANE DOE, 123 MAIN ST, SPRINGFIELD 12345", WantVersion: 4, Params: map[string]string{"symbol": "pdf417", "columns": "4"}},
+	// Code 128 de un número de seguimiento con el texto legible, que no
+	// tiene que confundir al lector; Version son los caracteres de símbolo
+	{Name: "code128", Payload: "RI476394652CH", WantVersion: 11, Params: map[string]string{"symbol": "code128", "show-text": "true"}},
+	// Códigos de productos con sus dígitos debajo, y el ISBN además arriba;
+	// no tienen versión
+	{Name: "ean13", Payload: "4006381333931", Params: map[string]string{"symbol": "ean13"}},
+	{Name: "upca", Payload: "036000291452", Params: map[string]string{"symbol": "upca"}},
+	{Name: "isbn", Payload: "9780306406157", Params: map[string]string{"symbol": "isbn"}},
+	// Code 39 de un inventario con el dígito de control módulo 43, que el
+	// lector devuelve con los datos; Version son los caracteres
+	{Name: "code39", Payload: "INV-0042", WantText: "INV-0042S", WantVersion: 9, Params: map[string]string{"symbol": "code39", "mod43": "true", "show-text": "true"}},
+	// Payloads comprimidos y cifrados, que el decodificador devuelve como
+	// los escribió el usuario
+	{Name: "deflate64", Payload: strings.Repeat("menu del día ", 12), WantVersion: 5, Params: map[string]string{"compress": "base64"}},
+	{Name: "deflate45", Payload: strings.Repeat("MENU DEL DIA ", 12), WantVersion: 4, Params: map[string]string{"compress": "base45"}},
+	{Name: "encrypt", Payload: "WIFI:T:WPA;S:Home;P:correct-horse;;", WantVersion: 9, Passphrase: "selftest passphrase"},
+}
+
+// TypeCases son los payloads de referencia de cada tipo de --type; cada tipo
+// registrado necesita uno (ver MissingTypes)
+var TypeCases = []Case{
+	{Name: "bitcoin", Type: "bitcoin", Values: payload.Values{"address": "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "amount": "0.001", "label": "Coffee"}, WantText: "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.001&label=Coffee", WantVersion: 8},
+	{Name: "email", Type: "email", Values: payload.Values{"to": "jane@example.com", "subject": "Hello", "body": "See you"}, WantText: "mailto:jane@example.com?subject=Hello&body=See%20you", WantVersion: 6},
+	{Name: "epc", Type: "epc", Values: payload.Values{"name": "Red Cross", "iban": "DE89370400440532013000", "bic": "COBADEFFXXX", "amount": "12.50", "remittance": "Invoice 42"}, WantText: "BCD\n002\n1\nSCT\nCOBADEFFXXX\nRed Cross\nDE89370400440532013000\nEUR12.50\n\n\nInvoice 42", WantVersion: 8},
+	{Name: "ethereum", Type: "ethereum", Values: payload.Values{"address": "0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe", "amount": "0.01"}, WantText: "ethereum:0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe?value=10000000000000000", WantVersion: 8},
+	{Name: "event", Type: "event", Values: payload.Values{"title": "Launch", "start": "2026-11-02 10:00", "end": "2026-11-02 11:00", "tz": "UTC"}, WantText: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//qrgenerator_cli//EN\r\nBEGIN:VTIMEZONE\r\nTZID:UTC\r\nBEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0000\r\nTZNAME:UTC\r\nEND:STANDARD\r\nEND:VTIMEZONE\r\nBEGIN:VEVENT\r\nSUMMARY:Launch\r\nDTSTART;TZID=UTC:20261102T100000\r\nDTEND;TZID=UTC:20261102T110000\r\nEND:VEVENT\r\nEND:VCALENDAR", WantVersion: 19},
+	{Name: "geo", Type: "geo", Values: payload.Values{"lat": "40.7128", "lon": "-74.0060"}, WantText: "geo:40.7128,-74.006", WantVersion: 3},
+	{Name: "gs1", Type: "gs1", Values: payload.Values{"elements": "(01)09506000134352(17)261231(10)ABC123"}, WantText: "01095060001343521726123110ABC123", WantVersion: 3},
+	{Name: "gs1-link", Type: "gs1-link", Values: payload.Values{"gtin": "09506000134352", "lot": "ABC123"}, WantText: "https://id.gs1.org/01/09506000134352/10/ABC123", WantVersion: 5},
+	{Name: "mecard", Type: "mecard", Values: payload.Values{"name": "Doe, Jane", "phone": "+1 202 555 0123", "email": "jane@example.com"}, WantText: "MECARD:N:Doe,Jane;TEL:+1 202 555 0123;EMAIL:jane@example.com;;", WantVersion: 7},
+	{Name: "pgp", Type: "pgp", Values: payload.Values{"strip-armor": "true"}, Files: map[string]string{"key-file": pgpKey}, WantText: "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmDMEatE8ZRYJKwYBBAHaRw8BAQdA7OS3auSUBWf7vN4qrBGmysBfug9ktlk/AD8J\noJjovPS0H1NlbGZ0ZXN0IDxzZWxmdGVzdEBleGFtcGxlLmNvbT6IkAQTFggAOBYh\nBHmdUS40d6XsvFKOC7JSv5EFFaGFBQJq0TxlAhsDBQsJCAcCBhUKCQgLAgQWAgMB\nAh4BAheAAAoJELJSv5EFFaGFxSYBAOKOKSYvAWtqnYk87GN/c2bDMWi4kx39rSOO\nSD2Ji9rQAQDL6naTiNiNRYBFqwsWT8viFTsPtL7+ctMlw84L8OffAA==\n-----END PGP PUBLIC KEY BLOCK-----", WantVersion: 20},
+	{Name: "pix", Type: "pix", Values: payload.Values{"key": "pix@example.com", "name": "Loja", "city": "SAO PAULO", "amount": "10.00"}, WantText: "00020126370014br.gov.bcb.pix0115pix@example.com520400005303986540510.005802BR5904Loja6009SAO PAULO62070503***6304E1BF", WantVersion: 9},
+	// SMS a un número ficticio de América del Norte: central 555, línea 01xx
+	{Name: "sms", Type: "sms", Values: payload.Values{"to": "+1 202 555 0123", "body": "hi"}, WantText: "SMSTO:+12025550123:hi", WantVersion: 3},
+	{Name: "social", Type: "social", Values: payload.Values{"platform": "x", "handle": "octocat"}, WantText: "https://x.com/octocat", WantVersion: 3},
+	{Name: "ssh-fingerprint", Type: "ssh-fingerprint", Files: map[string]string{"public-key": sshKey}, WantText: "256 SHA256:/0jZUy47SNLiHwXcN8DnTgVi5pF/AT5vSVaQ0suCbqQ deploy@example.com (ED25519)", WantVersion: 8},
+	{Name: "ssh-key", Type: "ssh-key", Files: map[string]string{"public-key": sshKey}, WantText: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINaa6GGKsBbmYRHCXiDesI6Z1t+DfoybtIrhNertt4+W deploy@example.com", WantVersion: 10},
+	{Name: "tel", Type: "tel", Values: payload.Values{"number": "+1 202 555 0123"}, WantText: "tel:+12025550123", WantVersion: 2},
+	{Name: "token", Type: "token", Values: payload.Values{"length": "24"}, WantPattern: `[0-9A-Z]{24}`, WantVersion: 3},
+	{Name: "totp", Type: "totp", Values: payload.Values{"issuer": "Example", "account": "jane@example.com", "secret": "JBSWY3DPEHPK3PXP"}, WantText: "otpauth://totp/Example:jane@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example", WantVersion: 8},
+	{Name: "upi", Type: "upi", Values: payload.Values{"vpa": "shop@upi", "name": "Shop", "amount": "100"}, WantText: "upi://pay?pa=shop@upi&pn=Shop&am=100.00&cu=INR", WantVersion: 6},
+	{Name: "uuid", Type: "uuid", WantPattern: `[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}`, WantVersion: 4},
+	{Name: "vcard", Type: "vcard", Values: payload.Values{"name": "Jane Doe", "phone": "+1 202 555 0123", "email": "jane@example.com"}, WantText: "BEGIN:VCARD\r\nVERSION:3.0\r\nN:Doe;Jane;;;\r\nFN:Jane Doe\r\nTEL;TYPE=VOICE:+1 202 555 0123\r\nEMAIL;TYPE=INTERNET:jane@example.com\r\nEND:VCARD", WantVersion: 11},
+	{Name: "wifi", Type: "wifi", Values: payload.Values{"ssid": "Home", "password": "correct-horse", "security": "WPA"}, WantText: "WIFI:T:WPA;S:Home;P:correct-horse;;", WantVersion: 4},
+	{Name: "wireguard", Type: "wireguard", Files: map[string]string{"wg-conf": wgConf}, WantText: "[Interface]\nPrivateKey = IxxOpXkaol1hCcHcwEaKoTzuo7Sp6aQVAcc+KA2RP1M=\nAddress = 10.0.0.2/32\nDNS = 10.0.0.1\n\n[Peer]\nPublicKey = 01OZl1RqZv/esWjHDX1cC4JbadSWVWRGJhPKdjQaGU8=\nAllowedIPs = 0.0.0.0/0\nEndpoint = vpn.example.com:51820", WantVersion: 16},
+}
+
+// MissingTypes devuelve los tipos de payload registrados que no tienen un
+// caso en TypeCases
+func MissingTypes() []string {
+	var missing []string
+	for _, name := range payload.Types() {
+		if !slices.ContainsFunc(TypeCases, func(c Case) bool { return c.Type == name }) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Cell es el resultado de un payload en un formato
+type Cell struct {
+	Format qrgenerator.OutputFormat
+	Err    error // nil si pasó
+}
+
+// Row agrupa los resultados de un payload en todos los formatos
+type Row struct {
+	Case  Case
+	Cells []Cell
+}
+
+// Report es la matriz de resultados de la prueba
+type Report struct {
+	Formats []qrgenerator.OutputFormat
+	Rows    []Row
+	Skipped map[qrgenerator.OutputFormat]error // Formatos que no se pueden generar en este sistema
+	Missing []string                           // Tipos de payload sin caso en TypeCases
+}
+
+// Failures devuelve la cantidad de celdas que fallaron
+func (r *Report) Failures() int {
+	failures := 0
+	for _, row := range r.Rows {
+		for _, cell := range row.Cells {
+			if cell.Err != nil {
+				failures++
+			}
+		}
+	}
+	return failures + len(r.Missing)
+}
+
+// Run genera cada caso en cada formato dentro de dir, lo decodifica y compara
+// con lo esperado. Los formatos que dependen de programas no instalados se omiten.
+func Run(dir string) *Report {
+	report := &Report{Skipped: map[qrgenerator.OutputFormat]error{}, Missing: MissingTypes()}
+	for _, format := range qrgenerator.Formats() {
+		if err := qrgenerator.Available(format); err != nil {
+			report.Skipped[format] = err
+			continue
+		}
+		report.Formats = append(report.Formats, format)
+	}
+	for _, c := range slices.Concat(Cases, TypeCases) {
+		row := Row{Case: c}
+		for _, format := range report.Formats {
+			row.Cells = append(row.Cells, Cell{Format: format, Err: check(dir, c, format)})
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	return report
+}
+
+// check genera y verifica un caso en un formato
+func check(dir string, c Case, format qrgenerator.OutputFormat) error {
+	content := c.Payload
+	params := maps.Clone(c.Params)
+	if c.Type != "" {
+		values := maps.Clone(c.Values)
+		if values == nil {
+			values = payload.Values{}
+		}
+		for field, data := range c.Files {
+			path := filepath.Join(dir, c.Name+"-"+field)
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				return err
+			}
+			values[field] = path
+		}
+		contents, err := payload.Build(c.Type, values, nil)
+		if err != nil {
+			return i18n.Errorf("generate: %w", err)
+		}
+		content = contents[0]
+		if t, _ := payload.Lookup(c.Type); t.FNC1 {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params["gs1"] = "true"
+		}
+	}
+
+	path := filepath.Join(dir, c.Name+format.Extension())
+	_, err := qrgenerator.GenerateQR(qrgenerator.QRConfig{
+		URL:         content,
+		Size:        256,
+		OutputPath:  path,
+		Format:      format,
+		ExtraParams: params,
+		Passphrase:  c.Passphrase,
+	})
+	if err != nil {
+		return i18n.Errorf("generate: %w", err)
+	}
+
+	var result *qrcodec.Result
+	switch format {
+	case qrgenerator.FormatCSS:
+		result, err = decodeCSS(path)
+	case qrgenerator.FormatMatrix:
+		result, err = decodeMatrix(path)
+	case qrgenerator.FormatPDF:
+		result, err = decodePDF(path)
+	default:
+		result, err = qrcodec.DecodeFile(path)
+	}
+	if err != nil {
+		return i18n.Errorf("decode: %w", err)
+	}
+
+	// El camino de decode: descifrar con la frase y descomprimir
+	text := result.Text
+	if encrypt.IsPassphraseEncrypted(text) {
+		if text, err = encrypt.DecryptPassphrase(text, c.Passphrase); err != nil {
+			return i18n.Errorf("decode: %w", err)
+		}
+	}
+	if text, _, err = compress.Decompress(text); err != nil {
+		return i18n.Errorf("decode: %w", err)
+	}
+
+	want := c.Payload
+	if c.WantText != "" {
+		want = c.WantText
+	}
+	if c.WantPattern != "" {
+		if !regexp.MustCompile(`^(?:` + c.WantPattern + `)$`).MatchString(text) {
+			return i18n.Errorf("content %q does not match %s", text, c.WantPattern)
+		}
+	} else if text != want {
+		return i18n.Errorf("content %q, expected %q", text, want)
+	}
+	if result.Version != c.WantVersion {
+		return i18n.Errorf("version %d, expected %d", result.Version, c.WantVersion)
+	}
+	return nil
+}
+
+// shadowPattern reconoce cada píxel del generador CSS: "Xpx Ypx 0 Spx"
+var shadowPattern = regexp.MustCompile(`(\d+)px (\d+)px 0 (\d+)px`)
+
+// decodeCSS reconstruye la imagen a partir de los box-shadow del CSS y la decodifica
+func decodeCSS(path string) (*qrcodec.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	matches := shadowPattern.FindAllStringSubmatch(string(data), -1)
+	if len(matches) == 0 {
+		return nil, i18n.Errorf("the CSS contains no pixels")
+	}
+
+	type pixel struct{ x, y, size int }
+	pixels := make([]pixel, 0, len(matches))
+	width, height := 0, 0
+	for _, m := range matches {
+		x, _ := strconv.Atoi(m[1])
+		y, _ := strconv.Atoi(m[2])
+		spread, _ := strconv.Atoi(m[3])
+		// El elemento mide 1px y el spread lo agranda por cada lado
+		size := 1 + 2*spread
+		pixels = append(pixels, pixel{x, y, size})
+		width, height = max(width, x+size), max(height, y+size)
+	}
+
+	// Margen claro alrededor para que los patrones de posición tengan borde
+	margin := max(width, height) / 10
+	img := image.NewGray(image.Rect(0, 0, width+2*margin, height+2*margin))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, p := range pixels {
+		rect := image.Rect(p.x+margin, p.y+margin, p.x+margin+p.size, p.y+margin+p.size)
+		draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+	}
+	return qrcodec.Decode(img)
+}
+
+// decodeMatrix dibuja la matriz de 0 y 1 con la zona de silencio y la decodifica
+func decodeMatrix(path string) (*qrcodec.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rows := strings.Fields(string(data))
+	if len(rows) == 0 {
+		return nil, i18n.Errorf("the matrix is empty")
+	}
+	modules := make([][]bool, len(rows))
+	for y, row := range rows {
+		for _, module := range row {
+			modules[y] = append(modules[y], module == '1')
+		}
+	}
+	return decodeModules(modules)
+}
+
+// pdfImagePattern reconoce la imagen de módulos del generador PDF: un bitmap
+// de 1 bit comprimido con FlateDecode
+var pdfImagePattern = regexp.MustCompile(`/Width (\d+) /Height (\d+) [^>]*/Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+
+// decodePDF extrae el bitmap de la primera imagen del PDF y lo decodifica
+func decodePDF(path string) (*qrcodec.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := pdfImagePattern.FindSubmatchIndex(data)
+	if m == nil {
+		return nil, i18n.Errorf("the PDF contains no module image")
+	}
+	width, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+	height, _ := strconv.Atoi(string(data[m[4]:m[5]]))
+	length, _ := strconv.Atoi(string(data[m[6]:m[7]]))
+	if m[1]+length > len(data) {
+		return nil, i18n.Errorf("the PDF module image is truncated")
+	}
+	r, err := zlib.NewReader(bytes.NewReader(data[m[1] : m[1]+length]))
+	if err != nil {
+		return nil, err
+	}
+	bits, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filas de 1 bit alineadas a byte, 1 = negro
+	stride := (width + 7) / 8
+	if len(bits) < stride*height {
+		return nil, i18n.Errorf("the PDF module image is truncated")
+	}
+	modules := make([][]bool, height)
+	for y := range modules {
+		modules[y] = make([]bool, width)
+		for x := range modules[y] {
+			modules[y][x] = bits[y*stride+x/8]&(0x80>>(x%8)) != 0
+		}
+	}
+	return decodeModules(modules)
+}
+
+// decodeModules dibuja los módulos con la zona de silencio y los decodifica
+func decodeModules(modules [][]bool) (*qrcodec.Result, error) {
+	const scale, border = 4, 4
+	width, height := (len(modules[0])+2*border)*scale, (len(modules)+2*border)*scale
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for y, row := range modules {
+		for x, dark := range row {
+			if dark {
+				rect := image.Rect(x+border, y+border, x+border+1, y+border+1)
+				rect.Min, rect.Max = rect.Min.Mul(scale), rect.Max.Mul(scale)
+				draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+			}
+		}
+	}
+	return qrcodec.Decode(img)
+}
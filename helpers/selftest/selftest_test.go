@@ -0,0 +1,10 @@
+package selftest
+
+import "testing"
+
+// TestEveryTypeHasCase falla al registrar un tipo de payload sin su caso en TypeCases
+func TestEveryTypeHasCase(t *testing.T) {
+	if missing := MissingTypes(); len(missing) > 0 {
+		t.Errorf("payload types without a case in TypeCases: %v", missing)
+	}
+}
@@ -0,0 +1,103 @@
+package shamir
+
+import (
+	"crypto/rand"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Share es una parte del secreto: el valor de cada byte del polinomio en X
+type Share struct {
+	X byte
+	Y []byte
+}
+
+// Tablas de logaritmos y exponentes de GF(2^8) con el polinomio de AES
+// (x^8 + x^4 + x^3 + x + 1) y generador 3
+var logTable, expTable = func() ([256]byte, [510]byte) {
+	var logs [256]byte
+	var exps [510]byte
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exps[i], exps[i+255] = x, x
+		logs[x] = byte(i)
+		// x *= 3
+		high := x & 0x80
+		x ^= x << 1
+		if high != 0 {
+			x ^= 0x1b
+		}
+	}
+	return logs, exps
+}()
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+255-int(logTable[b])]
+}
+
+// Split reparte el secreto en n partes de las que cualquier k lo
+// reconstruyen; con menos de k no se obtiene ninguna información
+func Split(secret []byte, n, k int) ([]Share, error) {
+	if k < 2 || k > n || n > 255 {
+		return nil, i18n.Errorf("shares need 2 <= threshold <= shares <= 255")
+	}
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{X: byte(i + 1), Y: make([]byte, len(secret))}
+	}
+	coefficients := make([]byte, k)
+	for j, b := range secret {
+		coefficients[0] = b
+		rand.Read(coefficients[1:])
+		for i := range shares {
+			// Horner: evalúa el polinomio de grado k-1 en x
+			x, y := shares[i].X, byte(0)
+			for c := k - 1; c >= 0; c-- {
+				y = mul(y, x) ^ coefficients[c]
+			}
+			shares[i].Y[j] = y
+		}
+	}
+	return shares, nil
+}
+
+// Combine reconstruye el secreto interpolando en x = 0 (Lagrange). Con menos
+// partes que el umbral el resultado es basura, así que quien llama tiene que
+// verificarlo (el sobre de paperkey lo autentica con GCM).
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, i18n.Errorf("at least two shares are needed")
+	}
+	seen := map[byte]bool{}
+	for _, share := range shares {
+		if share.X == 0 || seen[share.X] || len(share.Y) != len(shares[0].Y) {
+			return nil, i18n.Errorf("the shares are inconsistent")
+		}
+		seen[share.X] = true
+	}
+
+	secret := make([]byte, len(shares[0].Y))
+	for i, share := range shares {
+		// Coeficiente de Lagrange de esta parte en x = 0
+		basis := byte(1)
+		for j, other := range shares {
+			if i != j {
+				basis = mul(basis, div(other.X, other.X^share.X))
+			}
+		}
+		for b := range secret {
+			secret[b] ^= mul(share.Y[b], basis)
+		}
+	}
+	return secret, nil
+}
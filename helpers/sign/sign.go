@@ -0,0 +1,248 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// ErrBadSignature indica que la firma no es de la clave indicada o que el
+// payload cambió después de firmarlo
+var ErrBadSignature = i18n.NewError("signature verification failed")
+
+// algorithm es un algoritmo de JWS (RFC 7518) con su función de hash;
+// EdDSA firma el mensaje entero y no tiene hash
+type algorithm struct {
+	name string
+	hash crypto.Hash
+}
+
+// header es el encabezado protegido del JWS
+type header struct {
+	Alg string `json:"alg"`
+}
+
+// algorithmFor elige el algoritmo según la clave: ES256/384/512 para las
+// curvas P-256/384/521, EdDSA para Ed25519 y RS256 para RSA
+func algorithmFor(key crypto.PublicKey) (algorithm, error) {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return algorithm{"ES256", crypto.SHA256}, nil
+		case elliptic.P384():
+			return algorithm{"ES384", crypto.SHA384}, nil
+		case elliptic.P521():
+			return algorithm{"ES512", crypto.SHA512}, nil
+		}
+		return algorithm{}, i18n.Errorf("unsupported ECDSA curve %s; use P-256, P-384 or P-521", key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return algorithm{"EdDSA", 0}, nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return algorithm{}, i18n.Errorf("the RSA key has %d bits; JWS needs at least 2048", key.N.BitLen())
+		}
+		return algorithm{"RS256", crypto.SHA256}, nil
+	}
+	return algorithm{}, i18n.Errorf("unsupported key type %T; use an ECDSA, Ed25519 or RSA key", key)
+}
+
+// readPEM lee el primer bloque PEM del archivo
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("cannot read the key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, i18n.Errorf("%s is not a PEM file", path)
+	}
+	return block, nil
+}
+
+// privateKey lee una clave privada PEM: PKCS#8, SEC 1 (EC) o PKCS#1 (RSA)
+func privateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, i18n.Errorf("%s is encrypted; export it without a passphrase (openssl pkey -in key.pem -out plain.pem)", path)
+	default:
+		return nil, i18n.Errorf("%s holds a %s, not a private key", path, block.Type)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("%s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, i18n.Errorf("unsupported key type %T; use an ECDSA, Ed25519 or RSA key", key)
+	}
+	return signer, nil
+}
+
+// publicKey lee la clave con la que se verifica: una clave pública PEM, un
+// certificado o la misma clave privada con la que se firmó
+func publicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, i18n.Errorf("%s: %w", path, err)
+		}
+		return key, nil
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, i18n.Errorf("%s: %w", path, err)
+		}
+		return cert.PublicKey, nil
+	}
+	signer, err := privateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Public(), nil
+}
+
+// CheckKey revisa que el archivo tenga una clave privada con la que se pueda firmar
+func CheckKey(path string) error {
+	signer, err := privateKey(path)
+	if err != nil {
+		return err
+	}
+	_, err = algorithmFor(signer.Public())
+	return err
+}
+
+// Sign firma el contenido con la clave privada PEM y lo devuelve como JWS
+// compacto (RFC 7515): encabezado.payload.firma, en base64url sin relleno
+func Sign(content, keyPath string) (string, error) {
+	signer, err := privateKey(keyPath)
+	if err != nil {
+		return "", err
+	}
+	alg, err := algorithmFor(signer.Public())
+	if err != nil {
+		return "", err
+	}
+	protected, _ := json.Marshal(header{Alg: alg.name})
+	input := base64.RawURLEncoding.EncodeToString(protected) + "." + base64.RawURLEncoding.EncodeToString([]byte(content))
+
+	var signature []byte
+	switch key := signer.(type) {
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(key, []byte(input))
+	case *ecdsa.PrivateKey:
+		// JWS usa r || s de largo fijo, no la forma ASN.1 de crypto/ecdsa
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest(alg.hash, input))
+		if err != nil {
+			return "", err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	default:
+		if signature, err = signer.Sign(rand.Reader, digest(alg.hash, input), alg.hash); err != nil {
+			return "", err
+		}
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// IsJWS informa si el texto tiene la forma de un JWS compacto
+func IsJWS(text string) bool {
+	parts := strings.Split(text, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(parts[0])
+	var h header
+	return err == nil && json.Unmarshal(protected, &h) == nil && h.Alg != ""
+}
+
+// Verify comprueba la firma del JWS con la clave de keyPath y devuelve el
+// payload; si no coincide devuelve ErrBadSignature
+func Verify(token, keyPath string) (string, error) {
+	key, err := publicKey(keyPath)
+	if err != nil {
+		return "", err
+	}
+	alg, err := algorithmFor(key)
+	if err != nil {
+		return "", err
+	}
+	if !IsJWS(token) {
+		return "", i18n.Errorf("%w: the payload is not signed", ErrBadSignature)
+	}
+	parts := strings.Split(token, ".")
+	protected, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	var h header
+	json.Unmarshal(protected, &h)
+	if h.Alg != alg.name {
+		return "", i18n.Errorf("%w: signed with %s, but the key uses %s", ErrBadSignature, h.Alg, alg.name)
+	}
+	content, err1 := base64.RawURLEncoding.DecodeString(parts[1])
+	signature, err2 := base64.RawURLEncoding.DecodeString(parts[2])
+	if err1 != nil || err2 != nil {
+		return "", i18n.Errorf("%w: the JWS is not valid base64url", ErrBadSignature)
+	}
+
+	input := parts[0] + "." + parts[1]
+	valid := false
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, []byte(input), signature)
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) == 2*size {
+			r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+			valid = ecdsa.Verify(key, digest(alg.hash, input), r, s)
+		}
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, alg.hash, digest(alg.hash, input), signature) == nil
+	}
+	if !valid {
+		return "", ErrBadSignature
+	}
+	return string(content), nil
+}
+
+// digest calcula el hash del algoritmo sobre la entrada de la firma
+func digest(hash crypto.Hash, input string) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(input))
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(input))
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(input))
+	return sum[:]
+}
@@ -0,0 +1,132 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// FileName es el archivo de contadores dentro del directorio de configuración
+const FileName = "telemetry.json"
+
+// Stats son los contadores de uso agregados en el equipo. Solo guardan
+// categorías y valores de una lista cerrada (formato, tamaño, tipo de payload,
+// comando, código de salida): nunca payloads, rutas ni nombres de archivo.
+// Nada se envía a ningún lado; el resumen se exporta a mano.
+type Stats struct {
+	Enabled bool                      `json:"enabled"`
+	Since   time.Time                 `json:"since"`
+	Counts  map[string]map[string]int `json:"counts,omitempty"` // categoría -> valor -> cantidad
+}
+
+// Summary es el resumen exportado
+type Summary struct {
+	Tool     string                    `json:"tool"`
+	Version  string                    `json:"version"`
+	Platform string                    `json:"platform"`
+	Since    time.Time                 `json:"since"`
+	Exported time.Time                 `json:"exported"`
+	Counts   map[string]map[string]int `json:"counts"`
+}
+
+// Path es la ruta del archivo de contadores
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load lee los contadores; si el archivo no existe la telemetría está desactivada
+func Load(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Stats{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, i18n.Errorf("%s is damaged: %w", path, err)
+	}
+	return stats, nil
+}
+
+// Save escribe los contadores de forma atómica, así dos ejecuciones
+// simultáneas a lo sumo pierden una cuenta pero nunca dañan el archivo
+func (s *Stats) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+FileName+".new-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Add suma una ocurrencia de value en la categoría
+func (s *Stats) Add(category, value string) {
+	if s.Counts == nil {
+		s.Counts = map[string]map[string]int{}
+	}
+	if s.Counts[category] == nil {
+		s.Counts[category] = map[string]int{}
+	}
+	s.Counts[category][value]++
+}
+
+// Reset borra los contadores y vuelve a empezar el período
+func (s *Stats) Reset(now time.Time) {
+	s.Counts = nil
+	s.Since = now.UTC().Truncate(time.Second)
+}
+
+// Categories devuelve las categorías ordenadas
+func (s *Stats) Categories() []string {
+	return slices.Sorted(maps.Keys(s.Counts))
+}
+
+// Record suma los eventos de una ejecución si la telemetría está activada.
+// Cada evento es una categoría con sus valores (una ejecución puede escribir
+// varios formatos).
+func Record(path string, events map[string][]string) error {
+	stats, err := Load(path)
+	if err != nil || !stats.Enabled {
+		return err
+	}
+	for category, values := range events {
+		for _, value := range values {
+			stats.Add(category, value)
+		}
+	}
+	return stats.Save(path)
+}
+
+// SizeBucket agrupa los tamaños en pixeles, para no registrar valores exactos
+func SizeBucket(size int) string {
+	for _, limit := range []int{256, 512, 1024, 2048, 4096} {
+		if size <= limit {
+			return "<=" + strconv.Itoa(limit)
+		}
+	}
+	return ">4096"
+}
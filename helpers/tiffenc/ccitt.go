@@ -0,0 +1,119 @@
+package tiffenc
+
+// bitWriter acumula códigos de longitud variable del bit más significativo al menos
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nBits uint
+}
+
+func (b *bitWriter) put(c code) {
+	b.acc = b.acc<<c.n | uint64(c.bits)
+	b.nBits += c.n
+	for b.nBits >= 8 {
+		b.nBits -= 8
+		b.buf = append(b.buf, byte(b.acc>>b.nBits))
+	}
+}
+
+// bytes completa el último byte con ceros y devuelve lo escrito
+func (b *bitWriter) bytes() []byte {
+	if b.nBits > 0 {
+		b.buf = append(b.buf, byte(b.acc<<(8-b.nBits)))
+		b.nBits = 0
+	}
+	return b.buf
+}
+
+// putRun escribe una corrida de píxeles del color indicado con los códigos de
+// relleno que hagan falta y el código terminal
+func (b *bitWriter) putRun(run int, black bool) {
+	terminating, makeup := &whiteTerminating, &whiteMakeup
+	if black {
+		terminating, makeup = &blackTerminating, &blackMakeup
+	}
+	for run >= 2560+64 {
+		b.put(extendedMakeup[len(extendedMakeup)-1])
+		run -= 2560
+	}
+	if run >= 64 {
+		m := run / 64
+		if m <= len(makeup) {
+			b.put(makeup[m-1])
+		} else {
+			b.put(extendedMakeup[m-len(makeup)-1])
+		}
+		run -= m * 64
+	}
+	b.put(terminating[run])
+}
+
+// findDiff devuelve la primera posición desde start cuyo color difiere de
+// color, o el ancho de la fila si no hay ninguna
+func findDiff(row []byte, start int, color byte) int {
+	for start < len(row) && row[start] == color {
+		start++
+	}
+	return start
+}
+
+// encodeG4 comprime la página con CCITT T.6 (Grupo 4): cada fila se codifica
+// respecto de la anterior, empezando con una fila de referencia blanca
+func encodeG4(p Page) []byte {
+	ref := make([]byte, p.Width)
+	cur := make([]byte, p.Width)
+	w := &bitWriter{}
+	for y := 0; y < p.Height; y++ {
+		for x := range cur {
+			cur[x] = 0
+			if p.Black(x, y) {
+				cur[x] = 1
+			}
+		}
+		encodeRowG4(w, cur, ref)
+		ref, cur = cur, ref
+	}
+	w.put(eofb)
+	return w.bytes()
+}
+
+// encodeRowG4 codifica una fila en modo bidimensional. a0 es el elemento de
+// referencia en la fila actual; a1 y a2 los cambios de color siguientes; b1 y
+// b2 los cambios en la fila de referencia (T.4 sección 4.2.1.3)
+func encodeRowG4(w *bitWriter, cur, ref []byte) {
+	width := len(cur)
+	a0 := 0
+	a1 := findDiff(cur, 0, 0)
+	b1 := findDiff(ref, 0, 0)
+	for {
+		b2 := width
+		if b1 < width {
+			b2 = findDiff(ref, b1, ref[b1])
+		}
+		switch d := b1 - a1; {
+		case b2 < a1:
+			w.put(passCode)
+			a0 = b2
+		case d >= -3 && d <= 3:
+			w.put(verticalCodes[d+3])
+			a0 = a1
+		default:
+			a2 := width
+			if a1 < width {
+				a2 = findDiff(cur, a1, cur[a1])
+			}
+			w.put(horizontalCode)
+			// a0 arranca en un píxel blanco imaginario antes de la fila
+			black := a0+a1 != 0 && cur[a0] == 1
+			w.putRun(a1-a0, black)
+			w.putRun(a2-a1, !black)
+			a0 = a2
+		}
+		if a0 >= width {
+			return
+		}
+		color := cur[a0]
+		a1 = findDiff(cur, a0, color)
+		b1 = findDiff(ref, findDiff(ref, a0, 1-color), color)
+	}
+}
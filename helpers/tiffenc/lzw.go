@@ -0,0 +1,57 @@
+package tiffenc
+
+// Códigos especiales y límites del LZW de TIFF
+const (
+	lzwClear    = 256
+	lzwEOI      = 257
+	lzwFirst    = 258
+	lzwMinWidth = 9
+	lzwMaxCode  = 4094 // Al llegar acá la tabla se reinicia con un código de limpieza
+)
+
+// encodeLZW comprime data con la variante LZW de TIFF 6.0: códigos de 9 a 12
+// bits, del bit más significativo al menos, con el "early change" (el ancho
+// crece un código antes que en GIF)
+func encodeLZW(data []byte) []byte {
+	w := &bitWriter{}
+	width := uint(lzwMinWidth)
+	next := lzwFirst
+	table := make(map[uint32]uint16)
+
+	emit := func(c int) {
+		w.put(code{width, uint32(c)})
+	}
+	// grow registra un código nuevo y ajusta el ancho como lo espera el decodificador
+	grow := func() {
+		next++
+		if next == lzwMaxCode {
+			emit(lzwClear)
+			clear(table)
+			width, next = lzwMinWidth, lzwFirst
+		} else if next == 1<<width {
+			width++
+		}
+	}
+
+	emit(lzwClear)
+	if len(data) == 0 {
+		emit(lzwEOI)
+		return w.bytes()
+	}
+	prefix := int(data[0])
+	for _, b := range data[1:] {
+		key := uint32(prefix)<<8 | uint32(b)
+		if c, ok := table[key]; ok {
+			prefix = int(c)
+			continue
+		}
+		emit(prefix)
+		table[key] = uint16(next)
+		grow()
+		prefix = int(b)
+	}
+	emit(prefix)
+	grow()
+	emit(lzwEOI)
+	return w.bytes()
+}
@@ -0,0 +1,255 @@
+// Package tiffenc escribe TIFF bitonales (1 bit por píxel) con compresión
+// CCITT Grupo 4 o LZW y varias páginas por archivo, como los que piden los
+// sistemas de etiquetado industrial, o de dos colores con una paleta y LZW.
+// golang.org/x/image/tiff no comprime con ninguno de los dos ni escribe más
+// de una página.
+package tiffenc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image/color"
+	"io"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Compression es el método de compresión de las páginas
+type Compression uint16
+
+// Compresiones soportadas, con su valor del tag Compression
+const (
+	CompressionG4  Compression = 4 // CCITT T.6: la más compacta para imágenes bitonales
+	CompressionLZW Compression = 5
+)
+
+// Page es una página bitonal; Black informa si el píxel (x, y) es negro, o
+// del segundo color de la paleta
+type Page struct {
+	Width  int
+	Height int
+	Black  func(x, y int) bool
+}
+
+// Options son los parámetros de escritura
+type Options struct {
+	Compression Compression   // Por defecto G4
+	DPI         int           // Resolución declarada; por defecto 72
+	Palette     color.Palette // Colores del 0 y del 1 en lugar de blanco y negro; solo con LZW, ya que G4 es bitonal
+}
+
+// Tags de TIFF usados, en el orden ascendente que exige el formato
+const (
+	tagNewSubfileType  = 254
+	tagImageWidth      = 256
+	tagImageLength     = 257
+	tagBitsPerSample   = 258
+	tagCompression     = 259
+	tagPhotometric     = 262
+	tagStripOffsets    = 273
+	tagSamplesPerPixel = 277
+	tagRowsPerStrip    = 278
+	tagStripByteCounts = 279
+	tagXResolution     = 282
+	tagYResolution     = 283
+	tagT6Options       = 293
+	tagResolutionUnit  = 296
+	tagPageNumber      = 297
+	tagColorMap        = 320
+)
+
+// Tipos de campo de TIFF
+const (
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+// entry es una entrada del directorio de una página (IFD)
+type entry struct {
+	tag, typ uint16
+	count    uint32
+	value    [4]byte // Valor en línea u offset a los datos
+}
+
+func shortEntry(tag uint16, values ...uint16) entry {
+	e := entry{tag: tag, typ: typeShort, count: uint32(len(values))}
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(e.value[2*i:], v)
+	}
+	return e
+}
+
+func longEntry(tag uint16, value uint32) entry {
+	e := entry{tag: tag, typ: typeLong, count: 1}
+	binary.LittleEndian.PutUint32(e.value[:], value)
+	return e
+}
+
+// Encode escribe las páginas como un TIFF. Con más de una página cada una se
+// marca como página de un documento (NewSubfileType y PageNumber).
+func Encode(w io.Writer, pages []Page, o *Options) error {
+	opts := Options{Compression: CompressionG4, DPI: 72}
+	if o != nil {
+		if o.Compression != 0 {
+			opts.Compression = o.Compression
+		}
+		if o.DPI > 0 {
+			opts.DPI = o.DPI
+		}
+	}
+	if len(pages) == 0 {
+		return i18n.NewError("TIFF needs at least one page")
+	}
+	if opts.Compression != CompressionG4 && opts.Compression != CompressionLZW {
+		return i18n.Errorf("unsupported TIFF compression: %d", opts.Compression)
+	}
+	if o != nil && o.Palette != nil {
+		if len(o.Palette) != 2 || opts.Compression != CompressionLZW {
+			return i18n.NewError("a TIFF palette needs two colors and LZW compression")
+		}
+		opts.Palette = o.Palette
+	}
+
+	// Comprimir todo antes de escribir: los offsets de cada directorio
+	// dependen del tamaño de las páginas siguientes
+	strips := make([][]byte, len(pages))
+	for i, p := range pages {
+		if p.Width < 1 || p.Height < 1 {
+			return i18n.NewError("invalid image dimensions for TIFF")
+		}
+		if opts.Compression == CompressionG4 {
+			strips[i] = encodeG4(p)
+		} else {
+			strips[i] = encodeLZW(pack(p))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	le := binary.LittleEndian
+	var err error
+	write := func(b []byte) {
+		if err == nil {
+			_, err = bw.Write(b)
+		}
+	}
+
+	multipage := len(pages) > 1
+	entries := 12
+	if opts.Compression == CompressionG4 {
+		entries++
+	}
+	if multipage {
+		entries += 2
+	}
+	// La paleta va después de las resoluciones: rojos, verdes y azules de 16 bits
+	colorMapSize := 0
+	if opts.Palette != nil {
+		entries++
+		colorMapSize = 3 * 2 * len(opts.Palette)
+	}
+	ifdSize := 2 + 12*entries + 4
+	const rationalSize = 16 // XResolution e YResolution
+
+	// Cabecera: little endian, 42 y el offset del primer directorio
+	offset := 8
+	write([]byte{'I', 'I', 42, 0})
+	write(le.AppendUint32(nil, uint32(offset+padded(len(strips[0])))))
+
+	for i, p := range pages {
+		stripOffset := offset
+		ifdOffset := stripOffset + padded(len(strips[i]))
+		rationalOffset := ifdOffset + ifdSize
+		offset = rationalOffset + rationalSize + colorMapSize
+		nextIFD := 0
+		if i+1 < len(pages) {
+			nextIFD = offset + padded(len(strips[i+1]))
+		}
+
+		var dir []entry
+		if multipage {
+			dir = append(dir, longEntry(tagNewSubfileType, 2)) // Página de un documento
+		}
+		dir = append(dir,
+			longEntry(tagImageWidth, uint32(p.Width)),
+			longEntry(tagImageLength, uint32(p.Height)),
+			shortEntry(tagBitsPerSample, 1),
+			shortEntry(tagCompression, uint16(opts.Compression)),
+			photometric(opts.Palette),
+			longEntry(tagStripOffsets, uint32(stripOffset)),
+			shortEntry(tagSamplesPerPixel, 1),
+			longEntry(tagRowsPerStrip, uint32(p.Height)),
+			longEntry(tagStripByteCounts, uint32(len(strips[i]))),
+			entry{tag: tagXResolution, typ: typeRational, count: 1, value: [4]byte(le.AppendUint32(nil, uint32(rationalOffset)))},
+			entry{tag: tagYResolution, typ: typeRational, count: 1, value: [4]byte(le.AppendUint32(nil, uint32(rationalOffset+8)))},
+		)
+		if opts.Compression == CompressionG4 {
+			dir = append(dir, longEntry(tagT6Options, 0))
+		}
+		dir = append(dir, shortEntry(tagResolutionUnit, 2)) // Pulgadas
+		if multipage {
+			dir = append(dir, shortEntry(tagPageNumber, uint16(i), uint16(len(pages))))
+		}
+		if opts.Palette != nil {
+			dir = append(dir, entry{tag: tagColorMap, typ: typeShort, count: uint32(colorMapSize / 2), value: [4]byte(le.AppendUint32(nil, uint32(rationalOffset+rationalSize)))})
+		}
+
+		write(strips[i])
+		if len(strips[i])%2 == 1 {
+			write([]byte{0}) // Los directorios empiezan en offsets pares
+		}
+		buf := le.AppendUint16(nil, uint16(len(dir)))
+		for _, e := range dir {
+			buf = le.AppendUint16(buf, e.tag)
+			buf = le.AppendUint16(buf, e.typ)
+			buf = le.AppendUint32(buf, e.count)
+			buf = append(buf, e.value[:]...)
+		}
+		buf = le.AppendUint32(buf, uint32(nextIFD))
+		for range 2 {
+			buf = le.AppendUint32(buf, uint32(opts.DPI))
+			buf = le.AppendUint32(buf, 1)
+		}
+		for channel := range 3 {
+			for _, c := range opts.Palette {
+				r, g, b, _ := c.RGBA()
+				buf = le.AppendUint16(buf, uint16([3]uint32{r, g, b}[channel]))
+			}
+		}
+		write(buf)
+	}
+
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// photometric es la interpretación de los píxeles: WhiteIsZero (1 es
+// negro) o, con una paleta, sus índices
+func photometric(palette color.Palette) entry {
+	if palette != nil {
+		return shortEntry(tagPhotometric, 3)
+	}
+	return shortEntry(tagPhotometric, 0)
+}
+
+// padded redondea n al siguiente número par
+func padded(n int) int {
+	return n + n%2
+}
+
+// pack empaqueta la página en filas de bits alineadas a byte, 1 para negro
+func pack(p Page) []byte {
+	stride := (p.Width + 7) / 8
+	data := make([]byte, stride*p.Height)
+	for y := 0; y < p.Height; y++ {
+		row := data[y*stride:]
+		for x := 0; x < p.Width; x++ {
+			if p.Black(x, y) {
+				row[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return data
+}
@@ -0,0 +1,60 @@
+package transfer
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"os"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// WriteGIF escribe los QR como un GIF animado en bucle, un cuadro por QR
+func WriteGIF(path string, frames []image.Image, delay time.Duration) error {
+	anim := &gif.GIF{}
+	palette := color.Palette{color.White, color.Black}
+	for _, frame := range frames {
+		paletted := image.NewPaletted(frame.Bounds(), palette)
+		draw.Draw(paletted, paletted.Bounds(), frame, frame.Bounds().Min, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, max(1, int(delay/(10*time.Millisecond))))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := gif.EncodeAll(f, anim); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// GIFFrames devuelve cada cuadro de un GIF animado ya compuesto sobre los
+// anteriores, como se ve en pantalla
+func GIFFrames(path string) ([]image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		return nil, i18n.Errorf("error decoding image: %w", err)
+	}
+
+	bounds := image.Rect(0, 0, anim.Config.Width, anim.Config.Height)
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, image.White, image.Point{}, draw.Src)
+	frames := make([]image.Image, len(anim.Image))
+	for i, frame := range anim.Image {
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		snapshot := image.NewRGBA(bounds)
+		copy(snapshot.Pix, canvas.Pix)
+		frames[i] = snapshot
+	}
+	return frames, nil
+}
@@ -0,0 +1,189 @@
+package transfer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/compress"
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Prefix identifica los QR de una transferencia. Cada uno lleva
+// QRF1:<n>/<total>:<sha256 del archivo>:<crc32 del trozo>:<trozo en base45>,
+// todo dentro del alfabeto del modo alfanumérico del QR.
+const Prefix = "QRF1:"
+
+// DefaultChunkSize son los bytes por QR: unos 850 caracteres, un símbolo de
+// versión 27 con corrección H que todavía lee la cámara de un teléfono
+const DefaultChunkSize = 512
+
+// MaxChunkSize es lo máximo que entra en un QR de versión 40 con corrección H
+const MaxChunkSize = 1150
+
+// Chunk es un trozo del archivo
+type Chunk struct {
+	Index int    // Desde 1
+	Total int    // Cantidad de trozos
+	Sum   string // SHA-256 del archivo completo, en hexadecimal
+	Data  []byte
+}
+
+// Split divide el archivo en los payloads de una secuencia de QR
+func Split(data []byte, chunkSize int) ([]string, error) {
+	if chunkSize <= 0 || chunkSize > MaxChunkSize {
+		return nil, i18n.Errorf("chunk size must be between 1 and %d bytes", MaxChunkSize)
+	}
+	if len(data) == 0 {
+		return nil, i18n.Errorf("the file is empty")
+	}
+	sum := sha256.Sum256(data)
+	fileSum := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	total := (len(data) + chunkSize - 1) / chunkSize
+	payloads := make([]string, total)
+	for i := range payloads {
+		part := data[i*chunkSize : min((i+1)*chunkSize, len(data))]
+		payloads[i] = fmt.Sprintf("%s%d/%d:%s:%08X:%s", Prefix, i+1, total, fileSum, crc32.ChecksumIEEE(part), compress.EncodeBase45(part))
+	}
+	return payloads, nil
+}
+
+// ParseChunk interpreta el payload de un QR de la secuencia y verifica su checksum
+func ParseChunk(payload string) (*Chunk, error) {
+	if !strings.HasPrefix(payload, Prefix) {
+		return nil, i18n.Errorf("not part of a file transfer")
+	}
+	parts := strings.SplitN(payload[len(Prefix):], ":", 4)
+	if len(parts) != 4 {
+		return nil, i18n.Errorf("malformed transfer chunk")
+	}
+	index, total, ok := strings.Cut(parts[0], "/")
+	chunk := &Chunk{Sum: parts[1]}
+	var err1, err2 error
+	chunk.Index, err1 = strconv.Atoi(index)
+	chunk.Total, err2 = strconv.Atoi(total)
+	if !ok || err1 != nil || err2 != nil || chunk.Index < 1 || chunk.Index > chunk.Total || len(chunk.Sum) != 2*sha256.Size {
+		return nil, i18n.Errorf("malformed transfer chunk")
+	}
+
+	data, err := compress.DecodeBase45(parts[3])
+	if err != nil {
+		return nil, i18n.Errorf("chunk %d: %w", chunk.Index, err)
+	}
+	if fmt.Sprintf("%08X", crc32.ChecksumIEEE(data)) != parts[2] {
+		return nil, i18n.Errorf("chunk %d: checksum mismatch", chunk.Index)
+	}
+	chunk.Data = data
+	return chunk, nil
+}
+
+// Assembler junta los trozos de un archivo en cualquier orden; los repetidos
+// se ignoran
+type Assembler struct {
+	total  int
+	sum    string
+	chunks map[int][]byte
+}
+
+// Add agrega un trozo; falla si es de otro archivo que los anteriores
+func (a *Assembler) Add(chunk *Chunk) error {
+	if a.chunks == nil {
+		a.total, a.sum, a.chunks = chunk.Total, chunk.Sum, map[int][]byte{}
+	}
+	if chunk.Total != a.total || chunk.Sum != a.sum {
+		return i18n.Errorf("chunk %d/%d belongs to a different file", chunk.Index, chunk.Total)
+	}
+	a.chunks[chunk.Index] = chunk.Data
+	return nil
+}
+
+// Total devuelve la cantidad de trozos del archivo, 0 si todavía no hay ninguno
+func (a *Assembler) Total() int {
+	return a.total
+}
+
+// Missing devuelve los números de los trozos que faltan
+func (a *Assembler) Missing() []int {
+	var missing []int
+	for i := 1; i <= a.total; i++ {
+		if _, ok := a.chunks[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// Bytes reconstruye el archivo y verifica su SHA-256
+func (a *Assembler) Bytes() ([]byte, error) {
+	if a.total == 0 {
+		return nil, i18n.Errorf("no transfer chunks found")
+	}
+	if missing := a.Missing(); len(missing) > 0 {
+		return nil, i18n.Errorf("missing %d of %d chunks: %s", len(missing), a.total, Ranges(missing))
+	}
+	var buf bytes.Buffer
+	for i := 1; i <= a.total; i++ {
+		buf.Write(a.chunks[i])
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), a.sum) {
+		return nil, i18n.Errorf("the reassembled file does not match its SHA-256")
+	}
+	return buf.Bytes(), nil
+}
+
+// Collection separa por archivo (por su SHA-256) los trozos leídos, para
+// juntar varios archivos a la vez, como las partes de un respaldo
+type Collection struct {
+	sums  []string
+	files map[string]*Assembler
+}
+
+// Add agrega el trozo al archivo al que pertenece
+func (c *Collection) Add(chunk *Chunk) error {
+	if c.files == nil {
+		c.files = map[string]*Assembler{}
+	}
+	assembler, ok := c.files[chunk.Sum]
+	if !ok {
+		assembler = &Assembler{}
+		c.files[chunk.Sum] = assembler
+		c.sums = append(c.sums, chunk.Sum)
+	}
+	return assembler.Add(chunk)
+}
+
+// Files devuelve un Assembler por archivo, en el orden en que aparecieron
+func (c *Collection) Files() []*Assembler {
+	files := make([]*Assembler, len(c.sums))
+	for i, sum := range c.sums {
+		files[i] = c.files[sum]
+	}
+	return files
+}
+
+// Ranges resume una lista ordenada de números: 1-3,7,9-10
+func Ranges(numbers []int) string {
+	numbers = slices.Clone(numbers)
+	slices.Sort(numbers)
+	var parts []string
+	for i := 0; i < len(numbers); {
+		j := i
+		for j+1 < len(numbers) && numbers[j+1] == numbers[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, strconv.Itoa(numbers[i])+"-"+strconv.Itoa(numbers[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(numbers[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
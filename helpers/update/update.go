@@ -0,0 +1,445 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// Valores por defecto para consultar las releases
+const (
+	DefaultAPI  = "https://api.github.com"
+	DefaultRepo = "elanticrypt0/qrgenerator_cli"
+)
+
+// Nombres de los archivos de verificación que acompañan a cada release
+const (
+	ChecksumsAsset = "checksums.txt"     // Formato de sha256sum: "<hash>  <archivo>"
+	SignatureAsset = "checksums.txt.sig" // Firma ed25519 de checksums.txt, binaria o en base64
+)
+
+// Canales de releases: stable sigue solo las releases finales; prerelease
+// incluye además las marcadas como prerelease (v1.3.0-rc1)
+const (
+	ChannelStable     = "stable"
+	ChannelPrerelease = "prerelease"
+)
+
+// maxDownload limita el tamaño de lo que se descarga
+const maxDownload = 256 << 20
+
+// PublicKey es la clave pública ed25519 en base64 con la que se firman las
+// releases. Se inyecta al compilar con
+// -ldflags "-X qrgenerator_cli/helpers/update.PublicKey=...". Sin clave la
+// descarga falla, salvo que se pida expresamente saltar la firma.
+var PublicKey string
+
+// Errores base de la actualización
+var (
+	ErrVerify   = i18n.NewError("update verification failed")
+	ErrNoAsset  = i18n.NewError("no binary for this platform")
+	ErrDownload = i18n.NewError("download failed")
+)
+
+// Asset es un archivo publicado en una release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Release es una release de GitHub
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset busca un archivo de la release por nombre
+func (r *Release) Asset(name string) (*Asset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Updater consulta las releases y descarga binarios verificados
+type Updater struct {
+	Client    *http.Client
+	API       string // URL base de la API de GitHub (GitHub Enterprise o un mirror)
+	Repo      string // owner/nombre
+	Token     string // Token opcional para evitar el límite de consultas anónimas
+	PublicKey ed25519.PublicKey
+
+	// SkipSignature acepta releases sin verificar la firma cuando no hay
+	// clave pública; el checksum sale de la misma release y no prueba nada
+	// sobre quién la publicó
+	SkipSignature bool
+}
+
+// New crea un updater con un cliente HTTP con el timeout indicado. La clave
+// pública sale de PublicKey; una clave inválida es un error.
+func New(api, repo, token string, timeout time.Duration) (*Updater, error) {
+	u := &Updater{
+		Client: &http.Client{Timeout: timeout},
+		API:    strings.TrimSuffix(api, "/"),
+		Repo:   repo,
+		Token:  token,
+	}
+	if PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, i18n.Errorf("%w: invalid embedded public key", ErrVerify)
+		}
+		u.PublicKey = key
+	}
+	return u, nil
+}
+
+// AssetName es el nombre del binario publicado para una plataforma
+func AssetName(goos, goarch string) string {
+	name := "qrgenerator_cli_" + goos + "_" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// CurrentAsset es el nombre del binario para la plataforma actual
+func CurrentAsset() string {
+	return AssetName(runtime.GOOS, runtime.GOARCH)
+}
+
+// CheckChannel valida el nombre de un canal
+func CheckChannel(channel string) error {
+	if channel != ChannelStable && channel != ChannelPrerelease {
+		return i18n.Errorf("unknown channel %q; use %s or %s", channel, ChannelStable, ChannelPrerelease)
+	}
+	return nil
+}
+
+// Latest devuelve la última release del canal, sin borradores. En stable es
+// la que GitHub marca como última; en prerelease, la de versión más alta
+// entre las recientes, sea o no prerelease.
+func (u *Updater) Latest(ctx context.Context, channel string) (*Release, error) {
+	if err := CheckChannel(channel); err != nil {
+		return nil, err
+	}
+	if channel == ChannelStable {
+		body, err := u.get(ctx, u.API+"/repos/"+u.Repo+"/releases/latest", "application/vnd.github+json")
+		if err != nil {
+			return nil, err
+		}
+		var release Release
+		if err := json.Unmarshal(body, &release); err != nil {
+			return nil, i18n.Errorf("%w: invalid release response: %w", ErrDownload, err)
+		}
+		if release.Tag == "" {
+			return nil, i18n.Errorf("%w: release without tag", ErrDownload)
+		}
+		return &release, nil
+	}
+
+	body, err := u.get(ctx, u.API+"/repos/"+u.Repo+"/releases?per_page=30", "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, i18n.Errorf("%w: invalid release response: %w", ErrDownload, err)
+	}
+	var latest *Release
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || r.Tag == "" {
+			continue
+		}
+		if latest == nil || Newer(r.Tag, latest.Tag) {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, i18n.Errorf("%w: %s has no releases", ErrDownload, u.Repo)
+	}
+	return latest, nil
+}
+
+// ManagedBy devuelve el gestor de paquetes que instaló el ejecutable en path
+// (Homebrew o Scoop), o "" si se instaló copiando el binario. Esos
+// ejecutables se actualizan con su gestor: reemplazarlos dejaría al gestor
+// con una versión que no es la que cree tener.
+func ManagedBy(path string) string {
+	path = filepath.ToSlash(strings.ToLower(path))
+	switch {
+	case strings.Contains(path, "/cellar/"), strings.Contains(path, "/homebrew/"), strings.Contains(path, "/.linuxbrew/"):
+		return "Homebrew"
+	case strings.Contains(path, "/scoop/apps/"):
+		return "Scoop"
+	}
+	return ""
+}
+
+// ManagerCommand es el comando con el que un gestor actualiza el programa
+func ManagerCommand(manager string) string {
+	if manager == "Scoop" {
+		return "scoop update qrgenerator_cli"
+	}
+	return "brew upgrade qrgenerator_cli"
+}
+
+// Download descarga el binario de la release para la plataforma actual y lo
+// verifica contra checksums.txt y su firma. Sin clave pública falla, salvo
+// con SkipSignature.
+func (u *Updater) Download(ctx context.Context, release *Release) ([]byte, error) {
+	name := CurrentAsset()
+	asset, ok := release.Asset(name)
+	if !ok {
+		return nil, i18n.Errorf("%w: %s has no %s", ErrNoAsset, release.Tag, name)
+	}
+	sums, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return nil, i18n.Errorf("%w: %s has no %s", ErrVerify, release.Tag, ChecksumsAsset)
+	}
+
+	if u.PublicKey == nil && !u.SkipSignature {
+		return nil, i18n.Errorf("%w: this build has no release signing key", ErrVerify)
+	}
+
+	checksums, err := u.get(ctx, sums.URL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	if u.PublicKey != nil {
+		sig, ok := release.Asset(SignatureAsset)
+		if !ok {
+			return nil, i18n.Errorf("%w: %s has no %s", ErrVerify, release.Tag, SignatureAsset)
+		}
+		signature, err := u.get(ctx, sig.URL, "application/octet-stream")
+		if err != nil {
+			return nil, err
+		}
+		if err := VerifySignature(u.PublicKey, checksums, signature); err != nil {
+			return nil, err
+		}
+	}
+
+	binary, err := u.get(ctx, asset.URL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(checksums, name, binary); err != nil {
+		return nil, err
+	}
+	return binary, nil
+}
+
+// get descarga una URL completa, con el token si hay uno
+func (u *Updater) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, i18n.Errorf("%w: %w", ErrDownload, err)
+	}
+	req.Header.Set("Accept", accept)
+	if u.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+u.Token)
+	}
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("%w: %w", ErrDownload, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("%w: %s answered %d", ErrDownload, url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownload+1))
+	if err != nil {
+		return nil, i18n.Errorf("%w: %w", ErrDownload, err)
+	}
+	if len(data) > maxDownload {
+		return nil, i18n.Errorf("%w: %s is larger than %d MB", ErrDownload, url, maxDownload>>20)
+	}
+	return data, nil
+}
+
+// VerifyChecksum comprueba el SHA-256 de data contra la línea de name en checksums
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marca los archivos binarios con un asterisco
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		want, err := hex.DecodeString(fields[0])
+		if err != nil || len(want) != sha256.Size {
+			return i18n.Errorf("%w: malformed checksum for %s", ErrVerify, name)
+		}
+		got := sha256.Sum256(data)
+		if !bytes.Equal(got[:], want) {
+			return i18n.Errorf("%w: checksum mismatch for %s", ErrVerify, name)
+		}
+		return nil
+	}
+	return i18n.Errorf("%w: %s is not listed in %s", ErrVerify, name, ChecksumsAsset)
+}
+
+// VerifySignature comprueba la firma ed25519 de checksums. La firma puede
+// venir en binario (64 bytes) o en base64.
+func VerifySignature(key ed25519.PublicKey, checksums, signature []byte) error {
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil {
+			return i18n.Errorf("%w: malformed signature", ErrVerify)
+		}
+		signature = decoded
+	}
+	if !ed25519.Verify(key, checksums, signature) {
+		return i18n.Errorf("%w: invalid signature for %s", ErrVerify, ChecksumsAsset)
+	}
+	return nil
+}
+
+// Newer informa si la versión latest es posterior a current. Las versiones
+// tienen la forma v1.2.3 con un sufijo de prerelease opcional (v1.2.3-rc1),
+// que se ordena antes de la versión final.
+// Los sufijos se comparan como en semver: por identificadores separados por
+// puntos, los numéricos como números, así rc10 va después de rc9.
+func Newer(latest, current string) bool {
+	l, lpre := parseVersion(latest)
+	c, cpre := parseVersion(current)
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	switch {
+	case lpre == cpre:
+		return false
+	case lpre == "":
+		return true
+	case cpre == "":
+		return false
+	}
+	return comparePrerelease(lpre, cpre) > 0
+}
+
+// comparePrerelease ordena dos sufijos de prerelease según semver. Los
+// identificadores numéricos van antes que los alfanuméricos; dentro de un
+// identificador como rc10, el número final se compara como número.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// compareIdentifier compara un identificador de prerelease
+func compareIdentifier(a, b string) int {
+	an, aerr := strconv.Atoi(a)
+	bn, berr := strconv.Atoi(b)
+	switch {
+	case aerr == nil && berr == nil:
+		return an - bn
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+	aprefix, adigits := splitDigits(a)
+	bprefix, bdigits := splitDigits(b)
+	if aprefix == bprefix && adigits != "" && bdigits != "" {
+		an, _ := strconv.Atoi(adigits)
+		bn, _ := strconv.Atoi(bdigits)
+		return an - bn
+	}
+	return strings.Compare(a, b)
+}
+
+// splitDigits separa los dígitos finales de un identificador (rc10 → rc, 10)
+func splitDigits(s string) (string, string) {
+	i := len(s)
+	for i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+		i--
+	}
+	return s[:i], s[i:]
+}
+
+// parseVersion separa los números y el sufijo de prerelease de una versión
+func parseVersion(version string) ([3]int, string) {
+	var numbers [3]int
+	version = strings.TrimPrefix(version, "v")
+	version, pre, _ := strings.Cut(version, "-")
+	for i, part := range strings.SplitN(version, ".", 3) {
+		numbers[i], _ = strconv.Atoi(part)
+	}
+	return numbers, pre
+}
+
+// Replace reemplaza de forma atómica el ejecutable en path por data: escribe
+// un archivo temporal en el mismo directorio y lo renombra encima. En Windows
+// el ejecutable en uso no se puede sobrescribir, así que antes se aparta
+// como .old (se puede borrar en la próxima ejecución).
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return i18n.Errorf("cannot write to %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			// Dejar el ejecutable original en su lugar
+			os.Rename(old, path)
+			return err
+		}
+		return nil
+	}
+	return os.Rename(tmp.Name(), path)
+}
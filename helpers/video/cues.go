@@ -0,0 +1,152 @@
+package video
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// Rate es la cadencia de cuadros como fracción, para representar exactamente
+// las de NTSC (30000/1001)
+type Rate struct {
+	Num, Den int
+}
+
+// ParseRate interpreta una cadencia: 25, 29.97, 59.94 o una fracción como 30000/1001
+func ParseRate(value string) (Rate, error) {
+	invalid := i18n.Errorf("%w: invalid frame rate %q (e.g. 25, 29.97 or 30000/1001)", qrgenerator.ErrInvalidInput, value)
+	if num, den, ok := strings.Cut(value, "/"); ok {
+		n, err1 := strconv.Atoi(num)
+		d, err2 := strconv.Atoi(den)
+		if err1 != nil || err2 != nil || n <= 0 || d <= 0 {
+			return Rate{}, invalid
+		}
+		return Rate{n, d}, nil
+	}
+	fps, err := strconv.ParseFloat(value, 64)
+	if err != nil || fps <= 0 || fps > 1000 {
+		return Rate{}, invalid
+	}
+	if whole := math.Round(fps); math.Abs(fps-whole) < 1e-9 {
+		return Rate{int(whole), 1}, nil
+	}
+	// 23.976, 29.97 y 59.94 son las cadencias NTSC N*1000/1001
+	if ntsc := math.Round(fps * 1.001); math.Abs(fps-ntsc/1.001) < 0.005 {
+		return Rate{int(ntsc) * 1000, 1001}, nil
+	}
+	return Rate{int(math.Round(fps * 1000)), 1000}, nil
+}
+
+// String devuelve la cadencia como la entiende ffmpeg
+func (r Rate) String() string {
+	return strconv.Itoa(r.Num) + "/" + strconv.Itoa(r.Den)
+}
+
+// nominal es la cadencia entera con la que cuenta cuadros un timecode
+func (r Rate) nominal() int {
+	return (r.Num + r.Den - 1) / r.Den
+}
+
+// Cue es un payload que se muestra desde un cuadro; Payload vacío oculta el QR
+type Cue struct {
+	Frame   int
+	Payload string
+	Caption string
+	Line    int
+}
+
+// endMarker cierra la lista de cues con el instante en que termina el video
+const endMarker = "END"
+
+// ParseCues lee una lista de cues, uno por línea: el instante, el payload y
+// opcionalmente " | " y un texto. El payload "-" oculta el QR y la última
+// línea debe ser "<instante> END". Devuelve los cues y el total de cuadros.
+func ParseCues(r io.Reader, rate Rate) ([]Cue, int, error) {
+	var cues []Cue
+	end := -1
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if end >= 0 {
+			return nil, 0, i18n.Errorf("%w: line %d: cue after %s", qrgenerator.ErrInvalidInput, n, endMarker)
+		}
+		stamp, rest, _ := strings.Cut(line, " ")
+		if i := strings.IndexByte(stamp, '\t'); i >= 0 {
+			stamp, rest = stamp[:i], stamp[i+1:]+" "+rest
+		}
+		frame, err := parseTime(stamp, rate)
+		if err != nil {
+			return nil, 0, i18n.Errorf("%w: line %d: %w", qrgenerator.ErrInvalidInput, n, err)
+		}
+		if len(cues) > 0 && frame <= cues[len(cues)-1].Frame {
+			return nil, 0, i18n.Errorf("%w: line %d: %s is not after the previous cue", qrgenerator.ErrInvalidInput, n, stamp)
+		}
+
+		payload, caption, _ := strings.Cut(strings.TrimSpace(rest), " | ")
+		payload, caption = strings.TrimSpace(payload), strings.TrimSpace(caption)
+		switch payload {
+		case endMarker:
+			end = frame
+			continue
+		case "":
+			return nil, 0, i18n.Errorf("%w: line %d: missing payload (use - to hide the QR)", qrgenerator.ErrInvalidInput, n)
+		case "-":
+			payload = ""
+		}
+		cues = append(cues, Cue{Frame: frame, Payload: payload, Caption: caption, Line: n})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+	}
+	if len(cues) == 0 {
+		return nil, 0, i18n.Errorf("%w: no cues", qrgenerator.ErrInvalidInput)
+	}
+	if end < 0 {
+		return nil, 0, i18n.Errorf("%w: the cue list needs a last line \"<time> %s\" with the end of the video", qrgenerator.ErrInvalidInput, endMarker)
+	}
+	return cues, end, nil
+}
+
+// parseTime convierte un instante en número de cuadro. Acepta segundos (12.5),
+// MM:SS o HH:MM:SS con milisegundos opcionales (00:01:02.500), y timecode
+// HH:MM:SS:FF, que cuenta cuadros a la cadencia nominal (sin drop frame).
+func parseTime(value string, rate Rate) (int, error) {
+	invalid := i18n.Errorf("invalid time %q (seconds, HH:MM:SS.mmm or HH:MM:SS:FF)", value)
+	parts := strings.Split(value, ":")
+	if len(parts) == 4 {
+		var fields [4]int
+		for i, part := range parts {
+			v, err := strconv.Atoi(part)
+			if err != nil || v < 0 {
+				return 0, invalid
+			}
+			fields[i] = v
+		}
+		if fields[1] >= 60 || fields[2] >= 60 || fields[3] >= rate.nominal() {
+			return 0, invalid
+		}
+		return ((fields[0]*60+fields[1])*60+fields[2])*rate.nominal() + fields[3], nil
+	}
+	if len(parts) > 3 {
+		return 0, invalid
+	}
+
+	var seconds float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 || math.IsInf(v, 0) || i > 0 && v >= 60 || i < len(parts)-1 && strings.Contains(part, ".") {
+			return 0, invalid
+		}
+		seconds = seconds*60 + v
+	}
+	return int(math.Round(seconds * float64(rate.Num) / float64(rate.Den))), nil
+}
@@ -0,0 +1,107 @@
+package video
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// Writer recibe los cuadros ya codificados en PNG; cada imagen se codifica
+// una vez y se repite mientras dura su cue. Close se llama siempre, también
+// después de un error, y su error es el más descriptivo.
+type Writer interface {
+	WriteFrames(png []byte, count int) error
+	Close() error
+}
+
+// PNGSequence escribe un PNG numerado por cuadro en un directorio
+// (frame_000000.png, frame_000001.png...), como los importan los editores
+type PNGSequence struct {
+	dir  string
+	next int
+}
+
+// NewPNGSequence crea el directorio de la secuencia
+func NewPNGSequence(dir string) (*PNGSequence, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+	}
+	return &PNGSequence{dir: dir}, nil
+}
+
+// WriteFrames implementa Writer
+func (s *PNGSequence) WriteFrames(png []byte, count int) error {
+	for ; count > 0; count-- {
+		path := filepath.Join(s.dir, fmt.Sprintf("frame_%06d.png", s.next))
+		if err := os.WriteFile(path, png, 0o644); err != nil {
+			return i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+		}
+		s.next++
+	}
+	return nil
+}
+
+// Close implementa Writer
+func (s *PNGSequence) Close() error {
+	return nil
+}
+
+// ffmpeg es el programa con el que se codifica ProRes; no hay codificador en Go
+const ffmpeg = "ffmpeg"
+
+// ProRes codifica ProRes 4444 con alfa en un .mov, pasándole los cuadros a
+// ffmpeg por un pipe
+type ProRes struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	output bytes.Buffer
+}
+
+// NewProRes arranca ffmpeg para escribir path a la cadencia indicada
+func NewProRes(path string, rate Rate) (*ProRes, error) {
+	if _, err := exec.LookPath(ffmpeg); err != nil {
+		return nil, i18n.Errorf("%w: ProRes output needs %s; install it or write a PNG sequence to a directory", qrgenerator.ErrEncode, ffmpeg)
+	}
+	p := &ProRes{}
+	p.cmd = exec.Command(ffmpeg, "-hide_banner", "-loglevel", "error", "-y",
+		"-f", "image2pipe", "-c:v", "png", "-framerate", rate.String(), "-i", "-",
+		"-c:v", "prores_ks", "-profile:v", "4444", "-pix_fmt", "yuva444p10le", "-vendor", "apl0",
+		"-r", rate.String(), path)
+	p.cmd.Stdout = &p.output
+	p.cmd.Stderr = &p.output
+	var err error
+	if p.stdin, err = p.cmd.StdinPipe(); err != nil {
+		return nil, i18n.Errorf("%w: %w", qrgenerator.ErrEncode, err)
+	}
+	if err := p.cmd.Start(); err != nil {
+		return nil, i18n.Errorf("%w: cannot start %s: %w", qrgenerator.ErrEncode, ffmpeg, err)
+	}
+	return p, nil
+}
+
+// WriteFrames implementa Writer
+func (p *ProRes) WriteFrames(png []byte, count int) error {
+	for ; count > 0; count-- {
+		if _, err := p.stdin.Write(png); err != nil {
+			// ffmpeg terminó; Close informa el motivo
+			return i18n.Errorf("%w: error encoding ProRes: %w", qrgenerator.ErrEncode, err)
+		}
+	}
+	return nil
+}
+
+// Close implementa Writer: cierra el pipe y espera a que ffmpeg termine el archivo
+func (p *ProRes) Close() error {
+	p.stdin.Close()
+	if err := p.cmd.Wait(); err != nil {
+		return i18n.Errorf("%w: error encoding ProRes: %w: %s", qrgenerator.ErrEncode, err, strings.TrimSpace(p.output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package viewer
+
+import (
+	"os/exec"
+	"runtime"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// command devuelve el programa del sistema que abre un archivo con la aplicación predeterminada
+func command(path string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", path)
+	case "windows":
+		// start es un builtin de cmd; el primer argumento entre comillas es el título
+		return exec.Command("cmd", "/c", "start", "", path)
+	default:
+		return exec.Command("xdg-open", path)
+	}
+}
+
+// Open abre el archivo en el visor predeterminado del sistema sin esperar a que se cierre
+func Open(path string) error {
+	cmd := command(path)
+	if err := cmd.Start(); err != nil {
+		return i18n.Errorf("cannot open %s with %s: %w", path, cmd.Path, err)
+	}
+	// Liberar el proceso hijo cuando termine, sin bloquear al CLI
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,73 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// DefaultInterval es la frecuencia con la que se revisan los archivos
+const DefaultInterval = 300 * time.Millisecond
+
+// stamp resume el estado de un archivo; un archivo inexistente tiene exists=false
+type stamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stat(path string) stamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return stamp{}
+	}
+	return stamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// Watcher detecta cambios en archivos comparando tamaño y fecha de
+// modificación. Se consulta por polling para no depender de APIs de cada
+// sistema operativo y para sobrevivir a los editores que guardan reemplazando
+// el archivo.
+type Watcher struct {
+	paths []string
+	seen  map[string]stamp
+}
+
+// New crea un watcher tomando como referencia el estado actual de los archivos
+func New(paths []string) *Watcher {
+	w := &Watcher{paths: paths, seen: map[string]stamp{}}
+	for _, path := range paths {
+		w.seen[path] = stat(path)
+	}
+	return w
+}
+
+// Wait bloquea hasta que alguno de los archivos cambie y devuelve su ruta.
+// Antes de volver espera a que el archivo deje de cambiar, para no leer un
+// guardado a medias. Devuelve el error del contexto si se cancela.
+func (w *Watcher) Wait(ctx context.Context, interval time.Duration) (string, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	changed := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+
+		settled := changed != ""
+		for _, path := range w.paths {
+			current := stat(path)
+			if current != w.seen[path] {
+				w.seen[path] = current
+				changed = path
+				settled = false
+			}
+		}
+		if settled {
+			return changed, nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// identifiersPath es el CSV de los identificadores generados, al lado de la
+// salida: qr.png da qr-ids.csv
+func identifiersPath(output string) string {
+	return strings.TrimSuffix(output, filepath.Ext(output)) + "-ids.csv"
+}
+
+// writeIdentifiers escribe el CSV que relaciona cada identificador de --type
+// uuid o token con el archivo que lo contiene, para cargarlos en el sistema de
+// entradas o de inventario. La página es la del TIFF de varias páginas; en el
+// resto de los formatos es 1.
+func writeIdentifiers(opts *generateOptions, results []*qrgenerator.QRResult) int {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"id", "file", "page"})
+	pages := map[string]int{}
+	for i, result := range results {
+		id := opts.identifiers[0]
+		if len(opts.identifiers) > 1 {
+			id = opts.identifiers[i]
+		}
+		pages[result.OutputPath]++
+		w.Write([]string{id, result.OutputPath, strconv.Itoa(pages[result.OutputPath])})
+	}
+	w.Flush()
+
+	path := identifiersPath(opts.outputs[0].Path)
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		opts.log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	opts.log.Infof("identifiers written to %s", path)
+	return exitOK
+}
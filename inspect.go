@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// runInspect muestra el manifiesto que --manifest embebe en los PNG y SVG:
+// con qué versión y opciones se generó cada archivo y el hash de su payload
+func runInspect(args []string) int {
+	flags := newFlagSet("inspect")
+	asJSON := flags.Bool("json", false, i18n.T("Print each manifest as a line of JSON"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli inspect [flags] file...\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+	if flags.NArg() == 0 {
+		log.Errorf("no files to inspect")
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	code := exitOK
+	for i, path := range flags.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			code = exitIO
+			continue
+		}
+		manifest, err := qrgenerator.ReadManifest(data)
+		if err != nil {
+			log.Errorf("%s: %v", path, err)
+			code = exitFailure
+			continue
+		}
+		if manifest == nil {
+			log.Errorf("%s: no manifest; generate it with --manifest", path)
+			code = exitFailure
+			continue
+		}
+
+		if *asJSON {
+			line, _ := json.Marshal(struct {
+				File string `json:"file"`
+				*qrgenerator.Manifest
+			}{path, manifest})
+			os.Stdout.Write(append(line, '\n'))
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%-9s %s\n", i18n.T("file"), path)
+		fmt.Printf("%-9s %s\n", i18n.T("tool"), manifest.Tool)
+		fmt.Printf("%-9s sha256:%s\n", i18n.T("payload"), manifest.PayloadSHA256)
+		fmt.Printf("%-9s %s, %dpx\n", i18n.T("format"), manifest.Format, manifest.Size)
+		fmt.Printf("%-9s %s\n", i18n.T("symbol"), i18n.Sprintf("version %d, level %s, mask %d", manifest.Version, manifest.Level, manifest.Mask))
+		var options []string
+		for _, key := range slices.Sorted(maps.Keys(manifest.Options)) {
+			options = append(options, key+"="+manifest.Options[key])
+		}
+		fmt.Printf("%-9s %s\n", i18n.T("options"), strings.Join(options, " "))
+	}
+	return code
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/landing"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// runLanding genera una página de aterrizaje multilingüe y el QR que apunta a
+// ella, para empaques que se venden en varios países con un solo código
+func runLanding(args []string) int {
+	flags := newFlagSet("landing")
+	links := flags.String("links", "", i18n.T("Comma-separated lang=URL pairs; the first language is the default (es=https://example.com/es,en=https://example.com/en)"))
+	pageURL := flags.String("url", "", i18n.T("URL where the page will be published; the QR points to it"))
+	outDir := flags.String("out-dir", "", i18n.T("Directory for index.html and the QR"))
+	title := flags.String("title", "", i18n.T("Page title shown above the language buttons"))
+	output := flags.String("o", "qr.png", i18n.T("QR file name inside --out-dir; the extension selects the format"))
+	size := flags.Int("size", 256, i18n.T("QR size"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli landing -links es=URL,en=URL -url PAGE_URL -out-dir DIR [flags]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if *links == "" || *pageURL == "" || *outDir == "" {
+		log.Errorf("%v", i18n.T("-links, -url and -out-dir are required"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	if u, err := url.Parse(*pageURL); err != nil || u.Scheme == "" || u.Host == "" {
+		log.Errorf("%v", i18n.Errorf("invalid page URL %q", *pageURL))
+		return exitInvalidInput
+	}
+	page := landing.Page{Title: *title}
+	var err error
+	if page.Links, err = landing.ParseLinks(*links); err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	if page.Title == "" {
+		page.Title = i18n.T("Choose your language")
+	}
+
+	htmlPath, err := landing.Write(*outDir, page)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot write the page: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	log.Infof("page written to %s", htmlPath)
+
+	config := qrgenerator.QRConfig{URL: *pageURL, Size: *size, OutputPath: filepath.Join(*outDir, *output)}
+	format, problems := qrgenerator.ResolveFormat(config.OutputPath, "")
+	for _, problem := range problems {
+		if !problem.Warning {
+			log.Errorf("%v", problem.Err)
+			return exitCodeFor(problem.Err)
+		}
+		log.Warnf("%v", problem.Err)
+	}
+	config.Format = format
+	result, err := qrgenerator.GenerateQR(config)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	logResult(log, result)
+	log.Infof("QR written to %s", config.OutputPath)
+	return exitOK
+}
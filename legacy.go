@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// deprecationsEnv es la variable de entorno que, con "error", hace fallar
+// las invocaciones obsoletas para encontrarlas en los scripts antes de que
+// dejen de funcionar
+const deprecationsEnv = "QRGENERATOR_DEPRECATIONS"
+
+// legacyFlag es un flag de la forma original y el de generate que lo reemplaza
+type legacyFlag struct {
+	name        string // Flag de la invocación sin subcomando, sin guiones
+	replacement string // Flag de generate con el mismo significado
+}
+
+// legacyFlags son los flags que existían antes de los subcomandos. Los demás
+// flags de una invocación sin subcomando pasan a generate sin cambios.
+var legacyFlags = []legacyFlag{
+	{name: "url", replacement: "url"},
+	{name: "size", replacement: "size"},
+	{name: "o", replacement: "o"},
+}
+
+// legacyInvocation indica que se generó sin subcomando, la forma original
+// (qrgenerator_cli -url ... -o qr.png). Sigue funcionando igual que generate,
+// pero avisa que está obsoleta.
+var legacyInvocation bool
+
+// legacyUsed son los flags de legacyFlags que usó la invocación, en orden
+var legacyUsed []legacyFlag
+
+// runLegacy pasa los flags originales a los de generate y lo corre
+func runLegacy(args []string) int {
+	legacyInvocation = true
+	args, legacyUsed = mapLegacyFlags(args)
+	return runGenerate(args)
+}
+
+// mapLegacyFlags reemplaza en args los flags de legacyFlags (-url, --url o
+// -url=valor) por los de generate y devuelve también los que encontró
+func mapLegacyFlags(args []string) ([]string, []legacyFlag) {
+	mapped := make([]string, 0, len(args))
+	var used []legacyFlag
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			mapped = append(mapped, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag, ok := lookupLegacyFlag(name)
+		if !ok || !strings.HasPrefix(arg, "-") {
+			mapped = append(mapped, arg)
+			continue
+		}
+		if !slices.Contains(used, flag) {
+			used = append(used, flag)
+		}
+		if hasValue {
+			mapped = append(mapped, "-"+flag.replacement+"="+value)
+			continue
+		}
+		// Todos los flags originales llevan un valor en el argumento siguiente
+		mapped = append(mapped, "-"+flag.replacement)
+		if i+1 < len(args) {
+			i++
+			mapped = append(mapped, args[i])
+		}
+	}
+	return mapped, used
+}
+
+// lookupLegacyFlag busca un flag original por nombre
+func lookupLegacyFlag(name string) (legacyFlag, bool) {
+	i := slices.IndexFunc(legacyFlags, func(f legacyFlag) bool { return f.name == name })
+	if i < 0 {
+		return legacyFlag{}, false
+	}
+	return legacyFlags[i], true
+}
+
+// warnLegacy avisa que la invocación sin subcomando está obsoleta con una
+// línea deprecation: por la invocación y otra por cada flag original, en
+// campos clave=valor para buscarlos en los logs; con
+// QRGENERATOR_DEPRECATIONS=error devuelve exitInvalidInput en lugar de seguir
+func warnLegacy(opts *generateOptions) int {
+	if !legacyInvocation {
+		return exitOK
+	}
+	report := opts.log.Warnf
+	if os.Getenv(deprecationsEnv) == "error" {
+		report = opts.log.Errorf
+	}
+	report("deprecation: usage=%q replacement=%q", program+" -flags", program+" generate -flags")
+	for _, flag := range legacyUsed {
+		report("deprecation: flag=%s replacement=%q", "-"+flag.name, "generate -"+flag.replacement)
+	}
+	if os.Getenv(deprecationsEnv) == "error" {
+		return exitInvalidInput
+	}
+	opts.log.Warnf("%s=error turns these deprecations into errors", deprecationsEnv)
+	return exitOK
+}
@@ -1,50 +1,923 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"maps"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"qrgenerator_cli/helpers/config"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/obs"
+	"qrgenerator_cli/helpers/payload"
+	"qrgenerator_cli/helpers/qrcodec"
 	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/viewer"
+	"qrgenerator_cli/helpers/watch"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/template"
 )
 
+// Códigos de salida del proceso, pensados para que CI pueda distinguir fallos
+const (
+	exitOK           = 0
+	exitFailure      = 1 // Error no clasificado
+	exitInvalidInput = 2 // Parámetros o payload inválidos
+	exitCapacity     = 3 // El payload no entra en el QR
+	exitEncode       = 4 // Falló la codificación del QR o de la imagen
+	exitIO           = 5 // Falló la escritura de la salida
+	exitCheckFailed  = 6 // Una verificación encontró fallos
+)
+
+// exitCodeFor traduce un error de generación a su código de salida
+func exitCodeFor(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, qrgenerator.ErrInvalidInput):
+		return exitInvalidInput
+	case errors.Is(err, qrgenerator.ErrCapacityExceeded):
+		return exitCapacity
+	case errors.Is(err, qrgenerator.ErrEncode):
+		return exitEncode
+	case errors.Is(err, qrgenerator.ErrIO):
+		return exitIO
+	default:
+		return exitFailure
+	}
+}
+
+// command es un subcomando: su función y la descripción de una línea que
+// muestran la ayuda y las páginas de manual
+type command struct {
+	run     func(args []string) int
+	summary string // En inglés; se traduce al mostrarla
+}
+
+// commands son los subcomandos disponibles; sin subcomando se genera un QR
+// como con generate (ver runLegacy)
+var commands = map[string]command{
+	"backup-codes":     {runBackupCodes, "Print 2FA backup codes on a PDF sheet, each with its QR, plus an encrypted master QR"},
+	"bundle":           {runBundle, "Export the binary, config files and assets as one tarball, or install one on an offline machine"},
+	"convert":          {runConvert, "Re-render a generated QR in another format, from its module matrix or by decoding it"},
+	"decode":           {runDecode, "Print the payload of QR images, decrypting and decompressing it"},
+	"inspect":          {runInspect, "Print the generation manifest embedded in PNG and SVG outputs by --manifest"},
+	"landing":          {runLanding, "Write a multilingual static landing page and the QR that points to it"},
+	"monitor":          {runMonitor, "Decode published QR images periodically and check that their destinations answer"},
+	"paperkey":         {runPaperkey, "Encrypt a key file with a passphrase and print it as QR codes on a PDF"},
+	"paperkey-restore": {runPaperkeyRestore, "Restore a key file from scans of its paperkey pages"},
+	"preview-grid":     {runPreviewGrid, "Render one payload with several EC levels, sizes and presets side by side on one comparison sheet"},
+	"receive-file":     {runReceiveFile, "Rebuild a file from the QR sequence written by send-file"},
+	"selftest":         {runSelftest, "Generate reference payloads in every format and decode them back"},
+	"size-for":         {runSizeFor, "Compute the printed and pixel size a QR needs to scan from a distance, or how far a printed size scans from"},
+	"self-update":      {runSelfUpdate, "Replace the binary with the latest release"},
+	"send-file":        {runSendFile, "Split a file into a numbered sequence of QR codes to cross an air gap"},
+	"telemetry":        {runTelemetry, "Opt in to local usage counts (formats, sizes, errors) and export a summary; off by default"},
+	"video":            {runVideo, "Export a transparent overlay video whose QR switches at given times"},
+}
+
+// docs y generate leen commands (generate en su ayuda), así que se
+// registran después de inicializarlo
+func init() {
+	commands["docs"] = command{runDocs, "Generate man pages or a markdown reference from the command and flag definitions"}
+	commands["generate"] = command{runGenerate, "Generate QR codes in many image and data formats"}
+}
+
 func main() {
+	// El idioma se elige antes de registrar los flags para traducir su ayuda
+	i18n.Set(i18n.Detect(i18n.FromArgs(os.Args[1:])))
+
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			code := command.run(os.Args[2:])
+			// generate registra su uso con los formatos y el tipo de payload
+			if os.Args[1] != "generate" {
+				recordUsage(os.Args[1], code, nil)
+			}
+			os.Exit(code)
+		}
+	}
+	os.Exit(runLegacy(os.Args[1:]))
+}
+
+// generateOptions son los flags del comando de generación ya resueltos
+type generateOptions struct {
+	config      qrgenerator.QRConfig
+	outputs     []qrgenerator.Output  // Archivos a escribir a partir de una sola codificación
+	outDir      string                // Directorio de las salidas (--out-dir), se crea antes de escribir
+	problems    []qrgenerator.Problem // Problemas al resolver el formato de salida
+	batch       string                // Archivo con un payload por línea
+	payloadType string                // --type; vacío para una URL
+	utm         payload.UTM           // Parámetros de campaña para las URL del lote
+	normalize   bool                  // Normalizar las URL (ver payload.NormalizeURL)
+	template    *template.Template    // Plantilla de los registros de batch; nil si batch tiene un payload por línea
+	identifiers []string              // Identificadores de --type uuid o token, uno por código
+	serials     *serialRange          // Serie de --serial-start; nil si no se pidió
+	split       int                   // Cantidad de QR en que se parte el payload; 0 para uno solo
+	structured  bool                  // --split con structured append en lugar del formato de send-file
+	push        pushOptions
+	mqtt        mqttOptions
+	obs         *obs.Target        // nil si no se actualiza OBS
+	printConfig string             // Formato de --print-config; vacío si no se pidió
+	settings    map[string]setting // Configuración efectiva para --print-config
+	strict      bool
+	open        bool
+	watch       []string
+	log         *logger.Logger
+}
 
-	qr_url := flag.String("url", "https://tryhackme.com", "Url to go with QR")
-	qr_size := flag.Int("size", 256, "QR size")
-	qr_output := flag.String("o", "new_qr.jpg", "Output path and file with extension. Formats: jpg, png, svg, css")
+// parseGenerate parsea los flags de generación. Si hay un archivo de
+// configuración sus valores se aplican a los flags que no se pasaron
+// explícitamente. Las opciones se devuelven aun con error para poder loguearlo.
+func parseGenerate(args []string) (*generateOptions, error) {
+	flags := newFlagSet(os.Args[0])
 
-	flag.Parse()
+	qr_url := flags.String("url", "https://tryhackme.com", i18n.T("Url to go with QR"))
+	input_file := flags.String("input-file", "", i18n.T("Encode the bytes of this file as they are, in byte mode, instead of -url: certificates, keys, CBOR..."))
+	no_normalize := flags.Bool("no-normalize", false, i18n.T("Encode -url as given; by default https:// is added when missing, the host is lowercased and punycoded and default ports are dropped"))
+	utm_source := flags.String("utm-source", "", i18n.T("Campaign source appended to URL payloads as utm_source, such as newsletter or poster"))
+	utm_medium := flags.String("utm-medium", "", i18n.T("Campaign medium appended to URL payloads as utm_medium, such as qr or print"))
+	utm_campaign := flags.String("utm-campaign", "", i18n.T("Campaign name appended to URL payloads as utm_campaign"))
+	utm_content := flags.String("utm-content", "", i18n.T("Variant appended to URL payloads as utm_content, to tell apart placements of the same campaign"))
+	qr_size := flags.Int("size", 256, i18n.T("QR size"))
+	scale := flags.Int("scale", 0, i18n.T("Pixels per module (1-100) instead of -size: the image width follows the module count, so every module has the same whole number of pixels and the edges stay crisp"))
+	width := flags.String("width", "", i18n.T("Printed width of the code with the quiet zone, such as 30mm or 1.5in, instead of -size: the pixel width follows from --dpi"))
+	dpi := flags.Int("dpi", 0, i18n.T("Print resolution in dots per inch, recorded in PNG, JPEG, TIFF, SVG and PDF outputs so they print at the intended size (default 300 with --width)"))
+	max_size := flags.Int("max-size", qrgenerator.DefaultMaxSize, i18n.T("Largest accepted size in pixels"))
+	qr_outputs := &outputList{paths: []string{"new_qr.jpg"}}
+	flags.Var(qr_outputs, "o", i18n.T("Output path and file with extension. Formats: jpg, png, svg, css, avif, heic, tiff, pdf, txt/json/pbm/h/rs (module matrix). Repeat it to write several files from one encode"))
+	format := flags.String("format", "", i18n.T("Output format; overrides the extension of -o"))
+	formats := flags.String("formats", "", i18n.T("Comma-separated formats to write from one encode, named after -o (-o qr.png -formats png,svg,pdf)"))
+	out_dir := flags.String("out-dir", "", i18n.T("Directory for the outputs, created if missing; relative -o paths are placed inside it"))
+	strict := flags.Bool("strict", false, i18n.T("Validate everything before generating, report all problems at once and treat warnings as errors"))
+	quality := flags.Int("quality", 0, i18n.T("JPEG quality 1-100 (default 90); AVIF/HEIF quality 1-100 (default 100, lossless)"))
+	subsampling := flags.String("jpeg-subsampling", "", i18n.T("JPEG chroma subsampling: 444 (default, sharpest), 422 or 420"))
+	progressive := flags.Bool("jpeg-progressive", false, i18n.T("Write a progressive JPEG"))
+	tiff_compression := flags.String("tiff-compression", "", i18n.T("TIFF compression: g4 (default, CCITT Group 4) or lzw"))
+	matrix_format := flags.String("matrix-format", "", i18n.T("Module matrix encoding: json, pbm, bits, c or rust (default from the extension: .json, .pbm, .txt, .h/.c, .rs)"))
+	thumbnail := flags.Int("thumbnail", 0, i18n.T("Also write a PNG preview of at most this many pixels per side next to each output, named with a _thumb suffix (16-1024)"))
+	scan_distance := flags.String("scan-distance", "", i18n.T("Warn when the QR, printed at --dpi (default 300), is too small to scan from this distance, such as 3m (see size-for)"))
+	manifest := flags.Bool("manifest", false, i18n.T("Embed a manifest (payload hash, options, tool version and module matrix) in PNG and SVG outputs; inspect reads it back"))
+	symbol := flags.String("symbol", "", i18n.T("Symbology: qr (default); rmqr, the rectangular micro QR (ISO/IEC 23941) for narrow label stock such as cable labels and test tubes, with EC levels M and H only; datamatrix (ECC 200, ISO/IEC 16022) for industrial part and label marking; aztec (ISO/IEC 24778) for boarding passes and transit tickets; pdf417 (ISO/IEC 15438) for ID cards and shipping labels; code128 (ISO/IEC 15417), a 1D barcode for SKUs and tracking numbers; code39 (ISO/IEC 16388), the older barcode of inventory and badge systems; or the retail barcodes ean13, upca and isbn (ISO/IEC 15420), which take the product or book number and add the check digit"))
+	shape := flags.String("shape", "", i18n.T("Symbol shape. With --symbol rmqr: R7x43 to R17x139 (height x width in modules), a height such as R13 to take the narrowest width that fits, or auto (default, the smallest area). With --symbol datamatrix: square (default), rectangle (8x18 to 16x48) or a fixed size such as 16x48"))
+	columns := flags.Int("columns", 0, i18n.T("With --symbol pdf417: data columns, 1-30 (default: chosen for a symbol about three times wider than tall)"))
+	rows := flags.Int("rows", 0, i18n.T("With --symbol pdf417: rows, 3-90 (default: as many as the payload needs)"))
+	ec_ratio := flags.String("ec-ratio", "", i18n.T("With --symbol pdf417: minimum error correction as a percentage of the data codewords, such as 25% (default: the security level the standard recommends for the payload size)"))
+	show_text := flags.Bool("show-text", false, i18n.T("With --symbol code128 or code39: print the payload in human-readable text below the bars (ean13, upca and isbn always print their digits)"))
+	mod43 := flags.Bool("mod43", false, i18n.T("With --symbol code39: append the mod-43 check character that some readers require"))
+	border := flags.Int("border", qrgenerator.DefaultBorder, i18n.T("Quiet zone around the code, in modules (0-40): the light margin scanners need to find the symbol; the standard asks for 4 (2 for rMQR and PDF417, 1 for Data Matrix, none for Aztec; 10 for Code 128 and Code 39, 11 for EAN-13 and ISBN and 9 for UPC-A, their defaults)"))
+	ec_level := flags.String("ec", "", i18n.T("Error correction level: L, M, Q or H (default H, the most damage-tolerant); preview-grid compares them"))
+	mode := flags.String("mode", "", i18n.T("Data encoding mode: auto (default; mixes modes and puts Japanese text in kanji mode), or numeric, alphanumeric, byte or kanji to encode the whole payload in that mode, failing if a character does not fit it"))
+	charset := flags.String("charset", "", i18n.T("Declare the character set of the payload with an ECI header and convert the text to it: utf-8, iso-8859-1 or shift-jis, for readers that do not assume UTF-8 (default: no ECI)"))
+	mask := flags.String("mask", "", i18n.T("QR mask pattern: 0-7 to force one, for artistic codes or to reproduce a reference symbol, or auto (default, the one easiest to scan)"))
+	qr_version := flags.Int("qr-version", 0, i18n.T("Encode every code in this QR version (1-40), so a print run has the same module count and density; fails if the payload does not fit (default: the smallest that fits)"))
+	fit := flags.String("fit", "", i18n.T("What to do when the payload does not fit: none (default, fail) or auto (step the EC level down until it fits, logging the level chosen)"))
+	fit_min_ec := flags.String("fit-min-ec", "", i18n.T("Lowest EC level --fit auto may step down to (default L)"))
+	fit_max_version := flags.Int("fit-max-version", 0, i18n.T("Largest QR version (1-40) --fit auto accepts; a lower EC level is chosen to stay within it (default 40)"))
+	fg := flags.String("fg", "", i18n.T("Module color: #rrggbb, #rgb or a CSS color name such as navy (default black); checked for contrast against --bg"))
+	fg_gradient := flags.String("fg-gradient", "", i18n.T("Paint the modules with a gradient instead of --fg: linear:FROM,TO[,ANGLEdeg] (0deg left to right, 90deg top to bottom) or radial:FROM,TO (center to corners); PNG, JPEG, AVIF, HEIF and SVG"))
+	module_style := flags.String("module-style", "", i18n.T("Shape of the dark modules: square (default), rounded (round corners except where modules touch), dots or diamond for the data modules of QR codes; the finder patterns follow --eye-style; raster formats and SVG"))
+	eye_style := flags.String("eye-style", "", i18n.T("Shape of the three finder patterns (eyes) of QR codes: square (default), rounded, circle or leaf (two opposite corners pointed), drawn whole apart from --module-style; raster formats and SVG"))
+	bg := flags.String("bg", "", i18n.T("Background color, including the quiet zone: #rrggbb, #rgb, a CSS color name or transparent (default white)"))
+	style := flags.String("preset", "", i18n.T("Styling preset: chromakey (opaque matte and no green or blue, for video keyed over a chroma background), lowerthird or lowerthird-4k (transparent 1080p or 4K PNG overlay with the QR in a corner)"))
+	corner := flags.String("corner", "", i18n.T("Corner of the lowerthird overlay: bottom-right (default), bottom-left, top-right or top-left"))
+	duplicate := flags.String("duplicate", "", i18n.T("Place a second copy of the QR in the lowerthird overlay, for large screens seen at an angle: opposite (the diagonally opposite corner), horizontal (the other side) or vertical (the other edge)"))
+	caption := flags.String("caption", "", i18n.T("Text shown next to the QR in the lowerthird overlay"))
+	compression := flags.String("compress", "", i18n.T("Compress the payload with zlib and encode it as base64 or base45 (denser, QR alphanumeric mode); only this tool's decode subcommand restores it"))
+	allow_inverted := flags.Bool("allow-inverted", false, i18n.T("Accept inverted (light-on-dark) symbols, which many older scanner apps cannot read"))
+	encrypt_passphrase := flags.Bool("encrypt", false, i18n.Sprintf("Encrypt the payload with a passphrase (AES-256-GCM, key from scrypt), asked twice or read from --passphrase-file or $%s; decode --decrypt decrypts it", passphraseEnv))
+	passphrase_file := flags.String("passphrase-file", "", i18n.Sprintf("File whose first line is the passphrase (default: $%s or a prompt)", passphraseEnv))
+	sign_key := flags.String("sign", "", i18n.T("Sign the payload with this PEM private key (ECDSA P-256/384/521, Ed25519 or RSA) and encode it as a compact JWS; decode --verify-signature checks it"))
+	encrypt_to := flags.String("encrypt-to", "", i18n.T("Encrypt the payload for this recipient before encoding: an age1... or SSH public key (needs age) or a GPG key ID, fingerprint or email (needs gpg); decode decrypts it"))
+	check_digit := flags.String("check-digit", "", i18n.T("Append check characters to the serial number at the end of the payload, and of the caption when it ends in the same serial: luhn (one character) or crc (four hex digits)"))
+	split := flags.Int("split", 0, i18n.T("Split the payload across this many QR codes, numbered like send-file, for long payloads such as SSH keys; receive-file puts it back together"))
+	structured := flags.Bool("structured-append", false, i18n.T("With --split, link the QR codes with the structured append header of the QR standard instead of the send-file format: up to 16 codes written as out_1of4.png..., which decode joins back"))
+	batch := flags.String("batch", "", i18n.T("File with one payload per line; TIFF output gets one page per payload, other formats one numbered file each"))
+	payload_template := flags.String("template", "", i18n.T("Go text/template rendered once per record of --batch, which is then read as CSV, TSV, JSON or JSON Lines: \"https://ex.com/t/{{.ID}}?u={{.User | urlquery}}\""))
+	open := flags.Bool("open", false, i18n.T("Open the generated file in the default viewer"))
+	config_path := flags.String("config", "", i18n.T("YAML or JSON file with flag values; flags on the command line take precedence"))
+	print_config := &printConfigFlag{}
+	flags.Var(print_config, "print-config", i18n.T("Print the effective configuration (defaults, config file, environment and flags) with the origin of each value, as YAML or with =json as JSON, and exit"))
+	watch_paths := flags.String("watch", "", i18n.T("Comma-separated files to watch; regenerate the output whenever one changes. A .yaml/.yml/.json file is also used as --config"))
+	buildPayload := payloadFlags(flags)
+	parsePush := pushFlags(flags)
+	parseMQTT := mqttFlags(flags)
+	parseOBS := obsFlags(flags)
+	parseSerial := serialFlags(flags)
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), i18n.T("Usage of %s:\n"), flags.Name())
+		flags.PrintDefaults()
+		fmt.Fprintf(flags.Output(), i18n.T("\nCommands (%s COMMAND -h for their flags):\n"), flags.Name())
+		for _, name := range slices.Sorted(maps.Keys(commands)) {
+			fmt.Fprintf(flags.Output(), "  %-18s %s\n", name, i18n.T(commands[name].summary))
+		}
+	}
 
-	qr_type := filepath.Ext(*qr_output)
+	flags.Parse(args)
+	// Los flags pueden ir también después del argumento: --type gs1 "(01)..." -o gs1.png
+	var positional []string
+	for flags.NArg() > 0 {
+		positional = append(positional, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
 
-	var qr_format_type qrgenerator.OutputFormat
+	explicit := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
 
-	switch qr_type[1:] {
-	case "jpg":
-		qr_format_type = qrgenerator.FormatJPEG
-	case "png":
-		qr_format_type = qrgenerator.FormatPNG
-	case "svg":
-		qr_format_type = qrgenerator.FormatSVG
-	case "css":
-		qr_format_type = qrgenerator.FormatCSS
-	default:
-		qr_format_type = qrgenerator.FormatJPEG
+	// --type wireguard --config wg0.conf: el .conf es la configuración del
+	// túnel, no un archivo de flags
+	if strings.EqualFold(filepath.Ext(*config_path), ".conf") && strings.EqualFold(flags.Lookup("type").Value.String(), "wireguard") {
+		flags.Set("wg-conf", *config_path)
+		*config_path = ""
+	}
+
+	watched := splitList(*watch_paths)
+	if *config_path == "" {
+		for _, path := range watched {
+			if config.IsConfigFile(path) {
+				*config_path = path
+				break
+			}
+		}
+	} else if len(watched) > 0 && !slices.Contains(watched, *config_path) {
+		watched = append(watched, *config_path)
+	}
+
+	opts := &generateOptions{log: newLogger(), watch: watched}
+	var values map[string]string
+	if *config_path != "" {
+		var err error
+		values, err = config.Load(*config_path)
+		if err != nil {
+			return opts, err
+		}
+		if _, ok := values["config"]; ok {
+			return opts, i18n.Errorf("%s: a config file cannot set config", *config_path)
+		}
+		if err := config.Apply(flags, values); err != nil {
+			return opts, fmt.Errorf("%s: %w", *config_path, err)
+		}
+		// La configuración puede cambiar --quiet/--verbose
+		opts.log = newLogger()
+	}
+	if print_config.format != "" {
+		opts.printConfig = print_config.format
+		opts.settings = effectiveSettings(flags, explicit, *config_path, values)
+		return opts, nil
+	}
+
+	contents, gs1, err := buildPayload(positional)
+	if err != nil {
+		return opts, err
+	}
+	var content string
+	if len(contents) > 0 {
+		if *batch != "" {
+			return opts, i18n.Errorf("--type cannot be combined with --batch")
+		}
+		content = contents[0]
+		*qr_url = content
+		opts.payloadType = strings.ToLower(flags.Lookup("type").Value.String())
+		if t, _ := payload.Lookup(opts.payloadType); t.Generated {
+			opts.identifiers = contents
+		}
+	}
+	opts.utm = payload.UTM{Source: *utm_source, Medium: *utm_medium, Campaign: *utm_campaign, Content: *utm_content}
+	if *input_file != "" {
+		switch {
+		case content != "":
+			return opts, i18n.Errorf("--type cannot be combined with --input-file")
+		case *batch != "":
+			return opts, i18n.Errorf("--input-file cannot be combined with --batch")
+		case !opts.utm.IsZero():
+			return opts, i18n.Errorf("--utm-* cannot be combined with --input-file")
+		}
+		data, err := os.ReadFile(*input_file)
+		if err != nil {
+			return opts, i18n.Errorf("%w: cannot read input file: %w", qrgenerator.ErrIO, err)
+		}
+		if len(data) == 0 {
+			return opts, i18n.Errorf("%w: %s is empty", qrgenerator.ErrInvalidInput, *input_file)
+		}
+		*qr_url = string(data)
+	}
+	if opts.serials, err = parseSerial(); err != nil {
+		return opts, err
+	}
+	if opts.serials != nil {
+		urlSet := false
+		flags.Visit(func(f *flag.Flag) { urlSet = urlSet || f.Name == "url" })
+		switch {
+		case *batch != "" || *input_file != "" || content != "":
+			return opts, i18n.Errorf("--serial-start builds the payloads; it cannot be combined with --batch, --input-file or --type")
+		case urlSet:
+			return opts, i18n.Errorf("-url cannot be combined with --serial-start; put the URL in --serial-format")
+		}
+		// Se valida con el primer payload de la serie
+		*qr_url = opts.serials.payload(opts.serials.start)
+	}
+	// La URL se normaliza y lleva los parámetros de campaña; en un lote, cada línea
+	opts.normalize = !*no_normalize && content == "" && *input_file == ""
+	if *batch == "" && *input_file == "" {
+		if *qr_url, err = preparePayload(opts, *qr_url); err != nil {
+			return opts, err
+		}
+	}
+
+	if opts.push, err = parsePush(); err != nil {
+		return opts, err
+	}
+	if opts.push.target != "" && *batch != "" {
+		return opts, i18n.Errorf("--push cannot be combined with --batch")
+	}
+	if opts.mqtt, err = parseMQTT(); err != nil {
+		return opts, err
+	}
+	if opts.mqtt.target != nil && *batch != "" {
+		return opts, i18n.Errorf("--mqtt cannot be combined with --batch")
+	}
+	if opts.obs, err = parseOBS(); err != nil {
+		return opts, err
+	}
+	if opts.obs != nil && *batch != "" {
+		return opts, i18n.Errorf("--obs cannot be combined with --batch")
+	}
+	if opts.serials != nil && (opts.push.target != "" || opts.mqtt.target != nil || opts.obs != nil) {
+		return opts, i18n.Errorf("--serial-start writes files; it cannot be combined with --push, --mqtt or --obs")
+	}
+	if len(opts.identifiers) > 1 && (opts.push.target != "" || opts.mqtt.target != nil || opts.obs != nil) {
+		return opts, i18n.Errorf("--count writes files; it cannot be combined with --push, --mqtt or --obs")
+	}
+
+	if *split != 0 {
+		switch {
+		case *split < 2:
+			return opts, i18n.Errorf("--split needs at least 2 QR codes")
+		case *batch != "":
+			return opts, i18n.Errorf("--split cannot be combined with --batch")
+		case len(opts.identifiers) > 1:
+			return opts, i18n.Errorf("--split cannot be combined with --count")
+		case opts.serials != nil:
+			return opts, i18n.Errorf("--split cannot be combined with --serial-start")
+		case opts.push.target != "" || opts.mqtt.target != nil || opts.obs != nil:
+			return opts, i18n.Errorf("--split writes files; it cannot be combined with --push, --mqtt or --obs")
+		case gs1:
+			return opts, i18n.Errorf("--type gs1 cannot be combined with --split")
+		case *symbol != "" && !strings.EqualFold(strings.TrimSpace(*symbol), "qr"):
+			return opts, i18n.Errorf("--split cannot be combined with --symbol %s", *symbol)
+		case *structured && *split > qrcodec.MaxStructuredAppend:
+			return opts, i18n.Errorf("--structured-append links at most %d QR codes", qrcodec.MaxStructuredAppend)
+		}
+		opts.split = *split
+		opts.structured = *structured
+	} else if *structured {
+		return opts, i18n.Errorf("--structured-append needs --split")
+	}
+
+	opts.outputs, opts.problems = resolveOutputs(qr_outputs.paths, *format, splitList(*formats))
+	if opts.push.target != "" && !qr_outputs.set && *formats == "" {
+		// Con --push solo se escribe un archivo si se pide con -o o --formats
+		opts.outputs, opts.problems = nil, nil
+	}
+	if *out_dir != "" {
+		opts.outDir = *out_dir
+		for i, output := range opts.outputs {
+			if !filepath.IsAbs(output.Path) {
+				opts.outputs[i].Path = filepath.Join(*out_dir, output.Path)
+			}
+		}
+	}
+	if *split != 0 && len(opts.outputs) > 1 {
+		opts.problems = append(opts.problems, qrgenerator.Problem{Err: i18n.Errorf("%w: --split writes a single numbered output; drop --formats or the extra -o", qrgenerator.ErrInvalidInput)})
+	}
+	if *batch != "" && len(opts.outputs) > 1 {
+		opts.problems = append(opts.problems, qrgenerator.Problem{Err: i18n.Errorf("%w: --batch writes a single output; drop --formats or the extra -o", qrgenerator.ErrInvalidInput)})
+	}
+	if len(opts.identifiers) > 1 && len(opts.outputs) > 1 {
+		opts.problems = append(opts.problems, qrgenerator.Problem{Err: i18n.Errorf("%w: --count writes a single numbered output; drop --formats or the extra -o", qrgenerator.ErrInvalidInput)})
+	}
+
+	opts.config = qrgenerator.QRConfig{
+		URL:     *qr_url,
+		Size:    *qr_size,
+		MaxSize: *max_size,
+	}
+	if len(opts.outputs) > 0 {
+		opts.config.OutputPath, opts.config.Format = opts.outputs[0].Path, opts.outputs[0].Format
+	}
+
+	opts.config.ExtraParams = map[string]string{}
+	if *quality != 0 {
+		opts.config.ExtraParams["quality"] = strconv.Itoa(*quality)
+	}
+	if *subsampling != "" {
+		opts.config.ExtraParams["subsampling"] = *subsampling
+	}
+	if *progressive {
+		opts.config.ExtraParams["progressive"] = "true"
+	}
+	if *tiff_compression != "" {
+		opts.config.ExtraParams["compression"] = *tiff_compression
+	}
+	if *matrix_format != "" {
+		opts.config.ExtraParams["matrix"] = *matrix_format
+	}
+	if *thumbnail != 0 {
+		opts.config.ExtraParams["thumbnail"] = strconv.Itoa(*thumbnail)
+	}
+	if *symbol != "" {
+		opts.config.ExtraParams["symbol"] = *symbol
+	}
+	if *shape != "" {
+		opts.config.ExtraParams["shape"] = *shape
+	}
+	if *columns != 0 {
+		opts.config.ExtraParams["columns"] = strconv.Itoa(*columns)
+	}
+	if *rows != 0 {
+		opts.config.ExtraParams["rows"] = strconv.Itoa(*rows)
+	}
+	if *ec_ratio != "" {
+		opts.config.ExtraParams["ec-ratio"] = *ec_ratio
+	}
+	if *show_text {
+		opts.config.ExtraParams["show-text"] = "true"
+	}
+	if *mod43 {
+		opts.config.ExtraParams["mod43"] = "true"
+	}
+	if *scale != 0 {
+		sizeSet := false
+		flags.Visit(func(f *flag.Flag) { sizeSet = sizeSet || f.Name == "size" })
+		if sizeSet {
+			return opts, i18n.Errorf("--scale sets the width from the module count; it cannot be combined with -size")
+		}
+		opts.config.ExtraParams["scale"] = strconv.Itoa(*scale)
+	}
+	if *width != "" {
+		sizeSet := false
+		flags.Visit(func(f *flag.Flag) { sizeSet = sizeSet || f.Name == "size" })
+		switch {
+		case sizeSet:
+			return opts, i18n.Errorf("--width sets the size from the printed width; it cannot be combined with -size")
+		case *scale != 0:
+			return opts, i18n.Errorf("--width cannot be combined with --scale")
+		}
+		opts.config.ExtraParams["width"] = *width
+	}
+	if *dpi != 0 {
+		opts.config.ExtraParams["dpi"] = strconv.Itoa(*dpi)
+	}
+	// Los códigos de barras tienen otra zona de silencio por defecto: -border 4 también cuenta
+	borderSet := false
+	flags.Visit(func(f *flag.Flag) { borderSet = borderSet || f.Name == "border" })
+	if borderSet {
+		opts.config.ExtraParams["border"] = strconv.Itoa(*border)
+	}
+	if *ec_level != "" {
+		opts.config.ExtraParams["ec"] = *ec_level
+	}
+	opts.config.Version = *qr_version
+	if *charset != "" {
+		opts.config.ExtraParams["charset"] = *charset
+	}
+	if *mode != "" {
+		opts.config.ExtraParams["mode"] = *mode
+	}
+	if *mask != "" {
+		opts.config.ExtraParams["mask"] = *mask
+	}
+	if *fit != "" {
+		opts.config.ExtraParams["fit"] = *fit
+	}
+	if *fit_min_ec != "" {
+		opts.config.ExtraParams["fit-min-ec"] = *fit_min_ec
+	}
+	if *fit_max_version != 0 {
+		opts.config.ExtraParams["fit-max-version"] = strconv.Itoa(*fit_max_version)
+	}
+	if *input_file != "" {
+		opts.config.ExtraParams["binary"] = "true"
+	}
+	if *scan_distance != "" {
+		opts.config.ExtraParams["scan-distance"] = *scan_distance
+	}
+	if *manifest {
+		opts.config.ExtraParams["manifest"] = program + " " + version
+	}
+	if *fg != "" {
+		opts.config.ExtraParams["fg"] = *fg
+	}
+	if *fg_gradient != "" {
+		opts.config.ExtraParams["fg-gradient"] = *fg_gradient
+	}
+	if *module_style != "" {
+		opts.config.ExtraParams["module-style"] = *module_style
 	}
+	if *eye_style != "" {
+		opts.config.ExtraParams["eye-style"] = *eye_style
+	}
+	if *bg != "" {
+		opts.config.ExtraParams["bg"] = *bg
+	}
+	if *style != "" {
+		opts.config.ExtraParams["preset"] = *style
+	}
+	if *corner != "" {
+		opts.config.ExtraParams["corner"] = *corner
+	}
+	if *caption != "" {
+		opts.config.ExtraParams["caption"] = *caption
+	}
+	if *duplicate != "" {
+		opts.config.ExtraParams["duplicate"] = *duplicate
+	}
+	if *compression != "" {
+		opts.config.ExtraParams["compress"] = *compression
+	}
+	if *allow_inverted {
+		opts.config.ExtraParams["allow-inverted"] = "true"
+	}
+	if *encrypt_to != "" {
+		opts.config.ExtraParams["encrypt-to"] = *encrypt_to
+	}
+	switch {
+	case *encrypt_passphrase && *encrypt_to != "":
+		return opts, i18n.Errorf("--encrypt cannot be combined with --encrypt-to")
+	case *encrypt_passphrase && opts.split > 0:
+		return opts, i18n.Errorf("--split cannot be combined with --encrypt")
+	case !*encrypt_passphrase && *passphrase_file != "":
+		return opts, i18n.Errorf("--passphrase-file needs --encrypt")
+	case *encrypt_passphrase && opts.printConfig == "":
+		if _, ok := os.LookupEnv(passphraseEnv); !ok && *passphrase_file == "" && len(opts.watch) > 0 {
+			// Cada cambio vuelve a leer las opciones: no se puede preguntar cada vez
+			return opts, i18n.Errorf("--encrypt with --watch needs --passphrase-file or $%s", passphraseEnv)
+		}
+		if opts.config.Passphrase, err = readPassphrase(*passphrase_file, true); err != nil {
+			return opts, i18n.Errorf("--encrypt: %w", err)
+		}
+	}
+	if *check_digit != "" {
+		opts.config.ExtraParams["check-digit"] = *check_digit
+	}
+	if *sign_key != "" {
+		opts.config.ExtraParams["sign"] = *sign_key
+	}
+	if gs1 {
+		opts.config.ExtraParams["gs1"] = "true"
+	}
+	if opts.split > 0 {
+		// Se transformaría cada trozo por separado y receive-file no los rearma
+		for _, param := range []string{"compress", "encrypt-to", "check-digit", "sign"} {
+			if opts.config.ExtraParams[param] != "" {
+				return opts, i18n.Errorf("--split cannot be combined with --%s", param)
+			}
+		}
+	}
+	if *payload_template != "" {
+		if *batch == "" {
+			return opts, i18n.Errorf("--template needs --batch with the records (CSV, TSV, JSON or JSON Lines)")
+		}
+		if opts.template, err = payload.ParseTemplate(*payload_template); err != nil {
+			return opts, err
+		}
+	}
+	opts.batch = *batch
+	opts.strict = *strict
+	opts.open = *open
+	return opts, nil
+}
+
+// outputList es el flag -o: se puede repetir y acepta rutas separadas por
+// comas, que es como llegan las listas de un archivo de configuración. El
+// primer valor reemplaza a la ruta por defecto.
+type outputList struct {
+	paths []string
+	set   bool
+}
+
+func (o *outputList) String() string {
+	if o == nil {
+		return ""
+	}
+	return strings.Join(o.paths, ",")
+}
 
-	config := qrgenerator.QRConfig{
-		URL:        *qr_url,
-		Size:       *qr_size,
-		OutputPath: *qr_output,
-		Format:     qr_format_type,
+func (o *outputList) Set(value string) error {
+	if !o.set {
+		o.paths, o.set = nil, true
 	}
-	err := qrgenerator.GenerateQR(config)
+	for _, path := range strings.Split(value, ",") {
+		o.paths = append(o.paths, strings.TrimSpace(path))
+	}
+	return nil
+}
+
+// splitList separa una lista por comas descartando los elementos vacíos
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// resolveOutputs arma la lista de salidas: con --formats, un archivo por
+// formato nombrado a partir de la única ruta de -o; sin él, cada -o con el
+// formato de su extensión o el de --format
+func resolveOutputs(paths []string, format string, formats []string) ([]qrgenerator.Output, []qrgenerator.Problem) {
+	fail := func(err error) ([]qrgenerator.Output, []qrgenerator.Problem) {
+		return nil, []qrgenerator.Problem{{Err: err}}
+	}
+
+	if len(formats) > 0 {
+		switch {
+		case format != "":
+			return fail(i18n.Errorf("%w: --format and --formats cannot be combined", qrgenerator.ErrInvalidInput))
+		case len(paths) > 1:
+			return fail(i18n.Errorf("%w: --formats takes a single -o path", qrgenerator.ErrInvalidInput))
+		}
+		parsed := make([]qrgenerator.OutputFormat, len(formats))
+		for i, name := range formats {
+			f, ok := qrgenerator.ParseFormat(name)
+			if !ok {
+				return fail(i18n.Errorf("%w: unsupported format: %s", qrgenerator.ErrInvalidInput, name))
+			}
+			parsed[i] = f
+		}
+		return qrgenerator.OutputsFor(paths[0], parsed), nil
+	}
+
+	var outputs []qrgenerator.Output
+	var problems []qrgenerator.Problem
+	for _, path := range paths {
+		f, resolved := qrgenerator.ResolveFormat(path, format)
+		outputs = append(outputs, qrgenerator.Output{Path: path, Format: f})
+		problems = append(problems, resolved...)
+	}
+	return outputs, problems
+}
+
+// runGenerate genera un código QR a partir de los flags
+func runGenerate(args []string) int {
+	opts, err := parseGenerate(args)
 	if err != nil {
-		log.Printf("%q", err)
+		opts.log.Errorf("%v", err)
+		recordUsage("generate", exitInvalidInput, nil)
+		if len(opts.watch) == 0 {
+			return exitInvalidInput
+		}
+	}
+
+	if err == nil {
+		if code := warnLegacy(opts); code != exitOK {
+			recordUsage("generate", code, nil)
+			return code
+		}
+	}
+	if err == nil && opts.printConfig != "" {
+		return printSettings(opts.settings, opts.printConfig)
+	}
+
+	code := exitInvalidInput
+	if err == nil {
+		var written string
+		code, written = generate(opts)
+		recordUsage("generate", code, generateEvents(opts))
+		if code == exitOK && opts.open && written != "" {
+			if err := viewer.Open(written); err != nil {
+				opts.log.Warnf("%v", err)
+			}
+		}
+	}
+	if len(opts.watch) == 0 {
+		return code
+	}
+	return watchGenerate(args, opts)
+}
+
+// validate informa los problemas de las opciones antes de generar. Sin
+// --strict corta en el primer error y solo advierte; con --strict revisa toda
+// la configuración, informa todos los problemas juntos y las advertencias
+// también cuentan como error.
+func validate(opts *generateOptions) int {
+	problems := opts.problems
+	if opts.strict {
+		// Los problemas que no dependen del formato se informan una sola vez
+		seen := map[string]bool{}
+		for _, output := range opts.outputs {
+			config := opts.config
+			config.OutputPath, config.Format = output.Path, output.Format
+			for _, problem := range qrgenerator.Validate(config) {
+				if !seen[problem.Err.Error()] {
+					seen[problem.Err.Error()] = true
+					problems = append(problems, problem)
+				}
+			}
+		}
+	}
+
+	code := exitOK
+	for _, problem := range problems {
+		if problem.Warning && !opts.strict {
+			opts.log.Warnf("%v", problem.Err)
+			continue
+		}
+		opts.log.Errorf("%v", problem.Err)
+		if code == exitOK {
+			code = exitCodeFor(problem.Err)
+			if problem.Warning {
+				code = exitInvalidInput
+			}
+		}
+		if !opts.strict {
+			return code
+		}
+	}
+	if code != exitOK {
+		opts.log.Errorf("strict validation failed: %d problem(s)", len(problems))
+	}
+	return code
+}
+
+// generate escribe el QR descrito por las opciones y devuelve el código de
+// salida y el archivo escrito (el primero, en un lote o con varias salidas)
+func generate(opts *generateOptions) (int, string) {
+	log := opts.log
+	config := opts.config
+
+	if code := validate(opts); code != exitOK {
+		return code, ""
+	}
+	if opts.outDir != "" {
+		if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+			return exitIO, ""
+		}
+	}
+	if opts.batch != "" || opts.split > 0 || len(opts.identifiers) > 1 {
+		return generateBatch(opts)
+	}
+	if opts.serials != nil {
+		return generateSerials(opts)
+	}
+
+	log.Debugf("payload: %q (%d bytes)", config.URL, len(config.URL))
+	var written string
+	if len(opts.outputs) > 0 {
+		for _, output := range opts.outputs {
+			if scale := config.ExtraParams["scale"]; scale != "" {
+				log.Debugf("output: %s (format %s, %spx per module)", output.Path, output.Format, scale)
+			} else if width := config.ExtraParams["width"]; width != "" {
+				log.Debugf("output: %s (format %s, %s wide)", output.Path, output.Format, width)
+			} else {
+				log.Debugf("output: %s (format %s, size %dpx)", output.Path, output.Format, config.Size)
+			}
+		}
+
+		results, err := qrgenerator.GenerateFormats(config, opts.outputs)
+		for _, result := range results {
+			logResult(log, result)
+			log.Infof("QR written to %s", result.OutputPath)
+		}
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err), ""
+		}
+		written = results[0].OutputPath
+		if opts.identifiers != nil {
+			if code := writeIdentifiers(opts, results); code != exitOK {
+				return code, written
+			}
+		}
+	}
+
+	if opts.push.target != "" {
+		if code := pushDisplay(opts); code != exitOK {
+			return code, written
+		}
+	}
+	if opts.mqtt.target != nil {
+		if code := publishMQTT(opts, written); code != exitOK {
+			return code, written
+		}
+	}
+	if opts.obs != nil {
+		if code := updateOBS(opts, written); code != exitOK {
+			return code, written
+		}
+	}
+	return exitOK, written
+}
+
+// barcodeName es el nombre de un código de productos en los mensajes
+func barcodeName(symbol string) string {
+	switch symbol {
+	case "upca":
+		return "UPC-A"
+	case "isbn":
+		return "ISBN"
 	}
+	return "EAN-13"
+}
+
+// logResult informa las advertencias y el diagnóstico de un QR generado
+func logResult(log *logger.Logger, result *qrgenerator.QRResult) {
+	for _, warning := range result.Warnings {
+		log.Warnf("%s", warning)
+	}
+
+	if result.FitFrom != "" {
+		log.Infof("--fit auto: EC level %s does not fit; using %s (version %d)", result.FitFrom, result.Level, result.Version)
+	}
+	switch result.Symbol {
+	case "datamatrix":
+		log.Debugf("Data Matrix %s (ECC 200)", result.Shape)
+	case "aztec":
+		log.Debugf("Aztec %s", result.Shape)
+	case "pdf417":
+		log.Debugf("PDF417 %s (columns x rows), security level %s", result.Shape, result.Level)
+	case "rmqr":
+		log.Debugf("rMQR %s, EC level %s", result.Shape, result.Level)
+	case "code128":
+		log.Debugf("Code 128, %d symbol characters, %d modules wide", result.Version, result.Modules)
+	case "code39":
+		log.Debugf("Code 39, %d characters, %d modules wide", result.Version, result.Modules)
+	case "ean13", "upca", "isbn":
+		log.Debugf("%s barcode, %d modules wide with guard bars", barcodeName(result.Symbol), result.Modules)
+	default:
+		log.Debugf("QR version %d (%dx%d modules), EC level %s, mask %d", result.Version, result.Modules, result.Modules, result.Level, result.Mask)
+	}
+	if result.Streamed {
+		log.Debugf("large output: pixels rendered on demand from the module matrix")
+	}
+	log.Debugf("encode %s, write %s", result.EncodeTime, result.WriteTime)
+	if result.Thumbnail != "" {
+		log.Debugf("thumbnail written to %s", result.Thumbnail)
+	}
+}
+
+// watchGenerate regenera la salida cada vez que cambia alguno de los archivos
+// vigilados, releyendo la configuración. Los errores se informan sin cortar la
+// vigilancia, así se puede corregir el archivo y seguir iterando.
+func watchGenerate(args []string, opts *generateOptions) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	fmt.Println("QR Generator")
-	fmt.Println("> Configuracion")
-	fmt.Printf("%v", config)
+	paths := opts.watch
+	log := opts.log
+	watcher := watch.New(paths)
+	log.Infof("watching %s (Ctrl+C to stop)", strings.Join(paths, ", "))
+
+	for {
+		changed, err := watcher.Wait(ctx, watch.DefaultInterval)
+		if err != nil {
+			return exitOK
+		}
+		log.Infof("%s changed, regenerating", changed)
+
+		opts, err := parseGenerate(args)
+		if err != nil {
+			opts.log.Errorf("%v", err)
+			continue
+		}
+		generate(opts)
+	}
+}
+
+// logFlags registra --quiet, --verbose, --lang y --no-telemetry y devuelve una
+// función que crea el logger una vez parseados los flags
+func logFlags(flags *flag.FlagSet) func() *logger.Logger {
+	quiet := flags.Bool("quiet", false, i18n.T("Only print errors"))
+	verbose := flags.Bool("verbose", false, i18n.T("Print QR version, mask, timings and file paths"))
+	lang := flags.String("lang", "", i18n.T("Message language: en or es (default from LC_ALL, LC_MESSAGES or LANG)"))
+	skipTelemetry := flags.Bool("no-telemetry", false, i18n.T("Do not count this run in the local usage metrics, even if telemetry is enabled"))
+	return func() *logger.Logger {
+		noTelemetry = *skipTelemetry
+		// --lang puede venir de un archivo de configuración
+		i18n.Set(i18n.Detect(*lang))
+		return logger.Default(logLevel(*quiet, *verbose))
+	}
+}
+
+// logLevel elige el nivel de log según los flags; --quiet tiene prioridad
+func logLevel(quiet, verbose bool) logger.Level {
+	switch {
+	case quiet:
+		return logger.LevelError
+	case verbose:
+		return logger.LevelDebug
+	default:
+		return logger.LevelInfo
+	}
 }
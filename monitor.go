@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/monitor"
+)
+
+// runMonitor revisa periódicamente imágenes QR publicadas y alerta por webhook
+// cuando un código deja de leerse o su destino falla. Con --once hace una sola
+// pasada y sale con exitCheckFailed si algún código falla.
+func runMonitor(args []string) int {
+	flags := newFlagSet("monitor")
+	interval := flags.Duration("interval", 5*time.Minute, i18n.T("Time between checks"))
+	timeout := flags.Duration("timeout", 10*time.Second, i18n.T("HTTP timeout for images, destinations and webhook"))
+	webhook := flags.String("webhook", "", i18n.T("URL that receives a JSON POST when a code starts failing or recovers"))
+	targets := flags.String("targets", "", i18n.T("File with one image path or URL per line"))
+	once := flags.Bool("once", false, i18n.T("Check every target once and exit"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli monitor [flags] image-or-url...\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	sources := flags.Args()
+	if *targets != "" {
+		listed, err := monitor.ReadSources(*targets)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitIO
+		}
+		sources = append(sources, listed...)
+	}
+	if len(sources) == 0 {
+		log.Errorf("no targets to monitor")
+		flags.Usage()
+		return exitInvalidInput
+	}
+	if *interval <= 0 {
+		log.Errorf("--interval must be positive")
+		return exitInvalidInput
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	m := monitor.New(*webhook, *timeout, log)
+	if *once {
+		if failing := m.CheckAll(ctx, sources); failing > 0 {
+			return exitCheckFailed
+		}
+		return exitOK
+	}
+
+	log.Infof("monitoring %d targets every %s", len(sources), *interval)
+	m.Run(ctx, sources, *interval)
+	return exitOK
+}
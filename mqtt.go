@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"flag"
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/eink"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/mqtt"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// Contenidos que se pueden publicar por MQTT
+const (
+	mqttPayloadImage  = "image"  // Bytes del archivo generado
+	mqttPayloadBase64 = "base64" // El archivo generado en base64
+	mqttPayloadMatrix = "matrix" // La matriz de módulos en JSON
+)
+
+// mqttOptions describen la publicación del QR en un tema MQTT
+type mqttOptions struct {
+	target  *mqtt.Target // nil si no se publica
+	url     string
+	payload string
+	publish mqtt.Options
+}
+
+// mqttFlags registra los flags de --mqtt; la función devuelta los interpreta
+// una vez parseados
+func mqttFlags(flags *flag.FlagSet) func() (mqttOptions, error) {
+	target := flags.String("mqtt", "", i18n.T("Publish the QR to an MQTT topic: mqtt(s)://user:pass@host:port/topic"))
+	payload := flags.String("mqtt-payload", mqttPayloadImage, i18n.T("What --mqtt publishes: image (the generated file), base64 (the file in base64) or matrix (module matrix JSON)"))
+	qos := flags.Uint("mqtt-qos", 0, i18n.T("MQTT QoS: 0, 1 or 2"))
+	retain := flags.Bool("mqtt-retain", false, i18n.T("Ask the broker to retain the message for later subscribers"))
+
+	return func() (mqttOptions, error) {
+		opts := mqttOptions{url: *target, payload: strings.ToLower(*payload)}
+		if opts.url == "" {
+			return opts, nil
+		}
+		var err error
+		if opts.target, err = mqtt.ParseTarget(opts.url); err != nil {
+			return opts, err
+		}
+		switch opts.payload {
+		case mqttPayloadImage, mqttPayloadBase64, mqttPayloadMatrix:
+		default:
+			return opts, i18n.Errorf("unsupported MQTT payload %q (image, base64 or matrix)", *payload)
+		}
+		if *qos > 2 {
+			return opts, i18n.Errorf("invalid MQTT QoS %d (0, 1 or 2)", *qos)
+		}
+		opts.publish = mqtt.Options{QoS: byte(*qos), Retain: *retain}
+		return opts, nil
+	}
+}
+
+// publishMQTT publica el archivo escrito (o la matriz) en el tema MQTT
+func publishMQTT(opts *generateOptions, written string) int {
+	log := opts.log
+	target := eink.Redact(opts.mqtt.url)
+
+	var data []byte
+	var err error
+	if opts.mqtt.payload == mqttPayloadMatrix {
+		data, err = qrgenerator.Matrix(opts.config, qrgenerator.MatrixJSON)
+	} else if written == "" {
+		err = i18n.Errorf("%w: --mqtt-payload %s publishes the generated file; add -o", qrgenerator.ErrInvalidInput, opts.mqtt.payload)
+	} else if data, err = os.ReadFile(written); err != nil {
+		err = i18n.Errorf("%w: %w", qrgenerator.ErrIO, err)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	if opts.mqtt.payload == mqttPayloadBase64 {
+		data = []byte(base64.StdEncoding.EncodeToString(data))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mqtt.DefaultTimeout)
+	defer cancel()
+	log.Debugf("mqtt: %d bytes (%s, QoS %d, retain %t) to %s", len(data), opts.mqtt.payload, opts.mqtt.publish.QoS, opts.mqtt.publish.Retain, target)
+	if err := mqtt.Publish(ctx, opts.mqtt.target, data, opts.mqtt.publish); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot publish to %s: %w", qrgenerator.ErrIO, target, err))
+		return exitIO
+	}
+	log.Infof("QR published to %s", target)
+	return exitOK
+}
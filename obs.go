@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/obs"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// obsPasswordEnv es la variable de entorno con la contraseña de obs-websocket,
+// para no dejarla en el historial de la shell
+const obsPasswordEnv = "OBS_WEBSOCKET_PASSWORD"
+
+// obsFlags registra los flags de --obs; la función devuelta los interpreta
+// una vez parseados y devuelve nil si no se pidió actualizar OBS
+func obsFlags(flags *flag.FlagSet) func() (*obs.Target, error) {
+	addr := flags.String("obs", "", i18n.T("Point an OBS image or browser source at the generated file via obs-websocket: host:port (default port 4455)"))
+	source := flags.String("obs-source", "", i18n.T("Name of the OBS source to update (with --obs)"))
+	password := flags.String("obs-password", "", i18n.Sprintf("obs-websocket password (default $%s)", obsPasswordEnv))
+
+	return func() (*obs.Target, error) {
+		if *addr == "" {
+			if *source != "" || *password != "" {
+				return nil, i18n.Errorf("--obs-source and --obs-password need --obs")
+			}
+			return nil, nil
+		}
+		u, err := obs.ParseAddress(*addr)
+		if err != nil {
+			return nil, err
+		}
+		if *source == "" {
+			return nil, i18n.Errorf("--obs needs --obs-source with the name of the source to update")
+		}
+		target := &obs.Target{URL: u, Source: *source, Password: *password}
+		if target.Password == "" {
+			target.Password = os.Getenv(obsPasswordEnv)
+		}
+		return target, nil
+	}
+}
+
+// updateOBS apunta la fuente de OBS al archivo escrito; en modo --watch se
+// actualiza con cada regeneración
+func updateOBS(opts *generateOptions, written string) int {
+	log := opts.log
+	if written == "" {
+		err := i18n.Errorf("%w: --obs shows the generated file; add -o", qrgenerator.ErrInvalidInput)
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	// OBS puede correr en otro directorio: la ruta tiene que ser absoluta
+	path, err := filepath.Abs(written)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), obs.DefaultTimeout)
+	defer cancel()
+	log.Debugf("obs: source %q at %s -> %s", opts.obs.Source, opts.obs.URL.Host, path)
+	if err := obs.UpdateSource(ctx, opts.obs, path); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot update OBS source %q: %w", qrgenerator.ErrIO, opts.obs.Source, err))
+		return exitIO
+	}
+	log.Infof("OBS source %q updated", opts.obs.Source)
+	return exitOK
+}
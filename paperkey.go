@@ -0,0 +1,229 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/paperkey"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// runPaperkey cifra un archivo de clave con una frase de contraseña y lo
+// imprime como QR en un PDF con las instrucciones para restaurarlo
+func runPaperkey(args []string) int {
+	flags := newFlagSet("paperkey")
+	output := flags.String("o", "", i18n.T("Output: a printable .pdf, or a .png name numbered per QR"))
+	passphraseFile := flags.String("passphrase-file", "", i18n.Sprintf("File whose first line is the passphrase (default: $%s or a prompt)", passphraseEnv))
+	chunk := flags.Int("chunk", transfer.DefaultChunkSize, i18n.Sprintf("Bytes per QR (1-%d); smaller chunks give sparser codes that scan faster", transfer.MaxChunkSize))
+	size := flags.Int("size", 768, i18n.T("QR size for .png output"))
+	shares := flags.Int("shares", 0, i18n.T("Split the backup into this many Shamir shares, one file each (key-001.pdf...)"))
+	threshold := flags.Int("threshold", 0, i18n.T("Shares needed to restore (2 to -shares)"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli paperkey -o backup.pdf [flags] keyfile\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() != 1 || *output == "" {
+		log.Errorf("%v", i18n.T("paperkey needs one key file and -o"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	ext := strings.ToLower(filepath.Ext(*output))
+	if ext != ".pdf" && ext != ".png" {
+		log.Errorf("%v", i18n.Errorf("%w: paperkey writes a .pdf or .png pages", qrgenerator.ErrInvalidInput))
+		return exitInvalidInput
+	}
+
+	if (*shares == 0) != (*threshold == 0) {
+		log.Errorf("%v", i18n.T("-shares and -threshold go together"))
+		return exitInvalidInput
+	}
+	if *shares != 0 && (*threshold < 2 || *threshold > *shares || *shares > 255) {
+		log.Errorf("%v", i18n.T("-threshold must be between 2 and -shares, and -shares at most 255"))
+		return exitInvalidInput
+	}
+
+	secret, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+	passphrase, err := readPassphrase(*passphraseFile, true)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	envelope, err := paperkey.Seal(flags.Arg(0), secret, passphrase)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	parts := [][]byte{envelope}
+	if *shares > 0 {
+		if parts, err = paperkey.SplitEnvelope(envelope, *shares, *threshold); err != nil {
+			log.Errorf("%v", err)
+			return exitInvalidInput
+		}
+	}
+
+	sum := sha256.Sum256(secret)
+	backup := paperkey.Backup{Name: filepath.Base(flags.Arg(0)), Sum: hex.EncodeToString(sum[:]), Created: time.Now(), Shares: *shares, Threshold: *threshold}
+	for i, part := range parts {
+		path := *output
+		if len(parts) > 1 {
+			path = qrgenerator.BatchPath(*output, i+1, len(parts))
+		}
+		backup.Share = i + 1
+		if code := writePaperkey(log, part, path, *chunk, *size, backup); code != exitOK {
+			return code
+		}
+	}
+	log.Infof("print it, then check the restore with paperkey-restore before deleting any copy")
+	return exitOK
+}
+
+// writePaperkey escribe un sobre (o una parte) como PDF o PNG numerados
+func writePaperkey(log *logger.Logger, data []byte, path string, chunk, size int, backup paperkey.Backup) int {
+	payloads, err := transfer.Split(data, chunk)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+
+	config := qrgenerator.QRConfig{Size: size, OutputPath: path, Format: qrgenerator.FormatPNG}
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		results, err := qrgenerator.GenerateBatch(config, payloads)
+		if err != nil {
+			return sendFileError(log, err)
+		}
+		log.Infof("%d QR codes written to %s ... %s", len(results), results[0].OutputPath, results[len(results)-1].OutputPath)
+		return exitOK
+	}
+
+	for _, payload := range payloads {
+		modules, err := moduleMatrix(payload)
+		if err != nil {
+			return sendFileError(log, err)
+		}
+		backup.Codes = append(backup.Codes, modules)
+	}
+	if err := paperkey.Layout(backup).Write(path); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot write the PDF: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	log.Infof("%d QR codes written to %s", len(payloads), path)
+	return exitOK
+}
+
+// moduleMatrix codifica el texto y devuelve sus módulos, para dibujarlos en un PDF
+func moduleMatrix(text string) ([][]bool, error) {
+	bits, err := qrgenerator.Matrix(qrgenerator.QRConfig{URL: text}, qrgenerator.MatrixBits)
+	if err != nil {
+		return nil, err
+	}
+	var modules [][]bool
+	for _, row := range strings.Fields(string(bits)) {
+		line := make([]bool, len(row))
+		for i := range row {
+			line[i] = row[i] == '1'
+		}
+		modules = append(modules, line)
+	}
+	return modules, nil
+}
+
+// runPaperkeyRestore rearma y descifra un respaldo de paperkey a partir de
+// las imágenes escaneadas de sus QR. Sale con exitCheckFailed si faltan
+// partes o la frase no abre el respaldo.
+func runPaperkeyRestore(args []string) int {
+	flags := newFlagSet("paperkey-restore")
+	output := flags.String("o", "", i18n.T("Path of the restored file (default: the original name, in the current directory)"))
+	passphraseFile := flags.String("passphrase-file", "", i18n.Sprintf("File whose first line is the passphrase (default: $%s or a prompt)", passphraseEnv))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli paperkey-restore [flags] image...\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() == 0 {
+		log.Errorf("no images to decode")
+		flags.Usage()
+		return exitInvalidInput
+	}
+	envelope, code := restoreEnvelope(log, flags.Args())
+	if code != exitOK {
+		return code
+	}
+	passphrase, err := readPassphrase(*passphraseFile, false)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	name, secret, err := paperkey.Open(envelope, passphrase)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCheckFailed
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Base(name)
+		if _, err := os.Stat(path); err == nil {
+			log.Errorf("%v", i18n.Errorf("%s already exists; choose another path with -o", path))
+			return exitIO
+		}
+	}
+	if err := os.WriteFile(path, secret, 0o600); err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+	log.Infof("%d bytes written to %s", len(secret), path)
+	return exitOK
+}
+
+// restoreEnvelope rearma el sobre cifrado de las imágenes. Si son partes de
+// Shamir, junta cada parte completa y las combina; las incompletas se
+// ignoran mientras alcancen las demás.
+func restoreEnvelope(log *logger.Logger, paths []string) ([]byte, int) {
+	files, code := collectChunks(log, paths)
+	if code != exitOK {
+		return nil, code
+	}
+	var parts [][]byte
+	for _, file := range files {
+		data, err := file.Bytes()
+		if err != nil {
+			log.Warnf("%v", err)
+			continue
+		}
+		parts = append(parts, data)
+	}
+	if len(parts) == 0 {
+		return nil, exitCheckFailed
+	}
+	if len(parts) == 1 && !paperkey.IsShare(parts[0]) {
+		return parts[0], exitOK
+	}
+
+	log.Debugf("combining %d shares", len(parts))
+	envelope, err := paperkey.CombineShares(parts)
+	if err != nil {
+		log.Errorf("%v", err)
+		return nil, exitCheckFailed
+	}
+	return envelope, exitOK
+}
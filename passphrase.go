@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+)
+
+// passphraseEnv es la variable de entorno con la frase, para scripts
+const passphraseEnv = "QRGENERATOR_PASSPHRASE"
+
+// readPassphrase obtiene la frase de contraseña de --passphrase-file, de la
+// variable de entorno o de la entrada estándar. En una terminal la pide sin
+// eco y, con confirm, dos veces para evitar un error de tipeo que haría
+// irrecuperable lo cifrado.
+func readPassphrase(file string, confirm bool) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if passphrase, ok := os.LookupEnv(passphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	stdin := bufio.NewReader(os.Stdin)
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		passphrase, err := stdin.ReadString('\n')
+		if err != nil && passphrase == "" {
+			return "", i18n.Errorf("cannot read the passphrase: %w", err)
+		}
+		return strings.TrimRight(passphrase, "\r\n"), nil
+	}
+
+	passphrase, err := promptHidden(stdin, i18n.T("Passphrase: "))
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	again, err := promptHidden(stdin, i18n.T("Repeat the passphrase: "))
+	if err != nil {
+		return "", err
+	}
+	if again != passphrase {
+		return "", i18n.Errorf("the passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// promptHidden pide una línea en la terminal con el eco desactivado mediante
+// stty; donde stty no existe (Windows) la frase se ve al escribirla
+func promptHidden(stdin *bufio.Reader, prompt string) (string, error) {
+	os.Stderr.WriteString(prompt)
+	hide := exec.Command("stty", "-echo")
+	hide.Stdin = os.Stdin
+	if hide.Run() == nil {
+		defer func() {
+			show := exec.Command("stty", "echo")
+			show.Stdin = os.Stdin
+			show.Run()
+			os.Stderr.WriteString("\n")
+		}()
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", i18n.Errorf("cannot read the passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
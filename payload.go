@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/payload"
+)
+
+// payloadFlags registra --type y un flag por cada campo de los tipos de
+// payload. La función devuelta arma el payload una vez parseados los flags (y
+// aplicada la configuración) con los argumentos que siguen a los flags;
+// devuelve los payloads (varios con --count en los tipos que generan
+// identificadores), ninguno si no se pidió ningún tipo, y si el payload es
+// una cadena GS1 que se codifica con FNC1.
+func payloadFlags(flags *flag.FlagSet) func(args []string) ([]string, bool, error) {
+	kind := flags.String("type", "", i18n.Sprintf("Payload type to build instead of -url: %s", strings.Join(payload.Types(), ", ")))
+	localeTag := flags.String("locale", "", i18n.T("Accept amounts and dates of the payload fields written in this locale's convention, such as es-AR (1.234,56 and 31/12/2025 18:30) or en-US (12/31/2025 6:30 PM)"))
+	fields := map[string]bool{}
+	for _, field := range payload.Fields() {
+		fields[field.Name] = true
+		if field.Bool {
+			flags.Bool(field.Name, false, i18n.T(field.Usage))
+		} else {
+			flags.String(field.Name, "", i18n.T(field.Usage))
+		}
+	}
+
+	return func(args []string) ([]string, bool, error) {
+		values := payload.Values{}
+		urlSet := false
+		flags.Visit(func(f *flag.Flag) {
+			if fields[f.Name] {
+				values[f.Name] = f.Value.String()
+			}
+			urlSet = urlSet || f.Name == "url"
+		})
+
+		if *kind == "" {
+			for name := range values {
+				return nil, false, i18n.Errorf("--%s needs --type (%s)", name, strings.Join(payload.TypesWith(name), ", "))
+			}
+			if *localeTag != "" {
+				return nil, false, i18n.Errorf("--locale needs --type; it applies to the payload fields")
+			}
+			return nil, false, nil
+		}
+		if urlSet {
+			return nil, false, i18n.Errorf("-url cannot be combined with --type; the payload is built from the type's fields")
+		}
+		t, known := payload.Lookup(*kind)
+		// El argumento después de los flags llena el campo principal del tipo:
+		// --type gs1 "(01)09506000134352(17)260101"
+		if len(args) > 0 && known {
+			switch {
+			case t.Arg == "":
+				return nil, false, i18n.Errorf("--type %s takes no arguments; use its flags", *kind)
+			case len(args) > 1:
+				return nil, false, i18n.Errorf("--type %s takes a single argument; quote it if it has spaces", *kind)
+			case values[t.Arg] != "":
+				return nil, false, i18n.Errorf("--%s and an argument set the same field; use one of them", t.Arg)
+			}
+			values[t.Arg] = args[0]
+		}
+		var locale *payload.Locale
+		if *localeTag != "" {
+			var err error
+			if locale, err = payload.ParseLocale(*localeTag); err != nil {
+				return nil, false, err
+			}
+		}
+		contents, err := payload.Build(*kind, values, locale)
+		return contents, known && t.FNC1, err
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// runPreviewGrid genera el mismo payload con varias combinaciones de nivel de
+// corrección, tamaño y preset y las reúne en una sola imagen para compararlas
+func runPreviewGrid(args []string) int {
+	flags := newFlagSet("preview-grid")
+	url := flags.String("url", "", i18n.T("Payload to compare; also the argument after the flags"))
+	levels := flags.String("ec", strings.Join(qrgenerator.ECLevels(), ","), i18n.T("Comma-separated EC levels to compare: L, M, Q, H"))
+	sizes := flags.String("sizes", "256", i18n.T("Comma-separated sizes in pixels to compare"))
+	styles := flags.String("presets", "none", i18n.T("Comma-separated presets to compare; none for the plain QR"))
+	columns := flags.Int("columns", 0, i18n.T("Variants per row (default: one per EC level)"))
+	output := flags.String("o", "preview-grid.png", i18n.T("Comparison sheet to write, .png or .jpg"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli preview-grid [flags] [payload]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	var positional []string
+	for flags.NArg() > 0 {
+		positional = append(positional, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+
+	payload := *url
+	switch {
+	case len(positional) > 1:
+		log.Errorf("%v", i18n.T("preview-grid takes a single payload; quote it if it has spaces"))
+		return exitInvalidInput
+	case len(positional) == 1 && payload != "":
+		log.Errorf("%v", i18n.T("-url and an argument set the same payload; use one of them"))
+		return exitInvalidInput
+	case len(positional) == 1:
+		payload = positional[0]
+	case payload == "":
+		log.Errorf("%v", i18n.T("preview-grid needs a payload: -url or an argument"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	cells, err := gridCells(payload, splitList(*levels), splitList(*sizes), splitList(*styles))
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	format, problems := qrgenerator.ResolveFormat(*output, "")
+	for _, problem := range problems {
+		if !problem.Warning {
+			log.Errorf("%v", problem.Err)
+			return exitInvalidInput
+		}
+	}
+	if *columns <= 0 {
+		*columns = len(splitList(*levels))
+	}
+
+	results, err := qrgenerator.GenerateGrid(cells, *columns, qrgenerator.Output{Path: *output, Format: format})
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	failed := 0
+	for i, result := range results {
+		if result == nil {
+			failed++
+			log.Warnf("%s: does not fit with these options", cells[i].Label)
+			continue
+		}
+		log.Debugf("%s: QR version %d (%dx%d modules), mask %d", cells[i].Label, result.Version, result.Modules, result.Modules, result.Mask)
+	}
+	log.Infof("%d variants compared in %s", len(cells)-failed, *output)
+	return exitOK
+}
+
+// gridCells arma las variantes en el orden de la hoja: una fila por preset y
+// tamaño, una columna por nivel de corrección
+func gridCells(payload string, levels, sizes, styles []string) ([]qrgenerator.GridCell, error) {
+	if len(levels) == 0 || len(sizes) == 0 || len(styles) == 0 {
+		return nil, i18n.Errorf("%w: -ec, -sizes and -presets need at least one value", qrgenerator.ErrInvalidInput)
+	}
+	var cells []qrgenerator.GridCell
+	for _, style := range styles {
+		for _, value := range sizes {
+			size, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, i18n.Errorf("%w: invalid size %q", qrgenerator.ErrInvalidInput, value)
+			}
+			for _, level := range levels {
+				config := qrgenerator.QRConfig{URL: payload, Size: size, ExtraParams: map[string]string{"ec": strings.ToUpper(level)}}
+				label := i18n.Sprintf("EC %s, %dpx", strings.ToUpper(level), size)
+				if !strings.EqualFold(style, "none") {
+					config.ExtraParams["preset"] = style
+					label += ", " + style
+				}
+				// Las opciones inválidas se informan antes de dibujar la hoja
+				for _, problem := range qrgenerator.Validate(config) {
+					if !problem.Warning {
+						return nil, problem.Err
+					}
+				}
+				cells = append(cells, qrgenerator.GridCell{Config: config, Label: label})
+			}
+		}
+	}
+	return cells, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/url"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/payload"
+)
+
+// printConfigFlag es --print-config: solo, imprime la configuración en YAML;
+// --print-config=json la imprime en JSON
+type printConfigFlag struct {
+	format string
+}
+
+func (p *printConfigFlag) String() string {
+	if p == nil {
+		return ""
+	}
+	return p.format
+}
+
+func (p *printConfigFlag) Set(value string) error {
+	switch value {
+	case "true", "yaml":
+		p.format = "yaml"
+	case "json":
+		p.format = "json"
+	case "false":
+		p.format = ""
+	default:
+		return i18n.Errorf("use yaml or json")
+	}
+	return nil
+}
+
+// IsBoolFlag permite pasar --print-config sin valor
+func (p *printConfigFlag) IsBoolFlag() bool {
+	return true
+}
+
+// setting es el valor efectivo de un flag y de dónde sale: default, flag,
+// config <archivo> o env <variable>
+type setting struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// secretFlags son los flags cuyo valor no se imprime
+var secretFlags = []string{"password", "secret", "obs-password"}
+
+// urlFlags pueden llevar usuario y contraseña en la URL; se imprimen sin la contraseña
+var urlFlags = []string{"mqtt", "push"}
+
+// effectiveSettings arma la configuración efectiva de generate una vez
+// aplicado el archivo de configuración: explicit son los flags de la línea de
+// comandos y fromConfig los valores del archivo. Los campos de payload solo
+// aparecen si los usa el --type elegido.
+func effectiveSettings(flags *flag.FlagSet, explicit map[string]bool, configPath string, fromConfig map[string]string) map[string]setting {
+	fields := map[string]bool{}
+	for _, field := range payload.Fields() {
+		fields[field.Name] = true
+	}
+	kind, _ := payload.Lookup(flags.Lookup("type").Value.String())
+
+	settings := map[string]setting{}
+	flags.VisitAll(func(f *flag.Flag) {
+		if f.Name == "print-config" || f.Name == "config" || fields[f.Name] && (kind == nil || !kind.Has(f.Name)) {
+			return
+		}
+		var value any = f.Value.String()
+		if getter, ok := f.Value.(flag.Getter); ok {
+			value = getter.Get()
+		}
+		source := "default"
+		_, configured := fromConfig[f.Name]
+		switch {
+		case explicit[f.Name]:
+			source = "flag"
+		case configured:
+			source = "config " + configPath
+		case f.Name == "obs-password" && os.Getenv(obsPasswordEnv) != "":
+			value, source = os.Getenv(obsPasswordEnv), "env "+obsPasswordEnv
+		case f.Name == "no-telemetry" && os.Getenv("DO_NOT_TRACK") == "1":
+			value, source = true, "env DO_NOT_TRACK"
+		}
+
+		switch {
+		case slices.Contains(secretFlags, f.Name) && value != "":
+			value = "********"
+		case slices.Contains(urlFlags, f.Name):
+			if u, err := url.Parse(f.Value.String()); err == nil {
+				value = u.Redacted()
+			}
+		}
+		settings[f.Name] = setting{Value: value, Source: source}
+	})
+	return settings
+}
+
+// printSettings imprime la configuración efectiva: el YAML lleva el origen
+// de cada valor como comentario y el JSON da valor y origen por flag
+func printSettings(settings map[string]setting, format string) int {
+	if format == "json" {
+		data, _ := json.MarshalIndent(settings, "", "  ")
+		os.Stdout.Write(append(data, '\n'))
+		return exitOK
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode, HeadComment: "Effective configuration of " + program + " " + version + "; each comment tells where the value comes from"}
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		var value yaml.Node
+		value.Encode(settings[name].Value)
+		value.LineComment = settings[name].Source
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, &value)
+	}
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	encoder.Encode(doc)
+	encoder.Close()
+	return exitOK
+}
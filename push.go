@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"qrgenerator_cli/helpers/eink"
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// pushOptions describen el envío de la imagen a un display e-ink
+type pushOptions struct {
+	target        string // URL http(s) o mqtt(s); vacía si no se envía
+	format        eink.Format
+	width, height int // Resolución del display; 0 = tamaño del QR
+	invert        bool
+}
+
+// pushFlags registra los flags de --push; la función devuelta los interpreta
+// una vez parseados
+func pushFlags(flags *flag.FlagSet) func() (pushOptions, error) {
+	target := flags.String("push", "", i18n.T("Send the 1-bit image to an e-ink display: an http(s) URL (POST) or mqtt(s)://host/topic"))
+	format := flags.String("push-format", string(eink.FormatRaw), i18n.T("Image format for --push: raw (1 bit per pixel, 1 = black), pbm, bmp or png"))
+	canvas := flags.String("push-canvas", "", i18n.T("Display resolution for --push, e.g. 296x128; the QR is centered on it"))
+	invert := flags.Bool("push-invert", false, i18n.T("With --push-format raw, send 1 for white pixels"))
+
+	return func() (pushOptions, error) {
+		opts := pushOptions{target: *target, invert: *invert}
+		if opts.target == "" {
+			return opts, nil
+		}
+		err := eink.CheckTarget(opts.target)
+		if err != nil {
+			return opts, err
+		}
+		if opts.format, err = eink.ParseFormat(*format); err != nil {
+			return opts, err
+		}
+		if *canvas != "" {
+			if opts.width, opts.height, err = eink.ParseCanvas(*canvas); err != nil {
+				return opts, err
+			}
+		}
+		return opts, nil
+	}
+}
+
+// pushDisplay renderiza el QR en 1 bit y lo envía al display
+func pushDisplay(opts *generateOptions) int {
+	log := opts.log
+	push := opts.push
+
+	img, _, err := qrgenerator.Render(opts.config)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	bitmap, err := eink.NewBitmap(img, push.width, push.height)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	data, err := bitmap.Encode(push.format, push.invert)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitEncode
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eink.DefaultTimeout)
+	defer cancel()
+	target := eink.Redact(push.target)
+	log.Debugf("push: %d bytes (%s, %dx%d) to %s", len(data), push.format, bitmap.Width, bitmap.Height, target)
+	if err := eink.Push(ctx, push.target, data, push.format, bitmap.Width, bitmap.Height); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: cannot push to %s: %w", qrgenerator.ErrIO, target, err))
+		return exitIO
+	}
+	log.Infof("QR pushed to %s", target)
+	return exitOK
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/qrcodec"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// runReceiveFile rearma un archivo enviado con send-file a partir de las
+// imágenes de sus QR, en cualquier orden y con repetidos. Sale con
+// exitCheckFailed si faltan trozos.
+func runReceiveFile(args []string) int {
+	flags := newFlagSet("receive-file")
+	output := flags.String("o", "", i18n.T("Path of the reassembled file"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli receive-file -o file [flags] image... (PNG, JPEG, animated GIF...)\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() == 0 || *output == "" {
+		log.Errorf("%v", i18n.T("receive-file needs -o and at least one image"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	data, code := assembleImages(log, flags.Args())
+	if code != exitOK {
+		return code
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+	sum := sha256.Sum256(data)
+	log.Infof("%d bytes written to %s (SHA-256 %s)", len(data), *output, hex.EncodeToString(sum[:]))
+	return exitOK
+}
+
+// assembleImages junta los trozos de transferencia de las imágenes y
+// reconstruye el archivo; si falla devuelve el código de salida
+func assembleImages(log *logger.Logger, paths []string) ([]byte, int) {
+	files, code := collectChunks(log, paths)
+	if code != exitOK {
+		return nil, code
+	}
+	if len(files) > 1 {
+		log.Errorf("%v", i18n.Errorf("the images belong to %d different files", len(files)))
+		return nil, exitInvalidInput
+	}
+	data, err := files[0].Bytes()
+	if err != nil {
+		log.Errorf("%v", err)
+		return nil, exitCheckFailed
+	}
+	return data, exitOK
+}
+
+// collectChunks lee los trozos de transferencia de las imágenes, separados
+// por archivo; si no hay ninguno devuelve el código de salida
+func collectChunks(log *logger.Logger, paths []string) ([]*transfer.Assembler, int) {
+	var collection transfer.Collection
+	for _, path := range paths {
+		images, err := transferImages(path)
+		if err != nil {
+			log.Errorf("%s: %v", path, err)
+			return nil, exitIO
+		}
+		for _, img := range images {
+			result, err := qrcodec.Decode(img)
+			if err != nil {
+				log.Warnf("%s: %v", path, err)
+				continue
+			}
+			chunk, err := transfer.ParseChunk(result.Text)
+			if err != nil {
+				log.Warnf("%s: %v", path, err)
+				continue
+			}
+			if err := collection.Add(chunk); err != nil {
+				log.Errorf("%s: %v", path, err)
+				return nil, exitInvalidInput
+			}
+			log.Debugf("%s: chunk %d/%d", path, chunk.Index, chunk.Total)
+		}
+	}
+	files := collection.Files()
+	if len(files) == 0 {
+		log.Errorf("no transfer chunks found")
+		return nil, exitCheckFailed
+	}
+	return files, exitOK
+}
+
+// transferImages carga una imagen, o todos los cuadros si es un GIF
+func transferImages(path string) ([]image.Image, error) {
+	if strings.EqualFold(filepath.Ext(path), ".gif") {
+		return transfer.GIFFrames(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	img, err := qrcodec.LoadImage(data, path)
+	if err != nil {
+		return nil, err
+	}
+	return []image.Image{img}, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/selftest"
+)
+
+// runSelftest genera y decodifica cada payload de referencia y cada tipo de
+// payload en cada formato e imprime la matriz de resultados; sale con
+// exitCheckFailed si alguna celda falla o si un tipo no tiene caso
+func runSelftest(args []string) int {
+	flags := newFlagSet("selftest")
+	keep := flags.String("keep", "", i18n.T("Write the generated files to this directory instead of a temporary one"))
+	newLogger := logFlags(flags)
+	flags.Parse(args)
+	log := newLogger()
+
+	dir := *keep
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "qrgenerator-selftest-")
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitIO
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+
+	report := selftest.Run(dir)
+	for format, err := range report.Skipped {
+		log.Warnf("skipping %s: %v", format, err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-16s", "payload")
+	for _, format := range report.Formats {
+		fmt.Fprintf(&sb, " %-6s", format)
+	}
+	log.Infof("%s", strings.TrimRight(sb.String(), " "))
+	for _, row := range report.Rows {
+		sb.Reset()
+		fmt.Fprintf(&sb, "%-16s", row.Case.Name)
+		for _, cell := range row.Cells {
+			status := "PASS"
+			if cell.Err != nil {
+				status = "FAIL"
+			}
+			fmt.Fprintf(&sb, " %-6s", status)
+		}
+		log.Infof("%s", strings.TrimRight(sb.String(), " "))
+	}
+
+	for _, row := range report.Rows {
+		for _, cell := range row.Cells {
+			if cell.Err != nil {
+				log.Errorf("%s/%s: %v", row.Case.Name, cell.Format, cell.Err)
+			}
+		}
+	}
+	for _, name := range report.Missing {
+		log.Errorf("payload type %s has no selftest case", name)
+	}
+
+	if failures := report.Failures(); failures > 0 {
+		log.Errorf("selftest failed: %d of %d checks", failures, len(report.Rows)*len(report.Formats)+len(report.Missing))
+		return exitCheckFailed
+	}
+	log.Infof("selftest passed: %d checks", len(report.Rows)*len(report.Formats))
+	return exitOK
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/update"
+)
+
+// version es la versión del binario; se inyecta al compilar las releases con
+// -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+// runSelfUpdate descarga la última release para esta plataforma, la verifica y
+// reemplaza el ejecutable actual
+func runSelfUpdate(args []string) int {
+	flags := newFlagSet("self-update")
+	check := flags.Bool("check", false, i18n.T("Only report whether an update is available"))
+	force := flags.Bool("force", false, i18n.T("Reinstall even if the latest release is not newer, or if a package manager installed the binary"))
+	channel := flags.String("channel", update.ChannelStable, i18n.T("Release channel to follow: stable or prerelease (also release candidates)"))
+	repo := flags.String("repo", update.DefaultRepo, i18n.T("GitHub repository that publishes the releases"))
+	api := flags.String("api", update.DefaultAPI, i18n.T("GitHub API base URL"))
+	skipSignature := flags.Bool("insecure-skip-signature", false, i18n.T("Install a release without verifying its signature when this build has no signing key"))
+	timeout := flags.Duration("timeout", 2*time.Minute, i18n.T("HTTP timeout for the release query and downloads"))
+	newLogger := logFlags(flags)
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if err := update.CheckChannel(*channel); err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+
+	// Las instalaciones de Homebrew o Scoop se actualizan con su gestor
+	if manager := update.ManagedBy(exe); manager != "" && !*force && !*check {
+		log.Errorf("%s was installed with %s; update it with %s (or use -force)", exe, manager, update.ManagerCommand(manager))
+		return exitFailure
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	updater, err := update.New(*api, *repo, os.Getenv("GITHUB_TOKEN"), *timeout)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitFailure
+	}
+	release, err := updater.Latest(ctx, *channel)
+	if err != nil {
+		log.Errorf("%v", err)
+		return updateExitCode(err)
+	}
+
+	if !*force && !update.Newer(release.Tag, version) {
+		log.Infof("already up to date (%s)", version)
+		return exitOK
+	}
+	if *check {
+		log.Infof("update available: %s -> %s", version, release.Tag)
+		return exitOK
+	}
+
+	if updater.PublicKey == nil {
+		if !*skipSignature {
+			log.Errorf("this build has no release signing key; rebuild it with one or use -insecure-skip-signature")
+			return exitFailure
+		}
+		log.Warnf("this build has no release signing key; only the checksum is verified")
+		updater.SkipSignature = true
+	}
+	log.Debugf("downloading %s from %s", update.CurrentAsset(), release.Tag)
+	binary, err := updater.Download(ctx, release)
+	if err != nil {
+		log.Errorf("%v", err)
+		return updateExitCode(err)
+	}
+
+	if err := update.Replace(exe, binary); err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			log.Errorf("permission denied replacing %s; run again with sufficient privileges", exe)
+		} else {
+			log.Errorf("%v", err)
+		}
+		return exitIO
+	}
+	log.Infof("updated %s: %s -> %s", exe, version, release.Tag)
+	return exitOK
+}
+
+// updateExitCode distingue los fallos de verificación de los de red
+func updateExitCode(err error) int {
+	if errors.Is(err, update.ErrVerify) || errors.Is(err, update.ErrNoAsset) {
+		return exitFailure
+	}
+	return exitIO
+}
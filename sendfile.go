@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/transfer"
+)
+
+// runSendFile parte un archivo en una secuencia numerada de QR (PNG numerados
+// o un GIF animado) para pasarlo a un equipo sin red; receive-file lo rearma
+func runSendFile(args []string) int {
+	flags := newFlagSet("send-file")
+	output := flags.String("o", "", i18n.T("Output: a .png name, numbered per QR (file-001.png...), or a .gif animation"))
+	chunk := flags.Int("chunk", transfer.DefaultChunkSize, i18n.Sprintf("Bytes per QR (1-%d); smaller chunks give sparser codes that scan faster", transfer.MaxChunkSize))
+	size := flags.Int("size", 768, i18n.T("QR size"))
+	delay := flags.Duration("delay", time.Second, i18n.T("Time each QR stays on screen in the GIF"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli send-file -o chunks.png|chunks.gif [flags] file\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if flags.NArg() != 1 || *output == "" {
+		log.Errorf("%v", i18n.T("send-file needs one file and -o"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	animated := strings.EqualFold(filepath.Ext(*output), ".gif")
+	if !animated && !strings.EqualFold(filepath.Ext(*output), ".png") {
+		log.Errorf("%v", i18n.Errorf("%w: send-file writes .png pages or a .gif animation", qrgenerator.ErrInvalidInput))
+		return exitInvalidInput
+	}
+	if *delay <= 0 {
+		log.Errorf("--delay must be positive")
+		return exitInvalidInput
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+	payloads, err := transfer.Split(data, *chunk)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitInvalidInput
+	}
+	log.Debugf("%d bytes in %d chunks of up to %d bytes", len(data), len(payloads), *chunk)
+
+	config := qrgenerator.QRConfig{Size: *size, OutputPath: *output, Format: qrgenerator.FormatPNG}
+	if animated {
+		frames := make([]image.Image, len(payloads))
+		for i, payload := range payloads {
+			config.URL = payload
+			if frames[i], _, err = qrgenerator.Render(config); err != nil {
+				return sendFileError(log, err)
+			}
+		}
+		if err := transfer.WriteGIF(*output, frames, *delay); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: cannot write the GIF: %w", qrgenerator.ErrIO, err))
+			return exitIO
+		}
+		log.Infof("%d QR codes written to %s", len(payloads), *output)
+		return exitOK
+	}
+
+	results, err := qrgenerator.GenerateBatch(config, payloads)
+	if err != nil {
+		return sendFileError(log, err)
+	}
+	for _, result := range results {
+		logResult(log, result)
+	}
+	log.Infof("%d QR codes written to %s ... %s", len(results), results[0].OutputPath, results[len(results)-1].OutputPath)
+	return exitOK
+}
+
+// sendFileError informa un error de generación, sugiriendo trozos más chicos
+// cuando no entran en el QR
+func sendFileError(log *logger.Logger, err error) int {
+	log.Errorf("%v", err)
+	if errors.Is(err, qrgenerator.ErrCapacityExceeded) {
+		log.Errorf("lower -chunk")
+	}
+	return exitCodeFor(err)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// maxSerialCount es el largo máximo de una serie
+const maxSerialCount = 100000
+
+// serialRange es una serie de números consecutivos, un QR por número
+type serialRange struct {
+	start, count int
+	format       string // Formato de printf con un verbo entero: ASSET-%06d
+}
+
+// payload arma el payload del número n
+func (s *serialRange) payload(n int) string {
+	return fmt.Sprintf(s.format, n)
+}
+
+// last es el último número de la serie
+func (s *serialRange) last() int {
+	return s.start + s.count - 1
+}
+
+// serialFlags registra los flags de la serie; la función devuelta los
+// interpreta una vez parseados (y aplicada la configuración) y devuelve nil
+// si no se pidió una serie
+func serialFlags(flags *flag.FlagSet) func() (*serialRange, error) {
+	start := flags.Int("serial-start", 0, i18n.T("Generate a numbered series of QR codes starting at this number; each file is named after its number (qr-1000.png)"))
+	count := flags.Int("serial-count", 1, i18n.T("Number of QR codes in the --serial-start series"))
+	format := flags.String("serial-format", "%d", i18n.T("printf format of each payload of the series, with one integer verb: \"ASSET-%06d\" or \"https://ex.com/a/%d\""))
+
+	return func() (*serialRange, error) {
+		set := map[string]bool{}
+		flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		if !set["serial-start"] {
+			if set["serial-count"] || set["serial-format"] {
+				return nil, i18n.Errorf("--serial-count and --serial-format need --serial-start")
+			}
+			return nil, nil
+		}
+		switch {
+		case *start < 0:
+			return nil, i18n.Errorf("--serial-start cannot be negative")
+		case *count < 1 || *count > maxSerialCount:
+			return nil, i18n.Errorf("--serial-count must be a number from 1 to %d", maxSerialCount)
+		}
+		// Sprintf marca con %! los verbos que faltan, sobran o no son enteros
+		if sample := fmt.Sprintf(*format, *start); strings.Contains(sample, "%!") {
+			return nil, i18n.Errorf("--serial-format needs exactly one integer verb such as %%d or %%06d, not %q", *format)
+		}
+		return &serialRange{start: *start, count: *count, format: *format}, nil
+	}
+}
+
+// generateSerials genera un QR por número de la serie, cada uno en todas las
+// salidas pedidas y nombrado con su número: -o qr.png -o qr.svg da qr-1000.png
+// y qr-1000.svg, qr-1001.png...
+func generateSerials(opts *generateOptions) (int, string) {
+	log := opts.log
+	serials := opts.serials
+	log.Debugf("serial: %d QR codes from %d to %d", serials.count, serials.start, serials.last())
+
+	var written string
+	for n := serials.start; n <= serials.last(); n++ {
+		config := opts.config
+		var err error
+		if config.URL, err = preparePayload(opts, serials.payload(n)); err != nil {
+			log.Errorf("%v", i18n.Errorf("%w: serial %d: %w", qrgenerator.ErrInvalidInput, n, err))
+			return exitInvalidInput, written
+		}
+		outputs := make([]qrgenerator.Output, len(opts.outputs))
+		for i, output := range opts.outputs {
+			outputs[i] = qrgenerator.Output{Path: qrgenerator.BatchPath(output.Path, n, serials.last()), Format: output.Format}
+		}
+
+		results, err := qrgenerator.GenerateFormats(config, outputs)
+		for _, result := range results {
+			if n > serials.start {
+				// Las advertencias de la configuración son las mismas en toda la serie
+				result.Warnings = nil
+			}
+			logResult(log, result)
+			log.Infof("QR written to %s", result.OutputPath)
+		}
+		if err != nil {
+			log.Errorf("%v", i18n.Errorf("serial %d: %w", n, err))
+			return exitCodeFor(err), written
+		}
+		if written == "" {
+			written = results[0].OutputPath
+		}
+	}
+	return exitOK, written
+}
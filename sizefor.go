@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+)
+
+// runSizeFor calcula el tamaño de impresión de un código para una distancia
+// de escaneo, o la distancia máxima para un ancho impreso, y los píxeles que
+// necesita a la resolución de la impresora
+func runSizeFor(args []string) int {
+	flags := newFlagSet("size-for")
+	distance := flags.String("distance", "", i18n.T("Scan distance, such as 3m or 10ft: prints the smallest code that scans from there"))
+	width := flags.String("width", "", i18n.T("Printed width, such as 5cm or 2in: prints the farthest distance it scans from"))
+	dpi := flags.Int("dpi", qrgenerator.DefaultPrintDPI, i18n.T("Printer resolution in dots per inch"))
+	url := flags.String("url", "", i18n.T("Payload whose symbol to measure; also the argument after the flags"))
+	symbolVersion := flags.Int("version", 0, i18n.T("QR version 1-40 to measure instead of a payload"))
+	ecLevel := flags.String("ec", "", i18n.T("Error correction level of the payload: L, M, Q or H (default H)"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli size-for -distance D|-width W [flags] [payload]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	var positional []string
+	for flags.NArg() > 0 {
+		positional = append(positional, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+	if len(positional) > 0 {
+		*url = strings.Join(positional, " ")
+	}
+	if (*distance == "") == (*width == "") {
+		log.Errorf("%v", i18n.T("size-for needs either -distance or -width"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	if *dpi < 72 || *dpi > 4800 {
+		log.Errorf("%v", i18n.Errorf("%w: -dpi %d out of range (72-4800)", qrgenerator.ErrInvalidInput, *dpi))
+		return exitInvalidInput
+	}
+
+	// Módulos por lado con la zona de silencio, de la versión o del payload codificado
+	var modules int
+	switch {
+	case *symbolVersion != 0:
+		if *symbolVersion < 1 || *symbolVersion > 40 {
+			log.Errorf("%v", i18n.Errorf("%w: QR versions go from 1 to 40, not %d", qrgenerator.ErrInvalidInput, *symbolVersion))
+			return exitInvalidInput
+		}
+		modules = 17 + 4**symbolVersion + 8
+	case *url != "":
+		var err error
+		modules, err = qrgenerator.SymbolModules(qrgenerator.QRConfig{URL: *url, ExtraParams: map[string]string{"ec": *ecLevel}})
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+	default:
+		log.Errorf("%v", i18n.T("size-for needs the payload or a -version to count the modules"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	var size qrgenerator.PrintSize
+	if *distance != "" {
+		mm, err := qrgenerator.ParseLength(*distance)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitInvalidInput
+		}
+		size = qrgenerator.SizeFor(mm, modules, *dpi)
+	} else {
+		mm, err := qrgenerator.ParseLength(*width)
+		if err != nil {
+			log.Errorf("%v", err)
+			return exitInvalidInput
+		}
+		// El módulo es el ancho repartido entre los módulos: la distancia sale de ahí
+		size = qrgenerator.SizeFor(qrgenerator.MaxScanDistance(mm, modules), modules, *dpi)
+	}
+
+	fmt.Printf("%-9s %s\n", i18n.T("modules"), i18n.Sprintf("%d (version %d with the quiet zone)", size.Modules, (size.Modules-8-17)/4))
+	fmt.Printf("%-9s %s\n", i18n.T("module"), qrgenerator.FormatLength(size.ModuleMM))
+	if *distance != "" {
+		fmt.Printf("%-9s %s\n", i18n.T("width"), i18n.Sprintf("%s (%.2fin) or more", qrgenerator.FormatLength(size.SideMM), size.SideMM/25.4))
+	} else {
+		fmt.Printf("%-9s %s\n", i18n.T("distance"), i18n.Sprintf("up to %s", qrgenerator.FormatLength(qrgenerator.MaxScanDistance(size.SideMM, modules))))
+	}
+	fmt.Printf("%-9s %s\n", i18n.T("pixels"), i18n.Sprintf("-size %d at %d DPI (%d dots per module), %s printed", size.Pixels, size.DPI, size.PixelsPerModule, qrgenerator.FormatLength(size.PrintedMM)))
+	return exitOK
+}
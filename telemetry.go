@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/logger"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/telemetry"
+)
+
+// noTelemetry es --no-telemetry de la ejecución actual; lo asigna logFlags
+var noTelemetry bool
+
+// exitNames son los nombres de los códigos de salida en las métricas
+var exitNames = map[int]string{
+	exitOK:           "ok",
+	exitFailure:      "failure",
+	exitInvalidInput: "invalid-input",
+	exitCapacity:     "capacity",
+	exitEncode:       "encode",
+	exitIO:           "io",
+	exitCheckFailed:  "check-failed",
+}
+
+// telemetryPath es el archivo de contadores, en el directorio de configuración del usuario
+func telemetryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return telemetry.Path(filepath.Join(dir, program)), nil
+}
+
+// recordUsage suma la ejecución a los contadores locales si el usuario activó
+// la telemetría. Nunca hace fallar la ejecución: los errores se descartan.
+func recordUsage(name string, code int, events map[string][]string) {
+	if noTelemetry || os.Getenv("DO_NOT_TRACK") == "1" || name == "telemetry" {
+		return
+	}
+	path, err := telemetryPath()
+	if err != nil {
+		return
+	}
+	if events == nil {
+		events = map[string][]string{}
+	}
+	events["command"] = []string{name}
+	events["exit"] = []string{exitNames[code]}
+	telemetry.Record(path, events)
+}
+
+// generateEvents son las métricas de una generación: formatos, tamaño y tipo de payload
+func generateEvents(opts *generateOptions) map[string][]string {
+	events := map[string][]string{"size": {telemetry.SizeBucket(opts.config.Size)}}
+	for _, output := range opts.outputs {
+		events["format"] = append(events["format"], string(output.Format))
+	}
+	switch {
+	case opts.batch != "":
+		events["type"] = []string{"batch"}
+	case opts.serials != nil:
+		events["type"] = []string{"serial"}
+	case opts.payloadType != "":
+		events["type"] = []string{opts.payloadType}
+	case opts.config.ExtraParams["binary"] == "true":
+		events["type"] = []string{"binary"}
+	default:
+		events["type"] = []string{"url"}
+	}
+	return events
+}
+
+// runTelemetry activa, desactiva, muestra, exporta o reinicia las métricas de uso locales
+func runTelemetry(args []string) int {
+	flags := newFlagSet("telemetry")
+	output := flags.String("o", "", i18n.T("Summary file to write (export): .json, or .csv for spreadsheets (default: JSON on standard output)"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli telemetry enable|disable|status|export|reset [flags]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	action := flags.Arg(0)
+	if action != "" {
+		flags.Parse(flags.Args()[1:])
+	}
+	log := newLogger()
+	if !slices.Contains([]string{"enable", "disable", "status", "export", "reset"}, action) || flags.NArg() > 0 {
+		log.Errorf("%v", i18n.T("telemetry needs an action: enable, disable, status, export or reset"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+
+	path, err := telemetryPath()
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	stats, err := telemetry.Load(path)
+	if err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+
+	switch action {
+	case "status":
+		if !stats.Enabled {
+			log.Infof("telemetry is off; enable it with: qrgenerator_cli telemetry enable")
+		} else {
+			log.Infof("telemetry is on since %s, stored in %s", stats.Since.Format(time.DateOnly), path)
+		}
+		for _, category := range stats.Categories() {
+			counts := stats.Counts[category]
+			values := make([]string, 0, len(counts))
+			for _, value := range sortedByCount(counts) {
+				values = append(values, fmt.Sprintf("%s=%d", value, counts[value]))
+			}
+			fmt.Printf("%-8s %s\n", category, strings.Join(values, " "))
+		}
+		return exitOK
+	case "export":
+		return exportTelemetry(log, stats, *output)
+	case "enable":
+		if !stats.Enabled && stats.Since.IsZero() {
+			stats.Reset(time.Now())
+		}
+		stats.Enabled = true
+	case "disable":
+		stats.Enabled = false
+	case "reset":
+		stats.Reset(time.Now())
+	}
+	if err := stats.Save(path); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	switch action {
+	case "enable":
+		log.Infof("telemetry on: usage counts are kept in %s and never sent; --no-telemetry or DO_NOT_TRACK=1 skip a run", path)
+	case "disable":
+		log.Infof("telemetry off; the counts so far stay in %s until reset", path)
+	case "reset":
+		log.Infof("telemetry counts cleared")
+	}
+	return exitOK
+}
+
+// exportTelemetry escribe el resumen en JSON o, si el archivo termina en .csv, en CSV
+func exportTelemetry(log *logger.Logger, stats *telemetry.Stats, output string) int {
+	summary := telemetry.Summary{
+		Tool:     program,
+		Version:  version,
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		Since:    stats.Since,
+		Exported: time.Now().UTC().Truncate(time.Second),
+		Counts:   stats.Counts,
+	}
+	if summary.Counts == nil {
+		summary.Counts = map[string]map[string]int{}
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(output), ".csv") {
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+		w.Write([]string{"category", "value", "count"})
+		for _, category := range stats.Categories() {
+			for _, value := range sortedByCount(stats.Counts[category]) {
+				w.Write([]string{category, value, strconv.Itoa(stats.Counts[category][value])})
+			}
+		}
+		w.Flush()
+		data = []byte(b.String())
+	} else {
+		data, _ = json.MarshalIndent(summary, "", "  ")
+		data = append(data, '\n')
+	}
+
+	if output == "" {
+		os.Stdout.Write(data)
+		return exitOK
+	}
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		log.Errorf("%v", i18n.Errorf("%w: %w", qrgenerator.ErrIO, err))
+		return exitIO
+	}
+	return exitOK
+}
+
+// sortedByCount ordena los valores de más a menos usados
+func sortedByCount(counts map[string]int) []string {
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	slices.SortFunc(values, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	return values
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"qrgenerator_cli/helpers/i18n"
+	"qrgenerator_cli/helpers/qrgenerator"
+	"qrgenerator_cli/helpers/video"
+)
+
+// runVideo exporta un overlay de video transparente en el que el QR cambia en
+// los instantes indicados, para segmentos de transmisión pregrabados
+func runVideo(args []string) int {
+	flags := newFlagSet("video")
+	cuesPath := flags.String("cues", "", i18n.T("File with one cue per line: time, payload and optionally \" | caption\"; the last line is \"<time> END\""))
+	output := flags.String("o", "", i18n.T("Output: a .mov file (ProRes 4444 with alpha, needs ffmpeg) or a directory for a PNG sequence"))
+	fps := flags.String("fps", "30", i18n.T("Frame rate: 25, 29.97, 30, 59.94... or a fraction such as 30000/1001"))
+	style := flags.String("preset", qrgenerator.PresetLowerThird, i18n.T("Overlay preset: lowerthird (1920x1080) or lowerthird-4k (3840x2160)"))
+	size := flags.Int("size", 256, i18n.T("QR size at 1080p"))
+	corner := flags.String("corner", "", i18n.T("Corner of the lowerthird overlay: bottom-right (default), bottom-left, top-right or top-left"))
+	caption := flags.String("caption", "", i18n.T("Caption for the cues that do not set their own"))
+	newLogger := logFlags(flags)
+	flags.Usage = func() {
+		flags.Output().Write([]byte(i18n.T("Usage: qrgenerator_cli video -cues cues.txt -o overlay.mov [flags]\n")))
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+	log := newLogger()
+
+	if *cuesPath == "" || *output == "" {
+		log.Errorf("%v", i18n.T("-cues and -o are required"))
+		flags.Usage()
+		return exitInvalidInput
+	}
+	if *style != qrgenerator.PresetLowerThird && *style != qrgenerator.PresetLowerThird4K {
+		log.Errorf("%v", i18n.Errorf("video needs a transparent overlay preset: %s or %s", qrgenerator.PresetLowerThird, qrgenerator.PresetLowerThird4K))
+		return exitInvalidInput
+	}
+	rate, err := video.ParseRate(*fps)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	f, err := os.Open(*cuesPath)
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitIO
+	}
+	cues, end, err := video.ParseCues(f, rate)
+	f.Close()
+	if err != nil {
+		log.Errorf("%s: %v", *cuesPath, err)
+		return exitCodeFor(err)
+	}
+
+	// Validar y codificar cada cuadro distinto antes de escribir nada
+	config := qrgenerator.QRConfig{Size: *size, ExtraParams: map[string]string{"preset": *style}}
+	if *corner != "" {
+		config.ExtraParams["corner"] = *corner
+	}
+	frames := make([][]byte, len(cues))
+	encoded := map[[2]string][]byte{}
+	var bounds image.Rectangle
+	for i, cue := range cues {
+		if cue.Payload == "" {
+			continue
+		}
+		if cue.Caption == "" {
+			cue.Caption = *caption
+		}
+		key := [2]string{cue.Payload, cue.Caption}
+		if frames[i] = encoded[key]; frames[i] != nil {
+			continue
+		}
+		cfg := config
+		cfg.URL = cue.Payload
+		cfg.ExtraParams = map[string]string{"caption": cue.Caption}
+		for k, v := range config.ExtraParams {
+			cfg.ExtraParams[k] = v
+		}
+		img, _, err := qrgenerator.Render(cfg)
+		if err != nil {
+			log.Errorf("%s: %v", *cuesPath, i18n.Errorf("line %d: %w", cue.Line, err))
+			return exitCodeFor(err)
+		}
+		if frames[i], err = encodePNG(img); err != nil {
+			log.Errorf("%v", err)
+			return exitCodeFor(err)
+		}
+		encoded[key], bounds = frames[i], img.Bounds()
+	}
+	if bounds.Empty() {
+		log.Errorf("%s: %v", *cuesPath, i18n.T("every cue hides the QR"))
+		return exitInvalidInput
+	}
+	blank, err := encodePNG(image.NewNRGBA(bounds))
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+
+	var w video.Writer
+	if strings.EqualFold(filepath.Ext(*output), ".mov") {
+		w, err = video.NewProRes(*output, rate)
+	} else {
+		w, err = video.NewPNGSequence(*output)
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+
+	err = w.WriteFrames(blank, cues[0].Frame)
+	for i := 0; i < len(cues) && err == nil; i++ {
+		until := end
+		if i+1 < len(cues) {
+			until = cues[i+1].Frame
+		}
+		frame := frames[i]
+		if frame == nil {
+			frame = blank
+		}
+		log.Debugf("video: frames %d-%d: %q", cues[i].Frame, until-1, cues[i].Payload)
+		err = w.WriteFrames(frame, until-cues[i].Frame)
+	}
+	if closeErr := w.Close(); closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Errorf("%v", err)
+		return exitCodeFor(err)
+	}
+	log.Infof("%d frames at %s fps written to %s", end, *fps, *output)
+	return exitOK
+}
+
+// encodePNG codifica un cuadro; la compresión rápida alcanza porque cada
+// imagen distinta se codifica una sola vez
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, i18n.Errorf("%w: error encoding PNG: %w", qrgenerator.ErrEncode, err)
+	}
+	return buf.Bytes(), nil
+}